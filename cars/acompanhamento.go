@@ -0,0 +1,179 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// usuarioSistema identifica quem usa o prompt, gravado como autor dos eventos
+func usuarioSistema() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if nome := os.Getenv("USER"); nome != "" {
+		return nome
+	}
+	return "desconhecido"
+}
+
+// comoAutor faz os eventos emitidos até a chamada de restaurar levarem o autor informado
+// (chamador deve segurar o lock até restaurar)
+func (c *CadastroCarros) comoAutor(autor string) (restaurar func()) {
+	anterior := c.autor
+	c.autor = autor
+	return func() { c.autor = anterior }
+}
+
+// assinaturasEventos entrega os eventos emitidos a quem acompanha o log ao vivo (audit tail -f,
+// /audit/stream); tem lock próprio porque os canais são lidos por outras goroutines
+type assinaturasEventos struct {
+	mu     sync.Mutex
+	canais []chan Evento
+}
+
+// publicar entrega os eventos sem bloquear: um assinante que não acompanha o ritmo perde eventos,
+// que continuam no log (chamado por emitir, com o lock do cadastro)
+func (a *assinaturasEventos) publicar(eventos []Evento) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, canal := range a.canais {
+		for _, e := range eventos {
+			select {
+			case canal <- e:
+			default:
+			}
+		}
+	}
+}
+
+// AssinarEventos passa a receber os eventos emitidos e devolve também o log até o momento, sem
+// lacuna nem repetição entre os dois. cancelar encerra a assinatura e fecha o canal.
+func (c *CadastroCarros) AssinarEventos() (canal <-chan Evento, anteriores []Evento, cancelar func()) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	novo := make(chan Evento, 256)
+	a := &c.assinaturas
+	a.mu.Lock()
+	a.canais = append(a.canais, novo)
+	a.mu.Unlock()
+
+	var uma sync.Once
+	cancelar = func() {
+		uma.Do(func() {
+			a.mu.Lock()
+			a.canais = slices.DeleteFunc(a.canais, func(ch chan Evento) bool { return ch == novo })
+			a.mu.Unlock()
+			close(novo)
+		})
+	}
+	return novo, slices.Clip(c.eventos), cancelar
+}
+
+// FiltroAuditoria seleciona eventos por autor, carro e ação; campos vazios aceitam qualquer valor
+type FiltroAuditoria struct {
+	Autor   string // Trecho do autor, sem diferenciar maiúsculas (ex: "api" pega todas as integrações)
+	CarroID string
+	Acao    string // Tipo do evento, com ou sem o prefixo "Carro" (ex: Vendido ou CarroVendido)
+}
+
+// Aceita informa se o evento passa pelo filtro
+func (f FiltroAuditoria) Aceita(e Evento) bool {
+	switch {
+	case f.Autor != "" && !strings.Contains(strings.ToLower(e.Autor), strings.ToLower(f.Autor)):
+		return false
+	case f.CarroID != "" && e.CarroID != f.CarroID:
+		return false
+	case f.Acao != "" && !strings.EqualFold(e.Tipo, f.Acao) && !strings.EqualFold(strings.TrimPrefix(e.Tipo, "Carro"), f.Acao):
+		return false
+	}
+	return true
+}
+
+// filtroDaConsulta lê o filtro dos parâmetros ?user=&car=&action= do stream
+func filtroDaConsulta(r *http.Request) FiltroAuditoria {
+	consulta := r.URL.Query()
+	return FiltroAuditoria{Autor: consulta.Get("user"), CarroID: consulta.Get("car"), Acao: consulta.Get("action")}
+}
+
+// apiStreamAuditoria transmite as entradas do log como Server-Sent Events, filtradas por
+// ?user=&car=&action=. Com o cabeçalho Last-Event-ID, reenvia antes o que foi perdido na reconexão
+// (com "0", o log inteiro, como faz uma réplica nova). O pulso periódico leva o último Seq do log,
+// para quem acompanha perceber eventos perdidos. O stream termina quando o cliente desconecta ou
+// encerrando é fechado (serve stop).
+func (c *CadastroCarros) apiStreamAuditoria(w http.ResponseWriter, r *http.Request, encerrando <-chan struct{}) {
+	descarregar, ok := w.(http.Flusher)
+	if !ok {
+		responderErro(w, http.StatusInternalServerError, "o servidor não permite streaming")
+		return
+	}
+	filtro := filtroDaConsulta(r)
+	retomar := r.Header.Get("Last-Event-ID") != ""
+	ultimo, _ := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+
+	canal, anteriores, cancelar := c.AssinarEventos()
+	defer cancelar()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	enviar := func(e Evento) {
+		if e.Seq <= ultimo || !filtro.Aceita(e) {
+			return
+		}
+		data, _ := json.Marshal(e)
+		fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Seq, e.Tipo, data)
+	}
+	if retomar {
+		for _, e := range anteriores {
+			enviar(e)
+		}
+	}
+	descarregar.Flush()
+
+	// Comentário periódico para manter a conexão viva através de proxies
+	pulso := time.NewTicker(30 * time.Second)
+	defer pulso.Stop()
+	for {
+		select {
+		case e := <-canal:
+			enviar(e)
+		case <-pulso.C:
+			c.mu.RLock()
+			seq := c.ultimoSeq()
+			c.mu.RUnlock()
+			fmt.Fprintf(w, ": pulso %d\n\n", seq)
+		case <-r.Context().Done():
+			return
+		case <-encerrando:
+			return
+		}
+		descarregar.Flush()
+	}
+}
+
+// apiEventoAuditoria devolve o evento do log com o Seq informado; é com ele que uma réplica confere,
+// antes de seguir, se o log dela é um trecho do log do primário
+func (c *CadastroCarros) apiEventoAuditoria(w http.ResponseWriter, r *http.Request) {
+	seq, err := strconv.ParseInt(r.PathValue("seq"), 10, 64)
+	if err != nil {
+		responderErro(w, http.StatusBadRequest, "seq inválido: %s", r.PathValue("seq"))
+		return
+	}
+	eventos := c.Snapshot().eventos
+	i, achou := slices.BinarySearchFunc(eventos, seq, func(e Evento, alvo int64) int {
+		return int(e.Seq - alvo)
+	})
+	if !achou {
+		responderErro(w, http.StatusNotFound, "evento #%d não está no log", seq)
+		return
+	}
+	responderJSON(w, http.StatusOK, eventos[i])
+}
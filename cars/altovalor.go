@@ -0,0 +1,36 @@
+package cars
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpcoesAltoValor é a seção "alto_valor" da configuração: acima do limite, remover, vender ou
+// mudar o preço de um carro exige digitar o modelo dele, como a confirmação de exclusão do GitHub,
+// para que um ID trocado ou um zero a mais não custe um carro de milhões
+type OpcoesAltoValor struct {
+	Limite Dinheiro `json:"limite,omitempty"` // Preço a partir do qual a confirmação é exigida (0 = desligada)
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesAltoValor) Validar() error {
+	if o.Limite < 0 {
+		return fmt.Errorf("alto_valor: limite não pode ser negativo")
+	}
+	return nil
+}
+
+// Exige informa se algum dos preços (ex: o atual e o novo) alcança o limite
+func (o OpcoesAltoValor) Exige(precos ...Dinheiro) bool {
+	for _, preco := range precos {
+		if o.Limite > 0 && preco >= o.Limite {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmaModelo compara a resposta digitada com o modelo, sem diferenciar maiúsculas
+func ConfirmaModelo(carro Carro, resposta string) bool {
+	return strings.EqualFold(strings.TrimSpace(resposta), strings.TrimSpace(carro.Modelo))
+}
@@ -0,0 +1,182 @@
+package cars
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// formatoFeed descreve um portal de anúncios: como validar um carro para ele e como gravar o feed
+type formatoFeed struct {
+	Descricao string
+	Validar   func(carro Carro) []string              // Problemas que impedem o anúncio (vazio = ok)
+	Gravar    func(w io.Writer, carros []Carro) error // Grava os carros já validados
+}
+
+// FormatosFeed são os portais suportados por `export feed --format=<nome>`
+var FormatosFeed = map[string]formatoFeed{
+	"webmotors": {Descricao: "XML de estoque do WebMotors", Validar: validarWebmotors, Gravar: gravarWebmotors},
+	"olx":       {Descricao: "CSV de carga em lote da OLX", Validar: validarOLX, Gravar: gravarOLX},
+}
+
+// validarAnuncioBasico confere os campos que todo portal exige
+func validarAnuncioBasico(carro Carro) []string {
+	var problemas []string
+	if carro.Marca == "" || carro.Modelo == "" {
+		problemas = append(problemas, "marca e modelo são obrigatórios")
+	}
+	if carro.Cor == "" {
+		problemas = append(problemas, "cor é obrigatória")
+	}
+	if carro.Preco <= 0 {
+		problemas = append(problemas, "preço deve ser positivo")
+	}
+	return problemas
+}
+
+// veiculoWebmotors é um <veiculo> do XML de estoque do WebMotors
+type veiculoWebmotors struct {
+	Codigo        string   `xml:"codigo"`
+	Marca         string   `xml:"marca"`
+	Modelo        string   `xml:"modelo"`
+	AnoFabricacao int      `xml:"ano_fabricacao"`
+	AnoModelo     int      `xml:"ano_modelo"`
+	Cor           string   `xml:"cor"`
+	Preco         string   `xml:"preco"`
+	Procedencia   string   `xml:"procedencia"`
+	PaisOrigem    string   `xml:"pais_origem"`
+	Opcionais     []string `xml:"opcionais>opcional,omitempty"`
+}
+
+// validarWebmotors exige, além do básico, ano a partir de 1950 (o portal não aceita anteriores)
+func validarWebmotors(carro Carro) []string {
+	problemas := validarAnuncioBasico(carro)
+	if carro.Ano < 1950 {
+		problemas = append(problemas, "WebMotors só aceita ano a partir de 1950")
+	}
+	return problemas
+}
+
+// gravarWebmotors grava o feed XML, um <veiculo> por carro
+func gravarWebmotors(w io.Writer, carros []Carro) error {
+	feed := struct {
+		XMLName  xml.Name           `xml:"estoque"`
+		Veiculos []veiculoWebmotors `xml:"veiculo"`
+	}{}
+	for _, carro := range carros {
+		feed.Veiculos = append(feed.Veiculos, veiculoWebmotors{
+			Codigo:        carro.ID,
+			Marca:         carro.Marca,
+			Modelo:        carro.Modelo,
+			AnoFabricacao: carro.Ano,
+			AnoModelo:     carro.Ano,
+			Cor:           carro.Cor,
+			Preco:         carro.Preco.String(),
+			Procedencia:   "importado",
+			PaisOrigem:    carro.PaisOrigem,
+			Opcionais:     carro.Opcionais,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// limiteTituloOLX é o tamanho máximo do título de um anúncio na OLX
+const limiteTituloOLX = 70
+
+// tituloOLX monta o título do anúncio ("Marca Modelo Ano")
+func tituloOLX(carro Carro) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %d", carro.Marca, carro.Modelo, carro.Ano))
+}
+
+// validarOLX exige, além do básico, título dentro do limite e preço em reais inteiros
+func validarOLX(carro Carro) []string {
+	problemas := validarAnuncioBasico(carro)
+	if n := utf8.RuneCountInString(tituloOLX(carro)); n > limiteTituloOLX {
+		problemas = append(problemas, fmt.Sprintf("título com %d caracteres (máximo %d na OLX)", n, limiteTituloOLX))
+	}
+	if carro.Preco%100 != 0 {
+		problemas = append(problemas, "OLX só aceita preço em reais inteiros")
+	}
+	return problemas
+}
+
+// gravarOLX grava o CSV de carga em lote, separado por ponto e vírgula
+func gravarOLX(w io.Writer, carros []Carro) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+	cw.Write([]string{"codigo", "categoria", "titulo", "descricao", "preco", "marca", "modelo", "ano", "cor", "opcionais"})
+	for _, carro := range carros {
+		descricao := fmt.Sprintf("%s %s %d, cor %s, importado de %s.", carro.Marca, carro.Modelo, carro.Ano, carro.Cor, carro.PaisOrigem)
+		if len(carro.Opcionais) > 0 {
+			descricao += " Opcionais: " + strings.Join(carro.Opcionais, ", ") + "."
+		}
+		cw.Write([]string{
+			carro.ID, "carros", tituloOLX(carro), descricao, strconv.FormatInt(int64(carro.Preco/100), 10),
+			carro.Marca, carro.Modelo, strconv.Itoa(carro.Ano), carro.Cor, strings.Join(carro.Opcionais, ","),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// RecusaFeed é um carro deixado fora do feed de um portal, com os motivos
+type RecusaFeed struct {
+	Carro     Carro
+	Problemas []string
+}
+
+// FeedAnuncios é o feed de um portal de anúncios pronto para gravar: os carros que passaram na
+// validação do portal e os que ficaram de fora
+type FeedAnuncios struct {
+	Formato   string
+	Descricao string
+	Carros    []Carro
+	Recusados []RecusaFeed
+}
+
+// MontarFeed separa os carros em estoque que o portal aceita dos que ele recusaria. Com canal,
+// o feed anuncia o preço daquele canal de venda.
+func (c *CadastroCarros) MontarFeed(formato, canal string) (FeedAnuncios, error) {
+	defer c.Medir("export feed")()
+	f, existe := FormatosFeed[formato]
+	if !existe {
+		return FeedAnuncios{}, fmt.Errorf("formato de feed desconhecido: %s", formato)
+	}
+
+	carros := c.Snapshot().carros
+	if canal != "" {
+		c.mu.RLock()
+		carros = c.configAtiva.Canais.noCanal(carros, canal)
+		c.mu.RUnlock()
+	}
+	feed := FeedAnuncios{Formato: formato, Descricao: f.Descricao}
+	for _, carro := range carros {
+		if problemas := f.Validar(carro); len(problemas) > 0 {
+			feed.Recusados = append(feed.Recusados, RecusaFeed{Carro: carro, Problemas: problemas})
+			continue
+		}
+		feed.Carros = append(feed.Carros, carro)
+	}
+	return feed, nil
+}
+
+// Gravar escreve os carros aceitos no formato do portal
+func (feed FeedAnuncios) Gravar(w io.Writer) error {
+	if err := FormatosFeed[feed.Formato].Gravar(w, feed.Carros); err != nil {
+		return fmt.Errorf("erro ao gerar feed: %v", err)
+	}
+	return nil
+}
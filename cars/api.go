@@ -0,0 +1,436 @@
+package cars
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// comandoDoMetodo associa cada método HTTP da API ao comando do prompt equivalente, para que a
+// seção "permissoes" da configuração valha também para quem usa a API
+var comandoDoMetodo = map[string]string{
+	http.MethodGet:    "list",
+	http.MethodPost:   "add",
+	http.MethodPut:    "update",
+	http.MethodDelete: "remove",
+}
+
+// responderJSON grava o valor como JSON com o status informado
+func responderJSON(w http.ResponseWriter, status int, valor interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	codificador := json.NewEncoder(w)
+	codificador.SetIndent("", "  ")
+	codificador.Encode(valor)
+}
+
+// responderErro grava {"erro": "..."} com o status informado
+func responderErro(w http.ResponseWriter, status int, formato string, args ...interface{}) {
+	responderJSON(w, status, map[string]string{"erro": fmt.Sprintf(formato, args...)})
+}
+
+// lerCarroJSON decodifica o corpo da requisição em um carro, recusando campos desconhecidos
+func lerCarroJSON(w http.ResponseWriter, r *http.Request) (Carro, error) {
+	var carro Carro
+	decodificador := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	decodificador.DisallowUnknownFields()
+	if err := decodificador.Decode(&carro); err != nil {
+		return carro, fmt.Errorf("corpo JSON inválido: %v", err)
+	}
+	return carro, nil
+}
+
+// rotasAPI monta as rotas da API REST sobre o cadastro; encerrando é fechado pelo serve stop para
+// terminar os streams abertos
+func (c *CadastroCarros) rotasAPI(encerrando <-chan struct{}) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /carros", c.apiListar)
+	mux.HandleFunc("GET /carros/{id}", c.apiBuscar)
+	mux.HandleFunc("POST /carros", c.apiCadastrar)
+	mux.HandleFunc("POST /carros/bulk", c.apiGravarLote)
+	mux.HandleFunc("PUT /carros/{id}", c.apiAtualizar)
+	mux.HandleFunc("DELETE /carros/{id}", c.apiRemover)
+	mux.HandleFunc("GET /audit/stream", func(w http.ResponseWriter, r *http.Request) {
+		c.apiStreamAuditoria(w, r, encerrando)
+	})
+	mux.HandleFunc("GET /audit/events/{seq}", c.apiEventoAuditoria)
+
+	return c.registrarRequisicoes(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		comandos := []string{comandoDoMetodo[r.Method]}
+		if strings.HasPrefix(r.URL.Path, "/audit/") {
+			comandos = []string{"audit"}
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/carros/bulk" {
+			// O lote cadastra e atualiza: precisa dos dois comandos
+			comandos = append(comandos, "update")
+		}
+		for _, comando := range comandos {
+			if err := c.conferirAcessoRemoto(r.Header.Get("X-API-Key"), comando, r.Method != http.MethodGet); err != nil {
+				responderRecusa(w, err)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+// Carros por página de GET /carros paginado: o padrão quando só vem o cursor e o máximo de limit
+const (
+	limitePaginaPadrao = 100
+	limitePaginaMaximo = 1000
+)
+
+// paginaAPI é o envelope de GET /carros paginado; next_cursor fica de fora na última página
+type paginaAPI struct {
+	Carros     interface{} `json:"carros"` // []Carro, ou os registros projetados com ?fields=
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// camposDaURL lê ?fields=marca,modelo,preco, ou fields[carros]=... em JSON:API (vazio = todos os campos)
+func camposDaURL(r *http.Request) []string {
+	lista := r.URL.Query().Get("fields")
+	if lista == "" {
+		lista = r.URL.Query().Get("fields[carros]")
+	}
+	var campos []string
+	for _, campo := range strings.Split(lista, ",") {
+		if campo = strings.TrimSpace(campo); campo != "" {
+			campos = append(campos, campo)
+		}
+	}
+	return campos
+}
+
+// consultaDaURL lê os parâmetros de GET /carros; paginada é true com limit ou cursor
+func consultaDaURL(r *http.Request) (q Consulta, paginada bool, err error) {
+	params := r.URL.Query()
+	q.Cursor = params.Get("cursor")
+	q.Campos = camposDaURL(r)
+	if paginada = params.Has("limit") || params.Has("cursor"); paginada {
+		q.Limite = limitePaginaPadrao
+	}
+	if valor := params.Get("limit"); valor != "" {
+		n, err := strconv.Atoi(valor)
+		if err != nil || n < 1 || n > limitePaginaMaximo {
+			return q, paginada, fmt.Errorf("limit inválido: %s (de 1 a %d)", valor, limitePaginaMaximo)
+		}
+		q.Limite = n
+	}
+	return q, paginada, nil
+}
+
+// apiListar devolve os carros em estoque, na ordem de cadastro. Com ?limit=N ou ?cursor=..., devolve
+// uma página no envelope {carros, total, next_cursor}, em ordem estável: repetir a chamada com o
+// next_cursor continua do último carro, mesmo que outros tenham sido cadastrados ou removidos.
+// Com ?fields=marca,modelo,preco, cada carro vem só com esses campos e o id. Com ?format=jsonapi
+// (ou Accept: application/vnd.api+json), a resposta é um documento JSON:API com links de paginação.
+func (c *CadastroCarros) apiListar(w http.ResponseWriter, r *http.Request) {
+	q, paginada, err := consultaDaURL(r)
+	if err != nil {
+		responderErroNoFormato(w, r, http.StatusBadRequest, "%v", err)
+		return
+	}
+	jsonapi := pedeJSONAPI(r)
+	if !paginada && len(q.Campos) == 0 && !jsonapi {
+		carros := c.Snapshot().carros
+		if carros == nil {
+			carros = []Carro{}
+		}
+		responderJSON(w, http.StatusOK, carros)
+		return
+	}
+	resultado, err := c.Consultar(q)
+	if err != nil {
+		responderErroNoFormato(w, r, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if jsonapi {
+		recursos, err := c.recursosCarros(c.Snapshot(), resultado.Carros, q.Campos)
+		if err != nil {
+			responderErroNoFormato(w, r, http.StatusInternalServerError, "erro ao montar os recursos: %v", err)
+			return
+		}
+		documento := documentoJSONAPI{Data: recursos, Meta: map[string]interface{}{"total": resultado.Total}}
+		if paginada {
+			documento.Links = linksPaginacao(r, resultado.ProximoCursor)
+		}
+		responderJSONAPI(w, http.StatusOK, documento)
+		return
+	}
+	var carros interface{} = resultado.Carros
+	if len(q.Campos) > 0 {
+		carros = resultado.Registros
+	}
+	if !paginada {
+		responderJSON(w, http.StatusOK, carros)
+		return
+	}
+	responderJSON(w, http.StatusOK, paginaAPI{Carros: carros, Total: resultado.Total, NextCursor: resultado.ProximoCursor})
+}
+
+// apiBuscar devolve um carro em estoque pelo ID; ?fields= e ?format=jsonapi valem como em GET /carros
+func (c *CadastroCarros) apiBuscar(w http.ResponseWriter, r *http.Request) {
+	q := Consulta{Campos: camposDaURL(r)}
+	if err := q.validar(); err != nil {
+		responderErroNoFormato(w, r, http.StatusBadRequest, "%v", err)
+		return
+	}
+	visao := c.Snapshot()
+	carro, existe := visao.carrosMap[r.PathValue("id")]
+	if !existe {
+		responderErroNoFormato(w, r, http.StatusNotFound, "carro com ID '%s' não encontrado", r.PathValue("id"))
+		return
+	}
+	if pedeJSONAPI(r) {
+		recursos, err := c.recursosCarros(visao, []Carro{carro}, q.Campos)
+		if err != nil {
+			responderErroNoFormato(w, r, http.StatusInternalServerError, "erro ao montar o recurso: %v", err)
+			return
+		}
+		responderJSONAPI(w, http.StatusOK, documentoJSONAPI{Data: recursos[0], Links: map[string]string{"self": r.URL.RequestURI()}})
+		return
+	}
+	if len(q.Campos) == 0 {
+		responderJSON(w, http.StatusOK, carro)
+		return
+	}
+	registros, err := projetarCampos([]Carro{carro}, q.Campos)
+	if err != nil {
+		responderErro(w, http.StatusInternalServerError, "erro ao projetar os campos: %v", err)
+		return
+	}
+	responderJSON(w, http.StatusOK, registros[0])
+}
+
+// clienteHTTP identifica o cliente da requisição; o modelo de alto valor vem no cabeçalho
+// X-Confirmar-Modelo
+func clienteHTTP(r *http.Request) clienteRemoto {
+	return clienteRemoto{canal: "API", autor: "api:" + r.RemoteAddr, endereco: r.RemoteAddr,
+		confirmacao: r.Header.Get("X-Confirmar-Modelo"), comoConfirmar: "no cabeçalho X-Confirmar-Modelo"}
+}
+
+// statusDaRecusa é o status HTTP de cada motivo de recusa das operações remotas
+var statusDaRecusa = map[motivoRecusa]int{
+	recusaInvalida:       http.StatusUnprocessableEntity,
+	recusaNaoEncontrado:  http.StatusNotFound,
+	recusaConflito:       http.StatusConflict,
+	recusaConfirmacao:    http.StatusPreconditionRequired,
+	recusaProibida:       http.StatusForbidden,
+	recusaNaoAutenticada: http.StatusUnauthorized,
+}
+
+// responderRecusa grava o erro de uma operação remota com o status do motivo (500 para falhas)
+func responderRecusa(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if motivo, ok := motivoDaRecusa(err); ok {
+		status = statusDaRecusa[motivo]
+	}
+	responderErro(w, status, "%v", err)
+}
+
+// apiCadastrar cadastra o carro do corpo
+func (c *CadastroCarros) apiCadastrar(w http.ResponseWriter, r *http.Request) {
+	carro, err := lerCarroJSON(w, r)
+	if err != nil {
+		responderErro(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	carro, err = c.cadastrarRemoto(carro, clienteHTTP(r))
+	if err != nil {
+		responderRecusa(w, err)
+		return
+	}
+	w.Header().Set("Location", "/carros/"+carro.ID)
+	responderJSON(w, http.StatusCreated, carro)
+}
+
+// apiAtualizar substitui o carro pela versão do corpo
+func (c *CadastroCarros) apiAtualizar(w http.ResponseWriter, r *http.Request) {
+	editado, err := lerCarroJSON(w, r)
+	if err != nil {
+		responderErro(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	editado, err = c.atualizarRemoto(r.PathValue("id"), editado, clienteHTTP(r))
+	if err != nil {
+		responderRecusa(w, err)
+		return
+	}
+	responderJSON(w, http.StatusOK, editado)
+}
+
+// resultadoLoteAPI é o resultado de um registro de POST /carros/bulk; com o lote aplicado, o
+// status é 201 (cadastrado) ou 200 (atualizado); recusado, é o da recusa do registro, ou 424 para
+// os registros válidos que não foram aplicados por causa dos outros
+type resultadoLoteAPI struct {
+	Indice int    `json:"indice"` // Posição do registro no lote, a partir de 0
+	Acao   string `json:"acao"`   // "cadastrar" ou "atualizar"
+	ID     string `json:"id,omitempty"`
+	Status int    `json:"status"`
+	Erro   string `json:"erro,omitempty"`
+}
+
+// respostaLoteAPI é o corpo da resposta de POST /carros/bulk
+type respostaLoteAPI struct {
+	Aplicado   bool               `json:"aplicado"`
+	Resultados []resultadoLoteAPI `json:"resultados"`
+}
+
+// apiGravarLote cadastra ou atualiza, numa só transação, os carros do array do corpo: cada registro
+// atualiza o carro do mesmo ID ou, sem ID, o do mesmo chassi, e os demais são cadastrados. Se algum
+// registro for recusado, nenhum é aplicado (422, com o resultado de cada um). O cabeçalho
+// Idempotency-Key é obrigatório: repetir um lote aplicado com a mesma chave devolve a resposta
+// original sem aplicá-lo de novo, e reusar a chave com outro corpo é recusado.
+func (c *CadastroCarros) apiGravarLote(w http.ResponseWriter, r *http.Request) {
+	chave := r.Header.Get("Idempotency-Key")
+	if chave == "" {
+		responderErro(w, http.StatusBadRequest, "informe o cabeçalho Idempotency-Key (ex: um UUID por lote)")
+		return
+	}
+	corpo, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 32<<20))
+	if err != nil {
+		responderErro(w, http.StatusBadRequest, "erro ao ler o corpo: %v", err)
+		return
+	}
+	resumo := sha256.Sum256(corpo)
+
+	// Segura as chaves durante o lote, para que um reenvio simultâneo espere e receba a mesma resposta
+	c.idempotencia.mu.Lock()
+	defer c.idempotencia.mu.Unlock()
+	if guardada, existe := c.idempotencia.buscar(chave); existe {
+		if guardada.resumo != resumo {
+			responderErro(w, http.StatusUnprocessableEntity, "a Idempotency-Key '%s' já foi usada com outro lote", chave)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(guardada.status)
+		w.Write(guardada.corpo)
+		return
+	}
+
+	var carros []Carro
+	decodificador := json.NewDecoder(bytes.NewReader(corpo))
+	decodificador.DisallowUnknownFields()
+	if err := decodificador.Decode(&carros); err != nil {
+		responderErro(w, http.StatusBadRequest, "corpo JSON inválido (esperado um array de carros): %v", err)
+		return
+	}
+	if len(carros) == 0 {
+		responderErro(w, http.StatusBadRequest, "o lote está vazio")
+		return
+	}
+
+	registros, aplicado := c.gravarLoteRemoto(carros, clienteHTTP(r))
+	resposta := respostaLoteAPI{Aplicado: aplicado, Resultados: make([]resultadoLoteAPI, 0, len(registros))}
+	for i, registro := range registros {
+		resultado := resultadoLoteAPI{Indice: i, Acao: "atualizar", ID: registro.carro.ID, Status: http.StatusOK}
+		if registro.cria {
+			resultado.Acao, resultado.Status = "cadastrar", http.StatusCreated
+		}
+		if motivo, recusado := motivoDaRecusa(registro.err); recusado {
+			resultado.Status, resultado.Erro = statusDaRecusa[motivo], registro.err.Error()
+		} else if !aplicado {
+			resultado.Status = http.StatusFailedDependency
+		}
+		resposta.Resultados = append(resposta.Resultados, resultado)
+	}
+	status := http.StatusOK
+	if !aplicado {
+		status = http.StatusUnprocessableEntity
+	}
+	data, _ := json.MarshalIndent(resposta, "", "  ")
+	data = append(data, '\n')
+	if aplicado {
+		// Só o lote aplicado é guardado: um recusado pode ser corrigido e reenviado com a mesma chave
+		c.idempotencia.guardar(chave, respostaIdempotente{resumo: resumo, status: status, corpo: data, em: time.Now()})
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// apiRemover retira o carro do estoque, como o comando remove
+func (c *CadastroCarros) apiRemover(w http.ResponseWriter, r *http.Request) {
+	if err := c.removerRemoto(r.PathValue("id"), clienteHTTP(r)); err != nil {
+		responderRecusa(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IniciarAPI serve a API REST em segundo plano e devolve o endereço em que ficou no ar; a sessão
+// continua disponível e vê as alterações feitas pela API, que passam pelo mesmo cadastro, log de
+// eventos e persistência
+func (c *CadastroCarros) IniciarAPI(endereco string) (string, error) {
+	if err := c.conferirAPIParada(); err != nil {
+		return "", err
+	}
+	ouvinte, err := net.Listen("tcp", endereco)
+	if err != nil {
+		return "", fmt.Errorf("erro ao abrir %s: %v", endereco, err)
+	}
+	return c.IniciarAPIEm(ouvinte)
+}
+
+// IniciarAPIEm serve a API REST em um ouvinte já aberto, ex: um socket recebido do systemd. O
+// ouvinte passa a ser da API: é fechado por PararAPI, ou já na volta se a API estiver no ar.
+func (c *CadastroCarros) IniciarAPIEm(ouvinte net.Listener) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.api != nil {
+		ouvinte.Close()
+		return "", fmt.Errorf("a API já está no ar em %s. Use 'serve stop' antes de iniciar outra", c.api.Addr)
+	}
+
+	encerrando := make(chan struct{})
+	servidor := &http.Server{Addr: ouvinte.Addr().String(), Handler: c.rotasAPI(encerrando), ReadHeaderTimeout: 10 * time.Second}
+	servidor.RegisterOnShutdown(func() { close(encerrando) })
+	c.api = servidor
+	go func() {
+		if err := servidor.Serve(ouvinte); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: a API parou: %v", err), "a API parou", "endereco", servidor.Addr, "erro", err)
+		}
+	}()
+	c.log.Info("API REST no ar", "endereco", servidor.Addr)
+	return servidor.Addr, nil
+}
+
+// conferirAPIParada recusa iniciar uma segunda API antes de abrir a porta
+func (c *CadastroCarros) conferirAPIParada() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.api != nil {
+		return fmt.Errorf("a API já está no ar em %s. Use 'serve stop' antes de iniciar outra", c.api.Addr)
+	}
+	return nil
+}
+
+// PararAPI encerra a API REST, esperando as requisições em andamento; parou é false se não havia
+// API no ar
+func (c *CadastroCarros) PararAPI() (parou bool, err error) {
+	c.mu.Lock()
+	servidor := c.api
+	c.api = nil
+	c.mu.Unlock()
+	if servidor == nil {
+		return false, nil
+	}
+	ctx, cancelar := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelar()
+	if err := servidor.Shutdown(ctx); err != nil {
+		return true, fmt.Errorf("a API não encerrou de forma limpa: %v", err)
+	}
+	c.log.Info("API REST encerrada", "endereco", servidor.Addr)
+	return true, nil
+}
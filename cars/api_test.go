@@ -0,0 +1,287 @@
+package cars
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// requisitarAPI faz uma requisição às rotas da API do cadastro, sem abrir porta
+func requisitarAPI(c *CadastroCarros, metodo, caminho, corpo string, cabecalhos ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(metodo, caminho, strings.NewReader(corpo))
+	for i := 0; i+1 < len(cabecalhos); i += 2 {
+		r.Header.Set(cabecalhos[i], cabecalhos[i+1])
+	}
+	c.rotasAPI(nil).ServeHTTP(w, r)
+	return w
+}
+
+func TestAPIPaginaPorCursor(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste, x5Teste, x1Teste)
+	ids := idsDe(c.Snapshot().Carros())
+
+	var vistos []string
+	caminho := "/carros?limit=3"
+	for paginas := 0; caminho != ""; paginas++ {
+		w := requisitarAPI(c, "GET", caminho, "")
+		var pagina struct {
+			Carros     []Carro `json:"carros"`
+			Total      int     `json:"total"`
+			NextCursor string  `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &pagina); w.Code != http.StatusOK || err != nil || pagina.Total != len(ids) {
+			t.Fatalf("%s: status %d (%v): %s", caminho, w.Code, err, w.Body)
+		}
+		vistos = append(vistos, idsDe(pagina.Carros)...)
+		if paginas == 0 {
+			// Um carro cadastrado no meio da iteração entra no fim, sem repetir os já vistos
+			novo, _, err := c.Adicionar(Carro{Marca: "Audi", Modelo: "A4", Ano: 2021, Cor: "Branco", Preco: Reais(210000), PaisOrigem: "Alemanha"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, novo.ID)
+		}
+		caminho = ""
+		if pagina.NextCursor != "" {
+			caminho = "/carros?limit=3&cursor=" + url.QueryEscape(pagina.NextCursor)
+		}
+	}
+	if !slices.Equal(vistos, ids) {
+		t.Fatalf("páginas deveriam trazer todos os carros uma vez, em ordem: %v x %v", vistos, ids)
+	}
+
+	for _, caminho := range []string{"/carros?limit=0", "/carros?limit=5000", "/carros?cursor=xyz"} {
+		if w := requisitarAPI(c, "GET", caminho, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s deveria dar 400, obtido %d", caminho, w.Code)
+		}
+	}
+	var todos []Carro
+	if w := requisitarAPI(c, "GET", "/carros", ""); json.Unmarshal(w.Body.Bytes(), &todos) != nil || len(todos) != len(ids) {
+		t.Fatalf("sem limit nem cursor a listagem continua um array com todos os carros: %s", w.Body)
+	}
+}
+
+func TestAPIProjetaCampos(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste)
+	var registros []map[string]interface{}
+	w := requisitarAPI(c, "GET", "/carros?fields=marca,modelo,preco", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &registros); w.Code != http.StatusOK || err != nil || len(registros) != 2 {
+		t.Fatalf("status %d (%v): %s", w.Code, err, w.Body)
+	}
+	if len(registros[0]) != 4 || registros[0]["marca"] != "Toyota" || registros[0]["preco"] != 145000.0 || registros[0]["cor"] != nil {
+		t.Fatalf("esperado só id, marca, modelo e preço: %v", registros[0])
+	}
+
+	var pagina struct {
+		Carros     []map[string]interface{} `json:"carros"`
+		NextCursor string                   `json:"next_cursor"`
+	}
+	w = requisitarAPI(c, "GET", "/carros?fields=modelo&limit=1", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &pagina); err != nil || len(pagina.Carros) != 1 || len(pagina.Carros[0]) != 2 || pagina.NextCursor == "" {
+		t.Fatalf("projeção paginada: %s", w.Body)
+	}
+
+	id := c.Snapshot().Carros()[1].ID
+	var uno map[string]interface{}
+	if w := requisitarAPI(c, "GET", "/carros/"+id+"?fields=ano", ""); json.Unmarshal(w.Body.Bytes(), &uno) != nil || len(uno) != 2 || uno["ano"] != 2020.0 {
+		t.Fatalf("projeção de um carro: %s", w.Body)
+	}
+	for _, caminho := range []string{"/carros?fields=senha", "/carros/" + id + "?fields=marca,protegido"} {
+		if w := requisitarAPI(c, "GET", caminho, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s deveria dar 400, obtido %d", caminho, w.Code)
+		}
+	}
+}
+
+func TestAPIRespondeEmJSONAPI(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, x5Teste)
+	cfg := c.Config()
+	cfg.Catalogo.URL = "https://loja.com.br/carros/{id}"
+	c.Configurar(cfg, "", false)
+	corolla, x5 := c.Snapshot().Carros()[0], c.Snapshot().Carros()[1]
+	if _, err := c.RegistrarPagamento(Pagamento{CarroID: corolla.ID, Valor: Reais(5000), Data: "2025-01-10", Metodo: "pix", Tipo: "sinal"}); err != nil {
+		t.Fatal(err)
+	}
+	// O Uno entra como troca na venda do X5: a venda aparece nas relações dele
+	_, _, err := c.Vender(x5.ID, Reais(390000), &Carro{Marca: "Fiat", Modelo: "Uno", Ano: 2020, Cor: "Azul", Custo: Reais(40000), PaisOrigem: "Itália"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type documento struct {
+		Data []struct {
+			Type          string                     `json:"type"`
+			ID            string                     `json:"id"`
+			Attributes    map[string]json.RawMessage `json:"attributes"`
+			Relationships map[string]relacaoJSONAPI  `json:"relationships"`
+		} `json:"data"`
+		Meta  map[string]int    `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	w := requisitarAPI(c, "GET", "/carros?limit=1&fields=marca,preco", "", "Accept", tipoJSONAPI)
+	var doc documento
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || w.Header().Get("Content-Type") != tipoJSONAPI || len(doc.Data) != 1 {
+		t.Fatalf("status %d (%v), %s: %s", w.Code, err, w.Header().Get("Content-Type"), w.Body)
+	}
+	recurso := doc.Data[0]
+	if recurso.Type != "carros" || recurso.ID != corolla.ID || len(recurso.Attributes) != 2 || string(recurso.Attributes["marca"]) != `"Toyota"` {
+		t.Fatalf("recurso inesperado: %+v", recurso)
+	}
+	if reservas := recurso.Relationships["reservas"].Data.([]interface{}); len(reservas) != 1 {
+		t.Fatalf("o sinal do Corolla deveria aparecer nas reservas: %+v", recurso.Relationships)
+	}
+	if recurso.Relationships["fotos"].Links["related"] != "https://loja.com.br/carros/"+corolla.ID {
+		t.Fatalf("fotos deveria apontar para o catálogo: %+v", recurso.Relationships["fotos"])
+	}
+	if doc.Meta["total"] != 2 || doc.Links["next"] == "" || !strings.Contains(doc.Links["first"], "limit=1") || strings.Contains(doc.Links["first"], "cursor") {
+		t.Fatalf("meta e links de paginação inesperados: %+v %+v", doc.Meta, doc.Links)
+	}
+
+	w = requisitarAPI(c, "GET", doc.Links["next"]+"&format=jsonapi", "")
+	doc = documento{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || len(doc.Data) != 1 || doc.Links["next"] != "" {
+		t.Fatalf("segunda página: %s", w.Body)
+	}
+	if vendas := doc.Data[0].Relationships["vendas"].Data.([]interface{}); len(vendas) != 1 || vendas[0].(map[string]interface{})["id"] != x5.ID {
+		t.Fatalf("a troca deveria apontar a venda do X5: %+v", doc.Data[0].Relationships)
+	}
+
+	var erro struct {
+		Errors []erroJSONAPI `json:"errors"`
+	}
+	w = requisitarAPI(c, "GET", "/carros/car_0?format=jsonapi", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &erro); err != nil || w.Code != http.StatusNotFound || len(erro.Errors) != 1 || erro.Errors[0].Status != "404" {
+		t.Fatalf("erro em JSON:API: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestAPIGravaLoteUmaVezSo(t *testing.T) {
+	t.Parallel()
+	corolla := corollaTeste
+	corolla.Chassi = "9BR53ZEC2M1234567"
+	c := cadastroTeste(t, corolla, unoTeste)
+	uno := c.Snapshot().Carros()[1]
+
+	type resposta struct {
+		Aplicado   bool               `json:"aplicado"`
+		Resultados []resultadoLoteAPI `json:"resultados"`
+	}
+	lote := `[
+		{"id": "` + uno.ID + `", "marca": "Fiat", "modelo": "Uno", "ano": 2020, "cor": "Azul", "preco": 48000, "pais_origem": "Itália"},
+		{"chassi": "9br53zec2m 1234567", "marca": "Toyota", "modelo": "Corolla", "ano": 2021, "cor": "Branco", "preco": 145000, "pais_origem": "Japão"},
+		{"marca": "BMW", "modelo": "X1", "ano": 2022, "cor": "Preto", "preco": 200000, "pais_origem": "Alemanha"}
+	]`
+	w := requisitarAPI(c, "POST", "/carros/bulk", lote, "Idempotency-Key", "lote-1")
+	var r resposta
+	if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil || w.Code != http.StatusOK || !r.Aplicado || len(r.Resultados) != 3 {
+		t.Fatalf("status %d (%v): %s", w.Code, err, w.Body)
+	}
+	if r.Resultados[0].Status != http.StatusOK || r.Resultados[1].Acao != "atualizar" || r.Resultados[2].Status != http.StatusCreated {
+		t.Fatalf("resultados inesperados: %+v", r.Resultados)
+	}
+	carros := c.Snapshot().Carros()
+	if len(carros) != 3 || carros[0].Cor != "Branco" || carros[1].Preco != Reais(48000) || carros[2].ID != r.Resultados[2].ID {
+		t.Fatalf("o chassi deveria atualizar o Corolla e o X1 ser cadastrado: %+v", carros)
+	}
+
+	// O reenvio com a mesma chave devolve a mesma resposta sem cadastrar outro X1
+	reenvio := requisitarAPI(c, "POST", "/carros/bulk", lote, "Idempotency-Key", "lote-1")
+	if reenvio.Code != http.StatusOK || reenvio.Header().Get("Idempotent-Replayed") != "true" || reenvio.Body.String() != w.Body.String() || len(c.Snapshot().Carros()) != 3 {
+		t.Fatalf("reenvio deveria repetir a resposta: %d %s", reenvio.Code, reenvio.Body)
+	}
+	if w := requisitarAPI(c, "POST", "/carros/bulk", "[]", "Idempotency-Key", "lote-1"); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("chave reusada com outro corpo deveria dar 422, obtido %d", w.Code)
+	}
+
+	// Um registro recusado impede o lote inteiro
+	w = requisitarAPI(c, "POST", "/carros/bulk", `[
+		{"marca": "BMW", "modelo": "X5", "ano": 2022, "cor": "Preto", "preco": 400000, "pais_origem": "Alemanha"},
+		{"id": "car_0", "marca": "Fiat", "modelo": "Uno", "ano": 2020, "cor": "Azul", "preco": 50000, "pais_origem": "Itália"}
+	]`, "Idempotency-Key", "lote-2")
+	r = resposta{}
+	if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil || w.Code != http.StatusUnprocessableEntity || r.Aplicado {
+		t.Fatalf("status %d (%v): %s", w.Code, err, w.Body)
+	}
+	if r.Resultados[0].Status != http.StatusFailedDependency || r.Resultados[1].Status != http.StatusNotFound || len(c.Snapshot().Carros()) != 3 {
+		t.Fatalf("nada deveria ser aplicado: %+v", r.Resultados)
+	}
+
+	if w := requisitarAPI(c, "POST", "/carros/bulk", lote); w.Code != http.StatusBadRequest {
+		t.Fatalf("sem Idempotency-Key deveria dar 400, obtido %d", w.Code)
+	}
+	cfg := c.Config()
+	cfg.Permissoes.Negados = []string{"update"}
+	c.Configurar(cfg, "", false)
+	if w := requisitarAPI(c, "POST", "/carros/bulk", lote, "Idempotency-Key", "lote-3"); w.Code != http.StatusForbidden {
+		t.Fatalf("o lote exige add e update; obtido %d", w.Code)
+	}
+}
+
+// chaveAPITeste configura a chave com os comandos liberados e devolve a chave em claro
+func chaveAPITeste(cfg *Config, nome string, permitidos ...string) string {
+	chave := "chave-de-" + nome
+	hash := sha256.Sum256([]byte(chave))
+	cfg.Permissoes.ChavesAPI = append(cfg.Permissoes.ChavesAPI, ChaveAPI{Nome: nome, ChaveHash: hex.EncodeToString(hash[:]),
+		ListaComandos: ListaComandos{Permitidos: permitidos}})
+	return chave
+}
+
+func TestAPIPermissoesPorChave(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste)
+	cfg := c.Config()
+	estagiario := chaveAPITeste(&cfg, "estagiario", "add", "list")
+	gerente := chaveAPITeste(&cfg, "gerente")
+	if err := cfg.Permissoes.Validar(); err != nil {
+		t.Fatal(err)
+	}
+	c.Configurar(cfg, "", false)
+	corolla, uno := c.Snapshot().Carros()[0], c.Snapshot().Carros()[1]
+
+	if w := requisitarAPI(c, "DELETE", "/carros/"+corolla.ID, "", "X-API-Key", estagiario); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "estagiario") {
+		t.Fatalf("a chave do estagiário não remove: %d %s", w.Code, w.Body)
+	}
+	if w := requisitarAPI(c, "GET", "/carros", "", "X-API-Key", estagiario); w.Code != http.StatusOK {
+		t.Fatalf("a chave do estagiário lista: %d %s", w.Code, w.Body)
+	}
+	if w := requisitarAPI(c, "DELETE", "/carros/"+uno.ID, "", "X-API-Key", gerente); w.Code != http.StatusNoContent || len(c.Snapshot().Carros()) != 1 {
+		t.Fatalf("a chave do gerente remove: %d %s", w.Code, w.Body)
+	}
+	for _, cabecalhos := range [][]string{nil, {"X-API-Key", "chave-de-ninguem"}} {
+		if w := requisitarAPI(c, "GET", "/carros", "", cabecalhos...); w.Code != http.StatusUnauthorized {
+			t.Errorf("%v: com chaves configuradas, a chamada sem chave conhecida deveria dar 401, obtido %d", cabecalhos, w.Code)
+		}
+	}
+
+	// As listas gerais continuam valendo para todas as chaves
+	cfg.Permissoes.Negados = []string{"list"}
+	c.Configurar(cfg, "", false)
+	if w := requisitarAPI(c, "GET", "/carros", "", "X-API-Key", gerente); w.Code != http.StatusForbidden {
+		t.Fatalf("list negado para todos: %d", w.Code)
+	}
+}
+
+func TestPermissoesPorUsuario(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t)
+	cfg := c.Config()
+	cfg.Permissoes.Usuarios = map[string]ListaComandos{usuarioSistema(): {Negados: []string{"remove"}}, "outro": {Negados: []string{"add"}}}
+	c.Configurar(cfg, "", false)
+	if c.ComandoPermitido("remove") || !c.ComandoPermitido("add") {
+		t.Fatal("o prompt deveria seguir as listas do usuário do sistema")
+	}
+	invalida := OpcoesPermissoes{Usuarios: map[string]ListaComandos{"x": {Negados: []string{"exit"}}}}
+	if invalida.Validar() == nil {
+		t.Fatal("exit não pode ser negado nem nas listas de um usuário")
+	}
+}
@@ -0,0 +1,375 @@
+package cars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Armazenamento é o backend onde o cadastro é persistido. O cadastro vive inteiro em memória e
+// cuida das regras (validação, quarentena, log de eventos, cifragem); o backend só lê e grava os
+// registros. Salvar grava tudo, então um backend novo não precisa saber o que mudou. Backends de
+// fora do pacote leem e gravam cada Anexo com encoding/json sobre Anexo.Lista.
+type Armazenamento interface {
+	// Carregar devolve os carros crus, um por registro, para que um malformado vá para a quarentena
+	// sem impedir os demais, e preenche as coleções auxiliares. origem identifica os registros na
+	// quarentena. Sem dados gravados, devolve nenhum carro e nenhum erro.
+	Carregar(anexos []Anexo) (carros []json.RawMessage, origem string, err error)
+	// Salvar regrava os carros e as coleções auxiliares
+	Salvar(carros []Carro, anexos []Anexo) error
+	// Descrever identifica o backend no diagnóstico, ex: "json (/dados/carros.json)"
+	Descrever() string
+	// Arquivos lista os arquivos em disco do backend (vazio se não houver)
+	Arquivos() []string
+	// Fechar libera o backend; o cadastro não deve ser gravado depois disso
+	Fechar() error
+}
+
+// carregar lê o cadastro do backend e reconstrói o map e o slice. Registros malformados vão para a
+// quarentena e o resultado é regravado sem eles (chamador deve segurar o lock, salvo na abertura).
+func (c *CadastroCarros) carregar() error {
+	defer c.Medir("carregar")()
+	brutos, origem, err := c.armazenamento.Carregar(c.anexos())
+	if err != nil {
+		return err
+	}
+
+	// Reconstrói o map e o slice
+	c.carros = make([]Carro, 0, len(brutos))
+	c.carrosMap = make(map[string]Carro)
+	novos := 0
+	for i, bruto := range brutos {
+		if err := c.acolherRegistro(bruto); err != nil {
+			c.quarentenar(origem, i+1, bruto, err)
+			novos++
+		}
+	}
+
+	for _, aviso := range c.revelarCampos() {
+		c.log.Warn("campos protegidos não decifrados", "aviso", aviso)
+		c.avisosAbertura = append(c.avisosAbertura, aviso)
+	}
+	mudou := c.conciliarEventos()
+	if novos > 0 || mudou {
+		// Regrava sem os registros inválidos; eles ficam preservados na quarentena
+		if err := c.salvar(); err != nil {
+			return err
+		}
+	}
+	c.quarentenados += novos
+	return nil
+}
+
+// AbrirArmazenamento abre o backend da configuração em vigor e carrega os dados: o banco bbolt ou
+// o arquivo no formato configurado, com os backups da configuração. Devolve quantos carros estão
+// em estoque depois da carga.
+func (c *CadastroCarros) AbrirArmazenamento() (int, error) {
+	cfg := c.Config()
+	if cfg.Armazenamento.Tipo == "bbolt" {
+		if err := c.AbrirBolt(cfg.Armazenamento.Arquivo); err != nil {
+			return 0, err
+		}
+	} else if err := c.Carregar(&armazenamentoArquivo{arquivo: cfg.Armazenamento.Arquivo, serializador: Serializadores[cfg.Armazenamento.Tipo], backup: cfg.Backup, log: c.log}); err != nil {
+		return 0, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.log.Info("dados carregados", "armazenamento", c.armazenamento.Descrever(), "perfil", cfg.Perfil,
+		"carros", len(c.carros), "eventos", len(c.eventos))
+	return len(c.carros), nil
+}
+
+// Carregar passa a usar o backend informado e lê dele o cadastro, descartando o que estiver em memória
+func (c *CadastroCarros) Carregar(armazenamento Armazenamento) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.armazenamento = armazenamento
+	c.invalidarVisao()
+	return c.carregar()
+}
+
+// AvisosAoAbrir devolve os avisos do carregamento dos dados que não impediram a abertura
+func (c *CadastroCarros) AvisosAoAbrir() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.avisosAbertura)
+}
+
+// FecharArmazenamento fecha o backend em uso
+func (c *CadastroCarros) FecharArmazenamento() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.armazenamento.Fechar()
+}
+
+// armazenamentoArquivo grava os carros em um arquivo e cada coleção auxiliar em um arquivo ao lado,
+// no formato do serializador (JSON, YAML ou MessagePack)
+type armazenamentoArquivo struct {
+	arquivo      string
+	serializador Serializador
+	backup       OpcoesBackup // Backups com rotação antes de cada gravação (ver backups.go)
+	log          *slog.Logger // Falhas de backup e arquivos recuperados (nil = descartar)
+}
+
+// registro devolve o logger do backend, ou um que descarta tudo se nenhum foi configurado
+func (a *armazenamentoArquivo) registro() *slog.Logger {
+	if a.log == nil {
+		return registroDescartado()
+	}
+	return a.log
+}
+
+func (a *armazenamentoArquivo) Descrever() string {
+	return a.serializador.Nome() + " (" + a.arquivo + ")"
+}
+
+func (a *armazenamentoArquivo) Fechar() error { return nil }
+
+func (a *armazenamentoArquivo) Arquivos() []string {
+	arquivos := []string{a.arquivo}
+	for _, nome := range nomesAnexos() {
+		arquivos = append(arquivos, a.arquivoAnexo(nome))
+	}
+	return arquivos
+}
+
+func (a *armazenamentoArquivo) Carregar(anexos []Anexo) ([]json.RawMessage, string, error) {
+	limparTemporarios(a.arquivo)
+	var brutos []json.RawMessage
+	existe, err := lerComRecuperacao(a.arquivo, a.registro(), func(data []byte) error {
+		brutos = nil
+		return a.serializador.Decodificar(data, &brutos)
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao carregar %s: %v", a.serializador.Nome(), err)
+	}
+	if !existe {
+		return nil, a.arquivo, nil
+	}
+	for _, anx := range anexos {
+		anx.Limpar()
+		if err := a.carregarAnexo(anx.Nome, anx.Lista); err != nil {
+			return nil, "", err
+		}
+	}
+	return brutos, a.arquivo, nil
+}
+
+func (a *armazenamentoArquivo) Salvar(carros []Carro, anexos []Anexo) error {
+	data, err := a.serializador.Codificar(carros)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar para %s: %v", a.serializador.Nome(), err)
+	}
+	// Um backup que falha não impede a gravação: perder a cópia é melhor que perder a alteração
+	if err := a.fazerBackup(); err != nil {
+		a.registro().Warn("falha ao fazer backup", "arquivo", a.arquivo, "diretorio", a.diretorioBackups(), "erro", err)
+	}
+	if err := GravarAtomico(a.arquivo, data, true); err != nil {
+		return fmt.Errorf("erro ao escrever arquivo %s: %v", a.serializador.Nome(), err)
+	}
+	for _, anx := range anexos {
+		if err := a.salvarAnexo(anx.Nome, anx.Lista, anx.Vazia()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arquivoAnexo devolve o caminho de um arquivo auxiliar ao lado do arquivo de dados, com a mesma
+// extensão (ex: carros.removidos.json ou carros.removidos.yaml)
+func (a *armazenamentoArquivo) arquivoAnexo(nome string) string {
+	ext := filepath.Ext(a.arquivo)
+	if ext == "" {
+		ext = "." + a.serializador.Extensao()
+	}
+	return strings.TrimSuffix(a.arquivo, ext) + "." + nome + ext
+}
+
+// salvarAnexo grava uma lista auxiliar (lápides, vendas...) no seu arquivo.
+// Listas vazias só são gravadas se o arquivo já existir, para não criar arquivos à toa.
+func (a *armazenamentoArquivo) salvarAnexo(nome string, lista interface{}, vazia bool) error {
+	caminho := a.arquivoAnexo(nome)
+	if vazia {
+		if _, err := os.Stat(caminho); os.IsNotExist(err) {
+			return nil
+		}
+	}
+	data, err := a.serializador.Codificar(lista)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar %s para %s: %v", nome, a.serializador.Nome(), err)
+	}
+	if err := GravarAtomico(caminho, data, true); err != nil {
+		return fmt.Errorf("erro ao escrever arquivo de %s: %v", nome, err)
+	}
+	return nil
+}
+
+// carregarAnexo lê uma lista auxiliar do seu arquivo, se ele existir
+func (a *armazenamentoArquivo) carregarAnexo(nome string, destino interface{}) error {
+	caminho := a.arquivoAnexo(nome)
+	limparTemporarios(caminho)
+	if _, err := lerComRecuperacao(caminho, a.registro(), func(data []byte) error {
+		return a.serializador.Decodificar(data, destino)
+	}); err != nil {
+		return fmt.Errorf("erro ao carregar %s: %v", nome, err)
+	}
+	return nil
+}
+
+// arquivoAnterior é onde fica a versão anterior de um arquivo de dados, a última que foi
+// carregada ou gravada por inteiro
+func arquivoAnterior(caminho string) string {
+	return caminho + ".bak"
+}
+
+// GravarAtomico grava o arquivo por inteiro ou não grava: escreve em um temporário no mesmo
+// diretório, força para o disco e o renomeia por cima do destino, então uma queda no meio da
+// gravação deixa o arquivo antigo intacto. Com manterAnterior, a versão substituída fica em
+// arquivoAnterior para lerComRecuperacao.
+func GravarAtomico(caminho string, data []byte, manterAnterior bool) error {
+	tmp, err := os.CreateTemp(filepath.Dir(caminho), "."+filepath.Base(caminho)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Só sobra se a gravação falhar antes do rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if manterAnterior {
+		if _, err := os.Stat(caminho); err == nil {
+			anterior := arquivoAnterior(caminho)
+			os.Remove(anterior)
+			// Link é instantâneo; sistemas de arquivos sem hard links recebem uma cópia
+			if err := os.Link(caminho, anterior); err != nil {
+				if err := copiarArquivo(caminho, anterior); err != nil {
+					return fmt.Errorf("erro ao preservar a versão anterior: %v", err)
+				}
+			}
+		}
+	}
+	if err := os.Rename(tmp.Name(), caminho); err != nil {
+		return err
+	}
+	// Sincroniza o diretório para o rename sobreviver a uma queda de energia (ignorado onde não é possível)
+	if dir, err := os.Open(filepath.Dir(caminho)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// lerComRecuperacao lê e decodifica o arquivo. Se ele estiver vazio ou corrompido, guarda uma
+// cópia para análise e o restaura da versão anterior (arquivoAnterior), avisando em log. existe é
+// false se o arquivo não existir.
+func lerComRecuperacao(caminho string, log *slog.Logger, decodificar func([]byte) error) (existe bool, err error) {
+	data, err := os.ReadFile(caminho)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("erro ao ler %s: %v", caminho, err)
+	}
+	erroPrincipal := decodificar(data)
+	vazio := len(bytes.TrimSpace(data)) == 0
+	if erroPrincipal == nil && !vazio {
+		return true, nil
+	}
+
+	// Um arquivo vazio que o formato aceita só é tratado como estrago se houver versão anterior
+	anterior := arquivoAnterior(caminho)
+	dataAnterior, err := os.ReadFile(anterior)
+	switch {
+	case err != nil && erroPrincipal == nil:
+		return true, nil
+	case err != nil:
+		return true, fmt.Errorf("%s está corrompido (%v) e não há versão anterior em %s", caminho, erroPrincipal, anterior)
+	case erroPrincipal == nil:
+		erroPrincipal = fmt.Errorf("arquivo vazio")
+	}
+	if err := decodificar(dataAnterior); err != nil {
+		return true, fmt.Errorf("%s está corrompido (%v) e a versão anterior também (%v)", caminho, erroPrincipal, err)
+	}
+	corrompido := fmt.Sprintf("%s.corrompido-%s", caminho, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(corrompido, data, 0644); err != nil {
+		corrompido = "(não foi possível guardar uma cópia: " + err.Error() + ")"
+	}
+	// Restaura já, para a próxima gravação não preservar o arquivo estragado como versão anterior
+	if err := GravarAtomico(caminho, dataAnterior, false); err != nil {
+		return true, fmt.Errorf("erro ao restaurar %s a partir de %s: %v", caminho, anterior, err)
+	}
+	log.Warn("arquivo corrompido restaurado da última versão boa",
+		"arquivo", caminho, "erro", erroPrincipal, "anterior", anterior, "copia_corrompida", corrompido)
+	return true, nil
+}
+
+// limparTemporarios apaga temporários de gravações interrompidas por uma queda
+func limparTemporarios(caminho string) {
+	restos, _ := filepath.Glob(filepath.Join(filepath.Dir(caminho), "."+filepath.Base(caminho)+".*.tmp"))
+	for _, resto := range restos {
+		os.Remove(resto)
+	}
+}
+
+// ArmazenamentoMemoria guarda a última gravação em JSON na memória do processo. Serve para testes e
+// para sessões descartáveis: cada carregamento desserializa uma cópia, como faria um backend em disco.
+type ArmazenamentoMemoria struct {
+	carros []byte
+	anexos map[string][]byte
+}
+
+func (m *ArmazenamentoMemoria) Descrever() string  { return "memória (nada é gravado em disco)" }
+func (m *ArmazenamentoMemoria) Arquivos() []string { return nil }
+func (m *ArmazenamentoMemoria) Fechar() error      { return nil }
+
+func (m *ArmazenamentoMemoria) Carregar(anexos []Anexo) ([]json.RawMessage, string, error) {
+	for _, anx := range anexos {
+		anx.Limpar()
+		if data, existe := m.anexos[anx.Nome]; existe {
+			if err := json.Unmarshal(data, anx.Lista); err != nil {
+				return nil, "", fmt.Errorf("%s inválido(s): %v", anx.Nome, err)
+			}
+		}
+	}
+	if m.carros == nil {
+		return nil, "memória", nil
+	}
+	var brutos []json.RawMessage
+	if err := json.Unmarshal(m.carros, &brutos); err != nil {
+		return nil, "", fmt.Errorf("erro ao desserializar carros: %v", err)
+	}
+	return brutos, "memória", nil
+}
+
+func (m *ArmazenamentoMemoria) Salvar(carros []Carro, anexos []Anexo) error {
+	data, err := json.Marshal(carros)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar carros: %v", err)
+	}
+	gravados := make(map[string][]byte, len(anexos))
+	for _, anx := range anexos {
+		if gravados[anx.Nome], err = json.Marshal(anx.Lista); err != nil {
+			return fmt.Errorf("erro ao serializar %s: %v", anx.Nome, err)
+		}
+	}
+	m.carros, m.anexos = data, gravados
+	return nil
+}
@@ -0,0 +1,79 @@
+package cars_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// armazenamentoMapa é um backend escrito fora do pacote, só com o que cars exporta: cada coleção
+// vira um documento JSON sob o nome do anexo
+type armazenamentoMapa struct {
+	documentos map[string][]byte
+}
+
+func (m *armazenamentoMapa) Descrever() string  { return "mapa" }
+func (m *armazenamentoMapa) Arquivos() []string { return nil }
+func (m *armazenamentoMapa) Fechar() error      { return nil }
+
+func (m *armazenamentoMapa) Carregar(anexos []cars.Anexo) ([]json.RawMessage, string, error) {
+	for _, anx := range anexos {
+		anx.Limpar()
+		if data, existe := m.documentos[anx.Nome]; existe {
+			if err := json.Unmarshal(data, anx.Lista); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	var brutos []json.RawMessage
+	if data, existe := m.documentos["carros"]; existe {
+		if err := json.Unmarshal(data, &brutos); err != nil {
+			return nil, "", err
+		}
+	}
+	return brutos, "mapa", nil
+}
+
+func (m *armazenamentoMapa) Salvar(carros []cars.Carro, anexos []cars.Anexo) error {
+	documentos := map[string][]byte{}
+	var err error
+	if documentos["carros"], err = json.Marshal(carros); err != nil {
+		return err
+	}
+	for _, anx := range anexos {
+		if documentos[anx.Nome], err = json.Marshal(anx.Lista); err != nil {
+			return err
+		}
+	}
+	m.documentos = documentos
+	return nil
+}
+
+func TestArmazenamentoDeForaDoPacote(t *testing.T) {
+	t.Parallel()
+	backend := &armazenamentoMapa{}
+	c := cars.NewCadastroCarrosEm(backend)
+	corolla, _, err := c.Adicionar(cars.Carro{Marca: "Toyota", Modelo: "Corolla", Ano: 2021, Cor: "Prata", Preco: cars.Reais(145000), PaisOrigem: "Japão"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uno, _, err := c.Adicionar(cars.Carro{Marca: "Fiat", Modelo: "Uno", Ano: 2020, Cor: "Azul", Preco: cars.Reais(50000), PaisOrigem: "Itália"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Remover(corolla.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.documentos["removidos"]) == 0 || string(backend.documentos["removidos"]) == "null" {
+		t.Fatalf("a lápide do Corolla deveria ter sido gravada como anexo: %s", backend.documentos)
+	}
+
+	recarregado := cars.NewCadastroCarrosEm(backend)
+	if err := recarregado.Carregar(backend); err != nil {
+		t.Fatal(err)
+	}
+	if carros := recarregado.Snapshot().Carros(); len(carros) != 1 || carros[0].ID != uno.ID {
+		t.Fatalf("esperado só o Uno depois de recarregar: %+v", carros)
+	}
+}
@@ -0,0 +1,274 @@
+package cars
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+// OpcoesAuditoria é a seção "auditoria" da configuração: retenção do log de eventos
+type OpcoesAuditoria struct {
+	RetencaoMeses int    `json:"retencao_meses,omitempty"` // Compacta os eventos de meses anteriores aos últimos N (0 = guarda tudo)
+	Diretorio     string `json:"diretorio,omitempty"`      // Onde ficam os arquivos com os eventos brutos (padrão: junto dos dados)
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesAuditoria) Validar() error {
+	if o.RetencaoMeses < 0 {
+		return fmt.Errorf("auditoria: retencao_meses não pode ser negativo")
+	}
+	return nil
+}
+
+// ResumoAuditoria substitui, no log, os eventos brutos de um mês compactado: quantos eventos de
+// cada tipo houve e em que arquivo eles foram guardados
+type ResumoAuditoria struct {
+	Mes          string `json:"mes"` // AAAA-MM (UTC, como o instante dos eventos)
+	Eventos      int    `json:"eventos"`
+	Adicionados  int    `json:"adicionados"`
+	Atualizados  int    `json:"atualizados"`
+	Removidos    int    `json:"removidos"`
+	Vendidos     int    `json:"vendidos"`
+	Carros       int    `json:"carros"`        // Carros distintos com eventos no mês
+	Arquivo      string `json:"arquivo"`       // Arquivo JSON Lines com os eventos brutos
+	CompactadoEm string `json:"compactado_em"` // Instante da compactação (RFC 3339)
+}
+
+// corteRetencao devolve o primeiro instante mantido no log: o início do mês de N meses atrás
+func corteRetencao(meses int, agora time.Time) time.Time {
+	return time.Date(agora.Year(), agora.Month()-time.Month(meses), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// compactarEventos separa o trecho inicial do log anterior ao corte e o troca pelo estado líquido
+// de cada carro: um "adicionado" com o último estado (na posição e no instante do cadastro) e,
+// se for o caso, a venda ou a remoção. Reaplicado, o log compactado gera a mesma projeção; o que
+// se perde é o passo a passo das alterações antigas, que fica no arquivo.
+func compactarEventos(eventos []Evento, corte time.Time) (compactados, antigos []Evento) {
+	fim := 0
+	for fim < len(eventos) {
+		em, err := time.Parse(time.RFC3339Nano, eventos[fim].Em)
+		if err != nil || !em.Before(corte) {
+			break
+		}
+		fim++
+	}
+	antigos = eventos[:fim]
+
+	type liquido struct {
+		adicao Evento // Primeiro evento do carro, que dá a posição e o instante do cadastro
+		carro  Carro  // Último estado conhecido
+		saida  *Evento
+		todos  []Evento
+	}
+	porID := make(map[string]*liquido)
+	for _, e := range antigos {
+		if e.Tipo == EventoCarroPurgado {
+			// O estado líquido de um ID purgado é nada: o histórico dele fica só no arquivo
+			delete(porID, e.CarroID)
+			continue
+		}
+		l, existe := porID[e.CarroID]
+		if !existe {
+			l = &liquido{adicao: e}
+			porID[e.CarroID] = l
+		}
+		l.todos = append(l.todos, e)
+		switch {
+		case e.Carro != nil:
+			l.carro = *e.Carro
+		case e.Venda != nil:
+			l.carro = e.Venda.Carro
+		}
+		switch e.Tipo {
+		case EventoCarroVendido, EventoCarroRemovido:
+			l.saida = &e
+		case EventoCarroRestaurado:
+			l.saida = nil
+		}
+	}
+
+	for _, l := range porID {
+		if l.adicao.Carro == nil {
+			// Sem estado inicial (lápide ou venda vindas de antes do log): mantém como está
+			compactados = append(compactados, l.todos...)
+			continue
+		}
+		carro := l.carro
+		compactados = append(compactados, Evento{Seq: l.adicao.Seq, Tipo: EventoCarroAdicionado, CarroID: l.adicao.CarroID, Em: l.adicao.Em, Carro: &carro})
+		if l.saida != nil {
+			compactados = append(compactados, *l.saida)
+		}
+	}
+	sort.Slice(compactados, func(i, j int) bool { return compactados[i].Seq < compactados[j].Seq })
+	return append(compactados, eventos[fim:]...), antigos
+}
+
+// resumirEventos agrupa os eventos por mês em resumos
+func resumirEventos(eventos []Evento, agora time.Time) []ResumoAuditoria {
+	porMes := make(map[string]*ResumoAuditoria)
+	carros := make(map[string]map[string]bool)
+	var meses []string
+	for _, e := range eventos {
+		mes := e.Em[:7]
+		r, existe := porMes[mes]
+		if !existe {
+			r = &ResumoAuditoria{Mes: mes, CompactadoEm: agora.UTC().Format(time.RFC3339Nano)}
+			porMes[mes], carros[mes] = r, make(map[string]bool)
+			meses = append(meses, mes)
+		}
+		r.Eventos++
+		carros[mes][e.CarroID] = true
+		switch e.Tipo {
+		case EventoCarroAdicionado:
+			r.Adicionados++
+		case EventoCarroAtualizado:
+			r.Atualizados++
+		case EventoCarroRemovido:
+			r.Removidos++
+		case EventoCarroVendido:
+			r.Vendidos++
+		}
+	}
+	sort.Strings(meses)
+	resumos := make([]ResumoAuditoria, len(meses))
+	for i, mes := range meses {
+		porMes[mes].Carros = len(carros[mes])
+		resumos[i] = *porMes[mes]
+	}
+	return resumos
+}
+
+// arquivarEventos grava os eventos brutos em JSON Lines, sem sobrescrever um arquivo existente
+func arquivarEventos(arquivo string, eventos []Evento) error {
+	f, err := os.OpenFile(arquivo, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo de auditoria: %v", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range eventos {
+		if err = enc.Encode(e); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync() // O log só é compactado depois que o arquivo está no disco
+	}
+	if errFechar := f.Close(); err == nil {
+		err = errFechar
+	}
+	if err != nil {
+		os.Remove(arquivo)
+		return fmt.Errorf("erro ao gravar arquivo de auditoria: %v", err)
+	}
+	return nil
+}
+
+// Compactacao é o resultado de uma compactação do log: o corte da retenção e, se algum evento foi
+// exportado, os meses, o arquivo e quantos eventos com o estado líquido ficaram no lugar
+type Compactacao struct {
+	Corte      time.Time
+	Exportados int
+	De, Ate    string // Primeiro e último mês exportados (AAAA-MM)
+	Arquivo    string
+	Liquidos   int
+}
+
+// CompactarAuditoria exporta os eventos de meses anteriores aos últimos `meses` para um arquivo e
+// os substitui no log pelo estado líquido de cada carro e por um resumo por mês
+func (c *CadastroCarros) CompactarAuditoria(meses int) (Compactacao, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	diretorio := c.configAtiva.Auditoria.Diretorio
+	if arquivos := c.armazenamento.Arquivos(); diretorio == "" && len(arquivos) > 0 {
+		diretorio = filepath.Dir(arquivos[0])
+	}
+	agora := time.Now()
+	corte := corteRetencao(meses, agora)
+	compactados, antigos := compactarEventos(c.eventos, corte)
+	// Os eventos de meses já compactados são o estado líquido de antes: entram de novo na
+	// compactação, mas não no arquivo nem nos resumos
+	limite := c.limiteAuditoria()
+	var novos []Evento
+	for _, e := range antigos {
+		if em, _ := time.Parse(time.RFC3339Nano, e.Em); !em.Before(limite) {
+			novos = append(novos, e)
+		}
+	}
+	if len(novos) == 0 {
+		return Compactacao{Corte: corte}, nil
+	}
+
+	resumos := resumirEventos(novos, agora)
+	nome := fmt.Sprintf("auditoria-%s-a-%s.jsonl", resumos[0].Mes, resumos[len(resumos)-1].Mes)
+	arquivo := filepath.Join(diretorio, nome)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(arquivo); os.IsNotExist(err) {
+			break
+		}
+		arquivo = filepath.Join(diretorio, fmt.Sprintf("%s.%d.jsonl", nome[:len(nome)-len(".jsonl")], i))
+	}
+	// O arquivo guarda os eventos como o arquivo de dados: com os campos sensíveis cifrados
+	arquivados := novos
+	cf, err := c.novoCifrador()
+	if err == nil && cf != nil {
+		arquivados, err = cf.protegerEventos(novos)
+	}
+	if err == nil {
+		err = arquivarEventos(arquivo, arquivados)
+	}
+	if err != nil {
+		return Compactacao{}, fmt.Errorf("%v. Log mantido como estava", err)
+	}
+	for i := range resumos {
+		resumos[i].Arquivo = arquivo
+	}
+
+	resultado := Compactacao{
+		Corte:      corte,
+		Exportados: len(novos),
+		De:         resumos[0].Mes,
+		Ate:        resumos[len(resumos)-1].Mes,
+		Arquivo:    arquivo,
+		Liquidos:   len(compactados) - (len(c.eventos) - len(antigos)),
+	}
+	c.eventos = compactados
+	c.resumosAuditoria = append(c.resumosAuditoria, resumos...)
+	c.log.Info("log de eventos compactado", "exportados", resultado.Exportados, "de", resultado.De, "ate", resultado.Ate,
+		"arquivo", arquivo, "liquidos", resultado.Liquidos)
+
+	// Persistir após compactar
+	return resultado, c.gravar()
+}
+
+// limiteAuditoria devolve o fim do último mês compactado (zero se o log está completo)
+func (d *dadosCarros) limiteAuditoria() time.Time {
+	var limite time.Time
+	for _, r := range d.resumosAuditoria {
+		if mes, err := time.Parse("2006-01", r.Mes); err == nil && mes.AddDate(0, 1, 0).After(limite) {
+			limite = mes.AddDate(0, 1, 0)
+		}
+	}
+	return limite
+}
+
+// LimiteAuditoria devolve o fim do último mês compactado do log (zero se ele está completo)
+func (v *VisaoCarros) LimiteAuditoria() time.Time {
+	return v.limiteAuditoria()
+}
+
+// ResumosAuditoria devolve os resumos dos meses compactados, em ordem de mês
+func (v *VisaoCarros) ResumosAuditoria() []ResumoAuditoria {
+	resumos := slices.Clone(v.resumosAuditoria)
+	sort.SliceStable(resumos, func(i, j int) bool { return resumos[i].Mes < resumos[j].Mes })
+	return resumos
+}
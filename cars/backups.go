@@ -0,0 +1,230 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpcoesBackup é a seção "backup" da configuração: antes de cada gravação, o arquivo de dados
+// atual é copiado para o diretório de backups com a data e a hora no nome, e só os mais recentes
+// são mantidos. Vale para os backends em arquivo (json, yaml, msgpack, protobuf).
+type OpcoesBackup struct {
+	Manter    int    `json:"manter"`              // Quantos backups manter (0 = desligado; padrão 10)
+	Diretorio string `json:"diretorio,omitempty"` // Padrão: "backups" ao lado do arquivo de dados
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesBackup) Validar() error {
+	if o.Manter < 0 {
+		return fmt.Errorf("backup: manter não pode ser negativo")
+	}
+	return nil
+}
+
+// diretorioBackups devolve onde ficam os backups do arquivo de dados
+func (a *armazenamentoArquivo) diretorioBackups() string {
+	if a.backup.Diretorio != "" {
+		return a.backup.Diretorio
+	}
+	return filepath.Join(filepath.Dir(a.arquivo), "backups")
+}
+
+// prefixoBackup é o início do nome dos backups deste arquivo, ex: "carros-" para carros.json
+func (a *armazenamentoArquivo) prefixoBackup() (prefixo, ext string) {
+	base := filepath.Base(a.arquivo)
+	ext = filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// listarBackups devolve os backups deste arquivo de dados, do mais recente ao mais antigo
+// (o nome começa pela data e hora, então a ordem alfabética é a cronológica)
+func (a *armazenamentoArquivo) listarBackups() []string {
+	prefixo, ext := a.prefixoBackup()
+	entradas, _ := os.ReadDir(a.diretorioBackups())
+	var backups []string
+	for _, e := range entradas {
+		if nome := e.Name(); !e.IsDir() && strings.HasPrefix(nome, prefixo) && strings.HasSuffix(nome, ext) {
+			backups = append(backups, filepath.Join(a.diretorioBackups(), nome))
+		}
+	}
+	slices.Sort(backups)
+	slices.Reverse(backups)
+	return backups
+}
+
+// fazerBackup copia o arquivo de dados atual para o diretório de backups, antes de ele ser
+// substituído, e apaga os backups além dos `manter` mais recentes
+func (a *armazenamentoArquivo) fazerBackup() error {
+	if a.backup.Manter == 0 {
+		return nil
+	}
+	if _, err := os.Stat(a.arquivo); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.MkdirAll(a.diretorioBackups(), 0755); err != nil {
+		return err
+	}
+	agora := time.Now()
+	prefixo, ext := a.prefixoBackup()
+	destino := filepath.Join(a.diretorioBackups(),
+		fmt.Sprintf("%s%s-%06d%s", prefixo, agora.Format("20060102-150405"), agora.Nanosecond()/int(time.Microsecond), ext))
+	// O arquivo de dados é substituído por rename, nunca reescrito, então um hard link basta
+	if err := os.Link(a.arquivo, destino); err != nil {
+		if err := copiarArquivo(a.arquivo, destino); err != nil {
+			return err
+		}
+	}
+	backups := a.listarBackups()
+	for _, antigo := range backups[min(a.backup.Manter, len(backups)):] {
+		os.Remove(antigo)
+	}
+	return nil
+}
+
+// resolverBackup aceita o número mostrado por `restore` (1 = mais recente), o nome do arquivo no
+// diretório de backups ou um caminho
+func (a *armazenamentoArquivo) resolverBackup(escolha string) (string, error) {
+	backups := a.listarBackups()
+	if n, err := strconv.Atoi(escolha); err == nil {
+		if n < 1 || n > len(backups) {
+			return "", fmt.Errorf("backup %d não existe (há %d; veja 'restore')", n, len(backups))
+		}
+		return backups[n-1], nil
+	}
+	if _, err := os.Stat(escolha); err == nil {
+		return escolha, nil
+	}
+	if caminho := filepath.Join(a.diretorioBackups(), escolha); slices.Contains(backups, caminho) {
+		return caminho, nil
+	}
+	return "", fmt.Errorf("backup '%s' não encontrado em %s", escolha, a.diretorioBackups())
+}
+
+// revelarCarros decifra os campos protegidos dos carros lidos de um backup com a chave da sessão,
+// para que a comparação com o estoque em memória não acuse diferenças só pela cifragem
+func (c *CadastroCarros) revelarCarros(carros []Carro) {
+	c.mu.RLock()
+	protecao := c.configAtiva.Protecao
+	c.mu.RUnlock()
+	privada, err := protecao.chavePrivada()
+	if err != nil || privada == nil {
+		return
+	}
+	publica, _ := decodificarChave(protecao.ChavePublica)
+	for i := range carros {
+		if len(carros[i].Protegido) > 0 {
+			revelar(&carros[i], publica, privada)
+		}
+	}
+}
+
+// armazenamentoComBackup devolve o backend em arquivo da sessão (nil para bbolt e memória)
+func (c *CadastroCarros) armazenamentoComBackup() *armazenamentoArquivo {
+	a, _ := c.armazenamento.(*armazenamentoArquivo)
+	return a
+}
+
+// Backup é um arquivo de backup com a data da cópia e o tamanho em bytes
+type Backup struct {
+	Arquivo string
+	FeitoEm time.Time
+	Tamanho int64
+}
+
+// BackupsDisponiveis são os backups do arquivo de dados, do mais recente ao mais antigo
+type BackupsDisponiveis struct {
+	Diretorio string
+	Manter    int // 0 = backups desligados
+	Backups   []Backup
+}
+
+// errSemBackups é o erro dos comandos de backup quando o armazenamento não é em arquivo
+var errSemBackups = errors.New("backups só existem com armazenamento em arquivo (json, yaml, msgpack ou protobuf)")
+
+// Backups devolve os backups disponíveis para o restore
+func (c *CadastroCarros) Backups() (BackupsDisponiveis, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	a := c.armazenamentoComBackup()
+	if a == nil {
+		return BackupsDisponiveis{}, errSemBackups
+	}
+	lista := BackupsDisponiveis{Diretorio: a.diretorioBackups(), Manter: a.backup.Manter}
+	for _, arquivo := range a.listarBackups() {
+		backup := Backup{Arquivo: arquivo}
+		if info, err := os.Stat(arquivo); err == nil {
+			backup.FeitoEm, backup.Tamanho = info.ModTime(), info.Size()
+		}
+		lista.Backups = append(lista.Backups, backup)
+	}
+	return lista, nil
+}
+
+// RestauracaoBackup é a prévia da volta a um backup: o que muda do estoque atual para o dele
+type RestauracaoBackup struct {
+	Arquivo   string
+	Diferenca DiferencaArquivos
+	carros    []Carro
+	visao     *VisaoCarros
+}
+
+// PlanejarRestauracao lê o backup escolhido (pelo número da listagem ou pelo arquivo) e compara
+// o estoque atual com o dele, sem alterar nada
+func (c *CadastroCarros) PlanejarRestauracao(escolha string) (*RestauracaoBackup, error) {
+	c.mu.RLock()
+	a := c.armazenamentoComBackup()
+	c.mu.RUnlock()
+	if a == nil {
+		return nil, errSemBackups
+	}
+	arquivo, err := a.resolverBackup(escolha)
+	if err != nil {
+		return nil, err
+	}
+	doBackup, err := lerArquivoCarros(arquivo)
+	if err != nil {
+		return nil, err
+	}
+	c.revelarCarros(doBackup)
+
+	visao := c.Snapshot()
+	return &RestauracaoBackup{Arquivo: arquivo, Diferenca: compararCarros(visao.carros, doBackup), carros: doBackup, visao: visao}, nil
+}
+
+// AplicarRestauracao registra no log os eventos que levam o estoque atual ao do backup (carros
+// que saíram voltam, os que entraram depois saem como removidos). Vendas e lápides não estão no
+// arquivo de dados, então não mudam, salvo os carros vendidos que voltam ao estoque. Falha se o
+// estoque mudou depois da prévia.
+func (c *CadastroCarros) AplicarRestauracao(r *RestauracaoBackup) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	visao, d := r.visao, r.Diferenca
+	if n := len(c.eventos); n != len(visao.eventos) || (n > 0 && c.eventos[n-1].Seq != visao.eventos[n-1].Seq) {
+		return errors.New("o estoque mudou enquanto a prévia era mostrada. Rode o restore de novo para ver as diferenças atuais")
+	}
+	var eventos []Evento
+	for _, carro := range d.Adicionados {
+		tipo := EventoCarroAdicionado
+		if s := c.situacao(carro.ID); s.removido || s.venda != nil {
+			tipo = EventoCarroRestaurado
+		}
+		eventos = append(eventos, Evento{Tipo: tipo, CarroID: carro.ID, Carro: &carro})
+	}
+	for _, alterado := range d.Alterados {
+		carro := r.carros[slices.IndexFunc(r.carros, func(c Carro) bool { return c.ID == alterado.ID })]
+		eventos = append(eventos, Evento{Tipo: EventoCarroAtualizado, CarroID: carro.ID, Carro: &carro})
+	}
+	for _, carro := range d.Removidos {
+		eventos = append(eventos, Evento{Tipo: EventoCarroRemovido, CarroID: carro.ID})
+	}
+	c.emitir(eventos...)
+
+	// Persistir após restaurar (o estado anterior fica em um backup novo)
+	return c.gravar()
+}
@@ -0,0 +1,191 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// bucketCarros guarda cada carro como JSON, indexado pelo ID. As coleções auxiliares
+// (lápides, vendas, quarentena...) ficam cada uma em um bucket com o nome do anexo.
+var bucketCarros = []byte("carros")
+
+// armazenamentoBolt grava o cadastro em um banco bbolt, em uma única transação por gravação
+type armazenamentoBolt struct {
+	db *bbolt.DB
+}
+
+// abrirBolt abre (ou cria) o banco bbolt com os buckets do cadastro
+func abrirBolt(caminho string) (*armazenamentoBolt, error) {
+	db, err := bbolt.Open(caminho, 0644, &bbolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir banco bbolt: %v", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(bucketCarros); err != nil {
+			return err
+		}
+		for _, nome := range nomesAnexos() {
+			if _, err := tx.CreateBucketIfNotExists([]byte(nome)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("erro ao preparar banco bbolt: %v", err)
+	}
+	return &armazenamentoBolt{db: db}, nil
+}
+
+// AbrirBolt passa o cadastro para o banco bbolt e carrega os carros para a memória.
+// Se o banco estiver vazio e existir o arquivo do cadastro, os dados são migrados dele.
+func (c *CadastroCarros) AbrirBolt(caminho string) error {
+	b, err := abrirBolt(caminho)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	anterior := c.armazenamento
+	c.armazenamento = b
+
+	if err := c.carregar(); err != nil {
+		return err
+	}
+	if len(c.carros) > 0 {
+		return nil
+	}
+
+	// Primeira execução com bbolt: migra o arquivo existente, se houver
+	arquivo, ok := anterior.(*armazenamentoArquivo)
+	if !ok {
+		return nil
+	}
+	if _, err := os.Stat(arquivo.arquivo); err != nil {
+		return nil
+	}
+	arquivo.log = c.log
+	c.armazenamento = arquivo
+	err = c.carregar()
+	c.armazenamento = b
+	if err != nil {
+		return fmt.Errorf("erro ao migrar %s para bbolt: %v", arquivo.arquivo, err)
+	}
+	if len(c.carros) == 0 {
+		return nil
+	}
+	if err := c.salvar(); err != nil {
+		return fmt.Errorf("erro ao migrar %s para bbolt: %v", arquivo.arquivo, err)
+	}
+	c.log.Info("dados migrados para bbolt", "carros", len(c.carros), "origem", arquivo.arquivo, "banco", caminho)
+	return nil
+}
+
+func (b *armazenamentoBolt) Descrever() string  { return "bbolt (" + b.db.Path() + ")" }
+func (b *armazenamentoBolt) Arquivos() []string { return []string{b.db.Path()} }
+func (b *armazenamentoBolt) Fechar() error      { return b.db.Close() }
+
+// Salvar regrava carros e coleções auxiliares nos buckets em uma única transação,
+// de modo que uma falha no meio da gravação nunca deixa o banco pela metade
+func (b *armazenamentoBolt) Salvar(carros []Carro, anexos []Anexo) error {
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := recriarBucket(tx, bucketCarros)
+		if err != nil {
+			return err
+		}
+		for _, carro := range carros {
+			if err := gravarRegistro(bucket, carro.ID, carro); err != nil {
+				return err
+			}
+		}
+
+		// Cada elemento de um anexo é gravado sob sua posição, preservando a ordem
+		for _, a := range anexos {
+			data, err := json.Marshal(a.Lista)
+			if err != nil {
+				return fmt.Errorf("erro ao serializar %s: %v", a.Nome, err)
+			}
+			var itens []json.RawMessage
+			if err := json.Unmarshal(data, &itens); err != nil {
+				return err
+			}
+			bucket, err := recriarBucket(tx, []byte(a.Nome))
+			if err != nil {
+				return err
+			}
+			for i, item := range itens {
+				if err := bucket.Put([]byte(fmt.Sprintf("%08d", i+1)), item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao gravar no banco bbolt: %v", err)
+	}
+	return nil
+}
+
+// recriarBucket apaga e recria um bucket dentro da transação
+func recriarBucket(tx *bbolt.Tx, nome []byte) (*bbolt.Bucket, error) {
+	if err := tx.DeleteBucket(nome); err != nil && err != bbolt.ErrBucketNotFound {
+		return nil, err
+	}
+	return tx.CreateBucket(nome)
+}
+
+// gravarRegistro serializa um valor em JSON e o grava no bucket sob a chave informada
+func gravarRegistro(b *bbolt.Bucket, chave string, valor interface{}) error {
+	data, err := json.Marshal(valor)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar registro %s: %v", chave, err)
+	}
+	return b.Put([]byte(chave), data)
+}
+
+// Carregar lê os carros do banco bbolt (ordenados pelo ID, que segue a ordem de cadastro) e as
+// coleções auxiliares dos seus buckets
+func (b *armazenamentoBolt) Carregar(anexos []Anexo) ([]json.RawMessage, string, error) {
+	var brutos []json.RawMessage
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		err := tx.Bucket(bucketCarros).ForEach(func(k, v []byte) error {
+			brutos = append(brutos, append(json.RawMessage(nil), v...))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, a := range anexos {
+			a.Limpar()
+			itens := []json.RawMessage{}
+			err := tx.Bucket([]byte(a.Nome)).ForEach(func(k, v []byte) error {
+				itens = append(itens, append(json.RawMessage(nil), v...))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(itens) == 0 {
+				continue
+			}
+			data, _ := json.Marshal(itens)
+			if err := json.Unmarshal(data, a.Lista); err != nil {
+				return fmt.Errorf("%s inválido(s): %v", a.Nome, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao ler banco bbolt: %v", err)
+	}
+	return brutos, "bbolt:carros", nil
+}
@@ -0,0 +1,242 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pesos de relevância da busca textual: marca exata vale mais que modelo, que vale mais que cor
+const (
+	pesoMarcaExata    = 100
+	pesoMarcaParcial  = 30
+	pesoModeloExato   = 60
+	pesoModeloParcial = 25
+	pesoCorExata      = 20
+	pesoCorParcial    = 10
+	pesoPaisExato     = 10
+	pesoPaisParcial   = 5
+	pesoAno           = 15
+)
+
+// ResultadoBusca associa um carro à sua pontuação de relevância
+type ResultadoBusca struct {
+	Carro     Carro
+	Pontuacao int
+}
+
+// operadoresFiltro são os operadores aceitos nos filtros, os de dois caracteres antes dos de um
+var operadoresFiltro = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// campoFiltro descreve um campo filtrável: campos de texto aceitam = e != sem diferenciar
+// maiúsculas; campos numéricos aceitam todos os operadores
+type campoFiltro struct {
+	texto       func(Carro) string
+	numero      func(Carro) int64
+	interpretar func(string) (int64, error)
+}
+
+// interpretarValorFiltro lê um valor em reais para os filtros de preço e custo (em centavos)
+func interpretarValorFiltro(valor string) (int64, error) {
+	d, err := InterpretarValorDigitado(valor)
+	return int64(d), err
+}
+
+// camposFiltro são os campos aceitos nos filtros do search (origem é o país de origem)
+var camposFiltro = map[string]campoFiltro{
+	"marca":  {texto: func(c Carro) string { return c.Marca }},
+	"modelo": {texto: func(c Carro) string { return c.Modelo }},
+	"cor":    {texto: func(c Carro) string { return c.Cor }},
+	"origem": {texto: func(c Carro) string { return c.PaisOrigem }},
+	"pais":   {texto: func(c Carro) string { return c.PaisOrigem }},
+	"status": {texto: StatusCarro},
+	"ano": {numero: func(c Carro) int64 { return int64(c.Ano) }, interpretar: func(valor string) (int64, error) {
+		ano, err := strconv.Atoi(valor)
+		return int64(ano), err
+	}},
+	"preco": {numero: func(c Carro) int64 { return int64(c.Preco) }, interpretar: interpretarValorFiltro},
+	"custo": {numero: func(c Carro) int64 { return int64(c.Custo) }, interpretar: interpretarValorFiltro},
+}
+
+// nomesCamposFiltro devolve os campos filtráveis em ordem alfabética, para mensagens
+func nomesCamposFiltro() []string {
+	nomes := make([]string, 0, len(camposFiltro))
+	for nome := range camposFiltro {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
+
+// FiltroBusca é uma condição `<campo><operador><valor>` do search, ex: ano>=2020
+type FiltroBusca struct {
+	Campo    string
+	Operador string
+	Valor    string
+	numero   int64 // Valor já interpretado, nos campos numéricos
+}
+
+// interpretarFiltroBusca reconhece um termo como filtro (ehFiltro = false para termos livres)
+func interpretarFiltroBusca(termo string) (f FiltroBusca, ehFiltro bool, err error) {
+	i := strings.IndexAny(termo, "=<>!")
+	if i <= 0 {
+		return f, false, nil
+	}
+	f.Campo = strings.ToLower(termo[:i])
+	for _, op := range operadoresFiltro {
+		if strings.HasPrefix(termo[i:], op) {
+			f.Operador = op
+			break
+		}
+	}
+	if f.Operador == "" {
+		return f, true, fmt.Errorf("operador inválido em '%s' (use %s)", termo, strings.Join(operadoresFiltro, " "))
+	}
+	campo, existe := camposFiltro[f.Campo]
+	if !existe {
+		return f, true, fmt.Errorf("campo '%s' não pode ser filtrado (use %s)", f.Campo, strings.Join(nomesCamposFiltro(), ", "))
+	}
+	if f.Valor = strings.TrimSpace(termo[i+len(f.Operador):]); f.Valor == "" {
+		return f, true, fmt.Errorf("informe o valor em '%s'", termo)
+	}
+	if campo.texto != nil {
+		if f.Operador != "=" && f.Operador != "!=" {
+			return f, true, fmt.Errorf("o campo '%s' só aceita = e !=", f.Campo)
+		}
+		return f, true, nil
+	}
+	if f.numero, err = campo.interpretar(f.Valor); err != nil {
+		return f, true, fmt.Errorf("valor inválido em '%s'", termo)
+	}
+	return f, true, nil
+}
+
+// Aceita informa se o carro satisfaz a condição
+func (f FiltroBusca) Aceita(carro Carro) bool {
+	campo := camposFiltro[f.Campo]
+	if campo.texto != nil {
+		return strings.EqualFold(campo.texto(carro), f.Valor) == (f.Operador == "=")
+	}
+	v := campo.numero(carro)
+	switch f.Operador {
+	case "=":
+		return v == f.numero
+	case "!=":
+		return v != f.numero
+	case ">=":
+		return v >= f.numero
+	case "<=":
+		return v <= f.numero
+	case ">":
+		return v > f.numero
+	}
+	return v < f.numero
+}
+
+// separarTermosBusca divide os termos do search em filtros e termos livres
+func separarTermosBusca(termos []string) (filtros []FiltroBusca, livres []string, err error) {
+	for _, termo := range termos {
+		f, ehFiltro, err := interpretarFiltroBusca(termo)
+		switch {
+		case err != nil:
+			return nil, nil, err
+		case ehFiltro:
+			filtros = append(filtros, f)
+		default:
+			livres = append(livres, termo)
+		}
+	}
+	return filtros, livres, nil
+}
+
+// filtrarCarros devolve os carros que satisfazem todos os filtros, na ordem original
+func filtrarCarros(carros []Carro, filtros []FiltroBusca) []Carro {
+	var aceitos []Carro
+	for _, carro := range carros {
+		if !slices.ContainsFunc(filtros, func(f FiltroBusca) bool { return !f.Aceita(carro) }) {
+			aceitos = append(aceitos, carro)
+		}
+	}
+	return aceitos
+}
+
+// Pesquisar busca carros em estoque pelos filtros (todos precisam valer) e por termos livres.
+// Com termos livres, os resultados vêm ordenados por relevância (Pontuacao); só com filtros,
+// na ordem de cadastro e sem pontuação.
+func (c *CadastroCarros) Pesquisar(termos []string) ([]ResultadoBusca, error) {
+	filtros, livres, err := separarTermosBusca(termos)
+	if err != nil {
+		return nil, err
+	}
+	candidatos := filtrarCarros(c.Snapshot().carros, filtros)
+	if len(livres) > 0 {
+		return rankearBusca(candidatos, livres), nil
+	}
+	resultados := make([]ResultadoBusca, len(candidatos))
+	for i, carro := range candidatos {
+		resultados[i] = ResultadoBusca{Carro: carro}
+	}
+	return resultados, nil
+}
+
+// rankearBusca pontua cada carro contra os termos (todos precisam casar com algum campo)
+// e ordena por relevância, depois pelo cadastro mais recente
+func rankearBusca(carros []Carro, termos []string) []ResultadoBusca {
+	var resultados []ResultadoBusca
+	for _, carro := range carros {
+		total := 0
+		casouTodos := true
+		for _, termo := range termos {
+			p := pontuarTermo(carro, strings.ToLower(termo))
+			if p == 0 {
+				casouTodos = false
+				break
+			}
+			total += p
+		}
+		if casouTodos && len(termos) > 0 {
+			resultados = append(resultados, ResultadoBusca{Carro: carro, Pontuacao: total})
+		}
+	}
+
+	sort.SliceStable(resultados, func(i, j int) bool {
+		a, b := resultados[i], resultados[j]
+		if a.Pontuacao != b.Pontuacao {
+			return a.Pontuacao > b.Pontuacao
+		}
+		if a.Carro.DataCadastro != b.Carro.DataCadastro {
+			return a.Carro.DataCadastro > b.Carro.DataCadastro
+		}
+		return a.Carro.ID > b.Carro.ID
+	})
+	return resultados
+}
+
+// pontuarTermo calcula a relevância de um termo (já em minúsculas) para um carro
+func pontuarTermo(carro Carro, termo string) int {
+	pontos := 0
+	pontos += pontuarCampo(carro.Marca, termo, pesoMarcaExata, pesoMarcaParcial)
+	pontos += pontuarCampo(carro.Modelo, termo, pesoModeloExato, pesoModeloParcial)
+	pontos += pontuarCampo(carro.Cor, termo, pesoCorExata, pesoCorParcial)
+	pontos += pontuarCampo(carro.PaisOrigem, termo, pesoPaisExato, pesoPaisParcial)
+	if termo == strconv.Itoa(carro.Ano) {
+		pontos += pesoAno
+	}
+	return pontos
+}
+
+// pontuarCampo devolve o peso exato se o campo for igual ao termo, o parcial se o contiver, ou zero
+func pontuarCampo(campo, termo string, exato, parcial int) int {
+	campo = strings.ToLower(campo)
+	switch {
+	case campo == "":
+		return 0
+	case campo == termo:
+		return exato
+	case strings.Contains(campo, termo):
+		return parcial
+	}
+	return 0
+}
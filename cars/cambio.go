@@ -0,0 +1,196 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Cotacao é o valor em reais de uma unidade de moeda estrangeira em uma data
+type Cotacao struct {
+	Data  string  `json:"data"`  // Data da cotação (formato YYYY-MM-DD)
+	Moeda string  `json:"moeda"` // Código ISO 4217, ex: USD, EUR, JPY
+	Taxa  float64 `json:"taxa"`  // R$ por unidade da moeda
+}
+
+// moedasPorPais liga o país de origem à moeda em que o carro costuma ser comprado.
+// Carros de outros países precisam ter a moeda informada no registro (edit).
+var moedasPorPais = []struct{ Pais, Moeda string }{
+	{"Japão", "JPY"}, {"Alemanha", "EUR"}, {"Itália", "EUR"}, {"França", "EUR"}, {"Espanha", "EUR"},
+	{"Bélgica", "EUR"}, {"Holanda", "EUR"}, {"Áustria", "EUR"}, {"Portugal", "EUR"},
+	{"EUA", "USD"}, {"Estados Unidos", "USD"}, {"Reino Unido", "GBP"}, {"Inglaterra", "GBP"},
+	{"Coreia", "KRW"}, {"Coreia do Sul", "KRW"}, {"China", "CNY"}, {"Suécia", "SEK"},
+	{"México", "MXN"}, {"Argentina", "ARS"}, {"Canadá", "CAD"}, {"Suíça", "CHF"},
+}
+
+// moedaDoPais devolve a moeda do país de origem, ignorando acentos e caixa ("" se desconhecido)
+func moedaDoPais(pais string) string {
+	colador := collate.New(language.BrazilianPortuguese, collate.IgnoreCase, collate.IgnoreDiacritics)
+	pais = strings.TrimSpace(pais)
+	for _, m := range moedasPorPais {
+		if colador.CompareString(m.Pais, pais) == 0 {
+			return m.Moeda
+		}
+	}
+	return ""
+}
+
+// lerCotacoes lê o CSV de cotações com as colunas data, moeda e taxa (R$ por unidade).
+// Aceita o ponto e vírgula com vírgula decimal das séries exportadas do Banco Central.
+func lerCotacoes(arquivo string) ([]Cotacao, error) {
+	linhas, colunas, err := LerPlanilha(arquivo, "planilha de cotações")
+	if err != nil {
+		return nil, err
+	}
+	for _, nome := range []string{"data", "moeda", "taxa"} {
+		if _, ok := colunas[nome]; !ok {
+			return nil, fmt.Errorf("o cabeçalho precisa das colunas data, moeda e taxa (falta %s)", nome)
+		}
+	}
+
+	var cotacoes []Cotacao
+	for n, linha := range linhas {
+		campo := func(nome string) string {
+			if i := colunas[nome]; i < len(linha) {
+				return strings.TrimSpace(linha[i])
+			}
+			return ""
+		}
+		if campo("data") == "" && campo("moeda") == "" {
+			continue // Linha em branco
+		}
+		data, err := InterpretarData(campo("data"))
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %v", n+2, err)
+		}
+		taxa, err := InterpretarNumero(campo("taxa"))
+		if err != nil || taxa <= 0 {
+			return nil, fmt.Errorf("linha %d: taxa inválida '%s'", n+2, campo("taxa"))
+		}
+		moeda := strings.ToUpper(campo("moeda"))
+		if len(moeda) != 3 {
+			return nil, fmt.Errorf("linha %d: moeda inválida '%s' (use o código de 3 letras, ex: USD)", n+2, campo("moeda"))
+		}
+		cotacoes = append(cotacoes, Cotacao{Data: data, Moeda: moeda, Taxa: taxa})
+	}
+	if len(cotacoes) == 0 {
+		return nil, fmt.Errorf("nenhuma cotação em %s", arquivo)
+	}
+	return cotacoes, nil
+}
+
+// InterpretarData aceita AAAA-MM-DD ou DD/MM/AAAA (dia e mês com um ou dois dígitos, barra,
+// hífen ou ponto) e devolve no formato YYYY-MM-DD
+func InterpretarData(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02", "2/1/2006", "2-1-2006", "2.1.2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("data inválida '%s' (use AAAA-MM-DD ou DD/MM/AAAA)", s)
+}
+
+// mesclarCotacoes junta as cotações novas às existentes (a nova vence na mesma data e moeda),
+// mantendo a lista ordenada por moeda e data
+func mesclarCotacoes(existentes, novas []Cotacao) []Cotacao {
+	porChave := make(map[string]Cotacao, len(existentes)+len(novas))
+	for _, lista := range [][]Cotacao{existentes, novas} {
+		for _, q := range lista {
+			porChave[q.Moeda+" "+q.Data] = q
+		}
+	}
+	mescladas := make([]Cotacao, 0, len(porChave))
+	for _, q := range porChave {
+		mescladas = append(mescladas, q)
+	}
+	sort.Slice(mescladas, func(i, j int) bool {
+		if mescladas[i].Moeda != mescladas[j].Moeda {
+			return mescladas[i].Moeda < mescladas[j].Moeda
+		}
+		return mescladas[i].Data < mescladas[j].Data
+	})
+	return mescladas
+}
+
+// cotacaoEm devolve a cotação mais recente da moeda até a data (inclusive), para cobrir fins
+// de semana e feriados; nunca usa uma cotação posterior
+func (d *dadosCarros) cotacaoEm(moeda, data string) (Cotacao, bool) {
+	var achada Cotacao
+	for _, q := range d.cotacoes {
+		if q.Moeda == moeda && q.Data <= data && q.Data >= achada.Data {
+			achada = q
+		}
+	}
+	return achada, achada.Moeda != ""
+}
+
+// cotacaoAtual devolve a última cotação conhecida da moeda
+func (d *dadosCarros) cotacaoAtual(moeda string) (Cotacao, bool) {
+	return d.cotacaoEm(moeda, "9999-12-31")
+}
+
+// PreenchimentoCambio é o resultado da importação de cotações
+type PreenchimentoCambio struct {
+	Cotacoes    int      // Cotações lidas do arquivo
+	Preenchidos int      // Carros que ganharam moeda e câmbio de compra
+	JaTinham    int      // Carros mantidos por já terem câmbio
+	SemMoeda    []string // "ID (país)" dos carros cujo país não tem moeda conhecida
+	SemCotacao  []string // "ID (moeda em data)" dos carros sem cotação até a data de cadastro
+}
+
+// PreencherCambio importa as cotações do arquivo e grava, em cada carro do estoque, a moeda de
+// compra e o câmbio na data de cadastro. Carros já preenchidos só são refeitos com forcar.
+func (c *CadastroCarros) PreencherCambio(arquivo string, forcar bool) (PreenchimentoCambio, error) {
+	novas, err := lerCotacoes(arquivo)
+	if err != nil {
+		return PreenchimentoCambio{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cotacoes = mesclarCotacoes(c.cotacoes, novas)
+	agora := time.Now().UTC().Format(time.RFC3339Nano)
+	resultado := PreenchimentoCambio{Cotacoes: len(novas)}
+	for _, carro := range c.carros {
+		if carro.CambioCompra > 0 && !forcar {
+			resultado.JaTinham++
+			continue
+		}
+		moeda := carro.Moeda
+		if moeda == "" {
+			moeda = moedaDoPais(carro.PaisOrigem)
+		}
+		if moeda == "" {
+			resultado.SemMoeda = append(resultado.SemMoeda, fmt.Sprintf("%s (%s)", carro.ID, carro.PaisOrigem))
+			continue
+		}
+		cotacao, ok := c.cotacaoEm(moeda, carro.DataCadastro)
+		if !ok {
+			resultado.SemCotacao = append(resultado.SemCotacao, fmt.Sprintf("%s (%s em %s)", carro.ID, moeda, carro.DataCadastro))
+			continue
+		}
+		carro.Moeda, carro.CambioCompra, carro.AtualizadoEm = moeda, cotacao.Taxa, agora
+		c.substituirCarro(carro)
+		resultado.Preenchidos++
+	}
+
+	// Persistir após preencher o câmbio
+	return resultado, c.gravar()
+}
+
+// Cotacoes devolve as cotações registradas
+func (v *VisaoCarros) Cotacoes() []Cotacao {
+	return slices.Clone(v.cotacoes)
+}
+
+// CotacaoAtual devolve a cotação mais recente da moeda
+func (v *VisaoCarros) CotacaoAtual(moeda string) (Cotacao, bool) {
+	return v.cotacaoAtual(moeda)
+}
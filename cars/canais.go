@@ -0,0 +1,130 @@
+package cars
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CanaisVenda são os canais em que o carro é anunciado, cada um com as próprias taxas
+var CanaisVenda = []string{"showroom", "site", "marketplace", "atacado"}
+
+// RegraCanal deriva o preço de um canal a partir do preço base (o preço pedido do carro)
+type RegraCanal struct {
+	Percentual     float64 `json:"percentual,omitempty"`      // Acréscimo sobre o preço base, ex: 12 para a comissão do marketplace (negativo = desconto)
+	Acrescimo      float64 `json:"acrescimo,omitempty"`       // Valor fixo em R$ somado depois do percentual (ex: taxa de anúncio)
+	ArredondarPara float64 `json:"arredondar_para,omitempty"` // Arredonda o resultado para o múltiplo mais próximo (0 = não arredonda)
+}
+
+// OpcoesCanais é a seção "canais" da configuração: a regra de cada canal de venda. Um canal sem
+// regra usa o preço base; um preço informado no carro com `channel set` vale sobre a regra.
+type OpcoesCanais struct {
+	Regras map[string]RegraCanal `json:"regras,omitempty"`
+}
+
+// Validar confere se os canais existem e se as regras fazem sentido
+func (o OpcoesCanais) Validar() error {
+	for canal, r := range o.Regras {
+		if !slices.Contains(CanaisVenda, canal) {
+			return fmt.Errorf("canais: canal desconhecido '%s' (use %s)", canal, strings.Join(CanaisVenda, ", "))
+		}
+		if r.Percentual <= -100 || r.ArredondarPara < 0 {
+			return fmt.Errorf("canais: regra de '%s' inválida (percentual acima de -100 e arredondar_para não negativo)", canal)
+		}
+	}
+	return nil
+}
+
+// Origens do preço de um canal, mostradas por `channel`
+const (
+	precoCanalBase   = "base"   // Sem regra nem preço próprio: o preço pedido
+	precoCanalRegra  = "regra"  // Derivado pela regra configurada
+	precoCanalManual = "manual" // Informado no carro com `channel set`
+)
+
+// Preco devolve o preço do carro no canal e de onde ele veio
+func (o OpcoesCanais) Preco(carro Carro, canal string) (Dinheiro, string) {
+	if preco, existe := carro.PrecosCanal[canal]; existe {
+		return preco, precoCanalManual
+	}
+	r, existe := o.Regras[canal]
+	if !existe {
+		return carro.Preco, precoCanalBase
+	}
+	preco := carro.Preco.Multiplicar(1+r.Percentual/100) + Reais(r.Acrescimo)
+	if passo := Reais(r.ArredondarPara); passo > 0 {
+		preco = Dinheiro(math.Round(float64(preco)/float64(passo))) * passo
+	}
+	return preco, precoCanalRegra
+}
+
+// noCanal devolve cópias dos carros com o preço do canal no lugar do preço base, para as
+// exportações de um canal específico
+func (o OpcoesCanais) noCanal(carros []Carro, canal string) []Carro {
+	convertidos := make([]Carro, len(carros))
+	for i, carro := range carros {
+		carro.Preco, _ = o.Preco(carro, canal)
+		convertidos[i] = carro
+	}
+	return convertidos
+}
+
+// validarPrecosCanal confere os preços próprios de canal de um carro (usado por validarCarro)
+func validarPrecosCanal(precos map[string]Dinheiro) error {
+	for canal, preco := range precos {
+		if !slices.Contains(CanaisVenda, canal) {
+			return fmt.Errorf("canal desconhecido '%s' (use %s)", canal, strings.Join(CanaisVenda, ", "))
+		}
+		if preco <= 0 {
+			return fmt.Errorf("preço do canal %s deve ser positivo", canal)
+		}
+	}
+	return nil
+}
+
+// AlterarPrecoCanal grava (ou, com preco zero, retira) o preço próprio do carro em um canal e
+// devolve o carro e se algo mudou (retirar um preço que o carro não tem não muda nada)
+func (c *CadastroCarros) AlterarPrecoCanal(id, canal string, preco Dinheiro) (Carro, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return Carro{}, false, ErroCarroNaoEncontrado(id)
+	}
+	if _, tinha := carro.PrecosCanal[canal]; preco == 0 && !tinha {
+		return carro, false, nil
+	}
+	carro.PrecosCanal = maps.Clone(carro.PrecosCanal)
+	if preco == 0 {
+		delete(carro.PrecosCanal, canal)
+		if len(carro.PrecosCanal) == 0 {
+			carro.PrecosCanal = nil
+		}
+	} else {
+		if carro.PrecosCanal == nil {
+			carro.PrecosCanal = make(map[string]Dinheiro)
+		}
+		carro.PrecosCanal[canal] = preco
+	}
+	if err := c.validarRegistrados(carro); err != nil {
+		return Carro{}, false, fmt.Errorf("%v. Preço do canal não alterado", err)
+	}
+	carro.AtualizadoEm = time.Now().UTC().Format(time.RFC3339Nano)
+	c.substituirCarro(carro)
+
+	// Persistir após alterar o preço do canal
+	return carro, true, c.gravar()
+}
+
+// InterpretarCanal lê o nome de um canal de venda
+func InterpretarCanal(nome string) (string, error) {
+	canal := strings.ToLower(strings.TrimSpace(nome))
+	if !slices.Contains(CanaisVenda, canal) {
+		return "", fmt.Errorf("canal desconhecido '%s' (use %s)", nome, strings.Join(CanaisVenda, ", "))
+	}
+	return canal, nil
+}
@@ -0,0 +1,713 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: carros.proto
+
+// Serviço gRPC do cadastro de carros importados, para integração entre serviços. Segue as mesmas
+// regras da API REST: seção permissoes da configuração, réplica somente leitura, confirmação de
+// alto valor e recusa de edições sobre uma versão desatualizada.
+
+package carrospb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Carro espelha o registro da API REST; os valores monetários vão em centavos de real
+type Carro struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Marca               string                 `protobuf:"bytes,2,opt,name=marca,proto3" json:"marca,omitempty"`
+	Modelo              string                 `protobuf:"bytes,3,opt,name=modelo,proto3" json:"modelo,omitempty"`
+	Ano                 int32                  `protobuf:"varint,4,opt,name=ano,proto3" json:"ano,omitempty"`
+	Cor                 string                 `protobuf:"bytes,5,opt,name=cor,proto3" json:"cor,omitempty"`
+	PrecoCentavos       int64                  `protobuf:"varint,6,opt,name=preco_centavos,json=precoCentavos,proto3" json:"preco_centavos,omitempty"`
+	PaisOrigem          string                 `protobuf:"bytes,7,opt,name=pais_origem,json=paisOrigem,proto3" json:"pais_origem,omitempty"`
+	DataCadastro        string                 `protobuf:"bytes,8,opt,name=data_cadastro,json=dataCadastro,proto3" json:"data_cadastro,omitempty"`     // YYYY-MM-DD
+	CustoCentavos       int64                  `protobuf:"varint,9,opt,name=custo_centavos,json=custoCentavos,proto3" json:"custo_centavos,omitempty"` // 0 = não informado
+	Origem              string                 `protobuf:"bytes,10,opt,name=origem,proto3" json:"origem,omitempty"`                                    // "" (importação) ou "troca"
+	AtualizadoEm        string                 `protobuf:"bytes,11,opt,name=atualizado_em,json=atualizadoEm,proto3" json:"atualizado_em,omitempty"`    // RFC 3339; no Update, se informado, precisa ser o atual
+	Chassi              string                 `protobuf:"bytes,12,opt,name=chassi,proto3" json:"chassi,omitempty"`
+	Status              string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`                                   // "" = em_estoque
+	StatusDesde         string                 `protobuf:"bytes,14,opt,name=status_desde,json=statusDesde,proto3" json:"status_desde,omitempty"`      // RFC 3339
+	Moeda               string                 `protobuf:"bytes,15,opt,name=moeda,proto3" json:"moeda,omitempty"`                                     // ISO 4217
+	CambioCompra        float64                `protobuf:"fixed64,16,opt,name=cambio_compra,json=cambioCompra,proto3" json:"cambio_compra,omitempty"` // R$ por unidade da moeda (0 = não preenchido)
+	Opcionais           []string               `protobuf:"bytes,17,rep,name=opcionais,proto3" json:"opcionais,omitempty"`
+	PrecosCanalCentavos map[string]int64       `protobuf:"bytes,18,rep,name=precos_canal_centavos,json=precosCanalCentavos,proto3" json:"precos_canal_centavos,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Protegido           map[string]string      `protobuf:"bytes,19,rep,name=protegido,proto3" json:"protegido,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Campos cifrados não abertos; só nos arquivos de dados, o gRPC não os envia
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Carro) Reset() {
+	*x = Carro{}
+	mi := &file_carros_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Carro) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Carro) ProtoMessage() {}
+
+func (x *Carro) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Carro.ProtoReflect.Descriptor instead.
+func (*Carro) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Carro) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Carro) GetMarca() string {
+	if x != nil {
+		return x.Marca
+	}
+	return ""
+}
+
+func (x *Carro) GetModelo() string {
+	if x != nil {
+		return x.Modelo
+	}
+	return ""
+}
+
+func (x *Carro) GetAno() int32 {
+	if x != nil {
+		return x.Ano
+	}
+	return 0
+}
+
+func (x *Carro) GetCor() string {
+	if x != nil {
+		return x.Cor
+	}
+	return ""
+}
+
+func (x *Carro) GetPrecoCentavos() int64 {
+	if x != nil {
+		return x.PrecoCentavos
+	}
+	return 0
+}
+
+func (x *Carro) GetPaisOrigem() string {
+	if x != nil {
+		return x.PaisOrigem
+	}
+	return ""
+}
+
+func (x *Carro) GetDataCadastro() string {
+	if x != nil {
+		return x.DataCadastro
+	}
+	return ""
+}
+
+func (x *Carro) GetCustoCentavos() int64 {
+	if x != nil {
+		return x.CustoCentavos
+	}
+	return 0
+}
+
+func (x *Carro) GetOrigem() string {
+	if x != nil {
+		return x.Origem
+	}
+	return ""
+}
+
+func (x *Carro) GetAtualizadoEm() string {
+	if x != nil {
+		return x.AtualizadoEm
+	}
+	return ""
+}
+
+func (x *Carro) GetChassi() string {
+	if x != nil {
+		return x.Chassi
+	}
+	return ""
+}
+
+func (x *Carro) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Carro) GetStatusDesde() string {
+	if x != nil {
+		return x.StatusDesde
+	}
+	return ""
+}
+
+func (x *Carro) GetMoeda() string {
+	if x != nil {
+		return x.Moeda
+	}
+	return ""
+}
+
+func (x *Carro) GetCambioCompra() float64 {
+	if x != nil {
+		return x.CambioCompra
+	}
+	return 0
+}
+
+func (x *Carro) GetOpcionais() []string {
+	if x != nil {
+		return x.Opcionais
+	}
+	return nil
+}
+
+func (x *Carro) GetPrecosCanalCentavos() map[string]int64 {
+	if x != nil {
+		return x.PrecosCanalCentavos
+	}
+	return nil
+}
+
+func (x *Carro) GetProtegido() map[string]string {
+	if x != nil {
+		return x.Protegido
+	}
+	return nil
+}
+
+type AddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Carro         *Carro                 `protobuf:"bytes,1,opt,name=carro,proto3" json:"carro,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRequest) Reset() {
+	*x = AddRequest{}
+	mi := &file_carros_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRequest) ProtoMessage() {}
+
+func (x *AddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRequest.ProtoReflect.Descriptor instead.
+func (*AddRequest) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddRequest) GetCarro() *Carro {
+	if x != nil {
+		return x.Carro
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_carros_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_carros_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{3}
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Carros        []*Carro               `protobuf:"bytes,1,rep,name=carros,proto3" json:"carros,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_carros_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListResponse) GetCarros() []*Carro {
+	if x != nil {
+		return x.Carros
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Carro           *Carro                 `protobuf:"bytes,1,opt,name=carro,proto3" json:"carro,omitempty"`                                            // carro.id identifica o carro alterado
+	ConfirmarModelo string                 `protobuf:"bytes,2,opt,name=confirmar_modelo,json=confirmarModelo,proto3" json:"confirmar_modelo,omitempty"` // Modelo repetido, exigido ao mudar o preço de um carro de alto valor
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_carros_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateRequest) GetCarro() *Carro {
+	if x != nil {
+		return x.Carro
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetConfirmarModelo() string {
+	if x != nil {
+		return x.ConfirmarModelo
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConfirmarModelo string                 `protobuf:"bytes,2,opt,name=confirmar_modelo,json=confirmarModelo,proto3" json:"confirmar_modelo,omitempty"` // Modelo repetido, exigido ao remover um carro de alto valor
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_carros_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetConfirmarModelo() string {
+	if x != nil {
+		return x.ConfirmarModelo
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_carros_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{7}
+}
+
+// Dados é um arquivo do armazenamento no formato protobuf: o arquivo principal traz os carros e os
+// auxiliares (vendas, lápides, eventos...) os registros da sua lista, com os campos das tags json
+type Dados struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Carros        []*Carro               `protobuf:"bytes,1,rep,name=carros,proto3" json:"carros,omitempty"`
+	Registros     []*structpb.Struct     `protobuf:"bytes,2,rep,name=registros,proto3" json:"registros,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dados) Reset() {
+	*x = Dados{}
+	mi := &file_carros_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dados) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dados) ProtoMessage() {}
+
+func (x *Dados) ProtoReflect() protoreflect.Message {
+	mi := &file_carros_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dados.ProtoReflect.Descriptor instead.
+func (*Dados) Descriptor() ([]byte, []int) {
+	return file_carros_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Dados) GetCarros() []*Carro {
+	if x != nil {
+		return x.Carros
+	}
+	return nil
+}
+
+func (x *Dados) GetRegistros() []*structpb.Struct {
+	if x != nil {
+		return x.Registros
+	}
+	return nil
+}
+
+var File_carros_proto protoreflect.FileDescriptor
+
+const file_carros_proto_rawDesc = "" +
+	"\n" +
+	"\fcarros.proto\x12\tcarros.v1\x1a\x1cgoogle/protobuf/struct.proto\"\x8a\x06\n" +
+	"\x05Carro\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05marca\x18\x02 \x01(\tR\x05marca\x12\x16\n" +
+	"\x06modelo\x18\x03 \x01(\tR\x06modelo\x12\x10\n" +
+	"\x03ano\x18\x04 \x01(\x05R\x03ano\x12\x10\n" +
+	"\x03cor\x18\x05 \x01(\tR\x03cor\x12%\n" +
+	"\x0epreco_centavos\x18\x06 \x01(\x03R\rprecoCentavos\x12\x1f\n" +
+	"\vpais_origem\x18\a \x01(\tR\n" +
+	"paisOrigem\x12#\n" +
+	"\rdata_cadastro\x18\b \x01(\tR\fdataCadastro\x12%\n" +
+	"\x0ecusto_centavos\x18\t \x01(\x03R\rcustoCentavos\x12\x16\n" +
+	"\x06origem\x18\n" +
+	" \x01(\tR\x06origem\x12#\n" +
+	"\ratualizado_em\x18\v \x01(\tR\fatualizadoEm\x12\x16\n" +
+	"\x06chassi\x18\f \x01(\tR\x06chassi\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12!\n" +
+	"\fstatus_desde\x18\x0e \x01(\tR\vstatusDesde\x12\x14\n" +
+	"\x05moeda\x18\x0f \x01(\tR\x05moeda\x12#\n" +
+	"\rcambio_compra\x18\x10 \x01(\x01R\fcambioCompra\x12\x1c\n" +
+	"\topcionais\x18\x11 \x03(\tR\topcionais\x12]\n" +
+	"\x15precos_canal_centavos\x18\x12 \x03(\v2).carros.v1.Carro.PrecosCanalCentavosEntryR\x13precosCanalCentavos\x12=\n" +
+	"\tprotegido\x18\x13 \x03(\v2\x1f.carros.v1.Carro.ProtegidoEntryR\tprotegido\x1aF\n" +
+	"\x18PrecosCanalCentavosEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a<\n" +
+	"\x0eProtegidoEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
+	"\n" +
+	"AddRequest\x12&\n" +
+	"\x05carro\x18\x01 \x01(\v2\x10.carros.v1.CarroR\x05carro\"\x1c\n" +
+	"\n" +
+	"GetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\r\n" +
+	"\vListRequest\"8\n" +
+	"\fListResponse\x12(\n" +
+	"\x06carros\x18\x01 \x03(\v2\x10.carros.v1.CarroR\x06carros\"b\n" +
+	"\rUpdateRequest\x12&\n" +
+	"\x05carro\x18\x01 \x01(\v2\x10.carros.v1.CarroR\x05carro\x12)\n" +
+	"\x10confirmar_modelo\x18\x02 \x01(\tR\x0fconfirmarModelo\"J\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10confirmar_modelo\x18\x02 \x01(\tR\x0fconfirmarModelo\"\x10\n" +
+	"\x0eDeleteResponse\"h\n" +
+	"\x05Dados\x12(\n" +
+	"\x06carros\x18\x01 \x03(\v2\x10.carros.v1.CarroR\x06carros\x125\n" +
+	"\tregistros\x18\x02 \x03(\v2\x17.google.protobuf.StructR\tregistros2\x96\x02\n" +
+	"\x06Carros\x12.\n" +
+	"\x03Add\x12\x15.carros.v1.AddRequest\x1a\x10.carros.v1.Carro\x12.\n" +
+	"\x03Get\x12\x15.carros.v1.GetRequest\x1a\x10.carros.v1.Carro\x127\n" +
+	"\x04List\x12\x16.carros.v1.ListRequest\x1a\x17.carros.v1.ListResponse\x124\n" +
+	"\x06Update\x12\x18.carros.v1.UpdateRequest\x1a\x10.carros.v1.Carro\x12=\n" +
+	"\x06Delete\x12\x18.carros.v1.DeleteRequest\x1a\x19.carros.v1.DeleteResponseB0Z.github.com/michellhornung/golang/cars/carrospbb\x06proto3"
+
+var (
+	file_carros_proto_rawDescOnce sync.Once
+	file_carros_proto_rawDescData []byte
+)
+
+func file_carros_proto_rawDescGZIP() []byte {
+	file_carros_proto_rawDescOnce.Do(func() {
+		file_carros_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_carros_proto_rawDesc), len(file_carros_proto_rawDesc)))
+	})
+	return file_carros_proto_rawDescData
+}
+
+var file_carros_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_carros_proto_goTypes = []any{
+	(*Carro)(nil),           // 0: carros.v1.Carro
+	(*AddRequest)(nil),      // 1: carros.v1.AddRequest
+	(*GetRequest)(nil),      // 2: carros.v1.GetRequest
+	(*ListRequest)(nil),     // 3: carros.v1.ListRequest
+	(*ListResponse)(nil),    // 4: carros.v1.ListResponse
+	(*UpdateRequest)(nil),   // 5: carros.v1.UpdateRequest
+	(*DeleteRequest)(nil),   // 6: carros.v1.DeleteRequest
+	(*DeleteResponse)(nil),  // 7: carros.v1.DeleteResponse
+	(*Dados)(nil),           // 8: carros.v1.Dados
+	nil,                     // 9: carros.v1.Carro.PrecosCanalCentavosEntry
+	nil,                     // 10: carros.v1.Carro.ProtegidoEntry
+	(*structpb.Struct)(nil), // 11: google.protobuf.Struct
+}
+var file_carros_proto_depIdxs = []int32{
+	9,  // 0: carros.v1.Carro.precos_canal_centavos:type_name -> carros.v1.Carro.PrecosCanalCentavosEntry
+	10, // 1: carros.v1.Carro.protegido:type_name -> carros.v1.Carro.ProtegidoEntry
+	0,  // 2: carros.v1.AddRequest.carro:type_name -> carros.v1.Carro
+	0,  // 3: carros.v1.ListResponse.carros:type_name -> carros.v1.Carro
+	0,  // 4: carros.v1.UpdateRequest.carro:type_name -> carros.v1.Carro
+	0,  // 5: carros.v1.Dados.carros:type_name -> carros.v1.Carro
+	11, // 6: carros.v1.Dados.registros:type_name -> google.protobuf.Struct
+	1,  // 7: carros.v1.Carros.Add:input_type -> carros.v1.AddRequest
+	2,  // 8: carros.v1.Carros.Get:input_type -> carros.v1.GetRequest
+	3,  // 9: carros.v1.Carros.List:input_type -> carros.v1.ListRequest
+	5,  // 10: carros.v1.Carros.Update:input_type -> carros.v1.UpdateRequest
+	6,  // 11: carros.v1.Carros.Delete:input_type -> carros.v1.DeleteRequest
+	0,  // 12: carros.v1.Carros.Add:output_type -> carros.v1.Carro
+	0,  // 13: carros.v1.Carros.Get:output_type -> carros.v1.Carro
+	4,  // 14: carros.v1.Carros.List:output_type -> carros.v1.ListResponse
+	0,  // 15: carros.v1.Carros.Update:output_type -> carros.v1.Carro
+	7,  // 16: carros.v1.Carros.Delete:output_type -> carros.v1.DeleteResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_carros_proto_init() }
+func file_carros_proto_init() {
+	if File_carros_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_carros_proto_rawDesc), len(file_carros_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_carros_proto_goTypes,
+		DependencyIndexes: file_carros_proto_depIdxs,
+		MessageInfos:      file_carros_proto_msgTypes,
+	}.Build()
+	File_carros_proto = out.File
+	file_carros_proto_goTypes = nil
+	file_carros_proto_depIdxs = nil
+}
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: carros.proto
+
+// Serviço gRPC do cadastro de carros importados, para integração entre serviços. Segue as mesmas
+// regras da API REST: seção permissoes da configuração, réplica somente leitura, confirmação de
+// alto valor e recusa de edições sobre uma versão desatualizada.
+
+package carrospb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Carros_Add_FullMethodName    = "/carros.v1.Carros/Add"
+	Carros_Get_FullMethodName    = "/carros.v1.Carros/Get"
+	Carros_List_FullMethodName   = "/carros.v1.Carros/List"
+	Carros_Update_FullMethodName = "/carros.v1.Carros/Update"
+	Carros_Delete_FullMethodName = "/carros.v1.Carros/Delete"
+)
+
+// CarrosClient is the client API for Carros service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Carros cadastra, consulta, altera e remove carros do estoque
+type CarrosClient interface {
+	// Add cadastra um carro; ID e datas são definidos pelo servidor
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*Carro, error)
+	// Get devolve um carro em estoque pelo ID
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Carro, error)
+	// List devolve os carros em estoque, na ordem de cadastro
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Update substitui o carro pela versão enviada
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Carro, error)
+	// Delete retira o carro do estoque (ele vai para a lixeira, como no comando remove)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type carrosClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCarrosClient(cc grpc.ClientConnInterface) CarrosClient {
+	return &carrosClient{cc}
+}
+
+func (c *carrosClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*Carro, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carro)
+	err := c.cc.Invoke(ctx, Carros_Add_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrosClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Carro, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carro)
+	err := c.cc.Invoke(ctx, Carros_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrosClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, Carros_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrosClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Carro, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Carro)
+	err := c.cc.Invoke(ctx, Carros_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carrosClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, Carros_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarrosServer is the server API for Carros service.
+// All implementations must embed UnimplementedCarrosServer
+// for forward compatibility.
+//
+// Carros cadastra, consulta, altera e remove carros do estoque
+type CarrosServer interface {
+	// Add cadastra um carro; ID e datas são definidos pelo servidor
+	Add(context.Context, *AddRequest) (*Carro, error)
+	// Get devolve um carro em estoque pelo ID
+	Get(context.Context, *GetRequest) (*Carro, error)
+	// List devolve os carros em estoque, na ordem de cadastro
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Update substitui o carro pela versão enviada
+	Update(context.Context, *UpdateRequest) (*Carro, error)
+	// Delete retira o carro do estoque (ele vai para a lixeira, como no comando remove)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedCarrosServer()
+}
+
+// UnimplementedCarrosServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCarrosServer struct{}
+
+func (UnimplementedCarrosServer) Add(context.Context, *AddRequest) (*Carro, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedCarrosServer) Get(context.Context, *GetRequest) (*Carro, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedCarrosServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedCarrosServer) Update(context.Context, *UpdateRequest) (*Carro, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedCarrosServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCarrosServer) mustEmbedUnimplementedCarrosServer() {}
+func (UnimplementedCarrosServer) testEmbeddedByValue()                {}
+
+// UnsafeCarrosServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CarrosServer will
+// result in compilation errors.
+type UnsafeCarrosServer interface {
+	mustEmbedUnimplementedCarrosServer()
+}
+
+func RegisterCarrosServer(s grpc.ServiceRegistrar, srv CarrosServer) {
+	// If the following call pancis, it indicates UnimplementedCarrosServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Carros_ServiceDesc, srv)
+}
+
+func _Carros_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrosServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Carros_Add_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrosServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Carros_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrosServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Carros_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrosServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Carros_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrosServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Carros_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrosServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Carros_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrosServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Carros_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrosServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Carros_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarrosServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Carros_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarrosServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Carros_ServiceDesc is the grpc.ServiceDesc for Carros service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Carros_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "carros.v1.Carros",
+	HandlerType: (*CarrosServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _Carros_Add_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Carros_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Carros_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Carros_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Carros_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "carros.proto",
+}
@@ -0,0 +1,5 @@
+// Package carrospb é o código gerado de carros.proto, o serviço gRPC do cadastro de carros
+// importados (servido por CadastroCarros.IniciarGRPC, no pacote cars).
+package carrospb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative carros.proto
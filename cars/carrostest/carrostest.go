@@ -0,0 +1,88 @@
+// Package carrostest reúne os auxiliares de teste de quem usa o pacote cars: fixtures de estoque
+// com o relógio fixado, comparação com arquivos golden e captura da saída no terminal. Os caminhos
+// são relativos ao diretório do pacote em teste, onde o go test roda.
+package carrostest
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// Atualizar regrava as saídas esperadas em vez de compará-las: go test -run Golden -update
+var Atualizar = flag.Bool("update", false, "regrava os arquivos de testdata/golden com a saída atual")
+
+// Instante é o "agora" das fixtures, para que dias em estoque e KPIs não mudem com a data
+var Instante = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// CarregarFixture abre um cadastro com os carros de testdata/<nome>.json e o relógio fixado em
+// Instante. O arquivo é copiado antes, já que a abertura pode regravá-lo.
+func CarregarFixture(t testing.TB, nome string) *cars.CadastroCarros {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", nome+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	arquivo := filepath.Join(t.TempDir(), "carros.json")
+	if err := os.WriteFile(arquivo, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := cars.NewCadastroCarros(arquivo)
+	c.FixarRelogio(func() time.Time { return Instante })
+	CapturarSaida(t, func() {
+		if _, err := c.AbrirArmazenamento(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return c
+}
+
+// CapturarSaida devolve o que f imprime. Redireciona os.Stdout, então só serve em testes que não
+// chamam t.Parallel (os paralelos só rodam depois deles).
+func CapturarSaida(t testing.TB, f func()) string {
+	t.Helper()
+	leitor, escritor, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := os.Stdout
+	os.Stdout = escritor
+	lido := make(chan []byte, 1)
+	go func() {
+		data, _ := io.ReadAll(leitor)
+		lido <- data
+	}()
+	defer func() {
+		escritor.Close()
+		os.Stdout = original
+	}()
+	f()
+	escritor.Close()
+	os.Stdout = original
+	return string(<-lido)
+}
+
+// ConferirGolden compara a saída com testdata/golden/<nome>.golden (ou a regrava com -update)
+func ConferirGolden(t testing.TB, nome, obtido string) {
+	t.Helper()
+	arquivo := filepath.Join("testdata", "golden", nome+".golden")
+	if *Atualizar {
+		if err := os.WriteFile(arquivo, []byte(obtido), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	esperado, err := os.ReadFile(arquivo)
+	if err != nil {
+		t.Fatalf("%v (rode go test -run Golden -update para criar)", err)
+	}
+	if !bytes.Equal(esperado, []byte(obtido)) {
+		t.Errorf("saída difere de %s (rode com -update se a mudança for intencional):\n--- obtido ---\n%s\n--- esperado ---\n%s", arquivo, obtido, esperado)
+	}
+}
@@ -0,0 +1,618 @@
+// Package cars é o cadastro de carros importados: o estoque em memória, a persistência (arquivo ou
+// banco bbolt), o log de eventos, as regras de preço e conformidade, os relatórios e os serviços de
+// rede (API REST, gRPC e painel). Os métodos devolvem valores e erros e não leem nem escrevem no
+// terminal; o prompt interativo que os usa fica em cmd/cars e o servidor sem prompt em cmd/cars-server.
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Carro representa um carro importado
+type Carro struct {
+	ID           string   `json:"id"`                      // ID único baseado em timestamp
+	Marca        string   `json:"marca"`                   // Ex: Toyota, BMW
+	Modelo       string   `json:"modelo"`                  // Ex: Corolla, X5
+	Ano          int      `json:"ano"`                     // Ano de fabricação
+	Cor          string   `json:"cor"`                     // Ex: Prata, Preto
+	Preco        Dinheiro `json:"preco"`                   // Preço em R$
+	PaisOrigem   string   `json:"pais_origem"`             // Ex: Japão, Alemanha
+	DataCadastro string   `json:"data_cadastro"`           // Data de cadastro (formato YYYY-MM-DD)
+	Custo        Dinheiro `json:"custo,omitempty"`         // Custo de importação posto no pátio em R$ (0 = não informado)
+	Origem       string   `json:"origem,omitempty"`        // Como o carro entrou no estoque: "" (importação) ou "troca"
+	AtualizadoEm string   `json:"atualizado_em,omitempty"` // Instante da última criação/alteração (RFC 3339)
+	Chassi       string   `json:"chassi,omitempty"`        // Número do chassi (VIN), em maiúsculas; usado na conferência física
+	Status       string   `json:"status,omitempty"`        // Situação no ciclo de vida (ver status.go; "" = em_estoque)
+	StatusDesde  string   `json:"status_desde,omitempty"`  // Instante da última mudança de situação (RFC 3339)
+	Moeda        string   `json:"moeda,omitempty"`         // Moeda em que o carro foi comprado (ISO 4217, ex: JPY)
+	CambioCompra float64  `json:"cambio_compra,omitempty"` // R$ por unidade da moeda na data de cadastro (0 = não preenchido)
+	Opcionais    []string `json:"opcionais,omitempty"`     // Equipamentos do vocabulário controlado (ver opcionais.go)
+
+	PrecosCanal map[string]Dinheiro `json:"precos_canal,omitempty"` // Preço próprio por canal de venda, sobre a regra do canal (ver canais.go)
+
+	Protegido map[string]string `json:"protegido,omitempty"` // Campos sensíveis cifrados que esta sessão não abriu (ver protecao.go)
+}
+
+// Igual compara dois carros campo a campo (Carro não é comparável com == por causa dos opcionais e dos preços por canal)
+func (c Carro) Igual(outro Carro) bool {
+	if !slices.Equal(c.Opcionais, outro.Opcionais) {
+		return false
+	}
+	c.Opcionais, outro.Opcionais = nil, nil
+	return reflect.DeepEqual(c, outro)
+}
+
+// Lapide registra a remoção de um carro, para que exportações incrementais possam propagá-la
+type Lapide struct {
+	ID         string `json:"id"`          // ID do carro removido
+	RemovidoEm string `json:"removido_em"` // Instante da remoção (RFC 3339)
+}
+
+// Config representa o arquivo de configuração opcional (config.json)
+type Config struct {
+	Exibicao      OpcoesExibicao      `json:"exibicao"`      // Como os preços são mostrados na tela
+	Armazenamento OpcoesArmazenamento `json:"armazenamento"` // Onde e como os carros são persistidos
+	Precificacao  OpcoesPrecificacao  `json:"precificacao"`  // Regras automáticas de preço pedido
+	Conformidade  OpcoesConformidade  `json:"conformidade"`  // Restrições de importação conferidas no cadastro
+	Documentos    OpcoesDocumentos    `json:"documentos"`    // Lembretes de vencimento de CRLV, seguro e garantia
+	Lucratividade OpcoesLucratividade `json:"lucratividade"` // Custo diário de pátio usado em profit
+	Catalogo      OpcoesCatalogo      `json:"catalogo"`      // Endereço público dos carros, usado em qr
+	Sessao        OpcoesSessao        `json:"sessao"`        // Bloqueio por inatividade em terminais compartilhados
+	Auditoria     OpcoesAuditoria     `json:"auditoria"`     // Retenção do log de eventos
+	Protecao      OpcoesProtecao      `json:"protecao"`      // Campos sensíveis cifrados no arquivo de dados
+	Permissoes    OpcoesPermissoes    `json:"permissoes"`    // Comandos do prompt liberados nesta instalação ou perfil
+	AltoValor     OpcoesAltoValor     `json:"alto_valor"`    // Confirmação pelo modelo antes de remover, vender ou mudar o preço
+	Canais        OpcoesCanais        `json:"canais"`        // Preço de cada canal de venda derivado do preço base
+	Marcas        OpcoesMarcas        `json:"marcas"`        // País de origem padrão por marca, além da tabela embutida
+	Backup        OpcoesBackup        `json:"backup"`        // Cópias do arquivo de dados antes de cada gravação
+	Compartilhar  OpcoesCompartilhar  `json:"compartilhar"`  // Mensagem de share para o WhatsApp
+
+	// Perfis nomeados (ex: "producao", "teste") sobrescrevem as seções acima quando selecionados
+	// com --profile=<nome>; PerfilPadrao é usado quando nenhum perfil é informado
+	PerfilPadrao string                     `json:"perfil_padrao,omitempty"`
+	Perfis       map[string]json.RawMessage `json:"perfis,omitempty"`
+	Perfil       string                     `json:"-"` // Perfil efetivamente aplicado ("" = nenhum)
+}
+
+// OpcoesArmazenamento escolhe o backend de persistência
+type OpcoesArmazenamento struct {
+	Tipo    string `json:"tipo"`    // Arquivo em "json" (padrão), "yaml", "msgpack" ou "protobuf", ou banco "bbolt"
+	Arquivo string `json:"arquivo"` // Caminho do arquivo de dados (padrão carros.<tipo> ou carros.db no diretório de dados do sistema)
+
+	ArquivoPadrao bool `json:"-"` // true quando Arquivo não foi informado e aponta para o diretório de dados padrão
+}
+
+// OpcoesExibicao controla a formatação de preços na saída (nunca altera os valores gravados)
+type OpcoesExibicao struct {
+	CasasDecimais  int     `json:"casas_decimais"`          // Casas decimais exibidas (padrão 2)
+	ArredondarPara float64 `json:"arredondar_para"`         // Ex: 100 arredonda para centenas (0 = sem arredondamento)
+	Escala         string  `json:"escala"`                  // "" para valor cheio ou "mil" para exibir "R$ 145k"
+	Moeda          string  `json:"moeda,omitempty"`         // Símbolo exibido antes dos valores (padrão "R$")
+	SemPaginacao   bool    `json:"sem_paginacao,omitempty"` // Mostra list e search direto, sem o pager interno
+}
+
+// ConfigPadrao retorna a configuração usada quando não há arquivo de configuração
+func ConfigPadrao() Config {
+	return Config{
+		Exibicao:      OpcoesExibicao{CasasDecimais: 2},
+		Armazenamento: OpcoesArmazenamento{Tipo: "json", Arquivo: filepath.Join(DiretorioDados(), "carros.json"), ArquivoPadrao: true},
+		Backup:        OpcoesBackup{Manter: 10},
+	}
+}
+
+// CarregarConfig lê o arquivo de configuração, mantendo os padrões para campos ausentes, e aplica
+// o perfil informado (ou o perfil padrão do arquivo, se perfil for vazio)
+func CarregarConfig(caminho, perfil string) (Config, error) {
+	cfg := ConfigPadrao()
+	data, err := os.ReadFile(caminho)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if perfil != "" {
+				return cfg, fmt.Errorf("perfil '%s' informado, mas %s não existe", perfil, caminho)
+			}
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("erro ao ler arquivo de configuração: %v", err)
+	}
+
+	// O arquivo padrão depende do tipo de armazenamento escolhido, então é resolvido depois
+	cfg.Armazenamento = OpcoesArmazenamento{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return ConfigPadrao(), fmt.Errorf("erro ao desserializar configuração: %v", err)
+	}
+
+	if perfil == "" {
+		perfil = cfg.PerfilPadrao
+	}
+	if perfil != "" {
+		bruto, existe := cfg.Perfis[perfil]
+		if !existe {
+			return ConfigPadrao(), fmt.Errorf("perfil '%s' não existe em %s", perfil, caminho)
+		}
+		// O armazenamento de um perfil substitui o da base por inteiro, para nunca misturar
+		// o tipo de um com o arquivo do outro; as demais seções são sobrepostas campo a campo
+		var secoes map[string]json.RawMessage
+		if err := json.Unmarshal(bruto, &secoes); err != nil {
+			return ConfigPadrao(), fmt.Errorf("perfil '%s' inválido: %v", perfil, err)
+		}
+		if _, temArmazenamento := secoes["armazenamento"]; temArmazenamento {
+			cfg.Armazenamento = OpcoesArmazenamento{}
+		}
+		if err := json.Unmarshal(bruto, &cfg); err != nil {
+			return ConfigPadrao(), fmt.Errorf("perfil '%s' inválido: %v", perfil, err)
+		}
+		cfg.Perfil = perfil
+	}
+
+	if cfg.Exibicao.CasasDecimais < 0 || cfg.Exibicao.ArredondarPara < 0 {
+		return ConfigPadrao(), fmt.Errorf("exibicao: casas_decimais e arredondar_para não podem ser negativos")
+	}
+	if cfg.Exibicao.Escala != "" && cfg.Exibicao.Escala != "mil" {
+		return ConfigPadrao(), fmt.Errorf("exibicao: escala inválida '%s' (use \"\" ou \"mil\")", cfg.Exibicao.Escala)
+	}
+	if err := cfg.Precificacao.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Conformidade.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Documentos.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Lucratividade.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Catalogo.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Sessao.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Auditoria.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Protecao.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Permissoes.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.AltoValor.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Backup.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Canais.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Marcas.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+	if err := cfg.Compartilhar.Validar(); err != nil {
+		return ConfigPadrao(), err
+	}
+
+	if cfg.Armazenamento.Tipo == "" {
+		cfg.Armazenamento.Tipo = "json"
+	}
+	if cfg.Armazenamento.Tipo == "bbolt" {
+		if cfg.Armazenamento.Arquivo == "" {
+			cfg.Armazenamento.Arquivo = filepath.Join(DiretorioDados(), "carros.db")
+			cfg.Armazenamento.ArquivoPadrao = true
+		}
+	} else {
+		s, err := interpretarFormato(cfg.Armazenamento.Tipo)
+		if err != nil {
+			return ConfigPadrao(), fmt.Errorf("armazenamento: tipo inválido '%s' (use %s ou bbolt)", cfg.Armazenamento.Tipo, strings.Join(nomesSerializadores(), ", "))
+		}
+		if cfg.Armazenamento.Arquivo == "" {
+			cfg.Armazenamento.Arquivo = filepath.Join(DiretorioDados(), "carros."+s.Extensao())
+			cfg.Armazenamento.ArquivoPadrao = true
+		}
+	}
+
+	return cfg, nil
+}
+
+// FormatarPreco aplica arredondamento, escala e precisão configurados a um preço para exibição
+func (o OpcoesExibicao) FormatarPreco(preco Dinheiro) string {
+	valor := preco.EmReais()
+	if o.ArredondarPara > 0 {
+		valor = math.Round(valor/o.ArredondarPara) * o.ArredondarPara
+	}
+	moeda := o.Moeda
+	if moeda == "" {
+		moeda = "R$"
+	}
+	if o.Escala == "mil" {
+		return fmt.Sprintf("%s %.*fk", moeda, o.CasasDecimais, valor/1000)
+	}
+	return fmt.Sprintf("%s %.*f", moeda, o.CasasDecimais, valor)
+}
+
+// dadosCarros são as coleções do banco em memória. Ficam em um tipo próprio para que as
+// visões imutáveis (VisaoCarros) compartilhem os mesmos métodos de consulta.
+type dadosCarros struct {
+	carrosMap  map[string]Carro      // Map para buscas rápidas por ID (banco principal)
+	carros     []Carro               // Slice para listagem ordenada
+	removidos  []Lapide              // Lápides dos carros removidos (usadas na exportação incremental)
+	vendidos   []Venda               // Carros vendidos, guardados como comparáveis para análise de preços
+	quarentena []RegistroQuarentena  // Registros malformados ignorados no carregamento, aguardando `repair`
+	pagamentos []Pagamento           // Sinais e parcelas recebidos por carro reservado ou vendido
+	lotes      []Lote                // Contêineres/leilões que agrupam carros com custos compartilhados
+	excecoes   []ExcecaoConformidade // Cadastros justificados apesar das regras de conformidade
+
+	historicoStatus []MudancaStatus // Transições de situação de todos os carros, em ordem cronológica
+	cotacoes        []Cotacao       // Cotações históricas importadas, por moeda e data
+	documentos      []Documento     // Vencimentos de CRLV, seguro e garantia dos carros
+	eventos         []Evento        // Log de eventos, fonte da verdade de carros, removidos e vendidos
+
+	resumosAuditoria []ResumoAuditoria // Meses do log compactados pela retenção, com o arquivo dos eventos brutos
+}
+
+// CadastroCarros gerencia o banco temporário em memória
+type CadastroCarros struct {
+	dadosCarros
+	mu            sync.RWMutex     // Mutex para thread-safety
+	armazenamento Armazenamento    // Backend de persistência (arquivo JSON/YAML/MessagePack, bbolt...)
+	exibicao      OpcoesExibicao   // Opções de formatação de preços na saída
+	regrasPreco   []RegraPreco     // Regras aplicadas ao preço pedido ao cadastrar/atualizar
+	validadores   []ValidadorCarro // Regras extras registradas por quem embute o cadastro
+
+	arquivoConfig  string // Arquivo de configuração carregado, relido por `config reload` e SIGHUP
+	configAtiva    Config // Configuração em vigor
+	arquivoForcado bool   // O arquivo de dados veio de --data-file e não da configuração
+
+	ultimoSalvamento time.Time // Instante da última persistência bem-sucedida (diagnóstico)
+	pendente         bool      // Há alterações em memória que a última gravação não conseguiu persistir
+	quarentenados    int       // Registros separados para a quarentena ao abrir os dados
+	avisosAbertura   []string  // Avisos do carregamento (ex: campos protegidos que não abriram)
+	metricas         metricasOperacoes
+	visao            atomic.Pointer[VisaoCarros] // Visão imutável em cache, descartada a cada alteração
+	notificacoes     filaNotificacoes            // Avisos de sinais e tarefas em segundo plano, mostrados antes do prompt
+	painel           *http.Server                // Painel do showroom no ar (comando board), nil se desligado
+	api              *http.Server                // API REST no ar (comando serve), nil se desligada
+	servidorGRPC     *grpc.Server                // Serviço gRPC no ar (comando grpc), nil se desligado
+	enderecoGRPC     string                      // Endereço em que o gRPC ficou no ar
+	autor            string                      // Autor gravado nos eventos emitidos (usuário do sistema; a API usa o cliente)
+	assinaturas      assinaturasEventos          // Quem acompanha o log ao vivo (audit tail -f, /audit/stream)
+	relogio          func() time.Time            // Instante "agora" dos relatórios (nil = time.Now); testes o fixam
+	diario           diarioOperacoes             // Operações do prompt que undo e redo desfazem e refazem
+	replica          *replicaPrimario            // Primário seguido (comando replica), nil se a instância aceita alterações
+	log              *slog.Logger                // Log estruturado da execução (--log-level, --log-format); descartado se não configurado
+
+	aoGravarBaseGrande func(ResumoGravacao) // Avisado do resumo de cada gravação de uma base grande (AoGravarBaseGrande)
+
+	idempotencia respostasIdempotentes // Respostas de POST /carros/bulk por Idempotency-Key
+}
+
+// Agora devolve o instante atual pelo relógio da sessão, para que listagens e relatórios que
+// dependem da data (dias em estoque, KPIs) sejam reproduzíveis em testes
+func (c *CadastroCarros) Agora() time.Time {
+	if c.relogio != nil {
+		return c.relogio()
+	}
+	return time.Now()
+}
+
+// FixarRelogio troca o relógio da sessão (nil volta a usar time.Now), para relatórios reproduzíveis
+func (c *CadastroCarros) FixarRelogio(agora func() time.Time) {
+	c.relogio = agora
+}
+
+// NewCadastroCarros cria um novo banco em memória, persistido em JSON no arquivo informado
+func NewCadastroCarros(nomeArquivo string) *CadastroCarros {
+	c := NewCadastroCarrosEm(&armazenamentoArquivo{arquivo: nomeArquivo, serializador: serializadorJSON{}})
+	c.configAtiva.Armazenamento = OpcoesArmazenamento{Tipo: "json", Arquivo: nomeArquivo}
+	return c
+}
+
+// NewCadastroCarrosEm cria um novo banco em memória, persistido no backend informado
+func NewCadastroCarrosEm(armazenamento Armazenamento) *CadastroCarros {
+	return &CadastroCarros{
+		dadosCarros: dadosCarros{
+			carrosMap: make(map[string]Carro),
+			carros:    make([]Carro, 0),
+		},
+		armazenamento: armazenamento,
+		exibicao:      ConfigPadrao().Exibicao,
+		configAtiva:   ConfigPadrao(),
+		autor:         usuarioSistema(),
+		log:           registroDescartado(),
+	}
+}
+
+// Adicionar cadastra o carro com ID, data de cadastro e situação inicial novos, depois de
+// conferi-lo com as validações do cadastro e as registradas. A exceção, se informada, registra a
+// justificativa de um cadastro que viola regras de conformidade. Devolve o carro como foi gravado
+// e os ajustes das regras de preço; se só a gravação falhar (ErroGravacao), o carro fica no cadastro.
+func (c *CadastroCarros) Adicionar(novoCarro Carro, excecao *ExcecaoConformidade) (Carro, []AjustePreco, error) {
+	if err := ValidarCarro(novoCarro); err != nil {
+		return Carro{}, nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.validarRegistrados(novoCarro); err != nil {
+		return Carro{}, nil, err
+	}
+	novoCarro, ajustes, err := c.cadastrarCarro(novoCarro, excecao)
+	if err != nil {
+		return novoCarro, ajustes, &ErroGravacao{Err: err}
+	}
+	return novoCarro, ajustes, nil
+}
+
+// cadastrarCarro inclui o carro e persiste. Devolve o carro como foi gravado, os ajustes de preço e
+// o erro da gravação; o carro fica no cadastro mesmo que a gravação falhe (chamador deve segurar o
+// lock).
+func (c *CadastroCarros) cadastrarCarro(novoCarro Carro, excecao *ExcecaoConformidade) (Carro, []AjustePreco, error) {
+	novoCarro, ajustes := c.incluirCarro(novoCarro, excecao)
+
+	// Persistir após adicionar
+	return novoCarro, ajustes, c.salvar()
+}
+
+// incluirCarro dá ID, data e situação inicial ao carro, aplica as regras de preço e registra a
+// exceção de conformidade (se houver), sem persistir (chamador deve segurar o lock)
+func (c *CadastroCarros) incluirCarro(novoCarro Carro, excecao *ExcecaoConformidade) (Carro, []AjustePreco) {
+	// Gera ID único (nunca reaproveita o de um carro removido ou vendido) e data dinâmica
+	novoCarro.ID = c.novoID()
+	novoCarro.DataCadastro = time.Now().Format("2006-01-02")
+	novoCarro.AtualizadoEm = time.Now().UTC().Format(time.RFC3339Nano)
+	inicial := StatusCarro(novoCarro)
+	novoCarro.Status = ""
+	c.registrarStatus(&novoCarro, inicial, time.Now())
+
+	ajustes := c.aplicarRegrasPreco(&novoCarro)
+
+	c.emitir(Evento{Tipo: EventoCarroAdicionado, CarroID: novoCarro.ID, Carro: &novoCarro})
+	if excecao != nil {
+		excecao.CarroID, excecao.RegistradaEm = novoCarro.ID, novoCarro.AtualizadoEm
+		c.excecoes = append(c.excecoes, *excecao)
+	}
+	return novoCarro, ajustes
+}
+
+// Carro devolve o carro em estoque com o ID informado
+func (c *CadastroCarros) Carro(id string) (Carro, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return Carro{}, ErroCarroNaoEncontrado(id)
+	}
+	return carro, nil
+}
+
+// ErroCarroNaoEncontrado é a falha dos comandos que recebem o ID de um carro fora do estoque
+func ErroCarroNaoEncontrado(id string) error {
+	return fmt.Errorf("carro com ID '%s' não encontrado no banco em memória", id)
+}
+
+// Remover retira o carro do estoque e o leva para a lixeira, de onde pode ser restaurado
+func (c *CadastroCarros) Remover(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, existe := c.carrosMap[id]; !existe {
+		return ErroCarroNaoEncontrado(id)
+	}
+	c.retirarCarro(id)
+
+	// Persistir após remover
+	return c.gravar()
+}
+
+// RemoverVarios retira do estoque, com uma única gravação no final, os carros com os IDs
+// informados; IDs fora do estoque e repetidos são ignorados. Devolve quantos carros saíram.
+func (c *CadastroCarros) RemoverVarios(ids []string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	remover := make(map[string]bool)
+	for _, id := range ids {
+		if _, existe := c.carrosMap[id]; existe {
+			remover[id] = true
+		}
+	}
+	if len(remover) == 0 {
+		return 0, nil
+	}
+
+	// Um único lote de eventos: a projeção compacta o slice uma vez só, em vez de por ID
+	var eventos []Evento
+	for _, carro := range c.carros {
+		if remover[carro.ID] {
+			eventos = append(eventos, Evento{Tipo: EventoCarroRemovido, CarroID: carro.ID})
+		}
+	}
+	c.emitir(eventos...)
+
+	// Persistir uma vez após remover o lote
+	return len(eventos), c.gravar()
+}
+
+// retirarCarro registra a remoção do carro; a projeção tira-o do map e do slice e grava a lápide
+// (chamador deve segurar o lock)
+func (c *CadastroCarros) retirarCarro(id string) {
+	c.emitir(Evento{Tipo: EventoCarroRemovido, CarroID: id})
+}
+
+// lapide devolve a lápide de um ID removido ou vendido, se houver (chamador deve segurar o lock)
+func (d *dadosCarros) lapide(id string) (Lapide, bool) {
+	for _, l := range d.removidos {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return Lapide{}, false
+}
+
+// idEmUso informa se o ID pertence a um carro em estoque, vendido ou removido (chamador deve segurar o lock)
+func (d *dadosCarros) idEmUso(id string) bool {
+	if _, existe := d.carrosMap[id]; existe {
+		return true
+	}
+	if _, removido := d.lapide(id); removido {
+		return true
+	}
+	for _, v := range d.vendidos {
+		if v.Carro.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// novoID gera um ID baseado em timestamp que nunca foi usado (chamador deve segurar o lock)
+func (c *CadastroCarros) novoID() string {
+	for {
+		id := fmt.Sprintf("car_%d", time.Now().UnixNano())
+		if !c.idEmUso(id) {
+			return id
+		}
+	}
+}
+
+// IDEmUso informa se o ID pertence a um carro em estoque, vendido ou removido
+func (c *CadastroCarros) IDEmUso(id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.idEmUso(id)
+}
+
+// Purgar apaga definitivamente um ID (carro, lápide, venda, pagamentos e lote). O log de eventos
+// ganha a purga e guarda o histórico anterior; sem a lápide, o ID volta a poder ser importado.
+func (c *CadastroCarros) Purgar(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.idEmUso(id) {
+		return ErroIDNaoEncontrado(id)
+	}
+	c.apagarRastro(id)
+
+	// Persistir após purgar
+	return c.gravar()
+}
+
+// ErroIDNaoEncontrado é a falha das operações que aceitam qualquer ID já usado
+func ErroIDNaoEncontrado(id string) error {
+	return fmt.Errorf("ID '%s' não encontrado no estoque, nas vendas nem entre os removidos", id)
+}
+
+// apagarRastro emite a purga do ID, que o tira do estoque, das lápides e das vendas, e apaga os
+// pagamentos, o histórico, os documentos e o lote dele, que ficam fora do log (chamador deve
+// segurar o lock e persistir)
+func (c *CadastroCarros) apagarRastro(id string) {
+	c.emitir(Evento{Tipo: EventoCarroPurgado, CarroID: id})
+	c.pagamentos = slices.DeleteFunc(c.pagamentos, func(p Pagamento) bool { return p.CarroID == id })
+	c.historicoStatus = slices.DeleteFunc(c.historicoStatus, func(m MudancaStatus) bool { return m.CarroID == id })
+	c.documentos = slices.DeleteFunc(c.documentos, func(doc Documento) bool { return doc.CarroID == id })
+	if lote := c.loteDoCarro(id); lote != nil {
+		lote.CarroIDs = slices.DeleteFunc(lote.CarroIDs, func(outro string) bool { return outro == id })
+	}
+}
+
+// substituirCarro registra a nova versão de um carro existente; a projeção a troca no map e no
+// slice (chamador deve segurar o lock)
+func (c *CadastroCarros) substituirCarro(carro Carro) {
+	c.emitir(Evento{Tipo: EventoCarroAtualizado, CarroID: carro.ID, Carro: &carro})
+}
+
+// persistir grava os carros no backend configurado, com os campos protegidos cifrados
+func (c *CadastroCarros) persistir() error {
+	restaurar, err := c.cifrarParaGravar()
+	if err != nil {
+		return err
+	}
+	defer restaurar()
+	return c.armazenamento.Salvar(c.carros, c.anexos())
+}
+
+// ValidarCarro aplica as mesmas regras do cadastro interativo a um carro já montado
+func ValidarCarro(carro Carro) error {
+	switch {
+	case strings.TrimSpace(carro.Marca) == "":
+		return fmt.Errorf("marca não pode ser vazia")
+	case strings.TrimSpace(carro.Modelo) == "":
+		return fmt.Errorf("modelo não pode ser vazio")
+	case carro.Ano <= 0 || carro.Ano > time.Now().Year()+1:
+		return fmt.Errorf("ano %d inválido (deve ser positivo e até %d)", carro.Ano, time.Now().Year()+1)
+	case carro.Preco <= 0:
+		return fmt.Errorf("preço deve ser positivo")
+	case strings.TrimSpace(carro.PaisOrigem) == "":
+		return fmt.Errorf("país de origem não pode ser vazio")
+	case carro.Custo < 0:
+		return fmt.Errorf("custo não pode ser negativo")
+	case carro.Origem != "" && carro.Origem != "troca":
+		return fmt.Errorf("origem '%s' inválida (use \"\" ou \"troca\")", carro.Origem)
+	case carro.CambioCompra < 0:
+		return fmt.Errorf("câmbio de compra não pode ser negativo")
+	case carro.Status != "" && !statusValido(carro.Status):
+		return fmt.Errorf("situação '%s' inválida (use %s)", carro.Status, strings.Join(ordemStatus, ", "))
+	}
+	if err := validarPrecosCanal(carro.PrecosCanal); err != nil {
+		return err
+	}
+	return validarOpcionais(carro.Opcionais)
+}
+
+// Anexo é uma coleção auxiliar persistida junto com os carros (arquivo próprio no JSON, bucket próprio no bbolt)
+type Anexo struct {
+	Nome  string      // Nome do arquivo/bucket, ex: "removidos"
+	Lista interface{} // Ponteiro para o slice da coleção; é nele que o backend desserializa ao carregar
+}
+
+// anexos lista as coleções auxiliares do cadastro, na ordem em que são persistidas
+func (c *CadastroCarros) anexos() []Anexo {
+	return []Anexo{
+		{Nome: "removidos", Lista: &c.removidos},
+		{Nome: "vendidos", Lista: &c.vendidos},
+		{Nome: "quarentena", Lista: &c.quarentena},
+		{Nome: "pagamentos", Lista: &c.pagamentos},
+		{Nome: "lotes", Lista: &c.lotes},
+		{Nome: "excecoes", Lista: &c.excecoes},
+		{Nome: "status", Lista: &c.historicoStatus},
+		{Nome: "cotacoes", Lista: &c.cotacoes},
+		{Nome: "documentos", Lista: &c.documentos},
+		{Nome: "eventos", Lista: &c.eventos},
+		{Nome: "auditoria", Lista: &c.resumosAuditoria},
+	}
+}
+
+// nomesAnexos lista os nomes das coleções auxiliares, para os backends prepararem seus arquivos/buckets
+func nomesAnexos() []string {
+	var nomes []string
+	for _, a := range (&CadastroCarros{}).anexos() {
+		nomes = append(nomes, a.Nome)
+	}
+	return nomes
+}
+
+// Vazia informa se a coleção não tem elementos
+func (a Anexo) Vazia() bool {
+	return reflect.ValueOf(a.Lista).Elem().Len() == 0
+}
+
+// Limpar esvazia a coleção antes de um novo carregamento
+func (a Anexo) Limpar() {
+	v := reflect.ValueOf(a.Lista).Elem()
+	v.Set(reflect.Zero(v.Type()))
+}
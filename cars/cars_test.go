@@ -0,0 +1,396 @@
+package cars
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Carros dos testes, como o quickadd do prompt os cadastraria
+var (
+	corollaTeste = Carro{Marca: "Toyota", Modelo: "Corolla", Ano: 2021, Cor: "Prata", Preco: Reais(145000), PaisOrigem: "Japão"}
+	unoTeste     = Carro{Marca: "Fiat", Modelo: "Uno", Ano: 2020, Cor: "Azul", Preco: Reais(50000), PaisOrigem: "Itália"}
+	x5Teste      = Carro{Marca: "BMW", Modelo: "X5", Ano: 2022, Cor: "Preto", Preco: Reais(400000), PaisOrigem: "Alemanha"}
+	x1Teste      = Carro{Marca: "BMW", Modelo: "X1", Ano: 2022, Cor: "Preto", Preco: Reais(200000), PaisOrigem: "Alemanha"}
+)
+
+// cadastroTeste cadastra os carros, em ordem, em um cadastro vazio gravado só em memória
+func cadastroTeste(t *testing.T, carros ...Carro) *CadastroCarros {
+	t.Helper()
+	c := NewCadastroCarrosEm(&ArmazenamentoMemoria{})
+	for _, carro := range carros {
+		if _, _, err := c.Adicionar(carro, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c
+}
+
+func TestArmazenamentoRecarregaCadastro(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste)
+	c.mu.Lock()
+	c.retirarCarro(c.carros[0].ID)
+	if err := c.salvar(); err != nil {
+		t.Fatal(err)
+	}
+	c.mu.Unlock()
+
+	recarregado := NewCadastroCarrosEm(c.armazenamento)
+	if err := recarregado.carregar(); err != nil {
+		t.Fatal(err)
+	}
+	if !mesmaProjecao(&c.dadosCarros, &recarregado.dadosCarros) || len(recarregado.eventos) != len(c.eventos) {
+		t.Fatalf("cadastro recarregado difere do gravado: %+v x %+v", recarregado.carros, c.carros)
+	}
+}
+
+func TestAPICadastraAtualizaERemove(t *testing.T) {
+	t.Parallel()
+	c := NewCadastroCarrosEm(&ArmazenamentoMemoria{})
+	api := c.rotasAPI(nil)
+	requisitar := func(metodo, caminho, corpo string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		api.ServeHTTP(w, httptest.NewRequest(metodo, caminho, strings.NewReader(corpo)))
+		return w
+	}
+
+	w := requisitar("POST", "/carros", `{"marca":"Toyota","modelo":"Corolla","ano":2021,"cor":"Prata","preco":145000,"pais_origem":"Japão"}`)
+	if w.Code != http.StatusCreated || len(c.carros) != 1 {
+		t.Fatalf("cadastro: status %d, %d carro(s): %s", w.Code, len(c.carros), w.Body)
+	}
+	id := c.carros[0].ID
+	if w := requisitar("POST", "/carros", `{"marca":"Fiat","cavalos":80}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("campo desconhecido deveria dar 400, obtido %d", w.Code)
+	}
+	if w := requisitar("PUT", "/carros/"+id, `{"marca":"Toyota","modelo":"Corolla","ano":2021,"cor":"Preto","preco":140000.50,"pais_origem":"Japão"}`); w.Code != http.StatusOK {
+		t.Fatalf("atualização: status %d: %s", w.Code, w.Body)
+	}
+	if carro := c.carrosMap[id]; carro.Cor != "Preto" || carro.Preco != Reais(140000.50) {
+		t.Fatalf("carro não atualizado: %+v", carro)
+	}
+	if w := requisitar("DELETE", "/carros/"+id, ""); w.Code != http.StatusNoContent || len(c.carros) != 0 {
+		t.Fatalf("remoção: status %d, %d carro(s)", w.Code, len(c.carros))
+	}
+	if w := requisitar("GET", "/carros/"+id, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("carro removido deveria dar 404, obtido %d", w.Code)
+	}
+}
+
+func TestDiferencasCarroIgnoraOpcionaisVazios(t *testing.T) {
+	t.Parallel()
+	antes := Carro{ID: "car_1", Marca: "Toyota", Modelo: "Corolla", Opcionais: []string{}}
+	depois := antes
+	depois.Opcionais = nil
+	if linhas := DiferencasCarro(antes, depois); len(linhas) != 0 {
+		t.Fatalf("opcionais vazios e nil não deveriam ser diferença: %q", linhas)
+	}
+	depois.Opcionais, depois.Cor = []string{"teto solar"}, "Preto"
+	if linhas := DiferencasCarro(antes, depois); len(linhas) != 2 {
+		t.Fatalf("esperadas diferenças em cor e opcionais: %q", linhas)
+	}
+}
+
+func TestCompararCarrosPorID(t *testing.T) {
+	t.Parallel()
+	corolla := Carro{ID: "car_1", Marca: "Toyota", Modelo: "Corolla", Ano: 2022, Preco: Reais(145000), PaisOrigem: "Japão"}
+	uno := Carro{ID: "car_2", Marca: "Fiat", Modelo: "Uno", Ano: 2020, Preco: Reais(30000), PaisOrigem: "Brasil"}
+	golf := Carro{ID: "car_3", Marca: "VW", Modelo: "Golf", Ano: 2021, Preco: Reais(90000), PaisOrigem: "Alemanha"}
+	reajustado := corolla
+	reajustado.Preco, reajustado.Opcionais = Reais(140000), []string{"teto_solar"}
+
+	d := compararCarros([]Carro{corolla, uno}, []Carro{golf, reajustado})
+	if len(d.Adicionados) != 1 || d.Adicionados[0].ID != "car_3" || len(d.Removidos) != 1 || d.Removidos[0].ID != "car_2" {
+		t.Fatalf("adicionados/removidos inesperados: %+v", d)
+	}
+	if len(d.Alterados) != 1 || len(d.Alterados[0].Campos) != 2 || d.Alterados[0].Campos[0].Campo != "preco" {
+		t.Fatalf("alterações inesperadas: %+v", d.Alterados)
+	}
+	if !compararCarros([]Carro{corolla}, []Carro{corolla}).Vazia() {
+		t.Fatal("arquivos iguais deveriam dar diff vazio")
+	}
+}
+
+func TestFiltrosDaBuscaValemJuntos(t *testing.T) {
+	t.Parallel()
+	carros := []Carro{
+		{ID: "car_1", Marca: "BMW", Modelo: "X1", Ano: 2021, Preco: Reais(180000), PaisOrigem: "Alemanha"},
+		{ID: "car_2", Marca: "BMW", Modelo: "X5", Ano: 2022, Preco: Reais(450000), PaisOrigem: "Alemanha"},
+		{ID: "car_3", Marca: "bmw", Modelo: "320i", Ano: 2018, Preco: Reais(120000), PaisOrigem: "Alemanha"},
+		{ID: "car_4", Marca: "Toyota", Modelo: "Corolla", Ano: 2023, Preco: Reais(150000), PaisOrigem: "Japão"},
+	}
+	filtros, livres, err := separarTermosBusca([]string{"marca=BMW", "ano>=2020", "preco<200000", "origem=alemanha"})
+	if err != nil || len(livres) != 0 {
+		t.Fatalf("filtros não reconhecidos: %v %v", err, livres)
+	}
+	if aceitos := filtrarCarros(carros, filtros); len(aceitos) != 1 || aceitos[0].ID != "car_1" {
+		t.Fatalf("esperado só car_1, obtido %+v", aceitos)
+	}
+	for _, invalido := range []string{"km>3", "marca>B", "ano>=dois", "preco="} {
+		if _, _, err := separarTermosBusca([]string{invalido}); err == nil {
+			t.Errorf("esperado erro para %q", invalido)
+		}
+	}
+}
+
+func TestArquivoCorrompidoVoltaParaVersaoAnterior(t *testing.T) {
+	t.Parallel()
+	arquivo := filepath.Join(t.TempDir(), "carros.json")
+	a := &armazenamentoArquivo{arquivo: arquivo, serializador: serializadorJSON{}}
+	corolla := Carro{ID: "car_1", Marca: "Toyota", Modelo: "Corolla", Ano: 2022, Preco: Reais(145000), PaisOrigem: "Japão"}
+	for _, carros := range [][]Carro{{corolla}, {corolla, corolla}} {
+		if err := a.Salvar(carros, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Simula uma gravação interrompida por fora do programa
+	if err := os.WriteFile(arquivo, []byte(`[{"id": "car_1", "mar`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	brutos, _, err := a.Carregar(nil)
+	if err != nil || len(brutos) != 1 {
+		t.Fatalf("esperado 1 carro da versão anterior, obtido %d (%v)", len(brutos), err)
+	}
+	if brutos, _, err = a.Carregar(nil); err != nil || len(brutos) != 1 {
+		t.Fatalf("o arquivo deveria ter sido restaurado: %d (%v)", len(brutos), err)
+	}
+}
+
+func TestBackupsGuardamSoOsMaisRecentes(t *testing.T) {
+	t.Parallel()
+	arquivo := filepath.Join(t.TempDir(), "carros.json")
+	a := &armazenamentoArquivo{arquivo: arquivo, serializador: serializadorJSON{}, backup: OpcoesBackup{Manter: 2}}
+	var carros []Carro
+	for i := range 5 {
+		carros = append(carros, Carro{ID: "car_" + strconv.Itoa(i), Marca: "Fiat", Modelo: "Uno", Ano: 2010, Preco: Reais(20000), PaisOrigem: "Brasil"})
+		if err := a.Salvar(carros, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backups := a.listarBackups()
+	if len(backups) != 2 {
+		t.Fatalf("esperados 2 backups, obtidos %d: %v", len(backups), backups)
+	}
+	// O mais recente é o arquivo como estava antes da última gravação
+	recente, err := lerArquivoCarros(backups[0])
+	if err != nil || len(recente) != 4 {
+		t.Fatalf("backup mais recente deveria ter 4 carros, tem %d (%v)", len(recente), err)
+	}
+	if escolhido, err := a.resolverBackup("2"); err != nil || escolhido != backups[1] {
+		t.Fatalf("restore 2 deveria escolher %s, escolheu %s (%v)", backups[1], escolhido, err)
+	}
+}
+
+func TestValidadorRegistradoRecusaCadastroEEdicao(t *testing.T) {
+	t.Parallel()
+	c := NewCadastroCarrosEm(&ArmazenamentoMemoria{})
+	c.RegistrarValidador(func(carro Carro) error {
+		if carro.Cor == "" {
+			return errors.New("cor obrigatória nesta loja")
+		}
+		return nil
+	})
+	semCor := unoTeste
+	semCor.Cor = ""
+	if _, _, err := c.Adicionar(semCor, nil); err == nil || !strings.Contains(err.Error(), "cor obrigatória") {
+		t.Fatalf("cadastro sem cor deveria ser recusado com o motivo, obtido %v", err)
+	}
+	if _, _, err := c.Adicionar(corollaTeste, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	api := c.rotasAPI(nil)
+	w := httptest.NewRecorder()
+	api.ServeHTTP(w, httptest.NewRequest("PUT", "/carros/"+c.carros[0].ID,
+		strings.NewReader(`{"marca":"Toyota","modelo":"Corolla","ano":2021,"preco":145000,"pais_origem":"Japão"}`)))
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "cor obrigatória") {
+		t.Fatalf("edição sem cor deveria dar 422 com o motivo, obtido %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestPrecoDoCanalPrefereManualARegra(t *testing.T) {
+	t.Parallel()
+	canais := OpcoesCanais{Regras: map[string]RegraCanal{
+		"marketplace": {Percentual: 12, ArredondarPara: 100},
+		"atacado":     {Percentual: -8},
+	}}
+	carro := Carro{Preco: Reais(145050), PrecosCanal: map[string]Dinheiro{"atacado": Reais(130000)}}
+	casos := []struct {
+		canal, origem string
+		preco         Dinheiro
+	}{
+		{"showroom", precoCanalBase, Reais(145050)},
+		{"marketplace", precoCanalRegra, Reais(162500)},
+		{"atacado", precoCanalManual, Reais(130000)},
+	}
+	for _, caso := range casos {
+		if preco, origem := canais.Preco(carro, caso.canal); preco != caso.preco || origem != caso.origem {
+			t.Errorf("%s: obtido %s (%s), esperado %s (%s)", caso.canal, preco, origem, caso.preco, caso.origem)
+		}
+	}
+}
+
+func TestLixeiraRestauraEApagaRemovidos(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste)
+	corolla, uno := c.carros[0].ID, c.carros[1].ID
+
+	for _, id := range []string{corolla, uno} {
+		if err := c.Remover(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.Restaurar(corolla); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ApagarDaLixeira([]string{uno}); err != nil {
+		t.Fatal(err)
+	}
+	if carro, existe := c.carrosMap[corolla]; !existe || carro.Preco != Reais(145000) {
+		t.Fatalf("o Corolla deveria voltar da lixeira como estava: %+v", carro)
+	}
+	if itens := c.lixeira(); len(itens) != 0 {
+		t.Fatalf("a lixeira deveria estar vazia depois do purge: %+v", itens)
+	}
+	if _, existe := c.ultimoEstado(uno); existe {
+		t.Fatal("depois do purge o Uno não deveria ter estado no log")
+	}
+	if ultimo := c.eventos[len(c.eventos)-1]; ultimo.Tipo != EventoCarroPurgado || ultimo.CarroID != uno || c.eventos[0].CarroID != corolla {
+		t.Fatalf("o purge deveria acrescentar um evento ao log, sem reescrevê-lo: %+v", c.eventos)
+	}
+	if projecao := projetar(c.eventos, time.Time{}); !mesmaProjecao(&c.dadosCarros, projecao) || projecao.idEmUso(uno) {
+		t.Fatal("a projeção do log deveria honrar o purge")
+	}
+}
+
+func TestMensagemDoShareUsaModeloECanal(t *testing.T) {
+	t.Parallel()
+	carro := Carro{ID: "car_1", Marca: "Toyota", Modelo: "Corolla", Ano: 2022, Preco: Reais(100000), PaisOrigem: "Japão"}
+	canais := OpcoesCanais{Regras: map[string]RegraCanal{"site": {Percentual: 10}}}
+	catalogo := OpcoesCatalogo{URL: "https://loja.com.br/carros/{id}"}
+	opcoes := OpcoesCompartilhar{Modelo: "*{{.Marca}} {{.Modelo}}* por {{.Preco}}{{if .Cor}} ({{.Cor}}){{end}} {{.Link}}", Canal: "site"}
+
+	mensagem, err := opcoes.MensagemCarro(carro, canais, catalogo, OpcoesExibicao{CasasDecimais: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if esperada := "*Toyota Corolla* por R$ 110000 https://loja.com.br/carros/car_1"; mensagem != esperada {
+		t.Fatalf("mensagem inesperada: %q", mensagem)
+	}
+	if err := (OpcoesCompartilhar{Modelo: "{{.Placa}}"}).Validar(); err == nil {
+		t.Fatal("modelo com campo inexistente deveria ser recusado")
+	}
+}
+
+func TestReplicaSegueOPrimario(t *testing.T) {
+	t.Parallel()
+	primario := cadastroTeste(t, corollaTeste)
+	encerrando := make(chan struct{})
+	servidor := httptest.NewServer(primario.rotasAPI(encerrando))
+	defer servidor.Close()
+	defer close(encerrando)
+
+	replica := cadastroTeste(t)
+	if err := replica.SeguirPrimario(servidor.URL); err != nil {
+		t.Fatal(err)
+	}
+	defer replica.PromoverReplica()
+	if _, _, err := primario.Adicionar(unoTeste, nil); err != nil {
+		t.Fatal(err)
+	}
+	for prazo := time.Now().Add(5 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if replica.Snapshot().ultimoSeq() == primario.Snapshot().ultimoSeq() {
+			break
+		}
+		if time.Now().After(prazo) {
+			t.Fatalf("réplica parou no evento #%d", replica.Snapshot().ultimoSeq())
+		}
+	}
+	if visao := replica.Snapshot(); !mesmaProjecao(&visao.dadosCarros, &primario.Snapshot().dadosCarros) {
+		t.Fatalf("réplica difere do primário: %+v", visao.carros)
+	}
+	if !replica.SomenteLeitura() {
+		t.Fatal("a réplica deveria recusar alterações enquanto segue o primário")
+	}
+}
+
+func TestReplicaRecusaLogDivergente(t *testing.T) {
+	t.Parallel()
+	primario := cadastroTeste(t, corollaTeste, unoTeste)
+	encerrando := make(chan struct{})
+	servidor := httptest.NewServer(primario.rotasAPI(encerrando))
+	defer servidor.Close()
+	defer close(encerrando)
+
+	// Mesmo Seq do último evento do primário, mas outro histórico
+	divergente := cadastroTeste(t, x1Teste, unoTeste)
+	if err := divergente.SeguirPrimario(servidor.URL); !errors.Is(err, errLogDivergente) {
+		t.Fatalf("esperado erro de log divergente, obtido %v", err)
+	}
+	if divergente.SomenteLeitura() {
+		t.Fatal("a réplica não deveria seguir um primário com outro histórico")
+	}
+
+	// Uma cópia do primário segue a partir do último evento dela
+	copia := NewCadastroCarrosEm(&ArmazenamentoMemoria{})
+	copia.eventos = slices.Clone(primario.Snapshot().eventos[:1])
+	copia.aplicarEventos(copia.eventos)
+	if err := copia.SeguirPrimario(servidor.URL); err != nil {
+		t.Fatal(err)
+	}
+	defer copia.PromoverReplica()
+}
+
+func TestRegistroEstruturadoRespeitaNivelEFormato(t *testing.T) {
+	t.Parallel()
+	if _, err := NovoRegistro(io.Discard, "verbose", ""); err == nil {
+		t.Fatal("nível desconhecido deveria ser recusado")
+	}
+	var saida strings.Builder
+	registro, err := NovoRegistro(&saida, "info", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCadastroCarrosEm(&ArmazenamentoMemoria{})
+	c.UsarRegistro(registro)
+	if _, _, err := c.Adicionar(corollaTeste, nil); err != nil {
+		t.Fatal(err)
+	}
+	c.notificar(true, "⚠️  Aviso: a API parou: %v", "porta em uso")
+
+	linhas := strings.Split(strings.TrimSpace(saida.String()), "\n")
+	if len(linhas) != 1 || !strings.Contains(linhas[0], `"level":"WARN","msg":"a API parou: porta em uso"`) {
+		t.Fatalf("esperado só o aviso, em JSON e sem o prefixo do terminal: %q", linhas)
+	}
+}
+
+func TestAnoForaDaProducaoDoModeloGeraAviso(t *testing.T) {
+	t.Parallel()
+	marcas := OpcoesMarcas{Modelos: []ProducaoModelo{{Marca: "Fiat", Modelo: "Uno", Inicio: 1983, Fim: 2013}}}
+	for _, caso := range []struct {
+		Modelo string
+		Ano    int
+		Avisa  bool
+	}{
+		{"Corolla Cross XRE", 2015, true}, {"Corolla XEi", 2015, false}, {"Uno", 2014, true}, {"Uno Mille", 2010, false}, {"Supra", 1950, false},
+	} {
+		marca := "Toyota"
+		if strings.HasPrefix(caso.Modelo, "Uno") {
+			marca = "fiat"
+		}
+		if aviso := marcas.AvisoAno(marca, caso.Modelo, caso.Ano); (aviso != "") != caso.Avisa {
+			t.Errorf("%s %s %d: aviso %q", marca, caso.Modelo, caso.Ano, aviso)
+		}
+	}
+	if err := (OpcoesMarcas{Modelos: []ProducaoModelo{{Marca: "Fiat", Modelo: "Uno", Inicio: 2020, Fim: 2010}}}).Validar(); err == nil {
+		t.Fatal("fim antes do início deveria ser recusado")
+	}
+}
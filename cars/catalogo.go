@@ -0,0 +1,32 @@
+package cars
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OpcoesCatalogo é a seção "catalogo" da configuração: endereço público da página de cada carro
+type OpcoesCatalogo struct {
+	URL string `json:"url,omitempty"` // Modelo da URL do carro, com {id} no lugar do ID (ex: https://loja.com.br/carros/{id})
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesCatalogo) Validar() error {
+	if o.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(strings.ReplaceAll(o.URL, "{id}", "x"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("catalogo: url '%s' inválida (use uma URL http ou https)", o.URL)
+	}
+	if !strings.Contains(o.URL, "{id}") {
+		return fmt.Errorf("catalogo: url '%s' precisa conter {id}, substituído pelo ID do carro", o.URL)
+	}
+	return nil
+}
+
+// EnderecoCarro devolve a URL pública do carro no catálogo
+func (o OpcoesCatalogo) EnderecoCarro(id string) string {
+	return strings.ReplaceAll(o.URL, "{id}", url.PathEscape(id))
+}
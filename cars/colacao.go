@@ -0,0 +1,23 @@
+package cars
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// NovoColador cria um colador do português do Brasil: ignora acentos e caixa no primeiro nível,
+// então "Água" vem antes de "Zebra" e "audi" fica junto de "Audi". Coladores não são seguros
+// para uso concorrente, por isso cada ordenação cria o seu.
+func NovoColador() *collate.Collator {
+	return collate.New(language.BrazilianPortuguese, collate.IgnoreCase)
+}
+
+// OrdenarPorTexto ordena os itens de forma estável pela chave textual, segundo a colação pt-BR
+func OrdenarPorTexto[T any](itens []T, chave func(T) string) {
+	colador := NovoColador()
+	sort.SliceStable(itens, func(i, j int) bool {
+		return colador.CompareString(chave(itens[i]), chave(itens[j])) < 0
+	})
+}
@@ -0,0 +1,159 @@
+package cars
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// LinhaComparacao é um atributo comparado, com o valor de cada carro e quais se destacam
+type LinhaComparacao struct {
+	Atributo  string
+	Valores   []string
+	Destaques []bool // Melhor valor do atributo (menor preço, ano mais novo...)
+}
+
+// ComparacaoCarros é a comparação lado a lado de alguns carros do estoque
+type ComparacaoCarros struct {
+	Titulos []string
+	QRCodes []template.URL // QR code da página do catálogo em data URI (vazio sem catálogo)
+	Linhas  []LinhaComparacao
+	Geracao string
+}
+
+// destacar marca os carros com o valor escolhido pela função (menor preço, maior ano...), desde que
+// nem todos empatem
+func destacar(valores []int64, melhor func(a, b int64) bool) []bool {
+	alvo := valores[0]
+	for _, v := range valores[1:] {
+		if melhor(v, alvo) {
+			alvo = v
+		}
+	}
+	destaques := make([]bool, len(valores))
+	empate := true
+	for i, v := range valores {
+		destaques[i] = v == alvo
+		empate = empate && destaques[i]
+	}
+	if empate {
+		return make([]bool, len(valores))
+	}
+	return destaques
+}
+
+// montarComparacao monta os atributos comparados dos carros, na ordem pedida
+func (v *VisaoCarros) montarComparacao(carros []Carro, catalogo OpcoesCatalogo, agora time.Time) ComparacaoCarros {
+	cmp := ComparacaoCarros{Geracao: agora.Format("02/01/2006 15:04")}
+	precos, anos := make([]int64, len(carros)), make([]int64, len(carros))
+	linha := func(atributo string, valor func(Carro) string) LinhaComparacao {
+		l := LinhaComparacao{Atributo: atributo, Destaques: make([]bool, len(carros))}
+		for _, carro := range carros {
+			l.Valores = append(l.Valores, valor(carro))
+		}
+		return l
+	}
+	for i, carro := range carros {
+		cmp.Titulos = append(cmp.Titulos, fmt.Sprintf("%s %s", carro.Marca, carro.Modelo))
+		precos[i], anos[i] = int64(carro.Preco), int64(carro.Ano)
+	}
+
+	preco := linha("Preço", func(c Carro) string { return v.exibicao.FormatarPreco(c.Preco) })
+	preco.Destaques = destacar(precos, func(a, b int64) bool { return a < b })
+	ano := linha("Ano", func(c Carro) string { return strconv.Itoa(c.Ano) })
+	ano.Destaques = destacar(anos, func(a, b int64) bool { return a > b })
+	cmp.Linhas = append(cmp.Linhas, preco, ano,
+		linha("Cor", func(c Carro) string { return c.Cor }),
+		linha("País de Origem", func(c Carro) string { return c.PaisOrigem }),
+		linha("Situação", StatusCarro),
+		linha("Dias em Estoque", func(c Carro) string { return strconv.Itoa(DiasEmEstoque(c, agora)) }),
+	)
+
+	// Uma linha por opcional presente em algum dos carros, na ordem do vocabulário
+	var opcionais []string
+	for _, carro := range carros {
+		for _, o := range carro.Opcionais {
+			if !slices.Contains(opcionais, o) {
+				opcionais = append(opcionais, o)
+			}
+		}
+	}
+	ordenarOpcionais(opcionais)
+	for _, opcional := range opcionais {
+		l := linha(opcional, func(c Carro) string {
+			if slices.Contains(c.Opcionais, opcional) {
+				return "✔"
+			}
+			return "—"
+		})
+		cmp.Linhas = append(cmp.Linhas, l)
+	}
+
+	if catalogo.URL != "" {
+		for _, carro := range carros {
+			png, err := qrcode.Encode(catalogo.EnderecoCarro(carro.ID), qrcode.Medium, 160)
+			if err != nil {
+				cmp.QRCodes = append(cmp.QRCodes, "")
+				continue
+			}
+			cmp.QRCodes = append(cmp.QRCodes, template.URL("data:image/png;base64,"+base64.StdEncoding.EncodeToString(png)))
+		}
+	}
+	return cmp
+}
+
+// paginaComparacao é a folha de comparação em HTML: um arquivo só, sem recursos externos, para
+// mandar ao cliente por e-mail
+var paginaComparacao = template.Must(template.New("comparacao").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Comparação: {{range $i, $t := .Titulos}}{{if $i}} × {{end}}{{$t}}{{end}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; margin: 24px; }
+h1 { font-size: 22px; margin: 0 0 16px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 8px 12px; text-align: center; }
+th { background: #f4f4f4; font-size: 16px; }
+td.atributo { text-align: left; font-weight: bold; color: #555; white-space: nowrap; }
+td.destaque { background: #e8f7ec; color: #1a7f37; font-weight: bold; }
+img { width: 120px; height: 120px; }
+.rodape { margin-top: 16px; font-size: 12px; color: #888; }
+</style>
+</head>
+<body>
+<h1>Comparação de veículos</h1>
+<table>
+<tr><th></th>{{range .Titulos}}<th>{{.}}</th>{{end}}</tr>
+{{range .Linhas}}<tr><td class="atributo">{{.Atributo}}</td>{{$d := .Destaques}}{{range $i, $v := .Valores}}<td{{if index $d $i}} class="destaque"{{end}}>{{$v}}</td>{{end}}</tr>
+{{end}}{{if .QRCodes}}<tr><td class="atributo">Ver no site</td>{{range .QRCodes}}<td>{{if .}}<img src="{{.}}" alt="QR code">{{end}}</td>{{end}}</tr>
+{{end}}</table>
+<div class="rodape">Gerado em {{.Geracao}}. Preços e disponibilidade sujeitos a alteração.</div>
+</body>
+</html>
+`))
+
+// Comparar monta a comparação lado a lado dos carros em estoque, na ordem pedida
+func (v *VisaoCarros) Comparar(ids []string, catalogo OpcoesCatalogo, agora time.Time) (ComparacaoCarros, error) {
+	carros := make([]Carro, 0, len(ids))
+	for _, id := range ids {
+		carro, existe := v.carrosMap[id]
+		if !existe {
+			return ComparacaoCarros{}, ErroCarroNaoEncontrado(id)
+		}
+		carros = append(carros, carro)
+	}
+	return v.montarComparacao(carros, catalogo, agora), nil
+}
+
+// GravarHTML escreve a comparação como uma página HTML autocontida
+func (cmp ComparacaoCarros) GravarHTML(w io.Writer) error {
+	return paginaComparacao.Execute(w, cmp)
+}
@@ -0,0 +1,107 @@
+package cars
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// modeloCompartilharPadrao é a mensagem de `share` quando a configuração não traz outra. Usa a
+// formatação do WhatsApp: *negrito* e _itálico_.
+const modeloCompartilharPadrao = `🚗 *{{.Marca}} {{.Modelo}} {{.Ano}}*
+💰 *{{.Preco}}*
+{{if .Cor}}🎨 Cor: {{.Cor}}
+{{end}}{{if .Origem}}🌎 Importado de {{.Origem}}
+{{end}}{{if .Opcionais}}✨ Opcionais: {{.Opcionais}}
+{{end}}{{if .Link}}📸 Fotos e detalhes: {{.Link}}
+{{end}}_Ref. {{.ID}}_`
+
+// OpcoesCompartilhar é a seção "compartilhar" da configuração: a mensagem que `share` monta para
+// colar no WhatsApp, em text/template com os campos de dadosCompartilhar (ex: {{.Marca}}, {{.Preco}})
+type OpcoesCompartilhar struct {
+	Modelo string `json:"modelo,omitempty"` // Modelo da mensagem ("" = modeloCompartilharPadrao)
+	Canal  string `json:"canal,omitempty"`  // Canal de venda cujo preço vai na mensagem ("" = preço base)
+}
+
+// dadosCompartilhar são os campos disponíveis no modelo da mensagem, já formatados
+type dadosCompartilhar struct {
+	ID, Marca, Modelo, Cor, Origem string
+	Ano                            int
+	Preco                          string // Com a moeda e o arredondamento da exibição (ex: "R$ 145000.00")
+	Opcionais                      string // Separados por vírgula ("" se não houver)
+	Link                           string // Página do carro no catálogo ("" se catalogo.url não estiver configurado)
+}
+
+// Validar confere o canal e se o modelo compila e só usa campos existentes
+func (o OpcoesCompartilhar) Validar() error {
+	if o.Canal != "" {
+		if _, err := InterpretarCanal(o.Canal); err != nil {
+			return fmt.Errorf("compartilhar: %v", err)
+		}
+	}
+	modelo, err := o.modelo()
+	if err != nil {
+		return fmt.Errorf("compartilhar: modelo inválido: %v", err)
+	}
+	if err := modelo.Execute(new(strings.Builder), dadosCompartilhar{}); err != nil {
+		return fmt.Errorf("compartilhar: modelo inválido: %v", err)
+	}
+	return nil
+}
+
+// modelo compila o modelo configurado, ou o padrão
+func (o OpcoesCompartilhar) modelo() (*template.Template, error) {
+	texto := o.Modelo
+	if texto == "" {
+		texto = modeloCompartilharPadrao
+	}
+	return template.New("compartilhar").Parse(texto)
+}
+
+// MensagemCarro monta a mensagem de um carro com o preço do canal e o endereço do catálogo
+func (o OpcoesCompartilhar) MensagemCarro(carro Carro, canais OpcoesCanais, catalogo OpcoesCatalogo, exibicao OpcoesExibicao) (string, error) {
+	modelo, err := o.modelo()
+	if err != nil {
+		return "", err
+	}
+	preco := carro.Preco
+	if o.Canal != "" {
+		preco, _ = canais.Preco(carro, o.Canal)
+	}
+	dados := dadosCompartilhar{
+		ID: carro.ID, Marca: carro.Marca, Modelo: carro.Modelo, Cor: carro.Cor, Origem: carro.PaisOrigem,
+		Ano:       carro.Ano,
+		Preco:     exibicao.FormatarPreco(preco),
+		Opcionais: strings.Join(carro.Opcionais, ", "),
+	}
+	if catalogo.URL != "" {
+		dados.Link = catalogo.EnderecoCarro(carro.ID)
+	}
+	var mensagem strings.Builder
+	if err := modelo.Execute(&mensagem, dados); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(mensagem.String()), nil
+}
+
+// Mensagem monta a mensagem de divulgação do carro com o modelo configurado. Com canal, usa o
+// preço daquele canal no lugar do configurado.
+func (c *CadastroCarros) Mensagem(id, canal string) (string, Carro, error) {
+	c.mu.RLock()
+	carro, existe := c.carrosMap[id]
+	cfg := c.configAtiva
+	exibicao := c.exibicao
+	c.mu.RUnlock()
+
+	if !existe {
+		return "", Carro{}, ErroCarroNaoEncontrado(id)
+	}
+	if canal != "" {
+		cfg.Compartilhar.Canal = canal
+	}
+	mensagem, err := cfg.Compartilhar.MensagemCarro(carro, cfg.Canais, cfg.Catalogo, exibicao)
+	if err != nil {
+		return "", Carro{}, fmt.Errorf("erro ao montar a mensagem: %v", err)
+	}
+	return mensagem, carro, nil
+}
@@ -0,0 +1,178 @@
+package cars
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NormalizarChassi deixa o chassi em maiúsculas e sem espaços, para comparar contagens digitadas à mão
+func NormalizarChassi(chassi string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(chassi), ""))
+}
+
+// ItemContagem é um carro encontrado no pátio durante a contagem física
+type ItemContagem struct {
+	Linha  int
+	Chassi string
+	ID     string
+	Marca  string
+	Modelo string
+	Ano    int
+	Cor    string
+}
+
+// Chave descreve o item nas mensagens (chassi, ou ID se a linha não tiver chassi)
+func (i ItemContagem) Chave() string {
+	if i.Chassi != "" {
+		return i.Chassi
+	}
+	return i.ID
+}
+
+// LerPlanilha lê um CSV com cabeçalho, separado por vírgula ou por ponto e vírgula, e devolve
+// as linhas de dados e a posição de cada coluna pelo nome em minúsculas
+func LerPlanilha(arquivo, descricao string) ([][]string, map[string]int, error) {
+	return lerPlanilhaCom(arquivo, descricao, 0)
+}
+
+// lerPlanilhaCom é lerPlanilha com o delimitador informado (0 = vírgula ou ponto e vírgula, detectado)
+func lerPlanilhaCom(arquivo, descricao string, delimitador rune) ([][]string, map[string]int, error) {
+	f, err := os.Open(arquivo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao abrir %s: %v", descricao, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.Comma = ','
+	if delimitador != 0 {
+		r.Comma = delimitador
+	}
+	linhas, err := r.ReadAll()
+	if err == nil && delimitador == 0 && len(linhas) > 0 && len(linhas[0]) == 1 && strings.Contains(linhas[0][0], ";") {
+		// Planilhas em português costumam exportar com ponto e vírgula
+		f.Seek(0, 0)
+		r = csv.NewReader(f)
+		r.FieldsPerRecord = -1
+		r.Comma = ';'
+		linhas, err = r.ReadAll()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler %s: %v", descricao, err)
+	}
+	if len(linhas) == 0 {
+		return nil, nil, fmt.Errorf("%s vazia", descricao)
+	}
+
+	colunas := make(map[string]int)
+	for i, nome := range linhas[0] {
+		colunas[strings.ToLower(strings.TrimSpace(nome))] = i
+	}
+	return linhas[1:], colunas, nil
+}
+
+// divergenciasContagem compara os atributos informados na contagem com os do cadastro
+func divergenciasContagem(item ItemContagem, carro Carro) []string {
+	var d []string
+	comparar := func(campo, contado, cadastrado string) {
+		if contado != "" && !strings.EqualFold(contado, cadastrado) {
+			d = append(d, fmt.Sprintf("%s: cadastro '%s', pátio '%s'", campo, cadastrado, contado))
+		}
+	}
+	comparar("marca", item.Marca, carro.Marca)
+	comparar("modelo", item.Modelo, carro.Modelo)
+	comparar("cor", item.Cor, carro.Cor)
+	if item.Ano != 0 && item.Ano != carro.Ano {
+		d = append(d, fmt.Sprintf("ano: cadastro %d, pátio %d", carro.Ano, item.Ano))
+	}
+	if item.Chassi != "" && item.ID != "" && carro.Chassi != "" && item.Chassi != carro.Chassi {
+		d = append(d, fmt.Sprintf("chassi: cadastro '%s', pátio '%s'", carro.Chassi, item.Chassi))
+	}
+	return d
+}
+
+// ItemSemCadastro é uma linha da contagem que não corresponde a um carro em estoque
+type ItemSemCadastro struct {
+	Item     ItemContagem
+	Carro    string // Marca e modelo, do cadastro se o carro foi contado duas vezes, senão da contagem
+	Situacao string // "sem cadastro", "vendido em ...", "removido em ..." ou "contado em duplicidade"
+}
+
+// Divergencia é um atributo de um carro que o pátio e o cadastro registram diferente
+type Divergencia struct {
+	Carro     Carro
+	Descricao string // Ex: "cor: cadastro 'Prata', pátio 'Preto'"
+}
+
+// ConciliacaoEstoque é o resultado da comparação da contagem física com o cadastro
+type ConciliacaoEstoque struct {
+	Contados     int               // Itens da contagem
+	EmEstoque    int               // Carros em estoque no cadastro
+	Ausentes     []Carro           // Cadastrados e não encontrados no pátio
+	SemCadastro  []ItemSemCadastro // No pátio sem cadastro em estoque
+	Divergencias []Divergencia     // Atributos que o pátio e o cadastro registram diferente
+}
+
+// Confere informa se a contagem bateu com o cadastro
+func (r ConciliacaoEstoque) Confere() bool {
+	return len(r.Ausentes)+len(r.SemCadastro)+len(r.Divergencias) == 0
+}
+
+// ConciliarEstoque compara a contagem física do pátio com o cadastro: os carros que faltam no
+// pátio, os que estão no pátio sem cadastro (ou já vendidos/removidos) e os atributos divergentes.
+// Os carros são casados pelo chassi e, nas linhas sem chassi, pelo ID.
+func (c *CadastroCarros) ConciliarEstoque(itens []ItemContagem) ConciliacaoEstoque {
+	visao := c.Snapshot()
+	porChassi := make(map[string]Carro)
+	for _, carro := range visao.carros {
+		if carro.Chassi != "" {
+			porChassi[carro.Chassi] = carro
+		}
+	}
+	vendidoPorChave := make(map[string]Venda)
+	for _, v := range visao.vendidos {
+		vendidoPorChave[v.Carro.ID] = v
+		if v.Carro.Chassi != "" {
+			vendidoPorChave[v.Carro.Chassi] = v
+		}
+	}
+
+	r := ConciliacaoEstoque{Contados: len(itens), EmEstoque: len(visao.carros)}
+	encontrados := make(map[string]bool)
+	for _, item := range itens {
+		carro, achado := porChassi[item.Chassi]
+		if !achado && item.ID != "" {
+			carro, achado = visao.carrosMap[item.ID]
+		}
+		if !achado {
+			situacao := "sem cadastro"
+			if v, vendido := vendidoPorChave[item.Chave()]; vendido {
+				situacao = "vendido em " + v.DataVenda
+			} else if l, removido := visao.lapide(item.ID); removido {
+				dia, _, _ := strings.Cut(l.RemovidoEm, "T")
+				situacao = "removido em " + dia
+			}
+			r.SemCadastro = append(r.SemCadastro, ItemSemCadastro{Item: item, Carro: strings.TrimSpace(item.Marca + " " + item.Modelo), Situacao: situacao})
+			continue
+		}
+		if encontrados[carro.ID] {
+			r.SemCadastro = append(r.SemCadastro, ItemSemCadastro{Item: item, Carro: carro.Marca + " " + carro.Modelo, Situacao: "contado em duplicidade"})
+			continue
+		}
+		encontrados[carro.ID] = true
+		for _, d := range divergenciasContagem(item, carro) {
+			r.Divergencias = append(r.Divergencias, Divergencia{Carro: carro, Descricao: d})
+		}
+	}
+
+	for _, carro := range visao.carros {
+		// Carros ainda em trânsito ou arquivados não são esperados no pátio
+		if s := StatusCarro(carro); !encontrados[carro.ID] && s != StatusEmTransito && s != StatusArquivado {
+			r.Ausentes = append(r.Ausentes, carro)
+		}
+	}
+	return r
+}
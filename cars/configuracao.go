@@ -0,0 +1,93 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// RecarregarConfig relê o arquivo de configuração (com o mesmo perfil) sem reiniciar nem perder o
+// banco em memória e devolve as mensagens da recarga. Exibição e regras de preço passam a valer
+// na hora; mudanças de armazenamento só são aplicadas no próximo início. Se o arquivo estiver
+// inválido, a configuração atual é mantida.
+func (c *CadastroCarros) RecarregarConfig() ([]string, error) {
+	mensagens, ok := c.recarregarConfig()
+	if !ok {
+		return nil, errors.New(textoDoRegistro(mensagens[0]))
+	}
+	return mensagens, nil
+}
+
+// recarregarConfig faz a recarga e devolve as mensagens para o usuário em vez de imprimi-las,
+// para que a recarga por SIGHUP as mostre como notificação; ok é false se a recarga falhou
+func (c *CadastroCarros) recarregarConfig() (mensagens []string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.arquivoConfig == "" {
+		return []string{"❌ Nenhum arquivo de configuração associado a esta execução."}, false
+	}
+	nova, err := CarregarConfig(c.arquivoConfig, c.configAtiva.Perfil)
+	if err != nil {
+		return []string{fmt.Sprintf("❌ Erro ao recarregar configuração: %v. Mantendo a atual.", err)}, false
+	}
+	if c.arquivoForcado {
+		nova.Armazenamento = c.configAtiva.Armazenamento
+	}
+
+	c.exibicao = nova.Exibicao
+	c.invalidarVisao()
+	for i, regra := range c.regrasPreco {
+		if _, ok := regra.(regraConfigurada); ok {
+			c.regrasPreco[i] = regraConfigurada{nova.Precificacao}
+		}
+	}
+	if nova.Armazenamento.Tipo != c.configAtiva.Armazenamento.Tipo || nova.Armazenamento.Arquivo != c.configAtiva.Armazenamento.Arquivo {
+		mensagens = append(mensagens, "⚠️  Aviso: alterações em armazenamento só valem após reiniciar o programa.")
+		nova.Armazenamento = c.configAtiva.Armazenamento
+	}
+	if a := c.armazenamentoComBackup(); a != nil {
+		a.backup = nova.Backup
+	}
+	c.configAtiva = nova
+	mensagens = append(mensagens, fmt.Sprintf("✅ Configuração recarregada de %s.", c.arquivoConfig))
+	return mensagens, true
+}
+
+// Config devolve a configuração em vigor, já com padrões e perfil aplicados
+func (c *CadastroCarros) Config() Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.configAtiva
+}
+
+// Exibicao devolve as opções de formatação de preços em vigor
+func (c *CadastroCarros) Exibicao() OpcoesExibicao {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.exibicao
+}
+
+// ArquivoConfig devolve o arquivo de configuração carregado ("" se não houver)
+func (c *CadastroCarros) ArquivoConfig() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.arquivoConfig
+}
+
+// Configurar põe em vigor a configuração lida de arquivo ("" se não veio de arquivo), com a regra
+// de preço dela; forcado indica que o arquivo de dados foi escolhido fora da configuração
+// (--data-file) e não muda na recarga. Os dados são abertos depois, com AbrirArmazenamento.
+func (c *CadastroCarros) Configurar(cfg Config, arquivo string, forcado bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exibicao = cfg.Exibicao
+	c.invalidarVisao()
+	regra := regraConfigurada{cfg.Precificacao}
+	if i := slices.IndexFunc(c.regrasPreco, func(r RegraPreco) bool { _, ok := r.(regraConfigurada); return ok }); i >= 0 {
+		c.regrasPreco[i] = regra
+	} else {
+		c.regrasPreco = append(c.regrasPreco, regra)
+	}
+	c.arquivoConfig, c.configAtiva, c.arquivoForcado = arquivo, cfg, forcado
+}
@@ -0,0 +1,79 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpcoesConformidade é a seção "conformidade" da configuração: restrições de importação
+// conferidas ao cadastrar um carro, gerais e por país de origem
+type OpcoesConformidade struct {
+	IdadeMaxima int                  `json:"idade_maxima,omitempty"` // Idade máxima em anos para importar (0 = sem limite)
+	Paises      map[string]RegraPais `json:"paises,omitempty"`       // Regras por país de origem (sem diferenciar maiúsculas)
+}
+
+// RegraPais sobrepõe ou complementa as restrições gerais para carros de um país de origem
+type RegraPais struct {
+	IdadeMaxima int    `json:"idade_maxima,omitempty"` // Substitui a idade máxima geral (0 = usa a geral)
+	Bloquear    bool   `json:"bloquear,omitempty"`     // Importação deste país exige justificativa
+	Alerta      string `json:"alerta,omitempty"`       // Aviso exibido no cadastro, ex: "volante à direita, exige conversão"
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesConformidade) Validar() error {
+	if o.IdadeMaxima < 0 {
+		return fmt.Errorf("conformidade: idade_maxima não pode ser negativa")
+	}
+	for pais, regra := range o.Paises {
+		if regra.IdadeMaxima < 0 {
+			return fmt.Errorf("conformidade: idade_maxima do país '%s' não pode ser negativa", pais)
+		}
+	}
+	return nil
+}
+
+// Avaliar confere o carro contra as regras: violações impedem o cadastro sem justificativa,
+// alertas só são exibidos
+func (o OpcoesConformidade) Avaliar(carro Carro, anoAtual int) (violacoes, alertas []string) {
+	idadeMaxima := o.IdadeMaxima
+	for pais, regra := range o.Paises {
+		if !strings.EqualFold(pais, carro.PaisOrigem) {
+			continue
+		}
+		if regra.IdadeMaxima > 0 {
+			idadeMaxima = regra.IdadeMaxima
+		}
+		if regra.Bloquear {
+			violacoes = append(violacoes, fmt.Sprintf("importação de '%s' restrita", carro.PaisOrigem))
+		}
+		if regra.Alerta != "" {
+			alertas = append(alertas, fmt.Sprintf("%s: %s", carro.PaisOrigem, regra.Alerta))
+		}
+	}
+	if idade := anoAtual - carro.Ano; idadeMaxima > 0 && idade > idadeMaxima {
+		violacoes = append(violacoes, fmt.Sprintf("carro com %d anos, acima da idade máxima de importação (%d)", idade, idadeMaxima))
+	}
+	sort.Strings(alertas)
+	return violacoes, alertas
+}
+
+// ExcecaoConformidade registra um cadastro feito apesar de violar regras de conformidade
+type ExcecaoConformidade struct {
+	CarroID       string   `json:"carro_id"`
+	Violacoes     []string `json:"violacoes"`
+	Justificativa string   `json:"justificativa"`
+	RegistradaEm  string   `json:"registrada_em"` // Instante do cadastro (RFC 3339)
+}
+
+// AvaliarConformidade confere o carro contra as regras de conformidade em vigor, no ano atual
+func (c *CadastroCarros) AvaliarConformidade(carro Carro) (violacoes, alertas []string) {
+	return c.Config().Conformidade.Avaliar(carro, time.Now().Year())
+}
+
+// Excecoes devolve os cadastros feitos com justificativa apesar das regras de conformidade
+func (v *VisaoCarros) Excecoes() []ExcecaoConformidade {
+	return slices.Clone(v.excecoes)
+}
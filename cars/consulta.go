@@ -0,0 +1,223 @@
+package cars
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Consulta descreve uma leitura do estoque para quem usa o pacote: filtros, ordenação, projeção e
+// paginação por cursor. Com Limite ou Cursor a ordem é total (o campo de Ordenar e, no empate, o
+// ID), para que a próxima página continue do último carro mesmo se o estoque mudar entre as duas.
+type Consulta struct {
+	Filtros     []FiltroBusca // Todos precisam valer (ver NovoFiltro)
+	Ordenar     string        // Campo de ordenação (ver NomesCamposOrdenacao); "" = pelo ID, a ordem de cadastro
+	Decrescente bool
+	Campos      []string // Projeção: nomes JSON dos campos em ResultadoConsulta.Registros (o id sempre vem)
+	Limite      int      // Máximo de carros devolvidos (0 = todos)
+	Cursor      string   // ProximoCursor da página anterior ("" = do início)
+}
+
+// ResultadoConsulta é uma página da consulta
+type ResultadoConsulta struct {
+	Carros        []Carro
+	Registros     []map[string]json.RawMessage // Os carros só com os campos pedidos (nil sem projeção)
+	Total         int                          // Carros que satisfazem os filtros, em todas as páginas
+	ProximoCursor string                       // Cursor da página seguinte ("" na última)
+}
+
+// ArmazenamentoConsultavel é um backend que responde consultas por conta própria, ex: um banco SQL
+// que traduz filtros, ordenação e limite para a consulta. Consultar o usa quando não há alterações
+// pendentes nem campos protegidos, e aplica a projeção sobre os carros devolvidos; os demais
+// backends são consultados em memória. Para a paginação, ver Consulta.DepoisDe e CursorDepoisDe.
+type ArmazenamentoConsultavel interface {
+	Armazenamento
+	Consultar(q Consulta) (ResultadoConsulta, error)
+}
+
+// NovoFiltro interpreta uma condição no formato do search, ex: "ano>=2020" ou "marca=toyota"
+func NovoFiltro(termo string) (FiltroBusca, error) {
+	f, ehFiltro, err := interpretarFiltroBusca(termo)
+	if err == nil && !ehFiltro {
+		err = fmt.Errorf("'%s' não é um filtro (use <campo><operador><valor>, ex: ano>=2020)", termo)
+	}
+	return f, err
+}
+
+// camposProjetaveis lista os nomes JSON dos campos de Carro, na ordem da struct
+func camposProjetaveis() []string {
+	tipo := reflect.TypeOf(Carro{})
+	nomes := make([]string, 0, tipo.NumField())
+	for i := range tipo.NumField() {
+		nome, _, _ := strings.Cut(tipo.Field(i).Tag.Get("json"), ",")
+		if nome != "" && nome != "-" && nome != "protegido" {
+			nomes = append(nomes, nome)
+		}
+	}
+	return nomes
+}
+
+// marcadorConsulta é o conteúdo do cursor: a ordenação em que ele vale e a chave do último carro
+// da página (só o ID e os campos de ordenação, para o cursor ficar curto e sem dados sensíveis)
+type marcadorConsulta struct {
+	Ordenar      string   `json:"o,omitempty"`
+	Decrescente  bool     `json:"d,omitempty"`
+	ID           string   `json:"id"`
+	Marca        string   `json:"ma,omitempty"`
+	Modelo       string   `json:"mo,omitempty"`
+	Ano          int      `json:"a,omitempty"`
+	Preco        Dinheiro `json:"p,omitempty"`
+	DataCadastro string   `json:"c,omitempty"`
+}
+
+// CursorDepoisDe devolve o cursor que continua a consulta depois do carro informado; backends
+// consultáveis o usam para preencher ProximoCursor
+func CursorDepoisDe(q Consulta, ultimo Carro) string {
+	data, _ := json.Marshal(marcadorConsulta{Ordenar: q.Ordenar, Decrescente: q.Decrescente, ID: ultimo.ID,
+		Marca: ultimo.Marca, Modelo: ultimo.Modelo, Ano: ultimo.Ano, Preco: ultimo.Preco, DataCadastro: ultimo.DataCadastro})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DepoisDe decodifica o cursor da consulta: ok é false sem cursor. Os carros da página são os que
+// vêm depois de ultimo na ordem da consulta; de ultimo, só o ID e os campos de ordenação vêm preenchidos.
+func (q Consulta) DepoisDe() (ultimo Carro, ok bool, err error) {
+	if q.Cursor == "" {
+		return Carro{}, false, nil
+	}
+	var m marcadorConsulta
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err == nil {
+		err = json.Unmarshal(data, &m)
+	}
+	if err != nil || m.ID == "" {
+		return Carro{}, false, fmt.Errorf("cursor inválido")
+	}
+	if m.Ordenar != q.Ordenar || m.Decrescente != q.Decrescente {
+		return Carro{}, false, fmt.Errorf("o cursor é de outra ordenação; repita a ordenação da primeira página")
+	}
+	return Carro{ID: m.ID, Marca: m.Marca, Modelo: m.Modelo, Ano: m.Ano, Preco: m.Preco, DataCadastro: m.DataCadastro}, true, nil
+}
+
+// validar confere os campos, a ordenação, o limite e o cursor antes de a consulta chegar a um backend
+func (q Consulta) validar() error {
+	if q.Ordenar != "" && !slices.Contains(NomesCamposOrdenacao(), q.Ordenar) {
+		return fmt.Errorf("campo de ordenação '%s' inválido (use %s)", q.Ordenar, strings.Join(NomesCamposOrdenacao(), ", "))
+	}
+	if q.Limite < 0 {
+		return fmt.Errorf("limite inválido: %d", q.Limite)
+	}
+	for _, campo := range q.Campos {
+		if !slices.Contains(camposProjetaveis(), campo) {
+			return fmt.Errorf("campo '%s' desconhecido (use %s)", campo, strings.Join(camposProjetaveis(), ", "))
+		}
+	}
+	_, _, err := q.DepoisDe()
+	return err
+}
+
+// comparador devolve a ordem total da consulta: o campo de ordenação e, no empate, o ID. IDs
+// gerados têm todos o mesmo tamanho, então comparar tamanho e texto segue a ordem de cadastro e
+// ainda põe car_9 antes de car_10 em IDs importados.
+func (q Consulta) comparador() func(a, b Carro) int {
+	comparar, colador := camposOrdenacao[q.Ordenar], NovoColador()
+	return func(a, b Carro) int {
+		if comparar != nil {
+			r := comparar(a, b, colador)
+			if q.Decrescente {
+				r = -r
+			}
+			if r != 0 {
+				return r
+			}
+		}
+		if len(a.ID) != len(b.ID) {
+			return len(a.ID) - len(b.ID)
+		}
+		return strings.Compare(a.ID, b.ID)
+	}
+}
+
+// consultarCarros avalia a consulta em memória sobre os carros informados
+func consultarCarros(carros []Carro, q Consulta) (ResultadoConsulta, error) {
+	aceitos := filtrarCarros(carros, q.Filtros)
+	comparar := q.comparador()
+	slices.SortFunc(aceitos, comparar)
+	r := ResultadoConsulta{Total: len(aceitos)}
+	ultimo, continua, err := q.DepoisDe()
+	if err != nil {
+		return r, err
+	}
+	if continua {
+		inicio, _ := slices.BinarySearchFunc(aceitos, ultimo, comparar)
+		if inicio < len(aceitos) && comparar(aceitos[inicio], ultimo) == 0 {
+			inicio++
+		}
+		aceitos = aceitos[inicio:]
+	}
+	if q.Limite > 0 && len(aceitos) > q.Limite {
+		aceitos = aceitos[:q.Limite]
+		r.ProximoCursor = CursorDepoisDe(q, aceitos[len(aceitos)-1])
+	}
+	r.Carros = slices.Clip(aceitos)
+	if r.Carros == nil {
+		r.Carros = []Carro{}
+	}
+	return r, nil
+}
+
+// projetarCampos devolve os carros só com os campos pedidos e o id
+func projetarCampos(carros []Carro, campos []string) ([]map[string]json.RawMessage, error) {
+	registros := make([]map[string]json.RawMessage, 0, len(carros))
+	for _, carro := range carros {
+		data, err := json.Marshal(carro)
+		if err != nil {
+			return nil, err
+		}
+		var completo map[string]json.RawMessage
+		if err := json.Unmarshal(data, &completo); err != nil {
+			return nil, err
+		}
+		registro := map[string]json.RawMessage{"id": completo["id"]}
+		for _, campo := range campos {
+			if valor, existe := completo[campo]; existe {
+				registro[campo] = valor
+			}
+		}
+		registros = append(registros, registro)
+	}
+	return registros, nil
+}
+
+// Consultar lê uma página do estoque. Se o backend for um ArmazenamentoConsultavel, a consulta é
+// feita nele; senão, é avaliada em memória sobre a visão atual.
+func (c *CadastroCarros) Consultar(q Consulta) (ResultadoConsulta, error) {
+	defer c.Medir("consultar")()
+	if err := q.validar(); err != nil {
+		return ResultadoConsulta{}, err
+	}
+	c.mu.RLock()
+	backend, consultavel := c.armazenamento.(ArmazenamentoConsultavel)
+	// O backend só vê o que foi gravado: com alterações pendentes ou campos cifrados, ele erraria
+	direto := consultavel && !c.pendente && c.configAtiva.Protecao.ChavePublica == ""
+	c.mu.RUnlock()
+
+	var r ResultadoConsulta
+	var err error
+	if direto {
+		r, err = backend.Consultar(q)
+	} else {
+		r, err = consultarCarros(c.Snapshot().carros, q)
+	}
+	if err != nil {
+		return ResultadoConsulta{}, err
+	}
+	if len(q.Campos) > 0 {
+		if r.Registros, err = projetarCampos(r.Carros, q.Campos); err != nil {
+			return ResultadoConsulta{}, fmt.Errorf("erro ao projetar os campos: %v", err)
+		}
+	}
+	return r, nil
+}
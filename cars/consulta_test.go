@@ -0,0 +1,100 @@
+package cars
+
+import (
+	"slices"
+	"testing"
+)
+
+// idsDe devolve os IDs dos carros, na ordem
+func idsDe(carros []Carro) []string {
+	ids := make([]string, 0, len(carros))
+	for _, carro := range carros {
+		ids = append(ids, carro.ID)
+	}
+	return ids
+}
+
+func TestConsultaPaginaPorCursorMesmoComAlteracoes(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste, x5Teste, x1Teste)
+	filtro, err := NovoFiltro("preco>=100000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := Consulta{Filtros: []FiltroBusca{filtro}, Ordenar: "preco", Decrescente: true, Limite: 2}
+	primeira, err := c.Consultar(q)
+	if err != nil || primeira.Total != 3 || len(primeira.Carros) != 2 || primeira.ProximoCursor == "" {
+		t.Fatalf("primeira página: %v %+v", err, primeira)
+	}
+	if primeira.Carros[0].Modelo != "X5" || primeira.Carros[1].Modelo != "X1" {
+		t.Fatalf("esperado X5 e X1 por preço decrescente: %v", idsDe(primeira.Carros))
+	}
+
+	// Um carro removido antes da próxima página não faz pular nem repetir ninguém
+	if err := c.Remover(primeira.Carros[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	q.Cursor = primeira.ProximoCursor
+	segunda, err := c.Consultar(q)
+	if err != nil || len(segunda.Carros) != 1 || segunda.Carros[0].Modelo != "Corolla" || segunda.ProximoCursor != "" {
+		t.Fatalf("segunda página: %v %+v", err, segunda)
+	}
+
+	q.Ordenar = "ano"
+	if _, err := c.Consultar(q); err == nil {
+		t.Fatal("cursor de outra ordenação deveria ser recusado")
+	}
+	for _, invalida := range []Consulta{{Ordenar: "cor"}, {Limite: -1}, {Campos: []string{"senha"}}, {Cursor: "???"}} {
+		if _, err := c.Consultar(invalida); err == nil {
+			t.Errorf("consulta %+v deveria ser recusada", invalida)
+		}
+	}
+}
+
+func TestConsultaProjetaCampos(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste)
+	r, err := c.Consultar(Consulta{Campos: []string{"marca", "preco"}})
+	if err != nil || len(r.Registros) != 1 {
+		t.Fatalf("%v %+v", err, r)
+	}
+	registro := r.Registros[0]
+	if len(registro) != 3 || string(registro["marca"]) != `"Toyota"` || string(registro["preco"]) != "145000.00" || registro["id"] == nil {
+		t.Fatalf("projeção inesperada: %s", registro)
+	}
+}
+
+// armazenamentoConsultavelTeste responde às consultas por conta própria, como faria um banco SQL
+type armazenamentoConsultavelTeste struct {
+	ArmazenamentoMemoria
+	consultas []Consulta
+}
+
+func (a *armazenamentoConsultavelTeste) Consultar(q Consulta) (ResultadoConsulta, error) {
+	a.consultas = append(a.consultas, q)
+	return ResultadoConsulta{Carros: []Carro{{ID: "car_sql", Marca: "Fiat"}}, Total: 1}, nil
+}
+
+func TestConsultaVaiAoBackendConsultavel(t *testing.T) {
+	t.Parallel()
+	backend := &armazenamentoConsultavelTeste{}
+	c := NewCadastroCarrosEm(backend)
+	r, err := c.Consultar(Consulta{Ordenar: "preco", Campos: []string{"marca"}})
+	if err != nil || len(backend.consultas) != 1 || !slices.Equal(idsDe(r.Carros), []string{"car_sql"}) {
+		t.Fatalf("a consulta deveria ir ao backend: %v %+v", err, r)
+	}
+	if len(r.Registros) != 1 || string(r.Registros[0]["marca"]) != `"Fiat"` {
+		t.Fatalf("a projeção vale também sobre o backend: %+v", r.Registros)
+	}
+
+	cfg := c.Config()
+	publica, _, err := NovasChavesProtecao()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Protecao.ChavePublica = publica
+	c.Configurar(cfg, "", false)
+	if _, err := c.Consultar(Consulta{}); err != nil || len(backend.consultas) != 1 {
+		t.Fatalf("com campos protegidos a consulta deveria ser em memória: %v (%d consultas no backend)", err, len(backend.consultas))
+	}
+}
@@ -0,0 +1,93 @@
+package cars
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// DiretorioDados devolve o diretório de dados da aplicação segundo a convenção de cada sistema:
+// %APPDATA%\CarrosImportados no Windows, ~/Library/Application Support/CarrosImportados no macOS
+// e $XDG_DATA_HOME/carros-importados (ou ~/.local/share/carros-importados) nos demais.
+// Se o diretório do usuário não puder ser determinado, usa o diretório atual.
+func DiretorioDados() string {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		// os.UserConfigDir já devolve %AppData% e ~/Library/Application Support
+		if base, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(base, "CarrosImportados")
+		}
+	default:
+		if base := os.Getenv("XDG_DATA_HOME"); base != "" && filepath.IsAbs(base) {
+			return filepath.Join(base, "carros-importados")
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "share", "carros-importados")
+		}
+	}
+	return "."
+}
+
+// CaminhoConfig devolve o config.json do diretório atual, se existir, ou o do diretório de dados
+func CaminhoConfig() string {
+	if _, err := os.Stat("config.json"); err == nil {
+		return "config.json"
+	}
+	return filepath.Join(DiretorioDados(), "config.json")
+}
+
+// PrepararDiretorioDados cria o diretório de dados e, se ele ainda não tiver dados, copia para ele
+// os arquivos de versões antigas que gravavam no diretório atual (carros.json, anexos e carros.db).
+// Os originais são mantidos como cópia de segurança. Devolve quantos arquivos foram copiados.
+func PrepararDiretorioDados(dir string) (migrados int, err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("erro ao criar diretório de dados: %v", err)
+	}
+
+	destinoAbs, _ := filepath.Abs(dir)
+	atualAbs, _ := filepath.Abs(".")
+	if destinoAbs == atualAbs {
+		return 0, nil
+	}
+
+	existentes, _ := filepath.Glob(filepath.Join(dir, "carros*"))
+	if len(existentes) > 0 {
+		return 0, nil // Diretório já em uso; nada a migrar
+	}
+
+	antigos, _ := filepath.Glob("carros.*json")
+	if _, err := os.Stat("carros.db"); err == nil {
+		antigos = append(antigos, "carros.db")
+	}
+	if len(antigos) == 0 {
+		return 0, nil
+	}
+
+	for _, arquivo := range antigos {
+		if err := copiarArquivo(arquivo, filepath.Join(dir, filepath.Base(arquivo))); err != nil {
+			return 0, fmt.Errorf("erro ao migrar %s: %v", arquivo, err)
+		}
+	}
+	return len(antigos), nil
+}
+
+// copiarArquivo copia o conteúdo de origem para destino, criando ou truncando o destino
+func copiarArquivo(origem, destino string) error {
+	entrada, err := os.Open(origem)
+	if err != nil {
+		return err
+	}
+	defer entrada.Close()
+
+	saida, err := os.OpenFile(destino, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(saida, entrada); err != nil {
+		saida.Close()
+		return err
+	}
+	return saida.Close()
+}
@@ -0,0 +1,165 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// limiteDiario é quantas operações o undo consegue desfazer em sequência
+const limiteDiario = 50
+
+// operacaoDiario é um comando do prompt que alterou o estoque. Os eventos dele continuam no log;
+// o diário guarda só onde ele começa e termina, para o undo voltar os carros à situação anterior
+// e o redo levá-los de novo à situação seguinte.
+type operacaoDiario struct {
+	Comando  string   // Linha digitada, mostrada pelo undo e pelo redo
+	IDs      []string // Carros alterados
+	Primeiro int64    // Primeiro evento da operação (o undo volta ao estado logo antes dele)
+	Ultimo   int64    // Último evento da operação (o redo volta ao estado logo depois dele)
+	Marca    int64    // Último evento que esta sessão gravou sobre os carros (operação, undo ou redo)
+}
+
+// diarioOperacoes guarda as operações da sessão que podem ser desfeitas e refeitas; fica só em
+// memória e acaba com a sessão (o log de eventos e o rollback continuam valendo depois dela)
+type diarioOperacoes struct {
+	feitas    []operacaoDiario // Pilha do undo
+	desfeitas []operacaoDiario // Pilha do redo; esvaziada por qualquer operação nova
+}
+
+// MarcoDiario é o ponto do log em que um comando do prompt começou
+type MarcoDiario struct {
+	seq   int64
+	autor string
+}
+
+// MarcarDiario registra o último evento do log e o autor da sessão antes de um comando
+func (c *CadastroCarros) MarcarDiario() MarcoDiario {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return MarcoDiario{seq: c.ultimoSeq(), autor: c.autor}
+}
+
+// ultimoSeq devolve o número do último evento do log, ou 0 se estiver vazio
+func (d *dadosCarros) ultimoSeq() int64 {
+	if len(d.eventos) == 0 {
+		return 0
+	}
+	return d.eventos[len(d.eventos)-1].Seq
+}
+
+// RegistrarOperacao guarda no diário os eventos que a sessão emitiu desde o marco, se houver.
+// Eventos de outros autores no mesmo intervalo (ex: a API) não fazem parte da operação.
+func (c *CadastroCarros) RegistrarOperacao(linha string, marco MarcoDiario) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	inicio, _ := slices.BinarySearchFunc(c.eventos, marco.seq+1, func(e Evento, alvo int64) int {
+		return int(e.Seq - alvo)
+	})
+	var proprios []Evento
+	for _, e := range c.eventos[inicio:] {
+		if e.Autor == marco.autor {
+			proprios = append(proprios, e)
+		}
+	}
+	if len(proprios) == 0 {
+		return
+	}
+	ultimo := proprios[len(proprios)-1].Seq
+	op := operacaoDiario{Comando: linha, IDs: carrosDosEventos(proprios), Primeiro: proprios[0].Seq, Ultimo: ultimo, Marca: ultimo}
+	c.diario.feitas = append(c.diario.feitas, op)
+	if excesso := len(c.diario.feitas) - limiteDiario; excesso > 0 {
+		c.diario.feitas = slices.Delete(c.diario.feitas, 0, excesso)
+	}
+	c.diario.desfeitas = nil
+}
+
+// ErrNadaADesfazer e ErrNadaARefazer indicam que a pilha do undo ou a do redo está vazia
+var (
+	ErrNadaADesfazer = errors.New("nada a desfazer nesta sessão")
+	ErrNadaARefazer  = errors.New("nada a refazer")
+)
+
+// ResultadoVolta é o que um undo ou redo fez: o comando voltado e as mudanças nos carros (vazias
+// se eles já estavam na situação de destino)
+type ResultadoVolta struct {
+	Comando  string
+	Mudancas []MudancaReversao
+}
+
+// Desfazer volta os carros da última operação do prompt à situação em que estavam antes dela
+func (c *CadastroCarros) Desfazer() (ResultadoVolta, error) {
+	c.mu.RLock()
+	pilha := c.diario.feitas
+	c.mu.RUnlock()
+	if len(pilha) == 0 {
+		return ResultadoVolta{}, ErrNadaADesfazer
+	}
+	return c.voltarOperacao(pilha[len(pilha)-1], true)
+}
+
+// Refazer aplica de novo a última operação desfeita
+func (c *CadastroCarros) Refazer() (ResultadoVolta, error) {
+	c.mu.RLock()
+	pilha := c.diario.desfeitas
+	c.mu.RUnlock()
+	if len(pilha) == 0 {
+		return ResultadoVolta{}, ErrNadaARefazer
+	}
+	return c.voltarOperacao(pilha[len(pilha)-1], false)
+}
+
+// voltarOperacao leva os carros da operação à situação de antes dela (desfazer) ou de logo
+// depois dela (refazer), com eventos novos no log, e troca a operação de pilha. Se outro comando
+// ou a API alterou algum desses carros depois, nada é feito: o undo passaria por cima da alteração.
+func (c *CadastroCarros) voltarOperacao(op operacaoDiario, desfazer bool) (ResultadoVolta, error) {
+	visao := c.Snapshot()
+	for _, e := range visao.eventos {
+		if e.Seq > op.Marca && slices.Contains(op.IDs, e.CarroID) {
+			return ResultadoVolta{}, fmt.Errorf("o carro %s foi alterado depois (evento #%d por %s). Use 'rollback' para escolher o ponto de volta",
+				e.CarroID, e.Seq, e.Autor)
+		}
+	}
+	indice, achou := slices.BinarySearchFunc(visao.eventos, op.Primeiro, func(e Evento, alvo int64) int {
+		return int(e.Seq - alvo)
+	})
+	if !achou {
+		return ResultadoVolta{}, errors.New("os eventos da operação não estão mais no log (compactados pela retenção). Nada foi alterado")
+	}
+	alvo := visao.eventos[:indice]
+	if !desfazer {
+		fim, _ := slices.BinarySearchFunc(visao.eventos, op.Ultimo, func(e Evento, alvo int64) int {
+			return int(e.Seq - alvo)
+		})
+		alvo = visao.eventos[:fim+1]
+	}
+	resultado := ResultadoVolta{Comando: op.Comando, Mudancas: visao.planejarVolta(projetar(alvo, time.Time{}), op.IDs)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ultimoSeq() != visao.ultimoSeq() {
+		return ResultadoVolta{}, errors.New("o log mudou enquanto a operação era preparada. Tente de novo")
+	}
+	var novos []Evento
+	for _, m := range resultado.Mudancas {
+		novos = append(novos, m.Eventos...)
+	}
+	if len(novos) > 0 {
+		c.emitir(novos...)
+		op.Marca = novos[len(novos)-1].Seq
+	}
+	if desfazer {
+		c.diario.feitas = c.diario.feitas[:len(c.diario.feitas)-1]
+		c.diario.desfeitas = append(c.diario.desfeitas, op)
+	} else {
+		c.diario.desfeitas = c.diario.desfeitas[:len(c.diario.desfeitas)-1]
+		c.diario.feitas = append(c.diario.feitas, op)
+	}
+	if len(novos) == 0 {
+		return resultado, nil
+	}
+
+	// Persistir após desfazer/refazer
+	return resultado, c.gravar()
+}
@@ -0,0 +1,56 @@
+package cars
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// gravarDiagnostico escreve um retrato do processo para investigar travamentos: contagem de registros,
+// última persistência, contenção de locks e a pilha de todas as goroutines
+func (c *CadastroCarros) gravarDiagnostico(w io.Writer) {
+	fmt.Fprintf(w, "=== Diagnóstico do cadastro de carros (%s, pid %d) ===\n", time.Now().Format(time.RFC3339), os.Getpid())
+
+	// Não espera pelo lock: se alguém o segura há muito tempo, é justamente isso que se quer ver
+	if c.mu.TryRLock() {
+		fmt.Fprintf(w, "Carros em estoque: %d | Vendidos: %d | Removidos: %d | Pagamentos: %d | Quarentena: %d\n",
+			len(c.carros), len(c.vendidos), len(c.removidos), len(c.pagamentos), len(c.quarentena))
+		ultimo := "nenhuma nesta execução"
+		if !c.ultimoSalvamento.IsZero() {
+			ultimo = fmt.Sprintf("%s (há %s)", c.ultimoSalvamento.Format(time.RFC3339), time.Since(c.ultimoSalvamento).Round(time.Second))
+		}
+		fmt.Fprintf(w, "Persistência: %s | Última gravação: %s\n", c.armazenamento.Descrever(), ultimo)
+		// Toda alteração é gravada antes de liberar o lock; só fica pendente se a gravação falhou
+		fmt.Fprintf(w, "Alterações pendentes: %t\n", c.pendente)
+		c.mu.RUnlock()
+	} else {
+		fmt.Fprintln(w, "Lock do cadastro ocupado por um escritor; contagens indisponíveis.")
+	}
+
+	fmt.Fprintf(w, "Goroutines: %d\n", runtime.NumGoroutine())
+	fmt.Fprintln(w, "\n--- Tempos das operações ---")
+	c.escreverMetricas(w)
+	fmt.Fprintln(w, "\n--- Contenção de locks ---")
+	pprof.Lookup("mutex").WriteTo(w, 1)
+	fmt.Fprintln(w, "\n--- Pilhas das goroutines ---")
+	pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// despejarDiagnostico grava o diagnóstico no arquivo informado (acrescentando) ou, sem arquivo, em stderr
+func (c *CadastroCarros) despejarDiagnostico(arquivo string) {
+	if arquivo == "" {
+		c.gravarDiagnostico(os.Stderr)
+		return
+	}
+	f, err := os.OpenFile(arquivo, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erro ao abrir arquivo de diagnóstico: %v\n", err)
+		c.gravarDiagnostico(os.Stderr)
+		return
+	}
+	defer f.Close()
+	c.gravarDiagnostico(f)
+}
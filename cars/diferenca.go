@@ -0,0 +1,113 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CarroAlterado é um carro presente nos dois arquivos com campos diferentes
+type CarroAlterado struct {
+	ID     string          `json:"id"`
+	Carro  string          `json:"carro"` // Marca e modelo na versão do segundo arquivo
+	Campos []CampoAlterado `json:"campos"`
+}
+
+// DiferencaArquivos é o relatório do diff entre dois arquivos de dados: carros só no segundo
+// (adicionados), só no primeiro (removidos) e nos dois com campos diferentes (alterados)
+type DiferencaArquivos struct {
+	ArquivoA    string          `json:"arquivo_a"`
+	ArquivoB    string          `json:"arquivo_b"`
+	Adicionados []Carro         `json:"adicionados"`
+	Removidos   []Carro         `json:"removidos"`
+	Alterados   []CarroAlterado `json:"alterados"`
+	Iguais      int             `json:"iguais"`
+}
+
+// Vazia informa se os dois arquivos têm os mesmos carros
+func (d DiferencaArquivos) Vazia() bool {
+	return len(d.Adicionados) == 0 && len(d.Removidos) == 0 && len(d.Alterados) == 0
+}
+
+// lerArquivoCarros lê um arquivo de dados no formato indicado pela extensão (.yaml/.yml,
+// .msgpack, .pb ou JSON). Campos cifrados continuam em "protegido" e são comparados cifrados.
+func lerArquivoCarros(arquivo string) ([]Carro, error) {
+	formato := "json"
+	switch strings.ToLower(filepath.Ext(arquivo)) {
+	case ".yaml", ".yml":
+		formato = "yaml"
+	case ".msgpack":
+		formato = "msgpack"
+	case ".pb":
+		formato = "protobuf"
+	}
+	serializador, _ := interpretarFormato(formato)
+	data, err := os.ReadFile(arquivo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %v", arquivo, err)
+	}
+	var brutos []json.RawMessage
+	if err := serializador.Decodificar(data, &brutos); err != nil {
+		return nil, fmt.Errorf("erro ao desserializar %s: %v", arquivo, err)
+	}
+	carros := make([]Carro, len(brutos))
+	for i, bruto := range brutos {
+		if err := json.Unmarshal(bruto, &carros[i]); err != nil {
+			return nil, fmt.Errorf("%s: registro %d inválido: %v", arquivo, i+1, err)
+		}
+		if carros[i].ID == "" {
+			return nil, fmt.Errorf("%s: registro %d sem id", arquivo, i+1)
+		}
+	}
+	return carros, nil
+}
+
+// compararCarros casa os carros pelo ID; adicionados e alterados seguem a ordem do segundo
+// arquivo, removidos a do primeiro
+func compararCarros(a, b []Carro) DiferencaArquivos {
+	// Listas vazias em vez de nulas, para o JSON ter sempre a mesma forma
+	d := DiferencaArquivos{Adicionados: []Carro{}, Removidos: []Carro{}, Alterados: []CarroAlterado{}}
+	antes := make(map[string]Carro, len(a))
+	for _, carro := range a {
+		antes[carro.ID] = carro
+	}
+	presentes := make(map[string]bool, len(b))
+	for _, carro := range b {
+		presentes[carro.ID] = true
+		anterior, existia := antes[carro.ID]
+		switch {
+		case !existia:
+			d.Adicionados = append(d.Adicionados, carro)
+		case anterior.Igual(carro):
+			d.Iguais++
+		default:
+			d.Alterados = append(d.Alterados, CarroAlterado{
+				ID: carro.ID, Carro: carro.Marca + " " + carro.Modelo, Campos: camposAlterados(anterior, carro),
+			})
+		}
+	}
+	for _, carro := range a {
+		if !presentes[carro.ID] {
+			d.Removidos = append(d.Removidos, carro)
+		}
+	}
+	return d
+}
+
+// DiferencaEntreArquivos compara dois arquivos de dados e devolve o que mudou do primeiro para o
+// segundo
+func DiferencaEntreArquivos(arquivoA, arquivoB string) (DiferencaArquivos, error) {
+	a, err := lerArquivoCarros(arquivoA)
+	if err != nil {
+		return DiferencaArquivos{}, err
+	}
+	b, err := lerArquivoCarros(arquivoB)
+	if err != nil {
+		return DiferencaArquivos{}, err
+	}
+	d := compararCarros(a, b)
+	d.ArquivoA, d.ArquivoB = arquivoA, arquivoB
+	return d, nil
+}
@@ -0,0 +1,188 @@
+package cars
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dinheiro é um valor monetário em centavos. Somas e subtrações são exatas; operações com
+// frações (markup, juros, rateio, médias) arredondam para o centavo mais próximo.
+// No JSON continua sendo um número em reais com duas casas (ex: 145000.50), então arquivos
+// gravados com float64 são lidos normalmente e normalizados para centavos ao carregar.
+type Dinheiro int64
+
+// Reais converte um valor em reais para Dinheiro, arredondando para o centavo
+func Reais(v float64) Dinheiro {
+	return Dinheiro(math.Round(v * 100))
+}
+
+// EmReais devolve o valor em reais como float64, para cálculos de proporção e percentuais
+func (d Dinheiro) EmReais() float64 {
+	return float64(d) / 100
+}
+
+// Multiplicar aplica um fator (ex: markup 1.25) arredondando para o centavo
+func (d Dinheiro) Multiplicar(fator float64) Dinheiro {
+	return Dinheiro(math.Round(float64(d) * fator))
+}
+
+// Dividir divide o valor em n partes, arredondando para o centavo (n <= 0 devolve zero)
+func (d Dinheiro) Dividir(n int) Dinheiro {
+	if n <= 0 {
+		return 0
+	}
+	return Dinheiro(math.Round(float64(d) / float64(n)))
+}
+
+// String devolve o valor em reais com duas casas e ponto decimal (ex: 145000.50)
+func (d Dinheiro) String() string {
+	sinal, centavos := "", int64(d)
+	if centavos < 0 {
+		sinal, centavos = "-", -centavos
+	}
+	return fmt.Sprintf("%s%d.%02d", sinal, centavos/100, centavos%100)
+}
+
+// MarshalJSON grava o valor como número em reais
+func (d Dinheiro) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON lê um número em reais (inteiro ou com casas decimais); null vira zero
+func (d *Dinheiro) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = 0
+		return nil
+	}
+	v, err := InterpretarDinheiro(string(data))
+	if err != nil {
+		return fmt.Errorf("valor monetário inválido %s", data)
+	}
+	*d = v
+	return nil
+}
+
+// InterpretarValorDigitado lê um valor em reais digitado pelo usuário, aceitando o formato
+// brasileiro ("R$ 145.000,50", "145000,5") além do ponto decimal. Vírgula sozinha é sempre a
+// casa decimal; ponto seguido de grupos de três dígitos é separador de milhar ("145.000");
+// havendo os dois, o que vem por último é o decimal ("145,000.50" também é aceito).
+func InterpretarValorDigitado(s string) (Dinheiro, error) {
+	limpo := strings.TrimSpace(s)
+	limpo = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(limpo), "R$"))
+	limpo = strings.NewReplacer(" ", "", "\u00a0", "").Replace(limpo)
+	normalizado, ok := normalizarNumero(limpo)
+	if !ok {
+		return 0, fmt.Errorf("valor inválido '%s'", strings.TrimSpace(s))
+	}
+	return InterpretarDinheiro(normalizado)
+}
+
+// InterpretarNumero lê um número digitado que não é valor em reais (taxas, percentuais), com
+// vírgula ou ponto decimal. Aqui o ponto é sempre decimal: "5.123" é uma cotação, não milhar.
+func InterpretarNumero(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ",") {
+		normalizado, ok := normalizarNumero(s)
+		if !ok {
+			return 0, fmt.Errorf("número inválido '%s'", s)
+		}
+		s = normalizado
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// normalizarNumero converte um número com separadores de milhar e vírgula ou ponto decimal para
+// o formato com ponto decimal e sem milhar. Notação científica passa sem alteração.
+func normalizarNumero(s string) (string, bool) {
+	if strings.ContainsAny(s, "eE") {
+		return s, true
+	}
+	sinal := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sinal, s = s[:1], s[1:]
+	}
+	virgula, ponto := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	var milhar, decimal string
+	switch {
+	case virgula >= 0 && ponto >= 0:
+		milhar, decimal = ".", ","
+		if ponto > virgula {
+			milhar, decimal = ",", "."
+		}
+	case virgula >= 0:
+		milhar, decimal = ",", ","
+		if strings.Count(s, ",") > 1 {
+			decimal = "" // "1,450,000": só milhar
+		}
+	case ponto >= 0:
+		milhar, decimal = ".", "."
+		inteiro, fracao, _ := strings.Cut(s, ".")
+		if strings.Count(s, ".") > 1 || (len(fracao) == 3 && strings.Trim(inteiro, "0") != "") {
+			decimal = "" // "145.000" ou "1.450.000": só milhar
+		}
+	default:
+		return sinal + s, true
+	}
+
+	inteiro, fracao := s, ""
+	if decimal != "" {
+		i := strings.LastIndex(s, decimal)
+		inteiro, fracao = s[:i], s[i+1:]
+		if strings.Contains(fracao, milhar) || strings.Contains(fracao, decimal) {
+			return "", false
+		}
+	}
+	if milhar != decimal && strings.Contains(inteiro, milhar) {
+		grupos := strings.Split(inteiro, milhar)
+		for i, g := range grupos {
+			if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				return "", false
+			}
+		}
+		inteiro = strings.Join(grupos, "")
+	}
+	if fracao != "" {
+		return sinal + inteiro + "." + fracao, true
+	}
+	return sinal + inteiro, true
+}
+
+// InterpretarDinheiro lê um valor em reais com ponto decimal ("145000", "145000.5", "1e5"),
+// sem passar por float64 quando há no máximo duas casas
+func InterpretarDinheiro(s string) (Dinheiro, error) {
+	s = strings.TrimSpace(s)
+	if strings.Trim(s, "+-.") == "" {
+		return 0, fmt.Errorf("valor vazio")
+	}
+	inteiro, fracao, temFracao := strings.Cut(s, ".")
+	if !strings.ContainsAny(s, "eE") && (!temFracao || len(fracao) <= 2) {
+		negativo := strings.HasPrefix(inteiro, "-")
+		inteiro = strings.TrimPrefix(inteiro, "-")
+		reais, err := strconv.ParseInt(inteiro, 10, 64)
+		if inteiro != "" && (err != nil || reais < 0 || inteiro[0] == '+') {
+			return 0, fmt.Errorf("valor inválido '%s'", s)
+		}
+		centavos := int64(0)
+		if fracao != "" {
+			if centavos, err = strconv.ParseInt(fracao, 10, 64); err != nil || fracao[0] == '-' || fracao[0] == '+' {
+				return 0, fmt.Errorf("casas decimais inválidas em '%s'", s)
+			}
+			if len(fracao) == 1 {
+				centavos *= 10
+			}
+		}
+		total := Dinheiro(reais*100 + centavos)
+		if negativo {
+			total = -total
+		}
+		return total, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, fmt.Errorf("valor inválido '%s'", s)
+	}
+	return Reais(v), nil
+}
@@ -0,0 +1,28 @@
+package cars
+
+import "testing"
+
+func TestInterpretarValorDigitado(t *testing.T) {
+	t.Parallel()
+	casos := map[string]Dinheiro{
+		"145000":         Reais(145000),
+		"145000.50":      Dinheiro(14500050),
+		"145000,5":       Dinheiro(14500050),
+		"145.000":        Reais(145000),
+		"145.000,50":     Dinheiro(14500050),
+		"R$ 1.234.567,8": Dinheiro(123456780),
+		"145,000.50":     Dinheiro(14500050),
+		"1.5":            Dinheiro(150),
+		"-2.500,00":      Reais(-2500),
+	}
+	for entrada, esperado := range casos {
+		if v, err := InterpretarValorDigitado(entrada); err != nil || v != esperado {
+			t.Errorf("%q: esperado %s, obtido %s (%v)", entrada, esperado, v, err)
+		}
+	}
+	for _, invalido := range []string{"12.34,5", "1,2.3", "1.2.3", "abc", ""} {
+		if v, err := InterpretarValorDigitado(invalido); err == nil {
+			t.Errorf("%q: esperado erro, obtido %s", invalido, v)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TiposDocumento são os documentos com vencimento acompanhados por carro
+var TiposDocumento = map[string]string{
+	"crlv":     "CRLV (licenciamento)",
+	"seguro":   "Seguro",
+	"garantia": "Garantia",
+}
+
+// Documento registra o vencimento de um documento de um carro em estoque
+type Documento struct {
+	CarroID    string `json:"carro_id"`   // Carro a que o documento pertence
+	Tipo       string `json:"tipo"`       // crlv, seguro ou garantia
+	Vencimento string `json:"vencimento"` // Data de vencimento (formato YYYY-MM-DD)
+}
+
+// OpcoesDocumentos é a seção "documentos" da configuração: lembretes de vencimento na abertura
+type OpcoesDocumentos struct {
+	AvisoDias int    `json:"aviso_dias,omitempty"` // Avisa na abertura dos vencimentos nos próximos N dias (0 = não avisa)
+	Webhook   string `json:"webhook,omitempty"`    // URL que recebe os vencimentos por POST em JSON (ex: ponte para e-mail ou chat)
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesDocumentos) Validar() error {
+	if o.AvisoDias < 0 {
+		return fmt.Errorf("documentos: aviso_dias não pode ser negativo")
+	}
+	if o.Webhook != "" {
+		u, err := url.Parse(o.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("documentos: webhook '%s' inválido (use uma URL http ou https)", o.Webhook)
+		}
+	}
+	return nil
+}
+
+// Vencimento é um documento a vencer (ou vencido), com o carro e os dias restantes
+type Vencimento struct {
+	Documento
+	Carro string `json:"carro"` // Marca e modelo, para a mensagem
+	Dias  int    `json:"dias"`  // Dias até o vencimento (negativo = vencido)
+}
+
+// vencimentos lista os documentos dos carros em estoque que vencem em até `dias` dias, incluindo
+// os já vencidos, do mais urgente para o menos urgente
+func (d *dadosCarros) vencimentos(dias int, hoje time.Time) []Vencimento {
+	hoje = time.Date(hoje.Year(), hoje.Month(), hoje.Day(), 0, 0, 0, 0, time.Local)
+	var lista []Vencimento
+	for _, doc := range d.documentos {
+		carro, emEstoque := d.carrosMap[doc.CarroID]
+		if !emEstoque {
+			continue
+		}
+		venc, err := time.ParseInLocation("2006-01-02", doc.Vencimento, time.Local)
+		if err != nil {
+			continue
+		}
+		if restantes := int(math.Round(venc.Sub(hoje).Hours() / 24)); restantes <= dias {
+			lista = append(lista, Vencimento{Documento: doc, Carro: carro.Marca + " " + carro.Modelo, Dias: restantes})
+		}
+	}
+	sort.SliceStable(lista, func(i, j int) bool { return lista[i].Vencimento < lista[j].Vencimento })
+	return lista
+}
+
+// GravarDocumento grava (ou substitui) o vencimento de um documento do carro e devolve o carro e
+// o documento gravado
+func (c *CadastroCarros) GravarDocumento(id, tipo, vencimento string) (Carro, Documento, error) {
+	tipo = strings.ToLower(tipo)
+	if _, ok := TiposDocumento[tipo]; !ok {
+		return Carro{}, Documento{}, fmt.Errorf("tipo de documento '%s' desconhecido (use crlv, seguro ou garantia)", tipo)
+	}
+	data, err := InterpretarData(vencimento)
+	if err != nil {
+		return Carro{}, Documento{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return Carro{}, Documento{}, ErroCarroNaoEncontrado(id)
+	}
+	doc := Documento{CarroID: id, Tipo: tipo, Vencimento: data}
+	substituido := false
+	for i := range c.documentos {
+		if c.documentos[i].CarroID == id && c.documentos[i].Tipo == tipo {
+			c.documentos[i], substituido = doc, true
+		}
+	}
+	if !substituido {
+		c.documentos = append(c.documentos, doc)
+	}
+
+	// Persistir após registrar o documento
+	return carro, doc, c.gravar()
+}
+
+// Vencimentos devolve os documentos dos carros em estoque que vencem em até `dias` dias a partir
+// de hoje, incluindo os já vencidos, do mais urgente para o menos urgente
+func (v *VisaoCarros) Vencimentos(dias int, hoje time.Time) []Vencimento {
+	return v.vencimentos(dias, hoje)
+}
+
+// LembrarVencimentos avisa, na abertura, dos documentos que vencem dentro do prazo configurado e,
+// havendo webhook, envia a lista em segundo plano; o resultado do envio chega como notificação
+func (c *CadastroCarros) LembrarVencimentos(opcoes OpcoesDocumentos) {
+	if opcoes.AvisoDias <= 0 {
+		return
+	}
+	lista := c.Snapshot().vencimentos(opcoes.AvisoDias, time.Now())
+	if len(lista) == 0 {
+		return
+	}
+	c.notificar(true, "%d documento(s) vencido(s) ou vencendo em até %d dia(s). Veja com 'expiring --days=%d'.",
+		len(lista), opcoes.AvisoDias, opcoes.AvisoDias)
+	if opcoes.Webhook == "" {
+		return
+	}
+	go func() {
+		if err := enviarWebhookVencimentos(opcoes.Webhook, lista); err != nil {
+			c.notificar(false, "⚠️  Aviso: falha ao enviar lembrete de vencimentos ao webhook: %v", err)
+			return
+		}
+		c.notificar(false, "Lembrete de %d vencimento(s) enviado ao webhook.", len(lista))
+	}()
+}
+
+// enviarWebhookVencimentos faz o POST da lista de vencimentos em JSON
+func enviarWebhookVencimentos(endereco string, lista []Vencimento) error {
+	corpo, err := json.Marshal(map[string]interface{}{
+		"evento":      "documentos_vencendo",
+		"gerado_em":   time.Now().UTC().Format(time.RFC3339),
+		"vencimentos": lista,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar vencimentos: %v", err)
+	}
+	cliente := http.Client{Timeout: 10 * time.Second}
+	resp, err := cliente.Post(endereco, "application/json", bytes.NewReader(corpo))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("resposta %s", resp.Status)
+	}
+	return nil
+}
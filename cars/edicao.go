@@ -0,0 +1,169 @@
+package cars
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CampoAlterado é um campo que mudou entre duas versões de um carro, pelo nome no JSON
+type CampoAlterado struct {
+	Campo  string      `json:"campo"`
+	Antes  interface{} `json:"antes"`
+	Depois interface{} `json:"depois"`
+}
+
+// camposAlterados compara duas versões de um carro campo a campo
+func camposAlterados(antes, depois Carro) []CampoAlterado {
+	var campos []CampoAlterado
+	va, vd := reflect.ValueOf(antes), reflect.ValueOf(depois)
+	for i := 0; i < va.NumField(); i++ {
+		if camposIguais(va.Field(i), vd.Field(i)) {
+			continue
+		}
+		nome, _, _ := strings.Cut(va.Type().Field(i).Tag.Get("json"), ",")
+		campos = append(campos, CampoAlterado{Campo: nome, Antes: va.Field(i).Interface(), Depois: vd.Field(i).Interface()})
+	}
+	return campos
+}
+
+// DiferencasCarro lista os campos alterados entre duas versões de um carro, no formato "campo: antes → depois"
+func DiferencasCarro(antes, depois Carro) []string {
+	var linhas []string
+	for _, campo := range camposAlterados(antes, depois) {
+		linhas = append(linhas, fmt.Sprintf("%s: %v → %v", campo.Campo, campo.Antes, campo.Depois))
+	}
+	return linhas
+}
+
+// camposIguais compara um campo de duas versões do carro. Slices não são comparáveis com ==, e
+// um slice vazio vale o mesmo que nil, para que um campo não tocado não apareça como alterado.
+func camposIguais(a, d reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice, reflect.Map:
+		if a.Len() == 0 && d.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), d.Interface())
+}
+
+// ConferirEdicao valida o registro editado e recusa alterações nos campos que só o sistema
+// mantém; usado pelo editor e pelo patch
+func ConferirEdicao(original, editado Carro) error {
+	if editado.ID != original.ID || editado.DataCadastro != original.DataCadastro || editado.AtualizadoEm != original.AtualizadoEm {
+		return fmt.Errorf("id, data_cadastro e atualizado_em não podem ser alterados")
+	}
+	if editado.Status != original.Status || editado.StatusDesde != original.StatusDesde {
+		return fmt.Errorf("status e status_desde só mudam pelo comando status")
+	}
+	if !maps.Equal(editado.Protegido, original.Protegido) {
+		return fmt.Errorf("protegido guarda campos cifrados e não pode ser editado")
+	}
+	return ValidarCarro(editado)
+}
+
+// ErrCarroAlterado indica que o carro mudou ou saiu do estoque entre a leitura e a gravação de
+// uma alteração
+var ErrCarroAlterado = errors.New("o carro foi alterado ou removido enquanto era editado")
+
+// Regravar substitui o carro original pela versão alterada, desde que ninguém o tenha alterado
+// nesse meio tempo, e persiste. Devolve o carro como foi gravado (após as regras de preço) e os
+// ajustes feitos; se só a gravação falhar (ErroGravacao), a alteração fica no cadastro.
+func (c *CadastroCarros) Regravar(original, alterado Carro) (Carro, []AjustePreco, error) {
+	if err := ConferirEdicao(original, alterado); err != nil {
+		return Carro{}, nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if atual, existe := c.carrosMap[original.ID]; !existe || !atual.Igual(original) {
+		return Carro{}, nil, ErrCarroAlterado
+	}
+	if err := c.validarRegistrados(alterado); err != nil {
+		return Carro{}, nil, err
+	}
+	alterado, ajustes, err := c.regravarCarro(alterado)
+	if err != nil {
+		return alterado, ajustes, &ErroGravacao{Err: err}
+	}
+	return alterado, ajustes, nil
+}
+
+// regravarCarro aplica a edição e persiste. Devolve o carro como foi gravado, os ajustes de preço e
+// o erro da gravação (chamador deve segurar o lock).
+func (c *CadastroCarros) regravarCarro(editado Carro) (Carro, []AjustePreco, error) {
+	editado, ajustes := c.aplicarEdicao(editado)
+
+	// Persistir após editar
+	return editado, ajustes, c.salvar()
+}
+
+// aplicarEdicao aplica as regras de preço à versão editada e substitui o carro, sem persistir
+// (chamador deve segurar o lock)
+func (c *CadastroCarros) aplicarEdicao(editado Carro) (Carro, []AjustePreco) {
+	ajustes := c.aplicarRegrasPreco(&editado)
+	editado.AtualizadoEm = time.Now().UTC().Format(time.RFC3339Nano)
+	c.substituirCarro(editado)
+	return editado, ajustes
+}
+
+// mesclarPatch aplica um JSON merge patch (RFC 7386): objetos são mesclados recursivamente,
+// null remove o campo e qualquer outro valor substitui o existente
+func mesclarPatch(alvo, patch interface{}) interface{} {
+	campos, ehObjeto := patch.(map[string]interface{})
+	if !ehObjeto {
+		return patch
+	}
+	destino, ok := alvo.(map[string]interface{})
+	if !ok {
+		destino = make(map[string]interface{})
+	}
+	for chave, valor := range campos {
+		if valor == nil {
+			delete(destino, chave)
+		} else {
+			destino[chave] = mesclarPatch(destino[chave], valor)
+		}
+	}
+	return destino
+}
+
+// EditarPorPatch aplica um JSON merge patch ao registro do carro e devolve a versão resultante,
+// já conferida pelas mesmas validações da edição; campos removidos com null voltam ao valor vazio.
+func EditarPorPatch(original Carro, patch string) (Carro, error) {
+	var alteracoes interface{}
+	if err := json.Unmarshal([]byte(patch), &alteracoes); err != nil {
+		return Carro{}, fmt.Errorf("patch inválido: %v", err)
+	}
+	if _, ok := alteracoes.(map[string]interface{}); !ok {
+		return Carro{}, errors.New("patch inválido: informe um objeto JSON, ex: '{\"cor\": \"Prata\"}'")
+	}
+
+	var registro interface{}
+	data, _ := json.Marshal(original)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&registro); err != nil {
+		return Carro{}, fmt.Errorf("erro ao ler o registro atual: %v", err)
+	}
+	data, err := json.Marshal(mesclarPatch(registro, alteracoes))
+	if err != nil {
+		return Carro{}, fmt.Errorf("erro ao aplicar patch: %v", err)
+	}
+
+	var editado Carro
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&editado); err != nil {
+		return Carro{}, fmt.Errorf("registro inválido após o patch: %v", err)
+	}
+	if err := ConferirEdicao(original, editado); err != nil {
+		return Carro{}, fmt.Errorf("registro inválido após o patch: %v", err)
+	}
+	return editado, nil
+}
@@ -0,0 +1,224 @@
+package cars
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Tipos de evento do log. O log é a fonte da verdade: carros, lápides e vendas em memória são
+// a projeção dele e podem ser reconstruídos a qualquer momento.
+const (
+	EventoCarroAdicionado = "CarroAdicionado" // Carro entrou no estoque (Carro = estado inicial)
+	EventoCarroAtualizado = "CarroAtualizado" // Carro alterado (Carro = estado completo após a alteração)
+	EventoCarroRemovido   = "CarroRemovido"   // Carro retirado do estoque sem venda
+	EventoCarroVendido    = "CarroVendido"    // Carro vendido (Venda = registro da venda)
+	EventoCarroRestaurado = "CarroRestaurado" // Carro vendido ou removido que voltou ao estoque por rollback ou restore (Carro = estado restaurado)
+	EventoCarroPurgado    = "CarroPurgado"    // ID apagado de vez por purge: sai do estoque, das lápides e das vendas e o ID fica livre
+)
+
+// Evento é uma entrada imutável do log de alterações do estoque
+type Evento struct {
+	Seq     int64  `json:"seq"`             // Posição no log, crescente e sem repetição
+	Tipo    string `json:"tipo"`            // Um dos tipos EventoCarro*
+	CarroID string `json:"carro_id"`        // Carro afetado
+	Em      string `json:"em"`              // Instante do evento (RFC 3339)
+	Carro   *Carro `json:"carro,omitempty"` // Estado do carro (adicionado/atualizado)
+	Venda   *Venda `json:"venda,omitempty"` // Venda registrada (vendido)
+
+	Rollback int64  `json:"rollback,omitempty"` // Evento de destino, quando gerado por `rollback --to`
+	Autor    string `json:"autor,omitempty"`    // Usuário do prompt, "api:<cliente>" ou "grpc:<cliente>"; vazio nos eventos antigos
+}
+
+// aplicarEventos projeta os eventos, em ordem, sobre carros, lápides e vendas. Os IDs nunca são
+// reaproveitados, então o slice é compactado uma única vez por lote em vez de a cada remoção.
+func (d *dadosCarros) aplicarEventos(eventos []Evento) {
+	saiu := false
+	for _, e := range eventos {
+		switch e.Tipo {
+		case EventoCarroAdicionado:
+			d.carrosMap[e.CarroID] = *e.Carro
+			d.carros = append(d.carros, *e.Carro)
+		case EventoCarroAtualizado:
+			d.carrosMap[e.CarroID] = *e.Carro
+			if i := slices.IndexFunc(d.carros, func(c Carro) bool { return c.ID == e.CarroID }); i >= 0 {
+				d.carros[i] = *e.Carro
+			}
+		case EventoCarroVendido:
+			d.vendidos = append(d.vendidos, *e.Venda)
+			fallthrough
+		case EventoCarroRemovido:
+			delete(d.carrosMap, e.CarroID)
+			d.removidos = append(d.removidos, Lapide{ID: e.CarroID, RemovidoEm: e.Em})
+			saiu = true
+		case EventoCarroRestaurado:
+			// Desfaz a venda ou a remoção; a entrada antiga do slice pode ainda não ter sido compactada
+			d.removidos = slices.DeleteFunc(d.removidos, func(l Lapide) bool { return l.ID == e.CarroID })
+			d.vendidos = slices.DeleteFunc(d.vendidos, func(v Venda) bool { return v.Carro.ID == e.CarroID })
+			d.carros = slices.DeleteFunc(d.carros, func(c Carro) bool { return c.ID == e.CarroID })
+			d.carrosMap[e.CarroID] = *e.Carro
+			d.carros = append(d.carros, *e.Carro)
+		case EventoCarroPurgado:
+			delete(d.carrosMap, e.CarroID)
+			d.removidos = slices.DeleteFunc(d.removidos, func(l Lapide) bool { return l.ID == e.CarroID })
+			d.vendidos = slices.DeleteFunc(d.vendidos, func(v Venda) bool { return v.Carro.ID == e.CarroID })
+			saiu = true
+		}
+	}
+	if saiu {
+		d.carros = slices.DeleteFunc(d.carros, func(c Carro) bool {
+			_, existe := d.carrosMap[c.ID]
+			return !existe
+		})
+	}
+}
+
+// emitir acrescenta os eventos ao log e os aplica à projeção; é o único caminho de alteração de
+// carros, lápides e vendas (chamador deve segurar o lock)
+func (c *CadastroCarros) emitir(eventos ...Evento) {
+	agora := time.Now().UTC().Format(time.RFC3339Nano)
+	seq := int64(0)
+	if n := len(c.eventos); n > 0 {
+		seq = c.eventos[n-1].Seq
+	}
+	for i := range eventos {
+		seq++
+		eventos[i].Seq = seq
+		if eventos[i].Em == "" {
+			eventos[i].Em = agora
+		}
+		if eventos[i].Autor == "" {
+			eventos[i].Autor = c.autor
+		}
+	}
+	c.eventos = append(c.eventos, eventos...)
+	c.aplicarEventos(eventos)
+	c.assinaturas.publicar(eventos)
+	for _, e := range eventos {
+		c.log.Debug("evento emitido", "seq", e.Seq, "tipo", e.Tipo, "carro_id", e.CarroID, "autor", e.Autor)
+	}
+}
+
+// projetar reconstrói carros, lápides e vendas a partir do log, considerando só os eventos até
+// o instante informado (zero = todos). As demais coleções não vêm do log e ficam vazias.
+func projetar(eventos []Evento, ate time.Time) *dadosCarros {
+	d := &dadosCarros{carrosMap: make(map[string]Carro), carros: make([]Carro, 0)}
+	if !ate.IsZero() {
+		var selecionados []Evento
+		for _, e := range eventos {
+			if em, err := time.Parse(time.RFC3339Nano, e.Em); err == nil && !em.After(ate) {
+				selecionados = append(selecionados, e)
+			}
+		}
+		eventos = selecionados
+	}
+	d.aplicarEventos(eventos)
+	return d
+}
+
+// mesmaProjecao compara carros, lápides e vendas de duas projeções. Os carros são comparados pelo
+// map, já que o bbolt os devolve na ordem das chaves e não na ordem do log.
+func mesmaProjecao(a, b *dadosCarros) bool {
+	return maps.EqualFunc(a.carrosMap, b.carrosMap, Carro.Igual) && slices.Equal(a.removidos, b.removidos) &&
+		slices.EqualFunc(a.vendidos, b.vendidos, func(x, y Venda) bool {
+			return x.Carro.Igual(y.Carro) && x.PrecoFinal == y.PrecoFinal && x.DataVenda == y.DataVenda
+		})
+}
+
+// eventosIniciais descreve os dados gravados antes do log existir: vendas, remoções e o estoque
+// atual viram eventos que, reaplicados, reproduzem o mesmo conteúdo. O histórico anterior de
+// alterações não é conhecido; cada carro entra com o estado atual na data de cadastro.
+func (d *dadosCarros) eventosIniciais() []Evento {
+	quando := func(data string) string {
+		if t, err := time.Parse("2006-01-02", data); err == nil {
+			return t.UTC().Format(time.RFC3339Nano)
+		}
+		return ""
+	}
+	var eventos []Evento
+	vendido := make(map[string]bool)
+	for i := range d.vendidos {
+		v := d.vendidos[i]
+		vendido[v.Carro.ID] = true
+		em := quando(v.DataVenda)
+		if l, ok := d.lapide(v.Carro.ID); ok {
+			em = l.RemovidoEm
+		}
+		eventos = append(eventos,
+			Evento{Tipo: EventoCarroAdicionado, CarroID: v.Carro.ID, Em: quando(v.Carro.DataCadastro), Carro: &v.Carro},
+			Evento{Tipo: EventoCarroVendido, CarroID: v.Carro.ID, Em: em, Venda: &v})
+	}
+	for _, l := range d.removidos {
+		if !vendido[l.ID] {
+			eventos = append(eventos, Evento{Tipo: EventoCarroRemovido, CarroID: l.ID, Em: l.RemovidoEm})
+		}
+	}
+	for i := range d.carros {
+		carro := d.carros[i]
+		eventos = append(eventos, Evento{Tipo: EventoCarroAdicionado, CarroID: carro.ID, Em: quando(carro.DataCadastro), Carro: &carro})
+	}
+	return eventos
+}
+
+// conciliarEventos roda depois do carregamento. Sem log (dados antigos), cria o log a partir do
+// que foi carregado; com log, reconstrói a projeção a partir dele e avisa se o arquivo de dados
+// divergia. Devolve se algo mudou e precisa ser gravado.
+func (c *CadastroCarros) conciliarEventos() bool {
+	if len(c.eventos) == 0 {
+		iniciais := c.eventosIniciais()
+		if len(iniciais) == 0 {
+			return false
+		}
+		c.removidos, c.vendidos = nil, nil
+		c.carros, c.carrosMap = make([]Carro, 0, len(c.carros)), make(map[string]Carro)
+		restaurar := c.comoAutor("migração")
+		c.emitir(iniciais...)
+		restaurar()
+		c.log.Info("log de eventos criado a partir dos dados existentes", "eventos", len(iniciais))
+		return true
+	}
+
+	projecao := projetar(c.eventos, time.Time{})
+	if mesmaProjecao(&c.dadosCarros, projecao) {
+		return false
+	}
+	c.carros, c.carrosMap, c.removidos, c.vendidos = projecao.carros, projecao.carrosMap, projecao.removidos, projecao.vendidos
+	c.log.Warn("dados gravados divergiam do log de eventos; projeção reconstruída a partir do log",
+		"eventos", len(c.eventos), "carros", len(c.carros))
+	return true
+}
+
+// ReconstruirProjecao descarta carros, lápides e vendas em memória e os refaz a partir do log.
+// Devolve se a projeção mudou (false quando ela já conferia com o log).
+func (c *CadastroCarros) ReconstruirProjecao() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	projecao := projetar(c.eventos, time.Time{})
+	if mesmaProjecao(&c.dadosCarros, projecao) {
+		return false, nil
+	}
+	c.carros, c.carrosMap, c.removidos, c.vendidos = projecao.carros, projecao.carrosMap, projecao.removidos, projecao.vendidos
+
+	// Persistir após reconstruir
+	return true, c.gravar()
+}
+
+// Eventos devolve o log de eventos, do mais antigo ao mais recente
+func (v *VisaoCarros) Eventos() []Evento {
+	return slices.Clone(v.eventos)
+}
+
+// estoqueEm devolve os carros em estoque ao fim do dia informado (AAAA-MM-DD), projetando o log
+func (d *dadosCarros) estoqueEm(data string) ([]Carro, error) {
+	dia, err := time.ParseInLocation("2006-01-02", data, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data inválida '%s' (use AAAA-MM-DD)", data)
+	}
+	if limite := d.limiteAuditoria(); dia.Before(limite) {
+		return nil, fmt.Errorf("o log anterior a %s foi compactado pela retenção; os eventos brutos estão nos arquivos listados em 'events summary'",
+			limite.Format("01/2006"))
+	}
+	return projetar(d.eventos, dia.AddDate(0, 0, 1).Add(-time.Nanosecond)).carros, nil
+}
@@ -0,0 +1,208 @@
+package cars
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"maps"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestProjecaoDoLogReproduzEstoque(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste, x5Teste)
+	if len(c.carros) != 3 {
+		t.Fatalf("esperado 3 carros, obtido %d", len(c.carros))
+	}
+
+	c.mu.Lock()
+	carro := c.carros[1]
+	carro.Cor = "Vermelho"
+	c.substituirCarro(carro)
+	c.retirarCarro(c.carros[0].ID)
+	venda := Venda{Carro: c.carros[1], PrecoFinal: Reais(390000), DataVenda: "2025-01-10"}
+	c.emitir(Evento{Tipo: EventoCarroVendido, CarroID: venda.Carro.ID, Venda: &venda})
+	c.mu.Unlock()
+
+	projecao := projetar(c.eventos, time.Time{})
+	if !mesmaProjecao(&c.dadosCarros, projecao) {
+		t.Fatalf("projeção do log difere do estoque: %+v x %+v", projecao.carros, c.carros)
+	}
+	if len(projecao.carros) != 1 || projecao.carros[0].Cor != "Vermelho" {
+		t.Fatalf("estoque projetado inesperado: %+v", projecao.carros)
+	}
+	if len(projecao.removidos) != 2 || len(projecao.vendidos) != 1 {
+		t.Fatalf("esperado 2 lápides e 1 venda, obtido %d e %d", len(projecao.removidos), len(projecao.vendidos))
+	}
+
+	// Antes de qualquer evento, o estoque projetado é vazio
+	if antes := projetar(c.eventos, time.Unix(0, 0)); len(antes.carros) != 0 {
+		t.Fatalf("esperado estoque vazio no passado, obtido %d carro(s)", len(antes.carros))
+	}
+}
+
+func TestCompactacaoMantemProjecao(t *testing.T) {
+	t.Parallel()
+	carro := func(id, cor string) *Carro {
+		return &Carro{ID: id, Marca: "Toyota", Modelo: "Corolla", Ano: 2021, Cor: cor, Preco: Reais(145000), PaisOrigem: "Japão"}
+	}
+	venda := Venda{Carro: *carro("b", "Azul"), PrecoFinal: Reais(140000), DataVenda: "2024-02-10"}
+	eventos := []Evento{
+		{Seq: 1, Tipo: EventoCarroAdicionado, CarroID: "a", Em: "2024-01-05T10:00:00Z", Carro: carro("a", "Prata")},
+		{Seq: 2, Tipo: EventoCarroAdicionado, CarroID: "b", Em: "2024-01-06T10:00:00Z", Carro: carro("b", "Prata")},
+		{Seq: 3, Tipo: EventoCarroAtualizado, CarroID: "a", Em: "2024-01-20T10:00:00Z", Carro: carro("a", "Preto")},
+		{Seq: 4, Tipo: EventoCarroAtualizado, CarroID: "b", Em: "2024-02-01T10:00:00Z", Carro: carro("b", "Azul")},
+		{Seq: 5, Tipo: EventoCarroVendido, CarroID: "b", Em: "2024-02-10T10:00:00Z", Venda: &venda},
+		{Seq: 6, Tipo: EventoCarroAdicionado, CarroID: "c", Em: "2024-02-15T10:00:00Z", Carro: carro("c", "Branco")},
+		{Seq: 7, Tipo: EventoCarroAtualizado, CarroID: "a", Em: "2024-04-02T10:00:00Z", Carro: carro("a", "Vermelho")},
+	}
+
+	compactados, antigos := compactarEventos(eventos, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if len(antigos) != 6 {
+		t.Fatalf("esperado 6 eventos antigos, obtido %d", len(antigos))
+	}
+	if len(compactados) != 5 {
+		t.Fatalf("esperado 5 eventos no log compactado (3 cadastros, 1 venda, 1 recente), obtido %d", len(compactados))
+	}
+	if !mesmaProjecao(projetar(eventos, time.Time{}), projetar(compactados, time.Time{})) {
+		t.Fatal("log compactado gera projeção diferente do original")
+	}
+
+	resumos := resumirEventos(antigos, time.Now())
+	if len(resumos) != 2 || resumos[0].Mes != "2024-01" || resumos[0].Atualizados != 1 || resumos[1].Vendidos != 1 || resumos[1].Carros != 2 {
+		t.Fatalf("resumos inesperados: %+v", resumos)
+	}
+}
+
+func TestCompactacaoArquivaCamposProtegidosCifrados(t *testing.T) {
+	t.Parallel()
+	publica, _, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cadastroTeste(t, corollaTeste)
+	c.mu.Lock()
+	c.configAtiva.Auditoria.Diretorio = t.TempDir()
+	c.configAtiva.Protecao = OpcoesProtecao{Campos: []string{"custo", "chassi"}, ChavePublica: base64.StdEncoding.EncodeToString(publica[:])}
+	for i := range c.eventos {
+		c.eventos[i].Em = "2020-03-10T10:00:00Z"
+		c.eventos[i].Carro.Custo, c.eventos[i].Carro.Chassi = Reais(98765), "9BWZZZ377VT004251"
+	}
+	c.mu.Unlock()
+
+	if _, err := c.CompactarAuditoria(1); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.resumosAuditoria) != 1 {
+		t.Fatalf("esperado 1 mês compactado, obtido %d", len(c.resumosAuditoria))
+	}
+	arquivado, err := os.ReadFile(c.resumosAuditoria[0].Arquivo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, claro := range []string{"9BWZZZ377VT004251", "98765"} {
+		if strings.Contains(string(arquivado), claro) {
+			t.Errorf("arquivo de auditoria tem %s em claro: %s", claro, arquivado)
+		}
+	}
+	if !strings.Contains(string(arquivado), `"protegido"`) {
+		t.Errorf("arquivo de auditoria deveria trazer os campos cifrados: %s", arquivado)
+	}
+}
+
+func TestRollbackVoltaAoEstadoDoEvento(t *testing.T) {
+	t.Parallel()
+	carro := func(id, cor string) *Carro {
+		return &Carro{ID: id, Marca: "Toyota", Modelo: "Corolla", Ano: 2021, Cor: cor, Preco: Reais(145000), PaisOrigem: "Japão"}
+	}
+	venda := Venda{Carro: *carro("b", "Prata"), PrecoFinal: Reais(140000), DataVenda: "2024-02-10"}
+	eventos := []Evento{
+		{Seq: 1, Tipo: EventoCarroAdicionado, CarroID: "a", Em: "2024-01-05T10:00:00Z", Carro: carro("a", "Prata")},
+		{Seq: 2, Tipo: EventoCarroAdicionado, CarroID: "b", Em: "2024-01-06T10:00:00Z", Carro: carro("b", "Prata")},
+		{Seq: 3, Tipo: EventoCarroAtualizado, CarroID: "a", Em: "2024-01-20T10:00:00Z", Carro: carro("a", "Preto")},
+		{Seq: 4, Tipo: EventoCarroVendido, CarroID: "b", Em: "2024-02-10T10:00:00Z", Venda: &venda},
+		{Seq: 5, Tipo: EventoCarroAdicionado, CarroID: "c", Em: "2024-02-15T10:00:00Z", Carro: carro("c", "Branco")},
+		{Seq: 6, Tipo: EventoCarroAtualizado, CarroID: "a", Em: "2024-04-02T10:00:00Z", Carro: carro("a", "Vermelho")},
+	}
+	visao := &VisaoCarros{dadosCarros: *projetar(eventos, time.Time{})}
+	visao.eventos = eventos
+
+	mudancas, err := visao.PlanejarReversao(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mudancas) != 3 {
+		t.Fatalf("esperado 3 carros revertidos, obtido %d: %+v", len(mudancas), mudancas)
+	}
+	var novos []Evento
+	for _, m := range mudancas {
+		novos = append(novos, m.Eventos...)
+	}
+	revertido := projetar(append(eventos, novos...), time.Time{})
+	if alvo := projetar(eventos[:3], time.Time{}); !maps.EqualFunc(revertido.carrosMap, alvo.carrosMap, Carro.Igual) {
+		t.Fatalf("estoque após o rollback difere do evento #3: %+v x %+v", revertido.carrosMap, alvo.carrosMap)
+	}
+	if len(revertido.vendidos) != 0 || len(revertido.removidos) != 1 || revertido.removidos[0].ID != "c" {
+		t.Fatalf("esperado a venda desfeita e só o carro c removido, obtido %+v e %+v", revertido.vendidos, revertido.removidos)
+	}
+
+	if _, err := visao.PlanejarReversao(6); err == nil {
+		t.Fatal("rollback até o último evento deveria falhar")
+	}
+}
+
+func TestReindexReparaEstoqueELog(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste, unoTeste)
+	if problemas := c.verificarIndices(); len(problemas) != 0 {
+		t.Fatalf("cadastro novo com problemas: %+v", problemas)
+	}
+
+	// Como se o arquivo tivesse sido editado à mão: carro repetido, evento fora de ordem e pagamento órfão
+	c.carros = append(c.carros, c.carros[0])
+	c.eventos[1].Seq = c.eventos[0].Seq
+	c.pagamentos = append(c.pagamentos, Pagamento{CarroID: "car_inexistente", Valor: Reais(1000)})
+	problemas := c.verificarIndices()
+	if len(problemas) < 3 {
+		t.Fatalf("esperado ao menos 3 problemas, obtido %+v", problemas)
+	}
+
+	c.repararIndices()
+	if restantes := c.verificarIndices(); len(restantes) != 0 {
+		t.Fatalf("problemas após o reparo: %+v", restantes)
+	}
+	if len(c.carros) != 2 || len(c.pagamentos) != 0 || c.eventos[1].Seq != 2 {
+		t.Fatalf("reparo inesperado: %d carro(s), %d pagamento(s), seq %d", len(c.carros), len(c.pagamentos), c.eventos[1].Seq)
+	}
+}
+
+func TestAssinaturaRecebeEventosNovosComAutor(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste)
+	canal, anteriores, cancelar := c.AssinarEventos()
+	defer cancelar()
+	if len(anteriores) != 1 {
+		t.Fatalf("esperado 1 evento anterior, obtido %d", len(anteriores))
+	}
+
+	c.mu.Lock()
+	restaurar := c.comoAutor("api:teste")
+	c.retirarCarro(c.carros[0].ID)
+	restaurar()
+	c.mu.Unlock()
+
+	e := <-canal
+	if e.Seq != 2 || e.Autor != "api:teste" {
+		t.Fatalf("evento recebido inesperado: %+v", e)
+	}
+	if !(FiltroAuditoria{Autor: "API", Acao: "removido"}).Aceita(e) || (FiltroAuditoria{Acao: "Vendido"}).Aceita(e) {
+		t.Fatalf("filtro não se comportou como esperado para %+v", e)
+	}
+	if anteriores[0].Autor != c.autor {
+		t.Fatalf("autor do prompt deveria ser %q, obtido %q", c.autor, anteriores[0].Autor)
+	}
+}
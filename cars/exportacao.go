@@ -0,0 +1,47 @@
+package cars
+
+import (
+	"time"
+)
+
+// ExportacaoIncremental é o documento emitido por `export --since`: apenas o que mudou após o instante informado
+type ExportacaoIncremental struct {
+	Desde     string   `json:"desde"`     // Instante de corte informado (RFC 3339)
+	GeradoEm  string   `json:"gerado_em"` // Instante da geração, a ser usado como próximo --since
+	Alterados []Carro  `json:"alterados"` // Carros criados ou atualizados após o corte
+	Removidos []Lapide `json:"removidos"` // Carros removidos após o corte
+}
+
+// ExportacaoDesde reúne os carros criados, atualizados ou removidos depois do instante informado
+func (c *CadastroCarros) ExportacaoDesde(desde time.Time) ExportacaoIncremental {
+	defer c.Medir("export")()
+	visao := c.Snapshot()
+	exportacao := ExportacaoIncremental{
+		Desde:     desde.UTC().Format(time.RFC3339Nano),
+		GeradoEm:  time.Now().UTC().Format(time.RFC3339Nano),
+		Alterados: []Carro{},
+		Removidos: []Lapide{},
+	}
+	for _, carro := range visao.carros {
+		if instanteAlteracao(carro).After(desde) {
+			exportacao.Alterados = append(exportacao.Alterados, carro)
+		}
+	}
+	for _, lapide := range visao.removidos {
+		removidoEm, err := time.Parse(time.RFC3339Nano, lapide.RemovidoEm)
+		if err == nil && removidoEm.After(desde) {
+			exportacao.Removidos = append(exportacao.Removidos, lapide)
+		}
+	}
+	return exportacao
+}
+
+// instanteAlteracao devolve quando o carro foi criado/alterado pela última vez.
+// Registros antigos sem atualizado_em usam a data de cadastro (meia-noite UTC).
+func instanteAlteracao(carro Carro) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, carro.AtualizadoEm); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02", carro.DataCadastro)
+	return t
+}
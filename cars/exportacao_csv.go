@@ -0,0 +1,115 @@
+package cars
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// colunasCSV é o cabeçalho da exportação de carros em CSV
+var colunasCSV = []string{"id", "marca", "modelo", "ano", "cor", "preco", "custo", "pais_origem", "data_cadastro", "origem", "atualizado_em"}
+
+// tamanhoLoteCSV é quantos carros cada worker codifica por vez; limita a memória dos buffers em voo
+const tamanhoLoteCSV = 10000
+
+// registroCSV converte um carro em uma linha do CSV (valores com duas casas e ponto decimal)
+func registroCSV(carro Carro) []string {
+	return []string{
+		carro.ID, carro.Marca, carro.Modelo, strconv.Itoa(carro.Ano), carro.Cor,
+		carro.Preco.String(), carro.Custo.String(),
+		carro.PaisOrigem, carro.DataCadastro, carro.Origem, carro.AtualizadoEm,
+	}
+}
+
+// codificarLoteCSV codifica um lote de carros em um buffer próprio
+func codificarLoteCSV(carros []Carro, delimitador rune) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = delimitador
+	for _, carro := range carros {
+		w.Write(registroCSV(carro))
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// loteCSV é o resultado da codificação de um lote
+type loteCSV struct {
+	dados []byte
+	err   error
+}
+
+// EscreverCarrosCSV grava o cabeçalho e os carros em w, separados pelo delimitador, dividindo-os em
+// lotes codificados por até `workers` goroutines. Os lotes são escritos na ordem original; no
+// máximo `workers` buffers ficam em memória ao mesmo tempo.
+func EscreverCarrosCSV(w io.Writer, carros []Carro, workers int, delimitador rune) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	cabecalho := csv.NewWriter(w)
+	cabecalho.Comma = delimitador
+	cabecalho.Write(colunasCSV)
+	cabecalho.Flush()
+	if err := cabecalho.Error(); err != nil {
+		return err
+	}
+
+	lotes := (len(carros) + tamanhoLoteCSV - 1) / tamanhoLoteCSV
+	resultados := make([]chan loteCSV, lotes)
+	for i := range resultados {
+		resultados[i] = make(chan loteCSV, 1)
+	}
+
+	// A vaga só é devolvida quando o lote é escrito, e não quando termina de ser codificado
+	vagas := make(chan struct{}, workers)
+	go func() {
+		for i := range lotes {
+			vagas <- struct{}{}
+			inicio, fim := i*tamanhoLoteCSV, min((i+1)*tamanhoLoteCSV, len(carros))
+			go func() {
+				dados, err := codificarLoteCSV(carros[inicio:fim], delimitador)
+				resultados[i] <- loteCSV{dados, err}
+			}()
+		}
+	}()
+
+	var primeiroErro error
+	for _, resultado := range resultados {
+		lote := <-resultado
+		// Depois de um erro os lotes restantes ainda são consumidos, para liberar todas as goroutines
+		if primeiroErro == nil {
+			primeiroErro = lote.err
+		}
+		if primeiroErro == nil {
+			_, primeiroErro = w.Write(lote.dados)
+		}
+		<-vagas
+	}
+	return primeiroErro
+}
+
+// EscreverCSV escreve todos os carros em estoque em CSV usando `workers` goroutines e devolve
+// quantos foram escritos. O cabeçalho usa os nomes de campo que o import reconhece, então o
+// arquivo pode ser reimportado. Com canal, a coluna preco traz o preço daquele canal de venda
+// em vez do preço base.
+func (c *CadastroCarros) EscreverCSV(w io.Writer, workers int, delimitador rune, canal string) (int, error) {
+	defer c.Medir("csv")()
+
+	// Codifica a partir de uma visão imutável, sem segurar o lock
+	carros := c.Snapshot().carros
+	if canal != "" {
+		c.mu.RLock()
+		carros = c.configAtiva.Canais.noCanal(carros, canal)
+		c.mu.RUnlock()
+	}
+
+	saida := bufio.NewWriterSize(w, 1<<20)
+	err := EscreverCarrosCSV(saida, carros, workers, delimitador)
+	if err == nil {
+		err = saida.Flush()
+	}
+	return len(carros), err
+}
@@ -0,0 +1,74 @@
+package cars
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// carrosSinteticos gera n carros para os benchmarks de exportação
+func carrosSinteticos(n int) []Carro {
+	carros := make([]Carro, n)
+	for i := range carros {
+		carros[i] = Carro{
+			ID: fmt.Sprintf("car_%d", 1700000000000000000+i), Marca: "Toyota", Modelo: "Corolla Altis Hybrid",
+			Ano: 2015 + i%10, Cor: "Prata", Preco: Reais(145000.5 + float64(i)), PaisOrigem: "Japão",
+			DataCadastro: "2024-06-01", AtualizadoEm: "2024-06-01T12:00:00.123456789Z",
+		}
+	}
+	return carros
+}
+
+func TestEscreverCSVParaleloMantemOrdem(t *testing.T) {
+	carros := carrosSinteticos(3*tamanhoLoteCSV + 17)
+	var sequencial, paralelo bytes.Buffer
+	if err := EscreverCarrosCSV(&sequencial, carros, 1, ','); err != nil {
+		t.Fatal(err)
+	}
+	if err := EscreverCarrosCSV(&paralelo, carros, 4, ','); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sequencial.Bytes(), paralelo.Bytes()) {
+		t.Fatal("exportação paralela difere da sequencial")
+	}
+}
+
+func TestCSVExportadoComDelimitadorReimporta(t *testing.T) {
+	carros := carrosSinteticos(3)
+	var saida bytes.Buffer
+	if err := EscreverCarrosCSV(&saida, carros, 1, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	arquivo := filepath.Join(t.TempDir(), "estoque.tsv")
+	if err := os.WriteFile(arquivo, saida.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	linhas, posicoes, err := lerPlanilhaCom(arquivo, "planilha", '\t')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linhas) != len(carros) {
+		t.Fatalf("%d linha(s) lidas, esperado %d", len(linhas), len(carros))
+	}
+	if i, ok := posicoes["modelo"]; !ok || linhas[0][i] != "Corolla Altis Hybrid" {
+		t.Fatalf("coluna modelo não reimportada: %v", posicoes)
+	}
+}
+
+func benchmarkEscreverCSV(b *testing.B, workers int) {
+	carros := carrosSinteticos(1000000)
+	b.ResetTimer()
+	for range b.N {
+		if err := EscreverCarrosCSV(io.Discard, carros, workers, ','); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEscreverCSVSequencial(b *testing.B) { benchmarkEscreverCSV(b, 1) }
+
+func BenchmarkEscreverCSVParalelo(b *testing.B) { benchmarkEscreverCSV(b, runtime.NumCPU()) }
@@ -0,0 +1,181 @@
+package cars
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// registroParquet é uma linha do histórico exportado em Parquet: o último estado conhecido de
+// cada carro que passou pelo estoque, com a venda ou a remoção quando houver. O ano de cadastro
+// não é coluna: vem do diretório da partição (ano_cadastro=AAAA), no formato Hive.
+type registroParquet struct {
+	ID           string   `parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Marca        string   `parquet:"name=marca, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Modelo       string   `parquet:"name=modelo, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Ano          int32    `parquet:"name=ano, type=INT32"`
+	Cor          string   `parquet:"name=cor, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	PaisOrigem   string   `parquet:"name=pais_origem, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Origem       string   `parquet:"name=origem, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Chassi       string   `parquet:"name=chassi, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DataCadastro *int32   `parquet:"name=data_cadastro, type=INT32, convertedtype=DATE, repetitiontype=OPTIONAL"`
+	Preco        int64    `parquet:"name=preco, type=INT64, convertedtype=DECIMAL, scale=2, precision=18"`
+	Custo        *int64   `parquet:"name=custo, type=INT64, convertedtype=DECIMAL, scale=2, precision=18, repetitiontype=OPTIONAL"`
+	Moeda        string   `parquet:"name=moeda, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	CambioCompra *float64 `parquet:"name=cambio_compra, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Status       string   `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Situacao     string   `parquet:"name=situacao, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	PrecoFinal   *int64   `parquet:"name=preco_final, type=INT64, convertedtype=DECIMAL, scale=2, precision=18, repetitiontype=OPTIONAL"`
+	DataVenda    *int32   `parquet:"name=data_venda, type=INT32, convertedtype=DATE, repetitiontype=OPTIONAL"`
+	DiasEstoque  *int32   `parquet:"name=dias_em_estoque, type=INT32, repetitiontype=OPTIONAL"`
+	SaiuEm       *int64   `parquet:"name=saiu_em, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+}
+
+// particaoSemAno é o nome de partição do Hive para valores ausentes
+const particaoSemAno = "__HIVE_DEFAULT_PARTITION__"
+
+// diaParquet converte AAAA-MM-DD em dias desde 1970-01-01 (tipo DATE do Parquet); nil se inválida
+func diaParquet(data string) *int32 {
+	t, err := time.Parse("2006-01-02", data)
+	if err != nil {
+		return nil
+	}
+	dias := int32(t.Unix() / 86400)
+	return &dias
+}
+
+// historicoParquet percorre o log de eventos e devolve, por ano de cadastro, o último estado de
+// cada carro: em estoque, vendido ou removido
+func (d *dadosCarros) historicoParquet() map[string][]registroParquet {
+	type estado struct {
+		carro  Carro
+		venda  *Venda
+		saiuEm string
+	}
+	porID := make(map[string]*estado)
+	var ordem []string
+	for _, e := range d.eventos {
+		s, existe := porID[e.CarroID]
+		if !existe {
+			s = &estado{}
+			porID[e.CarroID] = s
+			ordem = append(ordem, e.CarroID)
+		}
+		switch e.Tipo {
+		case EventoCarroAdicionado, EventoCarroAtualizado, EventoCarroRestaurado:
+			s.carro, s.venda, s.saiuEm = *e.Carro, nil, ""
+		case EventoCarroVendido:
+			s.carro, s.venda, s.saiuEm = e.Venda.Carro, e.Venda, e.Em
+		case EventoCarroRemovido:
+			s.saiuEm = e.Em
+		case EventoCarroPurgado:
+			delete(porID, e.CarroID)
+			ordem = slices.DeleteFunc(ordem, func(id string) bool { return id == e.CarroID })
+		}
+	}
+
+	particoes := make(map[string][]registroParquet)
+	for _, id := range ordem {
+		s := porID[id]
+		carro := s.carro
+		r := registroParquet{
+			ID: id, Marca: carro.Marca, Modelo: carro.Modelo, Ano: int32(carro.Ano), Cor: carro.Cor,
+			PaisOrigem: carro.PaisOrigem, Origem: carro.Origem, Chassi: carro.Chassi,
+			DataCadastro: diaParquet(carro.DataCadastro), Preco: int64(carro.Preco),
+			Moeda: carro.Moeda, Status: StatusCarro(carro), Situacao: "em_estoque",
+		}
+		if carro.Custo > 0 {
+			custo := int64(carro.Custo)
+			r.Custo = &custo
+		}
+		if carro.CambioCompra > 0 {
+			cambio := carro.CambioCompra
+			r.CambioCompra = &cambio
+		}
+		if s.saiuEm != "" {
+			r.Situacao = "removido"
+			if t, err := time.Parse(time.RFC3339Nano, s.saiuEm); err == nil {
+				ms := t.UnixMilli()
+				r.SaiuEm = &ms
+			}
+		}
+		if v := s.venda; v != nil {
+			precoFinal, dias := int64(v.PrecoFinal), int32(v.DiasEmEstoque)
+			r.Situacao, r.PrecoFinal, r.DataVenda, r.DiasEstoque = "vendido", &precoFinal, diaParquet(v.DataVenda), &dias
+		}
+
+		ano := particaoSemAno
+		if len(carro.DataCadastro) >= 4 && r.DataCadastro != nil {
+			ano = carro.DataCadastro[:4]
+		}
+		particoes[ano] = append(particoes[ano], r)
+	}
+	return particoes
+}
+
+// gravarParquet grava os registros em um arquivo Parquet (compressão Snappy)
+func gravarParquet(arquivo string, registros []registroParquet) error {
+	f, err := os.Create(arquivo)
+	if err != nil {
+		return fmt.Errorf("erro ao criar %s: %v", arquivo, err)
+	}
+	pw, err := writer.NewParquetWriterFromWriter(f, new(registroParquet), 1)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("erro ao preparar o esquema Parquet: %v", err)
+	}
+	for _, r := range registros {
+		if err = pw.Write(r); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = pw.WriteStop()
+	}
+	if errFechar := f.Close(); err == nil {
+		err = errFechar
+	}
+	return err
+}
+
+// ExportacaoParquet resume uma exportação Parquet: quantos carros foram gravados e em quais
+// partições (anos de cadastro, em ordem)
+type ExportacaoParquet struct {
+	Carros int
+	Anos   []string
+}
+
+// GravarParquet grava o histórico do estoque (carros em estoque, vendidos e removidos) em arquivos
+// Parquet particionados por ano de cadastro: <destino>/ano_cadastro=AAAA/carros.parquet, prontos
+// para DuckDB (read_parquet com hive_partitioning) ou Spark. Sem histórico, não grava nada.
+func (c *CadastroCarros) GravarParquet(destino string) (ExportacaoParquet, error) {
+	defer c.Medir("export parquet")()
+
+	if entradas, err := os.ReadDir(destino); err == nil && len(entradas) > 0 {
+		return ExportacaoParquet{}, fmt.Errorf("o diretório %s já existe e não está vazio; escolha outro destino para não misturar exportações", destino)
+	}
+
+	particoes := c.Snapshot().historicoParquet()
+	var exportacao ExportacaoParquet
+	for ano := range particoes {
+		exportacao.Anos = append(exportacao.Anos, ano)
+	}
+	sort.Strings(exportacao.Anos)
+
+	for _, ano := range exportacao.Anos {
+		dir := filepath.Join(destino, "ano_cadastro="+ano)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return exportacao, fmt.Errorf("erro ao criar diretório da partição: %v", err)
+		}
+		if err := gravarParquet(filepath.Join(dir, "carros.parquet"), particoes[ano]); err != nil {
+			return exportacao, fmt.Errorf("erro ao exportar Parquet: %v", err)
+		}
+		exportacao.Carros += len(particoes[ano])
+	}
+	return exportacao, nil
+}
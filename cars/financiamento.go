@@ -0,0 +1,95 @@
+package cars
+
+import (
+	"errors"
+	"math"
+)
+
+// SimulacaoFinanciamento descreve os parâmetros de uma simulação de financiamento
+type SimulacaoFinanciamento struct {
+	Entrada           Dinheiro // Valor de entrada em R$
+	Meses             int      // Número de parcelas
+	TaxaMensal        float64  // Juros ao mês, em fração (1.49% = 0.0149)
+	Sistema           string   // "price" (parcelas fixas) ou "sac" (amortização constante)
+	ArquivoCSV        string   // Se informado, o cronograma é exportado para este arquivo
+	EntradaPercentual float64  // Entrada em fração do preço, quando informada como percentual
+}
+
+// ParcelaFinanciamento é uma linha do cronograma de pagamento
+type ParcelaFinanciamento struct {
+	Numero      int
+	Prestacao   Dinheiro
+	Juros       Dinheiro
+	Amortizacao Dinheiro
+	Saldo       Dinheiro
+}
+
+// cronogramaPrice calcula parcelas fixas (Tabela Price), com prestação e juros arredondados ao centavo
+func cronogramaPrice(financiado Dinheiro, taxa float64, meses int) []ParcelaFinanciamento {
+	prestacao := financiado.Dividir(meses)
+	if taxa > 0 {
+		prestacao = financiado.Multiplicar(taxa / (1 - math.Pow(1+taxa, -float64(meses))))
+	}
+	saldo := financiado
+	parcelas := make([]ParcelaFinanciamento, 0, meses)
+	for n := 1; n <= meses; n++ {
+		juros := saldo.Multiplicar(taxa)
+		amortizacao := prestacao - juros
+		if n == meses {
+			// A última parcela quita o saldo e absorve o resíduo de arredondamento
+			amortizacao = saldo
+		}
+		saldo -= amortizacao
+		parcelas = append(parcelas, ParcelaFinanciamento{n, amortizacao + juros, juros, amortizacao, saldo})
+	}
+	return parcelas
+}
+
+// cronogramaSAC calcula parcelas decrescentes com amortização constante (SAC)
+func cronogramaSAC(financiado Dinheiro, taxa float64, meses int) []ParcelaFinanciamento {
+	saldo := financiado
+	parcelas := make([]ParcelaFinanciamento, 0, meses)
+	for n := 1; n <= meses; n++ {
+		juros := saldo.Multiplicar(taxa)
+		amortizacao := financiado.Dividir(meses)
+		if n == meses {
+			amortizacao = saldo
+		}
+		saldo -= amortizacao
+		parcelas = append(parcelas, ParcelaFinanciamento{n, amortizacao + juros, juros, amortizacao, saldo})
+	}
+	return parcelas
+}
+
+// Financiamento é o resultado de uma simulação: o carro, a entrada, o valor financiado e os
+// cronogramas nos dois sistemas
+type Financiamento struct {
+	Carro      Carro
+	Entrada    Dinheiro
+	Financiado Dinheiro
+	Price      []ParcelaFinanciamento
+	SAC        []ParcelaFinanciamento
+}
+
+// CalcularFinanciamento monta os cronogramas Price e SAC para financiar um carro do estoque
+func (c *CadastroCarros) CalcularFinanciamento(id string, sim SimulacaoFinanciamento) (Financiamento, error) {
+	carro, err := c.Carro(id)
+	if err != nil {
+		return Financiamento{}, err
+	}
+
+	if sim.EntradaPercentual > 0 {
+		sim.Entrada = carro.Preco.Multiplicar(sim.EntradaPercentual)
+	}
+	financiado := carro.Preco - sim.Entrada
+	if financiado <= 0 {
+		return Financiamento{}, errors.New("a entrada cobre o valor total do carro; não há o que financiar")
+	}
+	return Financiamento{
+		Carro:      carro,
+		Entrada:    sim.Entrada,
+		Financiado: financiado,
+		Price:      cronogramaPrice(financiado, sim.TaxaMensal, sim.Meses),
+		SAC:        cronogramaSAC(financiado, sim.TaxaMensal, sim.Meses),
+	}, nil
+}
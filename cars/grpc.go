@@ -0,0 +1,273 @@
+package cars
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/michellhornung/golang/cars/carrospb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// metodoGRPC é o comando do prompt equivalente a um método do serviço e se ele altera o estoque,
+// para que a seção permissoes e a réplica somente leitura valham como na API REST
+type metodoGRPC struct {
+	comando string
+	altera  bool
+}
+
+var metodosGRPC = map[string]metodoGRPC{
+	carrospb.Carros_Add_FullMethodName:    {"add", true},
+	carrospb.Carros_Get_FullMethodName:    {"list", false},
+	carrospb.Carros_List_FullMethodName:   {"list", false},
+	carrospb.Carros_Update_FullMethodName: {"update", true},
+	carrospb.Carros_Delete_FullMethodName: {"remove", true},
+}
+
+// codigoDaRecusa é o código gRPC de cada motivo de recusa das operações remotas
+var codigoDaRecusa = map[motivoRecusa]codes.Code{
+	recusaInvalida:       codes.InvalidArgument,
+	recusaNaoEncontrado:  codes.NotFound,
+	recusaConflito:       codes.Aborted,
+	recusaConfirmacao:    codes.FailedPrecondition,
+	recusaProibida:       codes.PermissionDenied,
+	recusaNaoAutenticada: codes.Unauthenticated,
+}
+
+// erroGRPC converte o erro de uma operação remota no status gRPC do motivo (Internal para falhas)
+func erroGRPC(err error) error {
+	codigo := codes.Internal
+	if motivo, ok := motivoDaRecusa(err); ok {
+		codigo = codigoDaRecusa[motivo]
+	}
+	return status.Error(codigo, err.Error())
+}
+
+// CarroParaProto converte o carro na mensagem do serviço gRPC (os campos cifrados ficam de fora)
+func CarroParaProto(carro Carro) *carrospb.Carro {
+	var precosCanal map[string]int64
+	if len(carro.PrecosCanal) > 0 {
+		precosCanal = make(map[string]int64, len(carro.PrecosCanal))
+		for canal, preco := range carro.PrecosCanal {
+			precosCanal[canal] = int64(preco)
+		}
+	}
+	return &carrospb.Carro{
+		Id:                  carro.ID,
+		Marca:               carro.Marca,
+		Modelo:              carro.Modelo,
+		Ano:                 int32(carro.Ano),
+		Cor:                 carro.Cor,
+		PrecoCentavos:       int64(carro.Preco),
+		PaisOrigem:          carro.PaisOrigem,
+		DataCadastro:        carro.DataCadastro,
+		CustoCentavos:       int64(carro.Custo),
+		Origem:              carro.Origem,
+		AtualizadoEm:        carro.AtualizadoEm,
+		Chassi:              carro.Chassi,
+		Status:              carro.Status,
+		StatusDesde:         carro.StatusDesde,
+		Moeda:               carro.Moeda,
+		CambioCompra:        carro.CambioCompra,
+		Opcionais:           carro.Opcionais,
+		PrecosCanalCentavos: precosCanal,
+	}
+}
+
+// CarroDoProto converte a mensagem do serviço gRPC no carro
+func CarroDoProto(p *carrospb.Carro) Carro {
+	var precosCanal map[string]Dinheiro
+	if len(p.GetPrecosCanalCentavos()) > 0 {
+		precosCanal = make(map[string]Dinheiro, len(p.GetPrecosCanalCentavos()))
+		for canal, preco := range p.GetPrecosCanalCentavos() {
+			precosCanal[canal] = Dinheiro(preco)
+		}
+	}
+	return Carro{
+		ID:           p.GetId(),
+		Marca:        p.GetMarca(),
+		Modelo:       p.GetModelo(),
+		Ano:          int(p.GetAno()),
+		Cor:          p.GetCor(),
+		Preco:        Dinheiro(p.GetPrecoCentavos()),
+		PaisOrigem:   p.GetPaisOrigem(),
+		DataCadastro: p.GetDataCadastro(),
+		Custo:        Dinheiro(p.GetCustoCentavos()),
+		Origem:       p.GetOrigem(),
+		AtualizadoEm: p.GetAtualizadoEm(),
+		Chassi:       p.GetChassi(),
+		Status:       p.GetStatus(),
+		StatusDesde:  p.GetStatusDesde(),
+		Moeda:        p.GetMoeda(),
+		CambioCompra: p.GetCambioCompra(),
+		Opcionais:    p.GetOpcionais(),
+		PrecosCanal:  precosCanal,
+	}
+}
+
+// enderecoDoCliente devolve o endereço de quem fez a chamada gRPC
+func enderecoDoCliente(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "desconhecido"
+}
+
+// clienteGRPC identifica o cliente da chamada; o modelo de alto valor vem em confirmar_modelo
+func clienteGRPC(ctx context.Context, confirmacao string) clienteRemoto {
+	endereco := enderecoDoCliente(ctx)
+	return clienteRemoto{canal: "API gRPC", autor: "grpc:" + endereco, endereco: endereco,
+		confirmacao: confirmacao, comoConfirmar: "em confirmar_modelo"}
+}
+
+// servicoGRPC implementa o serviço Carros sobre o cadastro, com as mesmas operações da API REST
+type servicoGRPC struct {
+	carrospb.UnimplementedCarrosServer
+	c *CadastroCarros
+}
+
+func (s *servicoGRPC) Add(ctx context.Context, req *carrospb.AddRequest) (*carrospb.Carro, error) {
+	carro, err := s.c.cadastrarRemoto(CarroDoProto(req.GetCarro()), clienteGRPC(ctx, ""))
+	if err != nil {
+		return nil, erroGRPC(err)
+	}
+	return CarroParaProto(carro), nil
+}
+
+func (s *servicoGRPC) Get(ctx context.Context, req *carrospb.GetRequest) (*carrospb.Carro, error) {
+	carro, existe := s.c.Snapshot().Carro(req.GetId())
+	if !existe {
+		return nil, status.Errorf(codes.NotFound, "carro com ID '%s' não encontrado", req.GetId())
+	}
+	return CarroParaProto(carro), nil
+}
+
+func (s *servicoGRPC) List(ctx context.Context, req *carrospb.ListRequest) (*carrospb.ListResponse, error) {
+	carros := s.c.Snapshot().Carros()
+	resposta := &carrospb.ListResponse{Carros: make([]*carrospb.Carro, 0, len(carros))}
+	for _, carro := range carros {
+		resposta.Carros = append(resposta.Carros, CarroParaProto(carro))
+	}
+	return resposta, nil
+}
+
+func (s *servicoGRPC) Update(ctx context.Context, req *carrospb.UpdateRequest) (*carrospb.Carro, error) {
+	id := req.GetCarro().GetId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "informe o ID do carro em carro.id")
+	}
+	carro, err := s.c.atualizarRemoto(id, CarroDoProto(req.GetCarro()), clienteGRPC(ctx, req.GetConfirmarModelo()))
+	if err != nil {
+		return nil, erroGRPC(err)
+	}
+	return CarroParaProto(carro), nil
+}
+
+func (s *servicoGRPC) Delete(ctx context.Context, req *carrospb.DeleteRequest) (*carrospb.DeleteResponse, error) {
+	if err := s.c.removerRemoto(req.GetId(), clienteGRPC(ctx, req.GetConfirmarModelo())); err != nil {
+		return nil, erroGRPC(err)
+	}
+	return &carrospb.DeleteResponse{}, nil
+}
+
+// interceptarGRPC aplica as permissões e a réplica somente leitura a cada chamada e a registra no
+// log, como registrarRequisicoes faz com a API REST
+func (c *CadastroCarros) interceptarGRPC(ctx context.Context, req any, info *grpc.UnaryServerInfo, executar grpc.UnaryHandler) (any, error) {
+	inicio := time.Now()
+	var resposta any
+	metodo := metodosGRPC[info.FullMethod]
+	var chave string
+	if valores := metadata.ValueFromIncomingContext(ctx, "x-api-key"); len(valores) > 0 {
+		chave = valores[0]
+	}
+	err := c.conferirAcessoRemoto(chave, metodo.comando, metodo.altera)
+	if err != nil {
+		err = erroGRPC(err)
+	} else {
+		resposta, err = executar(ctx, req)
+	}
+	codigo := status.Code(err)
+	nivel := slog.LevelInfo
+	if codigo == codes.Internal || codigo == codes.Unknown {
+		nivel = slog.LevelError
+	}
+	c.log.Log(ctx, nivel, "chamada gRPC", "metodo", info.FullMethod, "codigo", codigo.String(),
+		"duracao", time.Since(inicio), "cliente", enderecoDoCliente(ctx))
+	return resposta, err
+}
+
+// IniciarGRPC serve o serviço gRPC (carrospb.Carros) em segundo plano e devolve o endereço em que
+// ficou no ar; como a API REST, as chamadas passam pelo mesmo cadastro, log de eventos e persistência
+func (c *CadastroCarros) IniciarGRPC(endereco string) (string, error) {
+	if err := c.conferirGRPCParado(); err != nil {
+		return "", err
+	}
+	ouvinte, err := net.Listen("tcp", endereco)
+	if err != nil {
+		return "", fmt.Errorf("erro ao abrir %s: %v", endereco, err)
+	}
+	return c.IniciarGRPCEm(ouvinte)
+}
+
+// IniciarGRPCEm serve o gRPC em um ouvinte já aberto, ex: um socket recebido do systemd. O ouvinte
+// passa a ser do gRPC: é fechado por PararGRPC, ou já na volta se o gRPC estiver no ar.
+func (c *CadastroCarros) IniciarGRPCEm(ouvinte net.Listener) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.servidorGRPC != nil {
+		ouvinte.Close()
+		return "", fmt.Errorf("o gRPC já está no ar em %s. Use 'grpc stop' antes de iniciar outro", c.enderecoGRPC)
+	}
+
+	servidor := grpc.NewServer(grpc.UnaryInterceptor(c.interceptarGRPC))
+	carrospb.RegisterCarrosServer(servidor, &servicoGRPC{c: c})
+	c.servidorGRPC, c.enderecoGRPC = servidor, ouvinte.Addr().String()
+	go func(endereco string) {
+		if err := servidor.Serve(ouvinte); err != nil {
+			c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: o gRPC parou: %v", err), "o gRPC parou", "endereco", endereco, "erro", err)
+		}
+	}(c.enderecoGRPC)
+	c.log.Info("gRPC no ar", "endereco", c.enderecoGRPC)
+	return c.enderecoGRPC, nil
+}
+
+// conferirGRPCParado recusa iniciar um segundo gRPC antes de abrir a porta
+func (c *CadastroCarros) conferirGRPCParado() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.servidorGRPC != nil {
+		return fmt.Errorf("o gRPC já está no ar em %s. Use 'grpc stop' antes de iniciar outro", c.enderecoGRPC)
+	}
+	return nil
+}
+
+// PararGRPC encerra o gRPC, esperando até 5 segundos pelas chamadas em andamento; parou é false se
+// não havia gRPC no ar
+func (c *CadastroCarros) PararGRPC() (parou bool, err error) {
+	c.mu.Lock()
+	servidor, endereco := c.servidorGRPC, c.enderecoGRPC
+	c.servidorGRPC, c.enderecoGRPC = nil, ""
+	c.mu.Unlock()
+	if servidor == nil {
+		return false, nil
+	}
+	encerrado := make(chan struct{})
+	go func() {
+		servidor.GracefulStop()
+		close(encerrado)
+	}()
+	select {
+	case <-encerrado:
+	case <-time.After(5 * time.Second):
+		servidor.Stop()
+		return true, fmt.Errorf("o gRPC não encerrou de forma limpa: chamadas em andamento foram interrompidas")
+	}
+	c.log.Info("gRPC encerrado", "endereco", endereco)
+	return true, nil
+}
@@ -0,0 +1,118 @@
+package cars
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/michellhornung/golang/cars/carrospb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// clienteGRPCTeste põe o gRPC do cadastro no ar em uma porta livre e devolve um cliente conectado
+func clienteGRPCTeste(t *testing.T, c *CadastroCarros) carrospb.CarrosClient {
+	t.Helper()
+	endereco, err := c.IniciarGRPC("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.PararGRPC() })
+	conexao, err := grpc.NewClient(endereco, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conexao.Close() })
+	return carrospb.NewCarrosClient(conexao)
+}
+
+func TestGRPCCadastraAtualizaERemove(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t)
+	cliente := clienteGRPCTeste(t, c)
+	ctx := context.Background()
+
+	criado, err := cliente.Add(ctx, &carrospb.AddRequest{Carro: CarroParaProto(corollaTeste)})
+	if err != nil || criado.GetId() == "" || len(c.Snapshot().Carros()) != 1 {
+		t.Fatalf("cadastro: %v %+v", err, criado)
+	}
+	if _, err := cliente.Add(ctx, &carrospb.AddRequest{Carro: &carrospb.Carro{Marca: "Fiat"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("carro incompleto deveria dar InvalidArgument, obtido %v", err)
+	}
+	lista, err := cliente.List(ctx, &carrospb.ListRequest{})
+	if err != nil || len(lista.GetCarros()) != 1 || lista.GetCarros()[0].GetPrecoCentavos() != int64(Reais(145000)) {
+		t.Fatalf("listagem: %v %+v", err, lista)
+	}
+
+	editado := CarroDoProto(criado)
+	editado.Cor, editado.Preco = "Preto", Reais(140000.50)
+	if _, err := cliente.Update(ctx, &carrospb.UpdateRequest{Carro: CarroParaProto(editado)}); err != nil {
+		t.Fatalf("atualização: %v", err)
+	}
+	if carro, _ := c.Snapshot().Carro(criado.GetId()); carro.Cor != "Preto" || carro.Preco != Reais(140000.50) {
+		t.Fatalf("carro não atualizado: %+v", carro)
+	}
+	// A versão lida antes da primeira atualização está desatualizada
+	if _, err := cliente.Update(ctx, &carrospb.UpdateRequest{Carro: CarroParaProto(editado)}); status.Code(err) != codes.Aborted {
+		t.Fatalf("edição sobre versão antiga deveria dar Aborted, obtido %v", err)
+	}
+
+	if _, err := cliente.Delete(ctx, &carrospb.DeleteRequest{Id: criado.GetId()}); err != nil || len(c.Snapshot().Carros()) != 0 {
+		t.Fatalf("remoção: %v", err)
+	}
+	if _, err := cliente.Get(ctx, &carrospb.GetRequest{Id: criado.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("carro removido deveria dar NotFound, obtido %v", err)
+	}
+}
+
+func TestGRPCSegueAsRegrasDaAPI(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, x5Teste)
+	cfg := c.Config()
+	cfg.AltoValor.Limite = Reais(300000)
+	cfg.Permissoes.Negados = []string{"add"}
+	c.Configurar(cfg, "", false)
+	cliente := clienteGRPCTeste(t, c)
+	ctx := context.Background()
+	id := c.Snapshot().Carros()[0].ID
+
+	if _, err := cliente.Add(ctx, &carrospb.AddRequest{Carro: CarroParaProto(unoTeste)}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("add negado nas permissões deveria dar PermissionDenied, obtido %v", err)
+	}
+	if _, err := cliente.Delete(ctx, &carrospb.DeleteRequest{Id: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("remoção de alto valor sem confirmação deveria dar FailedPrecondition, obtido %v", err)
+	}
+	if _, err := cliente.Delete(ctx, &carrospb.DeleteRequest{Id: id, ConfirmarModelo: "X5"}); err != nil {
+		t.Fatalf("remoção confirmada: %v", err)
+	}
+	if eventos := c.Snapshot().eventos; !strings.HasPrefix(eventos[len(eventos)-1].Autor, "grpc:") {
+		t.Fatalf("evento deveria levar o cliente gRPC como autor: %+v", eventos[len(eventos)-1])
+	}
+}
+
+func TestGRPCPermissoesPorChave(t *testing.T) {
+	t.Parallel()
+	c := cadastroTeste(t, corollaTeste)
+	cfg := c.Config()
+	estagiario := chaveAPITeste(&cfg, "estagiario", "list")
+	gerente := chaveAPITeste(&cfg, "gerente")
+	c.Configurar(cfg, "", false)
+	cliente := clienteGRPCTeste(t, c)
+	id := c.Snapshot().Carros()[0].ID
+	comChave := func(chave string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", chave)
+	}
+
+	if _, err := cliente.Delete(comChave(estagiario), &carrospb.DeleteRequest{Id: id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("a chave do estagiário não remove: %v", err)
+	}
+	if _, err := cliente.Get(context.Background(), &carrospb.GetRequest{Id: id}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("sem chave deveria dar Unauthenticated: %v", err)
+	}
+	if _, err := cliente.Delete(comChave(gerente), &carrospb.DeleteRequest{Id: id}); err != nil {
+		t.Fatalf("a chave do gerente remove: %v", err)
+	}
+}
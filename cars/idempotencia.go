@@ -0,0 +1,61 @@
+package cars
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Respostas guardadas por Idempotency-Key: o prazo em que um reenvio devolve a mesma resposta e o
+// máximo de chaves lembradas (as mais antigas saem primeiro)
+const (
+	validadeIdempotencia = 24 * time.Hour
+	maximoIdempotencia   = 1000
+)
+
+// respostaIdempotente é a resposta de uma requisição aplicada, guardada para os reenvios
+type respostaIdempotente struct {
+	resumo [sha256.Size]byte // Hash do corpo da requisição, para recusar a chave reusada com outro corpo
+	status int
+	corpo  []byte
+	em     time.Time
+}
+
+// respostasIdempotentes guarda, por Idempotency-Key, as respostas das requisições aplicadas, para
+// que o cliente possa repetir uma requisição sem aplicá-la duas vezes
+type respostasIdempotentes struct {
+	mu        sync.Mutex
+	respostas map[string]respostaIdempotente
+}
+
+// buscar devolve a resposta guardada para a chave, se ainda válida (chamador deve segurar o lock)
+func (ri *respostasIdempotentes) buscar(chave string) (respostaIdempotente, bool) {
+	resposta, existe := ri.respostas[chave]
+	if !existe || time.Since(resposta.em) > validadeIdempotencia {
+		return respostaIdempotente{}, false
+	}
+	return resposta, true
+}
+
+// guardar registra a resposta da chave, descartando as vencidas e, acima do máximo, as mais
+// antigas (chamador deve segurar o lock)
+func (ri *respostasIdempotentes) guardar(chave string, resposta respostaIdempotente) {
+	if ri.respostas == nil {
+		ri.respostas = make(map[string]respostaIdempotente)
+	}
+	ri.respostas[chave] = resposta
+	for chave, guardada := range ri.respostas {
+		if time.Since(guardada.em) > validadeIdempotencia {
+			delete(ri.respostas, chave)
+		}
+	}
+	for len(ri.respostas) > maximoIdempotencia {
+		maisAntiga := ""
+		for chave, guardada := range ri.respostas {
+			if maisAntiga == "" || guardada.em.Before(ri.respostas[maisAntiga].em) {
+				maisAntiga = chave
+			}
+		}
+		delete(ri.respostas, maisAntiga)
+	}
+}
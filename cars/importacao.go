@@ -0,0 +1,395 @@
+package cars
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// CampoImportacao é um campo do carro que uma coluna da planilha pode alimentar
+type CampoImportacao struct {
+	Nome  string   // Nome do campo, usado no remapeamento (map <coluna>=<campo>)
+	Tipo  string   // Tipo esperado: texto, inteiro ou decimal
+	Nomes []string // Cabeçalhos reconhecidos automaticamente (sem diferenciar caixa e acentos)
+}
+
+// camposImportacao são os campos aceitos pelo import, na ordem da prévia
+var camposImportacao = []CampoImportacao{
+	{"marca", "texto", []string{"marca", "brand", "make", "fabricante", "montadora"}},
+	{"modelo", "texto", []string{"modelo", "model"}},
+	{"ano", "inteiro", []string{"ano", "year", "ano modelo", "ano fabricacao"}},
+	{"cor", "texto", []string{"cor", "color", "colour"}},
+	{"preco", "decimal", []string{"preco", "price", "valor", "preco venda", "preco de venda"}},
+	{"custo", "decimal", []string{"custo", "cost", "custo importacao", "custo de importacao"}},
+	{"pais_origem", "texto", []string{"pais origem", "pais de origem", "pais", "country"}},
+	{"origem", "texto", []string{"origem"}},
+	{"chassi", "texto", []string{"chassi", "chassis", "vin"}},
+	{"moeda", "texto", []string{"moeda", "currency"}},
+	{"opcionais", "texto", []string{"opcionais", "opcionais de fabrica", "equipamentos", "options"}},
+}
+
+// BuscarCampoImportacao devolve o campo pelo nome (nil se não existir)
+func BuscarCampoImportacao(nome string) *CampoImportacao {
+	for i := range camposImportacao {
+		if camposImportacao[i].Nome == nome {
+			return &camposImportacao[i]
+		}
+	}
+	return nil
+}
+
+// ColunaImportacao descreve uma coluna da planilha: o tipo detectado nos valores, um exemplo e o
+// campo do carro para onde vai ("" = ignorada)
+type ColunaImportacao struct {
+	Cabecalho string
+	Tipo      string
+	Exemplo   string
+	Campo     string
+	Inferido  bool // O campo veio do conteúdo, não do cabeçalho
+}
+
+// inferirTipoColuna classifica a coluna pelo tipo da maioria dos valores não vazios (inteiro,
+// decimal, data ou texto), para que um valor digitado errado não esconda o tipo da coluna: ele
+// aparece como problema na linha. Colunas com inteiros e decimais são decimais.
+func inferirTipoColuna(valores []string) string {
+	contagem := make(map[string]int)
+	total := 0
+	for _, v := range valores {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		total++
+		switch {
+		case ehInteiro(v):
+			contagem["inteiro"]++
+		case ehDecimal(v):
+			contagem["decimal"]++
+		case ehData(v):
+			contagem["data"]++
+		default:
+			contagem["texto"]++
+		}
+	}
+	if total == 0 {
+		return "vazia"
+	}
+	if numeros := contagem["inteiro"] + contagem["decimal"]; numeros*2 > total {
+		if contagem["decimal"] > 0 {
+			return "decimal"
+		}
+		return "inteiro"
+	}
+	if contagem["data"]*2 > total {
+		return "data"
+	}
+	return "texto"
+}
+
+func ehInteiro(v string) bool {
+	_, err := strconv.Atoi(v)
+	return err == nil
+}
+
+func ehDecimal(v string) bool {
+	_, err := InterpretarValorDigitado(v)
+	return err == nil
+}
+
+func ehData(v string) bool {
+	_, err := InterpretarData(v)
+	return err == nil
+}
+
+// inferirMapeamento liga cada coluna a um campo pelo cabeçalho e, para colunas sem cabeçalho
+// conhecido, pelo conteúdo: inteiros entre 1900 e o próximo ano viram ano, e valores a partir
+// de mil viram preço, se esses campos ainda estiverem livres
+func inferirMapeamento(cabecalho []string, linhas [][]string) []ColunaImportacao {
+	colador := collate.New(language.BrazilianPortuguese, collate.IgnoreCase, collate.IgnoreDiacritics)
+	normalizar := func(s string) string {
+		return strings.Join(strings.Fields(strings.NewReplacer("_", " ", "-", " ", ".", " ").Replace(s)), " ")
+	}
+	usados := make(map[string]bool)
+	colunas := make([]ColunaImportacao, len(cabecalho))
+	for i, nome := range cabecalho {
+		valores := make([]string, 0, len(linhas))
+		for _, linha := range linhas {
+			if i < len(linha) {
+				valores = append(valores, linha[i])
+				if colunas[i].Exemplo == "" {
+					colunas[i].Exemplo = strings.TrimSpace(linha[i])
+				}
+			}
+		}
+		colunas[i].Cabecalho, colunas[i].Tipo = strings.TrimSpace(nome), inferirTipoColuna(valores)
+	procura:
+		for _, campo := range camposImportacao {
+			for _, reconhecido := range campo.Nomes {
+				if !usados[campo.Nome] && colador.CompareString(normalizar(nome), reconhecido) == 0 {
+					colunas[i].Campo, usados[campo.Nome] = campo.Nome, true
+					break procura
+				}
+			}
+		}
+	}
+
+	for i := range colunas {
+		col := &colunas[i]
+		if col.Campo != "" {
+			continue
+		}
+		var minimo, maximo float64
+		for j, linha := range linhas {
+			if i >= len(linha) {
+				continue
+			}
+			v, err := InterpretarValorDigitado(linha[i])
+			if err != nil {
+				continue
+			}
+			if j == 0 || v.EmReais() < minimo {
+				minimo = v.EmReais()
+			}
+			maximo = max(maximo, v.EmReais())
+		}
+		switch {
+		case col.Tipo == "inteiro" && !usados["ano"] && minimo >= 1900 && maximo <= float64(time.Now().Year()+1):
+			col.Campo = "ano"
+		case (col.Tipo == "inteiro" || col.Tipo == "decimal") && !usados["preco"] && minimo >= 1000:
+			col.Campo = "preco"
+		default:
+			continue
+		}
+		col.Inferido, usados[col.Campo] = true, true
+	}
+	return colunas
+}
+
+// LinhaImportada é uma linha da planilha convertida em carro, com os problemas encontrados
+type LinhaImportada struct {
+	Numero    int // Linha na planilha (o cabeçalho é a linha 1)
+	Carro     Carro
+	Problemas []string
+	Avisos    []string // Não impedem a importação (ex: país diferente do padrão da marca)
+}
+
+// converterLinhas converte as linhas da planilha em carros segundo o mapeamento e confere cada um
+// com as mesmas regras do cadastro (inclusive os validadores registrados), com a conformidade e
+// com os chassis já em estoque. O país vazio é preenchido pelo padrão da marca.
+func (d *dadosCarros) converterLinhas(colunas []ColunaImportacao, linhas [][]string, cfg Config, validadores []ValidadorCarro) []LinhaImportada {
+	chassis := make(map[string]int)
+	for _, carro := range d.carros {
+		if carro.Chassi != "" {
+			chassis[carro.Chassi] = 0
+		}
+	}
+	resultado := make([]LinhaImportada, 0, len(linhas))
+	for n, linha := range linhas {
+		item := LinhaImportada{Numero: n + 2}
+		vazia := true
+		for i, col := range colunas {
+			if i >= len(linha) || col.Campo == "" {
+				continue
+			}
+			valor := strings.TrimSpace(linha[i])
+			vazia = vazia && valor == ""
+			carro := &item.Carro
+			switch col.Campo {
+			case "marca":
+				carro.Marca = valor
+			case "modelo":
+				carro.Modelo = valor
+			case "cor":
+				carro.Cor = valor
+			case "pais_origem":
+				carro.PaisOrigem = valor
+			case "origem":
+				carro.Origem = strings.ToLower(valor)
+			case "chassi":
+				carro.Chassi = NormalizarChassi(valor)
+			case "moeda":
+				carro.Moeda = strings.ToUpper(valor)
+			case "opcionais":
+				opcionais, err := InterpretarOpcionais(valor)
+				if err != nil {
+					item.Problemas = append(item.Problemas, err.Error())
+				}
+				carro.Opcionais = opcionais
+			case "ano":
+				ano, err := strconv.Atoi(valor)
+				if err != nil {
+					item.Problemas = append(item.Problemas, fmt.Sprintf("ano '%s' não é um número inteiro", valor))
+				}
+				carro.Ano = ano
+			case "preco", "custo":
+				if valor == "" {
+					continue
+				}
+				v, err := InterpretarValorDigitado(valor)
+				if err != nil {
+					item.Problemas = append(item.Problemas, fmt.Sprintf("%s '%s' não é um valor válido", col.Campo, valor))
+				}
+				if col.Campo == "preco" {
+					carro.Preco = v
+				} else {
+					carro.Custo = v
+				}
+			}
+		}
+		if vazia {
+			continue
+		}
+		if aviso := cfg.Marcas.AvisoAno(item.Carro.Marca, item.Carro.Modelo, item.Carro.Ano); aviso != "" {
+			item.Avisos = append(item.Avisos, aviso)
+		}
+		if item.Carro.PaisOrigem == "" {
+			item.Carro.PaisOrigem = cfg.Marcas.PaisDaMarca(item.Carro.Marca)
+		} else if aviso := cfg.Marcas.AvisoPais(item.Carro.Marca, item.Carro.PaisOrigem); aviso != "" {
+			item.Avisos = append(item.Avisos, aviso)
+		}
+		if len(item.Problemas) == 0 {
+			if err := ValidarCarro(item.Carro); err != nil {
+				item.Problemas = append(item.Problemas, err.Error())
+			} else if err := conferirValidadores(validadores, item.Carro); err != nil {
+				item.Problemas = append(item.Problemas, err.Error())
+			}
+		}
+		violacoes, _ := cfg.Conformidade.Avaliar(item.Carro, time.Now().Year())
+		item.Problemas = append(item.Problemas, violacoes...)
+		if chassi := item.Carro.Chassi; chassi != "" {
+			if linhaAnterior, repetido := chassis[chassi]; repetido {
+				if linhaAnterior == 0 {
+					item.Problemas = append(item.Problemas, fmt.Sprintf("chassi %s já está no estoque", chassi))
+				} else {
+					item.Problemas = append(item.Problemas, fmt.Sprintf("chassi %s repetido na linha %d", chassi, linhaAnterior))
+				}
+			} else {
+				chassis[chassi] = item.Numero
+			}
+		}
+		resultado = append(resultado, item)
+	}
+	return resultado
+}
+
+// remapearColuna aplica `<coluna>=<campo>`; a coluna pode ser o cabeçalho ou a posição (1, 2...)
+// e o campo "-" ignora a coluna. Um campo só alimenta uma coluna: quem o tinha perde.
+func remapearColuna(colunas []ColunaImportacao, pedido string) error {
+	coluna, campo, ok := strings.Cut(pedido, "=")
+	coluna, campo = strings.TrimSpace(coluna), strings.ToLower(strings.TrimSpace(campo))
+	if !ok || coluna == "" || campo == "" {
+		return fmt.Errorf("use map <coluna>=<campo>, ex: map valor=preco")
+	}
+	alvo := -1
+	if n, err := strconv.Atoi(coluna); err == nil && n >= 1 && n <= len(colunas) {
+		alvo = n - 1
+	}
+	for i, col := range colunas {
+		if strings.EqualFold(col.Cabecalho, coluna) {
+			alvo = i
+		}
+	}
+	if alvo < 0 {
+		return fmt.Errorf("coluna '%s' não existe na planilha", coluna)
+	}
+	if campo == "-" {
+		colunas[alvo].Campo, colunas[alvo].Inferido = "", false
+		return nil
+	}
+	if BuscarCampoImportacao(campo) == nil {
+		nomes := make([]string, len(camposImportacao))
+		for i, c := range camposImportacao {
+			nomes[i] = c.Nome
+		}
+		return fmt.Errorf("campo '%s' desconhecido (use %s ou - para ignorar)", campo, strings.Join(nomes, ", "))
+	}
+	for i := range colunas {
+		if colunas[i].Campo == campo {
+			colunas[i].Campo, colunas[i].Inferido = "", false
+		}
+	}
+	colunas[alvo].Campo, colunas[alvo].Inferido = campo, false
+	return nil
+}
+
+// OpcoesImportacao são os parâmetros do import de carros
+type OpcoesImportacao struct {
+	Arquivo     string
+	Delimitador rune     // 0 = vírgula ou ponto e vírgula, detectado pelo cabeçalho
+	Mapeamento  []string // Remapeamentos `<coluna>=<campo>` aplicados antes da prévia (--map)
+	Simular     bool     // Só relata os problemas de cada linha, sem importar (--dry-run)
+	Confirmar   bool     // Importa sem perguntar (--yes)
+}
+
+// PlanilhaImportacao é uma planilha de carros lida e com as colunas já mapeadas para os campos
+type PlanilhaImportacao struct {
+	Arquivo string
+	Colunas []ColunaImportacao
+	linhas  [][]string
+}
+
+// LerPlanilhaImportacao lê a planilha de carros, infere para onde vai cada coluna e aplica os
+// remapeamentos pedidos com --map
+func LerPlanilhaImportacao(opcoes OpcoesImportacao) (*PlanilhaImportacao, error) {
+	linhas, posicoes, err := lerPlanilhaCom(opcoes.Arquivo, "planilha de carros", opcoes.Delimitador)
+	if err != nil {
+		return nil, err
+	}
+	cabecalho := make([]string, 0, len(posicoes))
+	for nome, i := range posicoes {
+		for len(cabecalho) <= i {
+			cabecalho = append(cabecalho, "")
+		}
+		cabecalho[i] = nome
+	}
+	p := &PlanilhaImportacao{Arquivo: opcoes.Arquivo, Colunas: inferirMapeamento(cabecalho, linhas), linhas: linhas}
+	for _, pedido := range opcoes.Mapeamento {
+		if err := p.Remapear(pedido); err != nil {
+			return nil, fmt.Errorf("erro em --map=%s: %v", pedido, err)
+		}
+	}
+	return p, nil
+}
+
+// Remapear aplica `<coluna>=<campo>` ao mapeamento da planilha
+func (p *PlanilhaImportacao) Remapear(pedido string) error {
+	return remapearColuna(p.Colunas, pedido)
+}
+
+// ConferirImportacao converte as linhas da planilha em carros e confere cada um contra o estoque
+// atual, sem gravar nada
+func (c *CadastroCarros) ConferirImportacao(p *PlanilhaImportacao) []LinhaImportada {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.converterLinhas(p.Colunas, p.linhas, c.configAtiva, c.validadores)
+}
+
+// Importar cadastra de uma vez as linhas sem problema e devolve quantos carros entraram e os
+// ajustes de preço feitos pelas regras
+func (c *CadastroCarros) Importar(itens []LinhaImportada) (int, []AjustePreco, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	importados := 0
+	var ajustes []AjustePreco
+	for _, item := range itens {
+		if len(item.Problemas) > 0 {
+			continue
+		}
+		carro := item.Carro
+		carro.ID = c.novoID()
+		carro.DataCadastro = time.Now().Format("2006-01-02")
+		carro.AtualizadoEm = time.Now().UTC().Format(time.RFC3339Nano)
+		c.registrarStatus(&carro, StatusCarro(carro), time.Now())
+		ajustes = append(ajustes, c.aplicarRegrasPreco(&carro)...)
+		c.emitir(Evento{Tipo: EventoCarroAdicionado, CarroID: carro.ID, Carro: &carro})
+		importados++
+	}
+	if importados == 0 {
+		return 0, nil, nil
+	}
+	return importados, ajustes, c.gravar()
+}
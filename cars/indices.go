@@ -0,0 +1,178 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// VerificacoesIndices são as verificações do reindex, na ordem em que são mostradas
+var VerificacoesIndices = []string{
+	"IDs únicos no estoque",
+	"Map e slice do estoque",
+	"Estoque sem lápides",
+	"Vendas com lápide",
+	"Sequência do log",
+	"Estoque confere com o log",
+	"Referências a carros",
+}
+
+// ProblemaIndice é uma invariante violada encontrada pelo reindex
+type ProblemaIndice struct {
+	Verificacao string // Uma de VerificacoesIndices
+	Detalhe     string
+}
+
+// verificarIndices confere as invariantes entre o slice, o map, as lápides, as vendas, o log de
+// eventos e as coleções que apontam para carros (pagamentos, lotes, documentos...)
+func (d *dadosCarros) verificarIndices() []ProblemaIndice {
+	var problemas []ProblemaIndice
+	problema := func(verificacao, formato string, args ...interface{}) {
+		problemas = append(problemas, ProblemaIndice{verificacao, fmt.Sprintf(formato, args...)})
+	}
+
+	vistos := make(map[string]bool)
+	for i, carro := range d.carros {
+		if vistos[carro.ID] {
+			problema(VerificacoesIndices[0], "ID '%s' repetido (posição %d)", carro.ID, i+1)
+		}
+		vistos[carro.ID] = true
+		if noMap, existe := d.carrosMap[carro.ID]; !existe {
+			problema(VerificacoesIndices[1], "ID '%s' está no slice e não no map", carro.ID)
+		} else if !noMap.Igual(carro) {
+			problema(VerificacoesIndices[1], "ID '%s' difere entre slice e map", carro.ID)
+		}
+	}
+	for id, carro := range d.carrosMap {
+		if !vistos[id] {
+			problema(VerificacoesIndices[1], "ID '%s' está no map e não no slice", id)
+		}
+		if carro.ID != id {
+			problema(VerificacoesIndices[1], "chave '%s' do map aponta para o carro '%s'", id, carro.ID)
+		}
+	}
+	if len(d.carros) != len(d.carrosMap) {
+		problema(VerificacoesIndices[1], "slice com %d carro(s) e map com %d", len(d.carros), len(d.carrosMap))
+	}
+
+	for _, l := range d.removidos {
+		if _, existe := d.carrosMap[l.ID]; existe {
+			problema(VerificacoesIndices[2], "ID '%s' está em estoque e tem lápide de %s", l.ID, l.RemovidoEm)
+		}
+	}
+	for _, v := range d.vendidos {
+		if _, removido := d.lapide(v.Carro.ID); !removido {
+			problema(VerificacoesIndices[3], "venda de '%s' em %s sem lápide", v.Carro.ID, v.DataVenda)
+		}
+	}
+
+	for i := 1; i < len(d.eventos); i++ {
+		if d.eventos[i].Seq <= d.eventos[i-1].Seq {
+			problema(VerificacoesIndices[4], "evento #%d depois do #%d", d.eventos[i].Seq, d.eventos[i-1].Seq)
+		}
+	}
+	if len(d.eventos) > 0 && !mesmaProjecao(d, projetar(d.eventos, time.Time{})) {
+		problema(VerificacoesIndices[5], "carros, lápides ou vendas diferem da projeção de %d evento(s)", len(d.eventos))
+	}
+
+	for _, id := range d.referenciasOrfas() {
+		problema(VerificacoesIndices[6], "'%s' citado em pagamentos, lotes, documentos, situações ou exceções sem existir", id)
+	}
+	return problemas
+}
+
+// referenciasOrfas lista, sem repetição, os IDs citados pelas coleções auxiliares que não pertencem
+// a nenhum carro conhecido
+func (d *dadosCarros) referenciasOrfas() []string {
+	var orfas []string
+	verificar := func(id string) {
+		if !d.idEmUso(id) && !slices.Contains(orfas, id) {
+			orfas = append(orfas, id)
+		}
+	}
+	for _, p := range d.pagamentos {
+		verificar(p.CarroID)
+	}
+	for _, lote := range d.lotes {
+		for _, id := range lote.CarroIDs {
+			verificar(id)
+		}
+	}
+	for _, doc := range d.documentos {
+		verificar(doc.CarroID)
+	}
+	for _, m := range d.historicoStatus {
+		verificar(m.CarroID)
+	}
+	for _, e := range d.excecoes {
+		verificar(e.CarroID)
+	}
+	return orfas
+}
+
+// repararIndices refaz o estoque a partir do log (renumerando-o antes se a sequência estiver
+// quebrada) e descarta as referências órfãs. Devolve o que foi feito.
+func (d *dadosCarros) repararIndices() []string {
+	var feito []string
+	for i := 1; i < len(d.eventos); i++ {
+		if d.eventos[i].Seq <= d.eventos[i-1].Seq {
+			// Mantém a ordem do arquivo, que é a ordem em que os eventos foram aplicados
+			eventos := slices.Clone(d.eventos)
+			for j := range eventos {
+				eventos[j].Seq = int64(j + 1)
+			}
+			d.eventos = eventos
+			feito = append(feito, fmt.Sprintf("log renumerado de 1 a %d na ordem gravada", len(eventos)))
+			break
+		}
+	}
+
+	estoqueIntegro := !slices.ContainsFunc(d.verificarIndices(), func(p ProblemaIndice) bool {
+		return p.Verificacao != VerificacoesIndices[6]
+	})
+	if len(d.eventos) > 0 && !estoqueIntegro {
+		projecao := projetar(d.eventos, time.Time{})
+		d.carros, d.carrosMap, d.removidos, d.vendidos = projecao.carros, projecao.carrosMap, projecao.removidos, projecao.vendidos
+		feito = append(feito, fmt.Sprintf("estoque, lápides e vendas refeitos a partir do log (%d carro(s) em estoque)", len(d.carros)))
+	}
+
+	if orfas := d.referenciasOrfas(); len(orfas) > 0 {
+		orfa := func(id string) bool { return slices.Contains(orfas, id) }
+		d.pagamentos = slices.DeleteFunc(d.pagamentos, func(p Pagamento) bool { return orfa(p.CarroID) })
+		d.documentos = slices.DeleteFunc(d.documentos, func(doc Documento) bool { return orfa(doc.CarroID) })
+		d.historicoStatus = slices.DeleteFunc(d.historicoStatus, func(m MudancaStatus) bool { return orfa(m.CarroID) })
+		d.excecoes = slices.DeleteFunc(d.excecoes, func(e ExcecaoConformidade) bool { return orfa(e.CarroID) })
+		for i := range d.lotes {
+			d.lotes[i].CarroIDs = slices.DeleteFunc(d.lotes[i].CarroIDs, orfa)
+		}
+		feito = append(feito, fmt.Sprintf("%d ID(s) órfão(s) retirado(s) de pagamentos, lotes, documentos, situações e exceções", len(orfas)))
+	}
+	return feito
+}
+
+// VerificacaoIndices é o resultado do reindex: o que foi encontrado e, se houve reparo, o que foi
+// feito e o que continuou errado depois dele
+type VerificacaoIndices struct {
+	Carros    int
+	Eventos   int
+	Problemas []ProblemaIndice
+	Reparos   []string
+	Restantes []ProblemaIndice
+}
+
+// VerificarIndices confere as invariantes do banco em memória e, com reparar, corrige o que for
+// possível e grava o resultado
+func (c *CadastroCarros) VerificarIndices(reparar bool) (VerificacaoIndices, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v := VerificacaoIndices{Carros: len(c.carros), Eventos: len(c.eventos), Problemas: c.verificarIndices()}
+	if len(v.Problemas) == 0 || !reparar {
+		return v, nil
+	}
+	v.Reparos = c.repararIndices()
+	v.Restantes = c.verificarIndices()
+
+	// Persistir após reparar
+	return v, c.gravar()
+}
@@ -0,0 +1,139 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// tipoJSONAPI é o Content-Type dos documentos JSON:API (https://jsonapi.org)
+const tipoJSONAPI = "application/vnd.api+json"
+
+// pedeJSONAPI informa se o cliente pediu a resposta em JSON:API, com ?format=jsonapi ou com
+// Accept: application/vnd.api+json
+func pedeJSONAPI(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "jsonapi" || strings.Contains(r.Header.Get("Accept"), tipoJSONAPI)
+}
+
+// identificadorJSONAPI aponta um recurso relacionado
+type identificadorJSONAPI struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// relacaoJSONAPI liga o carro a outros recursos: pelos identificadores (data), por um link, ou pelos dois
+type relacaoJSONAPI struct {
+	Data  interface{}       `json:"data,omitempty"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// recursoJSONAPI é um carro como recurso "carros"
+type recursoJSONAPI struct {
+	Type          string                     `json:"type"`
+	ID            string                     `json:"id"`
+	Attributes    map[string]json.RawMessage `json:"attributes"`
+	Relationships map[string]relacaoJSONAPI  `json:"relationships"`
+	Links         map[string]string          `json:"links"`
+}
+
+// documentoJSONAPI é o documento de topo; Data é um recurso ou uma lista deles
+type documentoJSONAPI struct {
+	Data  interface{}            `json:"data"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+	Links map[string]string      `json:"links,omitempty"`
+}
+
+// erroJSONAPI é um item de {"errors": [...]}
+type erroJSONAPI struct {
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// responderJSONAPI grava o documento com o Content-Type do JSON:API
+func responderJSONAPI(w http.ResponseWriter, status int, valor interface{}) {
+	w.Header().Set("Content-Type", tipoJSONAPI)
+	w.WriteHeader(status)
+	codificador := json.NewEncoder(w)
+	codificador.SetIndent("", "  ")
+	codificador.Encode(valor)
+}
+
+// responderErroNoFormato grava o erro como {"errors": [...]} se o cliente pediu JSON:API, ou como
+// {"erro": "..."} nos demais casos
+func responderErroNoFormato(w http.ResponseWriter, r *http.Request, status int, formato string, args ...interface{}) {
+	if !pedeJSONAPI(r) {
+		responderErro(w, status, formato, args...)
+		return
+	}
+	detalhe := fmt.Sprintf(formato, args...)
+	responderJSONAPI(w, status, map[string][]erroJSONAPI{"errors": {{Status: strconv.Itoa(status), Detail: detalhe}}})
+}
+
+// recursoCarro monta o recurso do carro com os atributos informados (já projetados, se for o caso).
+// As relações são as reservas (sinais pagos por ele), as vendas em que ele entrou como troca e as
+// fotos no catálogo público, se catalogo.url estiver configurado.
+func (v *VisaoCarros) recursoCarro(carro Carro, atributos map[string]json.RawMessage, catalogo OpcoesCatalogo) recursoJSONAPI {
+	delete(atributos, "id")
+	reservas := []identificadorJSONAPI{}
+	n := 0
+	for _, p := range v.pagamentos {
+		if p.CarroID != carro.ID {
+			continue
+		}
+		n++
+		if p.Tipo == "sinal" {
+			reservas = append(reservas, identificadorJSONAPI{Type: "pagamentos", ID: fmt.Sprintf("%s-%d", carro.ID, n)})
+		}
+	}
+	vendas := []identificadorJSONAPI{}
+	for _, venda := range v.vendidos {
+		if venda.Troca != nil && venda.Troca.CarroID == carro.ID {
+			vendas = append(vendas, identificadorJSONAPI{Type: "vendas", ID: venda.Carro.ID})
+		}
+	}
+	relacoes := map[string]relacaoJSONAPI{
+		"reservas": {Data: reservas},
+		"vendas":   {Data: vendas},
+	}
+	if catalogo.URL != "" {
+		relacoes["fotos"] = relacaoJSONAPI{Links: map[string]string{"related": catalogo.EnderecoCarro(carro.ID)}}
+	}
+	return recursoJSONAPI{Type: "carros", ID: carro.ID, Attributes: atributos, Relationships: relacoes,
+		Links: map[string]string{"self": "/carros/" + carro.ID}}
+}
+
+// recursosCarros monta os recursos dos carros, só com os campos pedidos se houver projeção
+func (c *CadastroCarros) recursosCarros(v *VisaoCarros, carros []Carro, campos []string) ([]recursoJSONAPI, error) {
+	if len(campos) == 0 {
+		campos = camposProjetaveis()
+	}
+	registros, err := projetarCampos(carros, campos)
+	if err != nil {
+		return nil, err
+	}
+	catalogo := c.Config().Catalogo
+	recursos := make([]recursoJSONAPI, 0, len(carros))
+	for i, carro := range carros {
+		recursos = append(recursos, v.recursoCarro(carro, registros[i], catalogo))
+	}
+	return recursos, nil
+}
+
+// linksPaginacao devolve os links self, first e next de GET /carros paginado em JSON:API
+func linksPaginacao(r *http.Request, proximoCursor string) map[string]string {
+	comCursor := func(cursor string) string {
+		params := r.URL.Query()
+		params.Del("cursor")
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		return r.URL.Path + "?" + params.Encode()
+	}
+	links := map[string]string{"self": r.URL.RequestURI(), "first": comCursor("")}
+	if proximoCursor != "" {
+		links["next"] = comCursor(proximoCursor)
+	}
+	return links
+}
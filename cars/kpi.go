@@ -0,0 +1,121 @@
+package cars
+
+import (
+	"time"
+)
+
+// IndicadoresMes reúne os indicadores de estoque e vendas de um mês
+type IndicadoresMes struct {
+	Mes           time.Time // Primeiro dia do mês
+	EstoqueInicio int       // Carros em estoque no primeiro dia do mês
+	Entradas      int       // Carros cadastrados durante o mês
+	Vendas        int       // Carros vendidos durante o mês
+	EstoqueFim    int       // Carros em estoque no último dia do mês
+	Faturamento   Dinheiro  // Soma dos preços finais das vendas do mês
+	MediaDias     float64   // Média de dias em estoque dos carros vendidos no mês
+	MediaDesconto float64   // Desconto médio sobre o preço pedido, em %
+	ReceitaCusto  Dinheiro  // Faturamento das vendas com custo conhecido
+	Custo         Dinheiro  // Custo de importação dessas mesmas vendas
+}
+
+// MargemBruta devolve a margem bruta, em %, das vendas com custo conhecido (false se não houver)
+func (m IndicadoresMes) MargemBruta() (float64, bool) {
+	if m.ReceitaCusto <= 0 {
+		return 0, false
+	}
+	return (m.ReceitaCusto - m.Custo).EmReais() / m.ReceitaCusto.EmReais() * 100, true
+}
+
+// Giro devolve o giro de estoque do mês: vendas divididas pelo estoque médio
+func (m IndicadoresMes) Giro() float64 {
+	medio := float64(m.EstoqueInicio+m.EstoqueFim) / 2
+	if medio == 0 {
+		return 0
+	}
+	return float64(m.Vendas) / medio
+}
+
+// TaxaEscoamento devolve o sell-through do mês: vendas sobre o estoque disponível (inicial + entradas), em %
+func (m IndicadoresMes) TaxaEscoamento() float64 {
+	disponivel := m.EstoqueInicio + m.Entradas
+	if disponivel == 0 {
+		return 0
+	}
+	return float64(m.Vendas) / float64(disponivel) * 100
+}
+
+// periodoEstoque é o intervalo [entrada, saida) em que um carro esteve no estoque (saida zero = ainda está)
+type periodoEstoque struct {
+	entrada, saida time.Time
+}
+
+// calcularIndicadores monta os indicadores dos últimos `meses` meses até o mês de `agora`
+// (chamador deve segurar o lock ou usar uma visão). Carros removidos sem venda não entram na conta,
+// pois só a data de remoção foi guardada.
+func (d *dadosCarros) calcularIndicadores(meses int, agora time.Time) []IndicadoresMes {
+	data := func(s string) time.Time {
+		t, _ := time.ParseInLocation("2006-01-02", s, agora.Location())
+		return t
+	}
+
+	var periodos []periodoEstoque
+	for _, carro := range d.carros {
+		periodos = append(periodos, periodoEstoque{entrada: data(carro.DataCadastro)})
+	}
+	for _, v := range d.vendidos {
+		periodos = append(periodos, periodoEstoque{entrada: data(v.Carro.DataCadastro), saida: data(v.DataVenda)})
+	}
+	emEstoque := func(dia time.Time) int {
+		n := 0
+		for _, p := range periodos {
+			if !p.entrada.After(dia) && (p.saida.IsZero() || p.saida.After(dia)) {
+				n++
+			}
+		}
+		return n
+	}
+
+	inicio := time.Date(agora.Year(), agora.Month(), 1, 0, 0, 0, 0, agora.Location()).AddDate(0, -(meses - 1), 0)
+	resultado := make([]IndicadoresMes, 0, meses)
+	for i := 0; i < meses; i++ {
+		mes := inicio.AddDate(0, i, 0)
+		proximo := mes.AddDate(0, 1, 0)
+		m := IndicadoresMes{
+			Mes:           mes,
+			EstoqueInicio: emEstoque(mes),
+			EstoqueFim:    emEstoque(proximo.AddDate(0, 0, -1)),
+		}
+		for _, p := range periodos {
+			if !p.entrada.Before(mes) && p.entrada.Before(proximo) {
+				m.Entradas++
+			}
+		}
+
+		somaDias, somaDesconto := 0, 0.0
+		for _, v := range d.vendidos {
+			if d := data(v.DataVenda); d.Before(mes) || !d.Before(proximo) {
+				continue
+			}
+			m.Vendas++
+			m.Faturamento += v.PrecoFinal
+			somaDias += v.DiasEmEstoque
+			somaDesconto += v.DescontoPercentual()
+			if v.Carro.Custo > 0 {
+				m.ReceitaCusto += v.PrecoFinal
+				m.Custo += v.Carro.Custo
+			}
+		}
+		if m.Vendas > 0 {
+			m.MediaDias = float64(somaDias) / float64(m.Vendas)
+			m.MediaDesconto = somaDesconto / float64(m.Vendas)
+		}
+		resultado = append(resultado, m)
+	}
+	return resultado
+}
+
+// Indicadores devolve os indicadores de estoque e vendas dos últimos `meses` meses até o mês de
+// `agora`
+func (v *VisaoCarros) Indicadores(meses int, agora time.Time) []IndicadoresMes {
+	return v.calcularIndicadores(meses, agora)
+}
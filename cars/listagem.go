@@ -0,0 +1,71 @@
+package cars
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"time"
+
+	"golang.org/x/text/collate"
+)
+
+// OpcoesListagem reúne as opções do comando list
+type OpcoesListagem struct {
+	Modo        ModoTabela // Largura da tabela (--wide/--narrow)
+	Dias        bool       // Inclui a coluna calculada "Dias em Estoque" (--aging)
+	FaixasIdade bool       // Agrupa por faixa de dias em estoque com subtotais (--aging-buckets)
+	Em          string     // Mostra o estoque como estava ao fim deste dia, projetado do log (--as-of)
+	AgruparPor  string     // Campo de agrupamento com subtotais (--group-by), ver camposAgrupamento
+	Opcionais   []string   // Só carros com todos estes opcionais (--opcional, pode repetir)
+	Ordenar     string     // Campo de ordenação (--sort), ver NomesCamposOrdenacao; "" = ordem de cadastro
+	Decrescente bool       // Inverte a ordenação (--desc)
+	Pagina      int        // Página mostrada, a partir de 1 (--page); 0 = todos os carros
+	PorPagina   int        // Carros por página (--page-size)
+}
+
+// DiasEmEstoque calcula quantos dias se passaram entre o cadastro do carro e o instante informado
+func DiasEmEstoque(carro Carro, agora time.Time) int {
+	cadastro, err := time.ParseInLocation("2006-01-02", carro.DataCadastro, agora.Location())
+	if err != nil || agora.Before(cadastro) {
+		return 0
+	}
+	return int(agora.Sub(cadastro).Hours() / 24)
+}
+
+// camposOrdenacao são os campos aceitos por list --sort e pelas consultas; os de texto seguem a
+// colação pt-BR
+var camposOrdenacao = map[string]func(a, b Carro, colador *collate.Collator) int{
+	"preco": func(a, b Carro, _ *collate.Collator) int { return cmp.Compare(a.Preco, b.Preco) },
+	"ano":   func(a, b Carro, _ *collate.Collator) int { return cmp.Compare(a.Ano, b.Ano) },
+	"marca": func(a, b Carro, colador *collate.Collator) int {
+		if r := colador.CompareString(a.Marca, b.Marca); r != 0 {
+			return r
+		}
+		return colador.CompareString(a.Modelo, b.Modelo)
+	},
+	"modelo":   func(a, b Carro, colador *collate.Collator) int { return colador.CompareString(a.Modelo, b.Modelo) },
+	"cadastro": func(a, b Carro, _ *collate.Collator) int { return cmp.Compare(a.DataCadastro, b.DataCadastro) },
+}
+
+// OrdenarCarros devolve uma cópia dos carros ordenada pelo campo; empates mantêm a ordem de cadastro
+func OrdenarCarros(carros []Carro, campo string, decrescente bool) []Carro {
+	ordenados := slices.Clone(carros)
+	comparar, colador := camposOrdenacao[campo], NovoColador()
+	slices.SortStableFunc(ordenados, func(a, b Carro) int {
+		if decrescente {
+			return comparar(b, a, colador)
+		}
+		return comparar(a, b, colador)
+	})
+	return ordenados
+}
+
+// NomesCamposOrdenacao devolve os campos de ordenação em ordem alfabética, para mensagens
+func NomesCamposOrdenacao() []string {
+	nomes := make([]string, 0, len(camposOrdenacao))
+	for nome := range camposOrdenacao {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
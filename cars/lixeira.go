@@ -0,0 +1,123 @@
+package cars
+
+import (
+	"fmt"
+	"time"
+)
+
+// ItemLixeira é um carro removido do estoque: a lápide guarda quando, e o log guarda o último
+// estado dele, usado para trazê-lo de volta
+type ItemLixeira struct {
+	Lapide
+	Carro     Carro
+	Conhecido bool // O log tem o estado do carro (lápides de dados antigos, anteriores ao log, não têm)
+}
+
+// ultimoEstado devolve a última versão do carro registrada no log, mesmo que ele tenha saído do
+// estoque; depois da purga, o ID não tem mais estado
+func (d *dadosCarros) ultimoEstado(id string) (Carro, bool) {
+	for i := len(d.eventos) - 1; i >= 0; i-- {
+		e := d.eventos[i]
+		switch {
+		case e.CarroID != id:
+		case e.Tipo == EventoCarroPurgado:
+			return Carro{}, false
+		case e.Carro != nil:
+			return *e.Carro, true
+		}
+	}
+	return Carro{}, false
+}
+
+// purgado informa se o último evento do ID é a purga: o carro não volta por rollback nem undo
+func (d *dadosCarros) purgado(id string) bool {
+	for i := len(d.eventos) - 1; i >= 0; i-- {
+		if e := d.eventos[i]; e.CarroID == id {
+			return e.Tipo == EventoCarroPurgado
+		}
+	}
+	return false
+}
+
+// lixeira lista os carros removidos, do mais recente ao mais antigo
+func (d *dadosCarros) lixeira() []ItemLixeira {
+	itens := make([]ItemLixeira, 0, len(d.removidos))
+	for i := len(d.removidos) - 1; i >= 0; i-- {
+		item := ItemLixeira{Lapide: d.removidos[i]}
+		item.Carro, item.Conhecido = d.ultimoEstado(item.ID)
+		itens = append(itens, item)
+	}
+	return itens
+}
+
+// Lixeira devolve os carros removidos, do mais recente ao mais antigo
+func (v *VisaoCarros) Lixeira() []ItemLixeira {
+	return v.lixeira()
+}
+
+// errForaDaLixeira é a falha das operações sobre um ID que não está na lixeira
+func errForaDaLixeira(id string) error {
+	return fmt.Errorf("o ID '%s' não está na lixeira (veja 'trash list')", id)
+}
+
+// Restaurar devolve ao estoque um carro removido, no último estado registrado no log, e o devolve
+func (c *CadastroCarros) Restaurar(id string) (Carro, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, removido := c.lapide(id); !removido {
+		return Carro{}, errForaDaLixeira(id)
+	}
+	carro, conhecido := c.ultimoEstado(id)
+	if !conhecido {
+		return Carro{}, fmt.Errorf("o log não tem o registro de '%s' (removido antes do log de eventos); não dá para restaurá-lo", id)
+	}
+	if err := c.validarRegistrados(carro); err != nil {
+		return Carro{}, fmt.Errorf("%v. Carro mantido na lixeira", err)
+	}
+	c.emitir(Evento{Tipo: EventoCarroRestaurado, CarroID: id, Carro: &carro})
+
+	// Persistir após restaurar
+	return carro, c.gravar()
+}
+
+// SelecionarLixeira devolve os IDs da lixeira que uma limpeza apagaria: o ID informado, ou todos
+// os removidos há pelo menos `dias` dias (0 = todos)
+func (v *VisaoCarros) SelecionarLixeira(id string, dias int, agora time.Time) ([]string, error) {
+	var ids []string
+	limite := agora.AddDate(0, 0, -dias)
+	for _, item := range v.lixeira() {
+		em, err := time.Parse(time.RFC3339Nano, item.RemovidoEm)
+		switch {
+		case id != "" && item.ID == id:
+			ids = append(ids, item.ID)
+		case id == "" && (dias == 0 || (err == nil && !em.After(limite))):
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(ids) == 0 && id != "" {
+		return nil, errForaDaLixeira(id)
+	}
+	return ids, nil
+}
+
+// ApagarDaLixeira apaga de vez, como o purge, os carros informados que ainda estejam na lixeira,
+// com uma única gravação no final. Devolve quantos foram apagados.
+func (c *CadastroCarros) ApagarDaLixeira(ids []string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	apagados := 0
+	for _, id := range ids {
+		if _, removido := c.lapide(id); removido {
+			c.apagarRastro(id)
+			apagados++
+		}
+	}
+	if apagados == 0 {
+		return 0, nil
+	}
+
+	// Persistir após esvaziar a lixeira
+	return apagados, c.gravar()
+}
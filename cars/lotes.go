@@ -0,0 +1,191 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Lote agrupa carros que chegaram no mesmo contêiner ou foram comprados no mesmo leilão
+type Lote struct {
+	ID        string      `json:"id"`        // ID único (lot_<timestamp>)
+	Descricao string      `json:"descricao"` // Ex: "Contêiner MSCU1234567 - Santos"
+	Data      string      `json:"data"`      // Data de criação (formato YYYY-MM-DD)
+	CarroIDs  []string    `json:"carro_ids"` // Carros do lote, em estoque ou já vendidos
+	Custos    []CustoLote `json:"custos"`    // Custos compartilhados (frete, despachante, taxas)
+}
+
+// CustoLote é um custo compartilhado, rateado entre os carros do lote
+type CustoLote struct {
+	Descricao string   `json:"descricao"`
+	Valor     Dinheiro `json:"valor"`
+}
+
+// TotalCustos soma os custos compartilhados do lote
+func (l Lote) TotalCustos() Dinheiro {
+	var total Dinheiro
+	for _, custo := range l.Custos {
+		total += custo.Valor
+	}
+	return total
+}
+
+// ItemLote é um carro do lote com sua situação atual (chamador deve segurar o lock)
+type ItemLote struct {
+	ID      string
+	Carro   Carro
+	Venda   *Venda
+	Achado  bool
+	Rateio  Dinheiro
+	Receita Dinheiro // Preço final se vendido, preço pedido se em estoque
+}
+
+// itensLote localiza os carros do lote no estoque ou nas vendas e rateia os custos compartilhados:
+// proporcionalmente ao custo de importação se todos o tiverem, senão em partes iguais.
+// O último carro absorve a sobra de arredondamento, para o rateio somar exatamente o total.
+func (d *dadosCarros) itensLote(l Lote) []ItemLote {
+	itens := make([]ItemLote, 0, len(l.CarroIDs))
+	var somaCustos Dinheiro
+	todosComCusto := true
+	for _, id := range l.CarroIDs {
+		item := ItemLote{ID: id}
+		if carro, existe := d.carrosMap[id]; existe {
+			item.Carro, item.Achado, item.Receita = carro, true, carro.Preco
+		}
+		for i := range d.vendidos {
+			if v := &d.vendidos[i]; v.Carro.ID == id {
+				item.Carro, item.Venda, item.Achado, item.Receita = v.Carro, v, true, v.PrecoFinal
+			}
+		}
+		somaCustos += item.Carro.Custo
+		todosComCusto = todosComCusto && item.Carro.Custo > 0
+		itens = append(itens, item)
+	}
+
+	total, rateado := l.TotalCustos(), Dinheiro(0)
+	for i := range itens {
+		switch {
+		case i == len(itens)-1:
+			itens[i].Rateio = total - rateado
+		case todosComCusto && somaCustos > 0:
+			itens[i].Rateio = total.Multiplicar(itens[i].Carro.Custo.EmReais() / somaCustos.EmReais())
+		default:
+			itens[i].Rateio = total.Dividir(len(itens))
+		}
+		rateado += itens[i].Rateio
+	}
+	return itens
+}
+
+// buscarLote devolve o lote pelo ID (chamador deve segurar o lock ou usar uma visão)
+func (d *dadosCarros) buscarLote(id string) *Lote {
+	for i := range d.lotes {
+		if d.lotes[i].ID == id {
+			return &d.lotes[i]
+		}
+	}
+	return nil
+}
+
+// errLoteNaoEncontrado é a falha das operações sobre um lote inexistente
+func errLoteNaoEncontrado(id string) error {
+	return fmt.Errorf("lote com ID '%s' não encontrado", id)
+}
+
+// NovoLote cadastra um lote vazio e o devolve
+func (c *CadastroCarros) NovoLote(descricao string) (Lote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lote := Lote{
+		ID:        fmt.Sprintf("lot_%d", time.Now().UnixNano()),
+		Descricao: descricao,
+		Data:      time.Now().Format("2006-01-02"),
+		CarroIDs:  []string{},
+		Custos:    []CustoLote{},
+	}
+	c.lotes = append(c.lotes, lote)
+
+	// Persistir após criar
+	return lote, c.gravar()
+}
+
+// RecusaLote é um carro que não entrou no lote: fora do estoque ou já em outro lote
+type RecusaLote struct {
+	CarroID string
+	Lote    string // Lote a que o carro já pertence ("" se ele não está no estoque)
+}
+
+// AdicionarAoLote acrescenta carros em estoque ao lote; um carro só pode pertencer a um lote.
+// Devolve o lote atualizado e os carros recusados; sem nenhum incluído, nada é gravado.
+func (c *CadastroCarros) AdicionarAoLote(loteID string, carroIDs []string) (Lote, []RecusaLote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lote := c.buscarLote(loteID)
+	if lote == nil {
+		return Lote{}, nil, errLoteNaoEncontrado(loteID)
+	}
+
+	var recusas []RecusaLote
+	incluidos := 0
+	for _, id := range carroIDs {
+		if _, existe := c.carrosMap[id]; !existe {
+			recusas = append(recusas, RecusaLote{CarroID: id})
+			continue
+		}
+		if outro := c.loteDoCarro(id); outro != nil {
+			recusas = append(recusas, RecusaLote{CarroID: id, Lote: outro.ID})
+			continue
+		}
+		lote.CarroIDs = append(lote.CarroIDs, id)
+		incluidos++
+	}
+	if incluidos == 0 {
+		return *lote, recusas, fmt.Errorf("nenhum carro incluído no lote %s", lote.ID)
+	}
+
+	// Persistir após incluir
+	return *lote, recusas, c.gravar()
+}
+
+// loteDoCarro devolve o lote a que o carro pertence, se houver (chamador deve segurar o lock)
+func (c *CadastroCarros) loteDoCarro(carroID string) *Lote {
+	for i := range c.lotes {
+		for _, id := range c.lotes[i].CarroIDs {
+			if id == carroID {
+				return &c.lotes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// RegistrarCustoLote registra um custo compartilhado no lote e devolve o lote atualizado
+func (c *CadastroCarros) RegistrarCustoLote(loteID string, custo CustoLote) (Lote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lote := c.buscarLote(loteID)
+	if lote == nil {
+		return Lote{}, errLoteNaoEncontrado(loteID)
+	}
+	lote.Custos = append(lote.Custos, custo)
+
+	// Persistir após lançar
+	return *lote, c.gravar()
+}
+
+// Lotes devolve os lotes cadastrados
+func (v *VisaoCarros) Lotes() []Lote {
+	return slices.Clone(v.lotes)
+}
+
+// Lote devolve o lote com o ID informado e os carros dele, com o rateio dos custos compartilhados
+func (v *VisaoCarros) Lote(id string) (Lote, []ItemLote, error) {
+	lote := v.buscarLote(id)
+	if lote == nil {
+		return Lote{}, nil, errLoteNaoEncontrado(id)
+	}
+	return *lote, v.itensLote(*lote), nil
+}
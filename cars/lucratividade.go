@@ -0,0 +1,113 @@
+package cars
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OpcoesLucratividade é a seção "lucratividade" da configuração: custos que correm enquanto o
+// carro está no pátio e corroem a margem
+type OpcoesLucratividade struct {
+	CustoDiario float64 `json:"custo_diario,omitempty"` // Custo de pátio por carro por dia em R$ (armazenagem, seguro, capital parado)
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesLucratividade) Validar() error {
+	if o.CustoDiario < 0 {
+		return fmt.Errorf("lucratividade: custo_diario não pode ser negativo")
+	}
+	return nil
+}
+
+// MarcoLucratividade é um ponto da linha do tempo de um carro: o preço em vigor e o custo
+// acumulado até aquele dia
+type MarcoLucratividade struct {
+	Data    string
+	Evento  string
+	Dias    int
+	Receita Dinheiro // Preço pedido em vigor, ou preço final na venda
+	Custo   Dinheiro // Custo de importação + rateio do lote + pátio acumulado
+}
+
+// Margem devolve a receita menos o custo acumulado
+func (m MarcoLucratividade) Margem() Dinheiro { return m.Receita - m.Custo }
+
+// linhaLucratividade monta a linha do tempo do carro a partir do log de eventos: a entrada, cada
+// mudança de preço ou custo, e a venda ou, para carros em estoque, o dia de hoje
+func (d *dadosCarros) linhaLucratividade(id string, custoDiario Dinheiro, agora time.Time) ([]MarcoLucratividade, Carro, bool) {
+	var carro Carro
+	var marcos []MarcoLucratividade
+	var rateio Dinheiro
+	for _, l := range d.lotes {
+		for _, item := range d.itensLote(l) {
+			if item.ID == id {
+				rateio = item.Rateio
+			}
+		}
+	}
+	marco := func(evento string, em time.Time, c Carro, receita Dinheiro) MarcoLucratividade {
+		dias := DiasEmEstoque(c, em)
+		return MarcoLucratividade{
+			Data: em.Format("2006-01-02"), Evento: evento, Dias: dias, Receita: receita,
+			Custo: c.Custo + rateio + custoDiario*Dinheiro(dias),
+		}
+	}
+
+	vendido := false
+	for _, e := range d.eventos {
+		if e.CarroID != id {
+			continue
+		}
+		em, err := time.Parse(time.RFC3339Nano, e.Em)
+		if err != nil {
+			continue
+		}
+		em = em.In(agora.Location())
+		switch e.Tipo {
+		case EventoCarroAdicionado:
+			carro = *e.Carro
+			marcos = append(marcos, marco("Entrada", em, carro, carro.Preco))
+		case EventoCarroAtualizado:
+			anterior := carro
+			carro = *e.Carro
+			var mudancas []string
+			if carro.Preco != anterior.Preco {
+				mudancas = append(mudancas, fmt.Sprintf("preço %s → %s", anterior.Preco, carro.Preco))
+			}
+			if carro.Custo != anterior.Custo {
+				mudancas = append(mudancas, fmt.Sprintf("custo %s → %s", anterior.Custo, carro.Custo))
+			}
+			if len(mudancas) > 0 {
+				marcos = append(marcos, marco("Alteração: "+strings.Join(mudancas, ", "), em, carro, carro.Preco))
+			}
+		case EventoCarroVendido:
+			carro = e.Venda.Carro
+			marcos = append(marcos, marco("Venda", em, carro, e.Venda.PrecoFinal))
+			vendido = true
+		case EventoCarroRestaurado:
+			carro = *e.Carro
+			marcos = append(marcos, marco("Volta ao estoque (rollback)", em, carro, carro.Preco))
+			vendido = false
+		case EventoCarroPurgado:
+			carro, marcos, vendido = Carro{}, nil, false
+		}
+	}
+	if len(marcos) == 0 {
+		return nil, carro, false
+	}
+	if _, emEstoque := d.carrosMap[id]; emEstoque && !vendido {
+		marcos = append(marcos, marco("Hoje", agora, carro, carro.Preco))
+	}
+	return marcos, carro, true
+}
+
+// Lucratividade devolve a linha do tempo da margem de um carro, com o custo de pátio diário
+// informado, e o último estado dele
+func (v *VisaoCarros) Lucratividade(id string, custoDiario Dinheiro, agora time.Time) ([]MarcoLucratividade, Carro, error) {
+	marcos, carro, ok := v.linhaLucratividade(id, custoDiario, agora)
+	if !ok {
+		return nil, Carro{}, fmt.Errorf("nenhum evento registrado para o carro com ID '%s'", id)
+	}
+	return marcos, carro, nil
+}
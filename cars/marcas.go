@@ -0,0 +1,184 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// paisesPorMarca liga as marcas mais comuns ao país de origem, usado para sugerir o país no
+// cadastro e na importação e para avisar de combinações estranhas (um Toyota da Alemanha).
+// A seção "marcas" da configuração acrescenta marcas ou troca o país de uma delas.
+var paisesPorMarca = []struct{ Marca, Pais string }{
+	{"Toyota", "Japão"}, {"Lexus", "Japão"}, {"Honda", "Japão"}, {"Nissan", "Japão"}, {"Infiniti", "Japão"},
+	{"Mazda", "Japão"}, {"Subaru", "Japão"}, {"Mitsubishi", "Japão"}, {"Suzuki", "Japão"},
+	{"BMW", "Alemanha"}, {"Mercedes-Benz", "Alemanha"}, {"Mercedes", "Alemanha"}, {"Audi", "Alemanha"},
+	{"Volkswagen", "Alemanha"}, {"VW", "Alemanha"}, {"Porsche", "Alemanha"},
+	{"Fiat", "Itália"}, {"Ferrari", "Itália"}, {"Lamborghini", "Itália"}, {"Maserati", "Itália"}, {"Alfa Romeo", "Itália"},
+	{"Renault", "França"}, {"Peugeot", "França"}, {"Citroën", "França"},
+	{"Ford", "Estados Unidos"}, {"Chevrolet", "Estados Unidos"}, {"Jeep", "Estados Unidos"}, {"Dodge", "Estados Unidos"},
+	{"RAM", "Estados Unidos"}, {"Cadillac", "Estados Unidos"}, {"Tesla", "Estados Unidos"},
+	{"Hyundai", "Coreia do Sul"}, {"Kia", "Coreia do Sul"},
+	{"Land Rover", "Reino Unido"}, {"Jaguar", "Reino Unido"}, {"Mini", "Reino Unido"}, {"Bentley", "Reino Unido"},
+	{"Rolls-Royce", "Reino Unido"}, {"Aston Martin", "Reino Unido"}, {"McLaren", "Reino Unido"},
+	{"Volvo", "Suécia"}, {"Seat", "Espanha"}, {"Cupra", "Espanha"},
+	{"BYD", "China"}, {"GWM", "China"}, {"Chery", "China"},
+}
+
+// paisesEquivalentes são nomes diferentes do mesmo país, que não devem gerar aviso
+var paisesEquivalentes = [][]string{
+	{"Estados Unidos", "EUA"}, {"Coreia do Sul", "Coreia"}, {"Reino Unido", "Inglaterra"},
+}
+
+// OpcoesMarcas é a seção "marcas" da configuração: país de origem de marcas que a tabela embutida
+// não conhece ou que a loja importa de outro lugar (ex: {"Jeep": "Brasil"} para os nacionais) e
+// anos de produção de modelos que o catálogo embutido não traz
+type OpcoesMarcas struct {
+	Paises  map[string]string `json:"paises,omitempty"`  // Marca → país de origem padrão
+	Modelos []ProducaoModelo  `json:"modelos,omitempty"` // Anos de produção, sobre os do catálogo embutido
+}
+
+// Validar confere se nenhuma marca ficou sem país e se os anos de produção fazem sentido
+func (o OpcoesMarcas) Validar() error {
+	for marca, pais := range o.Paises {
+		if strings.TrimSpace(marca) == "" || strings.TrimSpace(pais) == "" {
+			return fmt.Errorf("marcas: marca e país não podem ser vazios (%q: %q)", marca, pais)
+		}
+	}
+	return validarModelos(o.Modelos)
+}
+
+// novoColadorNomes compara nomes de marcas e países ignorando acentos e caixa
+func novoColadorNomes() *collate.Collator {
+	return collate.New(language.BrazilianPortuguese, collate.IgnoreCase, collate.IgnoreDiacritics)
+}
+
+// PaisDaMarca devolve o país de origem padrão da marca ("" se desconhecida); a configuração
+// vale sobre a tabela embutida
+func (o OpcoesMarcas) PaisDaMarca(marca string) string {
+	colador := novoColadorNomes()
+	marca = strings.TrimSpace(marca)
+	for nome, pais := range o.Paises {
+		if colador.CompareString(nome, marca) == 0 {
+			return pais
+		}
+	}
+	for _, m := range paisesPorMarca {
+		if colador.CompareString(m.Marca, marca) == 0 {
+			return m.Pais
+		}
+	}
+	return ""
+}
+
+// mesmoPais compara dois nomes de país, aceitando os equivalentes (EUA e Estados Unidos)
+func mesmoPais(a, b string) bool {
+	colador := novoColadorNomes()
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if colador.CompareString(a, b) == 0 {
+		return true
+	}
+	igual := func(nome string) func(string) bool {
+		return func(outro string) bool { return colador.CompareString(nome, outro) == 0 }
+	}
+	for _, nomes := range paisesEquivalentes {
+		if slices.ContainsFunc(nomes, igual(a)) && slices.ContainsFunc(nomes, igual(b)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AvisoPais descreve a divergência entre o país informado e o padrão da marca ("" se combinam
+// ou se a marca é desconhecida)
+func (o OpcoesMarcas) AvisoPais(marca, pais string) string {
+	padrao := o.PaisDaMarca(marca)
+	if padrao == "" || strings.TrimSpace(pais) == "" || mesmoPais(padrao, pais) {
+		return ""
+	}
+	return fmt.Sprintf("%s costuma vir de %s, não de %s", strings.TrimSpace(marca), padrao, strings.TrimSpace(pais))
+}
+
+// ProducaoModelo é o intervalo de anos em que um modelo foi fabricado
+type ProducaoModelo struct {
+	Marca  string `json:"marca"`
+	Modelo string `json:"modelo"`
+	Inicio int    `json:"inicio"`        // Primeiro ano de fabricação
+	Fim    int    `json:"fim,omitempty"` // Último ano de fabricação (0 = ainda em produção)
+}
+
+// producaoModelos é o catálogo embutido de anos de produção, usado para avisar de combinações
+// impossíveis (um Corolla Cross 2015). A seção "marcas" acrescenta modelos ou corrige os daqui.
+var producaoModelos = []ProducaoModelo{
+	{"Toyota", "Corolla", 1966, 0}, {"Toyota", "Corolla Cross", 2020, 0}, {"Toyota", "Hilux", 1968, 0},
+	{"Toyota", "RAV4", 1994, 0}, {"Toyota", "Prius", 1997, 0}, {"Toyota", "Yaris", 1999, 0},
+	{"Honda", "Civic", 1972, 0}, {"Honda", "CR-V", 1995, 0}, {"Honda", "Fit", 2001, 0},
+	{"Nissan", "Kicks", 2016, 0}, {"Nissan", "GT-R", 2007, 0}, {"Mazda", "MX-5", 1989, 0}, {"Subaru", "Impreza", 1992, 0},
+	{"BMW", "X1", 2009, 0}, {"BMW", "X5", 1999, 0}, {"BMW", "X6", 2008, 0}, {"BMW", "i3", 2013, 2022},
+	{"Mercedes-Benz", "GLA", 2013, 0}, {"Mercedes-Benz", "GLC", 2015, 0},
+	{"Audi", "Q3", 2011, 0}, {"Audi", "Q5", 2008, 0}, {"Audi", "e-tron", 2018, 0},
+	{"Volkswagen", "Golf", 1974, 0}, {"Volkswagen", "T-Cross", 2018, 0}, {"Volkswagen", "Nivus", 2020, 0},
+	{"Volkswagen", "New Beetle", 1997, 2011},
+	{"Porsche", "Cayenne", 2002, 0}, {"Porsche", "Macan", 2014, 0}, {"Porsche", "Taycan", 2019, 0},
+	{"Fiat", "Uno", 1983, 2021}, {"Fiat", "Toro", 2016, 0}, {"Fiat", "Pulse", 2021, 0},
+	{"Jeep", "Renegade", 2014, 0}, {"Jeep", "Compass", 2006, 0},
+	{"Ford", "Mustang", 1964, 0}, {"Ford", "Ka", 1996, 2021},
+	{"Chevrolet", "Onix", 2012, 0}, {"Chevrolet", "Camaro", 1966, 2024},
+	{"Tesla", "Model S", 2012, 0}, {"Tesla", "Model 3", 2017, 0}, {"Tesla", "Model Y", 2020, 0},
+	{"Hyundai", "HB20", 2012, 0}, {"Hyundai", "Creta", 2014, 0}, {"Kia", "Sportage", 1993, 0},
+	{"Volvo", "XC40", 2017, 0}, {"Volvo", "XC60", 2008, 0},
+	{"BYD", "Dolphin", 2021, 0}, {"BYD", "Seal", 2022, 0}, {"Ferrari", "Roma", 2020, 0},
+}
+
+// validarModelos confere os anos de produção informados na configuração (usado por Validar)
+func validarModelos(modelos []ProducaoModelo) error {
+	for _, m := range modelos {
+		switch {
+		case strings.TrimSpace(m.Marca) == "" || strings.TrimSpace(m.Modelo) == "":
+			return fmt.Errorf("marcas: modelo sem marca ou nome (%+v)", m)
+		case m.Inicio <= 0 || (m.Fim != 0 && m.Fim < m.Inicio):
+			return fmt.Errorf("marcas: anos de produção inválidos para %s %s (inicio positivo e fim 0 ou a partir do inicio)", m.Marca, m.Modelo)
+		}
+	}
+	return nil
+}
+
+// ProducaoDoModelo localiza os anos de produção do modelo. O modelo digitado pode trazer a versão
+// ("Corolla XEi" é um Corolla); vale o nome de catálogo mais longo que combina, e a configuração
+// vale sobre o catálogo embutido.
+func (o OpcoesMarcas) ProducaoDoModelo(marca, modelo string) (ProducaoModelo, bool) {
+	colador := novoColadorNomes()
+	modelo = strings.Join(strings.Fields(modelo), " ")
+	var achado ProducaoModelo
+	existe := false
+	for _, m := range append(slices.Clone(o.Modelos), producaoModelos...) {
+		if colador.CompareString(m.Marca, strings.TrimSpace(marca)) != 0 || len(m.Modelo) <= len(achado.Modelo) {
+			continue
+		}
+		nome := strings.Fields(m.Modelo)
+		palavras := strings.Fields(modelo)
+		if len(palavras) < len(nome) || colador.CompareString(strings.Join(palavras[:len(nome)], " "), m.Modelo) != 0 {
+			continue
+		}
+		achado, existe = m, true
+	}
+	return achado, existe
+}
+
+// AvisoAno descreve por que o ano é impossível para o modelo ("" se está dentro da produção ou
+// se o modelo não está no catálogo)
+func (o OpcoesMarcas) AvisoAno(marca, modelo string, ano int) string {
+	m, existe := o.ProducaoDoModelo(marca, modelo)
+	switch {
+	case !existe || ano <= 0:
+		return ""
+	case ano < m.Inicio:
+		return fmt.Sprintf("o %s %s só começou a ser fabricado em %d; não há %s de %d", m.Marca, m.Modelo, m.Inicio, m.Modelo, ano)
+	case m.Fim != 0 && ano > m.Fim:
+		return fmt.Sprintf("o %s %s deixou de ser fabricado em %d; não há %s de %d", m.Marca, m.Modelo, m.Fim, m.Modelo, ano)
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package cars
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LimiarOperacaoLenta é a duração a partir da qual uma operação do cadastro é reportada como lenta
+const LimiarOperacaoLenta = 500 * time.Millisecond
+
+// MetricaOperacao acumula os tempos de uma operação do cadastro
+type MetricaOperacao struct {
+	Chamadas int
+	Total    time.Duration
+	Maior    time.Duration
+}
+
+// metricasOperacoes guarda as métricas por operação; tem lock próprio porque operações
+// de leitura rodam em paralelo sob o RLock do cadastro
+type metricasOperacoes struct {
+	mu          sync.Mutex
+	porOperacao map[string]*MetricaOperacao
+	lenta       func(operacao string, duracao time.Duration) // Avisado de cada operação lenta (nil = só o log)
+}
+
+// Medir inicia a cronometragem de uma operação; use `defer c.Medir("nome")()`.
+// O tempo inclui a espera pelo lock, para que contenção também apareça como lentidão.
+func (c *CadastroCarros) Medir(operacao string) func() {
+	inicio := time.Now()
+	return func() {
+		duracao := time.Since(inicio)
+
+		m := &c.metricas
+		m.mu.Lock()
+		if m.porOperacao == nil {
+			m.porOperacao = make(map[string]*MetricaOperacao)
+		}
+		op, existe := m.porOperacao[operacao]
+		if !existe {
+			op = &MetricaOperacao{}
+			m.porOperacao[operacao] = op
+		}
+		op.Chamadas++
+		op.Total += duracao
+		if duracao > op.Maior {
+			op.Maior = duracao
+		}
+		lenta := m.lenta
+		m.mu.Unlock()
+
+		if duracao >= LimiarOperacaoLenta {
+			c.log.Warn("operação lenta", "operacao", operacao, "duracao", duracao)
+			if lenta != nil {
+				lenta(operacao, duracao)
+			}
+		}
+	}
+}
+
+// AoOperacaoLenta registra quem é avisado quando uma operação passa de limiarOperacaoLenta
+func (c *CadastroCarros) AoOperacaoLenta(aviso func(operacao string, duracao time.Duration)) {
+	m := &c.metricas
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lenta = aviso
+}
+
+// TabelaMetricas monta a tabela de métricas, das operações com mais tempo acumulado para as com menos
+func (c *CadastroCarros) TabelaMetricas() Tabela {
+	m := &c.metricas
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nomes := make([]string, 0, len(m.porOperacao))
+	for nome := range m.porOperacao {
+		nomes = append(nomes, nome)
+	}
+	sort.Slice(nomes, func(i, j int) bool { return m.porOperacao[nomes[i]].Total > m.porOperacao[nomes[j]].Total })
+
+	t := Tabela{Colunas: []ColunaTabela{
+		{Titulo: "Operação", Essencial: true},
+		{Titulo: "Chamadas", Direita: true, Essencial: true},
+		{Titulo: "Média", Direita: true, Essencial: true},
+		{Titulo: "Maior", Direita: true, Essencial: true},
+		{Titulo: "Total", Direita: true},
+	}}
+	for _, nome := range nomes {
+		op := m.porOperacao[nome]
+		media := op.Total / time.Duration(op.Chamadas)
+		t.Linhas = append(t.Linhas, []string{nome, strconv.Itoa(op.Chamadas),
+			media.Round(time.Microsecond).String(), op.Maior.Round(time.Microsecond).String(), op.Total.Round(time.Microsecond).String()})
+	}
+	return t
+}
+
+// escreverMetricas inclui as métricas no diagnóstico
+func (c *CadastroCarros) escreverMetricas(w io.Writer) {
+	if t := c.TabelaMetricas(); len(t.Linhas) > 0 {
+		io.WriteString(w, t.Renderizar(TabelaLarga, 0))
+	}
+}
@@ -0,0 +1,71 @@
+package cars
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Notificacao é um aviso gerado fora do fluxo do prompt (sinais, tarefas em segundo plano)
+type Notificacao struct {
+	Instante   time.Time
+	Mensagem   string
+	Importante bool // Merece atenção na hora (o prompt toca o sino do terminal ao chegar)
+}
+
+// filaNotificacoes guarda os avisos até o próximo prompt, para não misturar texto com o que o
+// usuário está digitando; tem lock próprio porque é alimentada por outras goroutines
+type filaNotificacoes struct {
+	mu        sync.Mutex
+	pendentes []Notificacao
+	aviso     func(Notificacao) // Chamado a cada aviso que chega, ex: para tocar o sino (nil = nada)
+}
+
+// notificar enfileira um aviso para o próximo prompt
+func (c *CadastroCarros) notificar(importante bool, formato string, args ...interface{}) {
+	mensagem := fmt.Sprintf(formato, args...)
+	c.notificarEstruturado(importante, mensagem, textoDoRegistro(mensagem))
+}
+
+// notificarEstruturado enfileira a mensagem para o prompt e registra o resumo com os atributos no
+// log (warn se importante, senão info), para quem filtra o log por campo em vez de ler o texto
+func (c *CadastroCarros) notificarEstruturado(importante bool, mensagem, resumo string, atributos ...any) {
+	n := Notificacao{Instante: time.Now(), Mensagem: mensagem, Importante: importante}
+	f := &c.notificacoes
+	f.mu.Lock()
+	f.pendentes = append(f.pendentes, n)
+	aviso := f.aviso
+	f.mu.Unlock()
+
+	// Sem ninguém no terminal, o log é onde o aviso é visto
+	nivel := slog.LevelInfo
+	if importante {
+		nivel = slog.LevelWarn
+	}
+	c.log.Log(context.Background(), nivel, resumo, atributos...)
+
+	if aviso != nil {
+		aviso(n)
+	}
+}
+
+// AoNotificar registra quem é avisado na hora em que cada notificação chega, antes de ela ser
+// retirada da fila
+func (c *CadastroCarros) AoNotificar(aviso func(Notificacao)) {
+	f := &c.notificacoes
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.aviso = aviso
+}
+
+// RetirarNotificacoes devolve e descarta os avisos pendentes, na ordem em que chegaram
+func (c *CadastroCarros) RetirarNotificacoes() []Notificacao {
+	f := &c.notificacoes
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pendentes := f.pendentes
+	f.pendentes = nil
+	return pendentes
+}
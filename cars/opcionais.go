@@ -0,0 +1,94 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// VocabularioOpcionais são os equipamentos aceitos em Carro.Opcionais. O vocabulário é fechado para
+// que filtros, feeds e acréscimos de preço não dependam de como cada um digitou o nome.
+var VocabularioOpcionais = []string{
+	"ACC",
+	"ar-condicionado digital",
+	"bancos de couro",
+	"bancos elétricos",
+	"câmera de ré",
+	"câmera 360",
+	"carregador por indução",
+	"central multimídia",
+	"faróis de LED",
+	"head-up display",
+	"partida sem chave",
+	"rodas de liga leve",
+	"sensor de estacionamento",
+	"som premium",
+	"teto panorâmico",
+	"teto solar",
+	"tração integral",
+}
+
+// OpcionalDoVocabulario devolve o nome canônico do opcional, sem diferenciar caixa e acentos
+// ("Camera de re" vira "câmera de ré")
+func OpcionalDoVocabulario(nome string) (string, bool) {
+	colador := collate.New(language.BrazilianPortuguese, collate.IgnoreCase, collate.IgnoreDiacritics)
+	nome = strings.Join(strings.Fields(nome), " ")
+	for _, conhecido := range VocabularioOpcionais {
+		if colador.CompareString(nome, conhecido) == 0 {
+			return conhecido, true
+		}
+	}
+	return "", false
+}
+
+// InterpretarOpcionais lê uma lista separada por vírgulas e devolve os nomes canônicos, sem
+// repetição e na ordem do vocabulário
+func InterpretarOpcionais(texto string) ([]string, error) {
+	var opcionais, desconhecidos []string
+	for _, nome := range strings.Split(texto, ",") {
+		if strings.TrimSpace(nome) == "" {
+			continue
+		}
+		canonico, ok := OpcionalDoVocabulario(nome)
+		if !ok {
+			desconhecidos = append(desconhecidos, strings.TrimSpace(nome))
+			continue
+		}
+		if !slices.Contains(opcionais, canonico) {
+			opcionais = append(opcionais, canonico)
+		}
+	}
+	if len(desconhecidos) > 0 {
+		return nil, fmt.Errorf("opcional(is) fora do vocabulário: %s (use %s)",
+			strings.Join(desconhecidos, ", "), strings.Join(VocabularioOpcionais, ", "))
+	}
+	ordenarOpcionais(opcionais)
+	return opcionais, nil
+}
+
+// ordenarOpcionais põe os opcionais na ordem do vocabulário
+func ordenarOpcionais(opcionais []string) {
+	slices.SortFunc(opcionais, func(a, b string) int {
+		return slices.Index(VocabularioOpcionais, a) - slices.Index(VocabularioOpcionais, b)
+	})
+}
+
+// validarOpcionais confere se os opcionais gravados estão no vocabulário, com o nome canônico e sem
+// repetição (usado por validarCarro, que vale também para edit e patch)
+func validarOpcionais(opcionais []string) error {
+	for i, nome := range opcionais {
+		if !slices.Contains(VocabularioOpcionais, nome) {
+			if canonico, ok := OpcionalDoVocabulario(nome); ok {
+				return fmt.Errorf("opcional '%s' deve ser escrito como '%s'", nome, canonico)
+			}
+			return fmt.Errorf("opcional '%s' fora do vocabulário (use %s)", nome, strings.Join(VocabularioOpcionais, ", "))
+		}
+		if slices.Contains(opcionais[:i], nome) {
+			return fmt.Errorf("opcional '%s' repetido", nome)
+		}
+	}
+	return nil
+}
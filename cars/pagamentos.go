@@ -0,0 +1,116 @@
+package cars
+
+import (
+	"fmt"
+)
+
+// Pagamento registra um sinal ou parcela recebido por um carro reservado (em estoque) ou vendido
+type Pagamento struct {
+	CarroID string   `json:"carro_id"` // ID do carro a que o pagamento se refere
+	Valor   Dinheiro `json:"valor"`    // Valor recebido em R$
+	Data    string   `json:"data"`     // Data do recebimento (formato YYYY-MM-DD)
+	Metodo  string   `json:"metodo"`   // Ex: pix, ted, boleto, cartao, dinheiro, financiamento
+	Tipo    string   `json:"tipo"`     // "sinal" (depósito de reserva) ou "parcela"
+}
+
+// valorDevido devolve quanto o cliente deve pagar pelo carro: o preço final (descontada a troca) se já
+// vendido, ou o preço pedido se ainda estiver em estoque (reserva). Chamador deve segurar o lock ou usar uma visão.
+func (d *dadosCarros) valorDevido(id string) (Dinheiro, string, bool) {
+	for _, v := range d.vendidos {
+		if v.Carro.ID == id {
+			return v.ValorLiquido(), fmt.Sprintf("%s %s (vendido)", v.Carro.Marca, v.Carro.Modelo), true
+		}
+	}
+	if carro, existe := d.carrosMap[id]; existe {
+		return carro.Preco, fmt.Sprintf("%s %s (em estoque)", carro.Marca, carro.Modelo), true
+	}
+	return 0, "", false
+}
+
+// totalPago soma os pagamentos de um carro (chamador deve segurar o lock ou usar uma visão)
+func (d *dadosCarros) totalPago(id string) Dinheiro {
+	var total Dinheiro
+	for _, p := range d.pagamentos {
+		if p.CarroID == id {
+			total += p.Valor
+		}
+	}
+	return total
+}
+
+// Saldo é a situação dos pagamentos de um carro
+type Saldo struct {
+	CarroID   string
+	Descricao string   // Ex: "BMW X5 (vendido)"
+	Removido  bool     // O carro saiu do estoque sem venda; o valor devido não é mais conhecido
+	Devido    Dinheiro // Preço final (descontada a troca) se vendido, ou preço pedido se em estoque
+	Pago      Dinheiro
+	Ultimo    string // Data do último pagamento (YYYY-MM-DD)
+}
+
+// EmAberto devolve quanto ainda falta pagar (negativo se os pagamentos excedem o devido)
+func (s Saldo) EmAberto() Dinheiro {
+	return s.Devido - s.Pago
+}
+
+// saldo calcula a situação dos pagamentos de um carro (chamador deve segurar o lock ou usar uma visão)
+func (d *dadosCarros) saldo(id string) Saldo {
+	s := Saldo{CarroID: id, Pago: d.totalPago(id)}
+	var existe bool
+	s.Devido, s.Descricao, existe = d.valorDevido(id)
+	if !existe {
+		s.Descricao, s.Removido = "(carro removido)", true
+	}
+	for _, p := range d.pagamentos {
+		if p.CarroID == id && p.Data > s.Ultimo {
+			s.Ultimo = p.Data
+		}
+	}
+	return s
+}
+
+// errSemValorDevido é a falha das operações de pagamento sobre um carro fora do estoque e das vendas
+func errSemValorDevido(id string) error {
+	return fmt.Errorf("carro com ID '%s' não encontrado no estoque nem nas vendas", id)
+}
+
+// RegistrarPagamento lança um sinal ou parcela para um carro e devolve o saldo resultante
+func (c *CadastroCarros) RegistrarPagamento(p Pagamento) (Saldo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, existe := c.valorDevido(p.CarroID); !existe {
+		return Saldo{}, errSemValorDevido(p.CarroID)
+	}
+	c.pagamentos = append(c.pagamentos, p)
+
+	// Persistir após registrar o pagamento
+	return c.saldo(p.CarroID), c.gravar()
+}
+
+// Pagamentos devolve o saldo e os pagamentos de um carro, em ordem de lançamento
+func (v *VisaoCarros) Pagamentos(id string) (Saldo, []Pagamento, error) {
+	if _, _, existe := v.valorDevido(id); !existe {
+		return Saldo{}, nil, errSemValorDevido(id)
+	}
+	var pagamentos []Pagamento
+	for _, p := range v.pagamentos {
+		if p.CarroID == id {
+			pagamentos = append(pagamentos, p)
+		}
+	}
+	return v.saldo(id), pagamentos, nil
+}
+
+// Saldos devolve o saldo de cada carro com pagamentos lançados, na ordem do primeiro pagamento
+func (v *VisaoCarros) Saldos() []Saldo {
+	var saldos []Saldo
+	vistos := make(map[string]bool)
+	for _, p := range v.pagamentos {
+		if !vistos[p.CarroID] {
+			vistos[p.CarroID] = true
+			saldos = append(saldos, v.saldo(p.CarroID))
+		}
+	}
+	return saldos
+}
@@ -0,0 +1,180 @@
+package cars
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// OpcoesPainel são os parâmetros do painel do showroom (comando board)
+type OpcoesPainel struct {
+	Endereco    string // Endereço HTTP em que o painel é servido (--listen)
+	Dias        int    // Janela de "recém-chegados" e "vendidos recentemente" (--days)
+	Atualizacao int    // Segundos entre as atualizações automáticas da página (--refresh)
+}
+
+// cartaoPainel é um carro como aparece no painel
+type cartaoPainel struct {
+	Titulo   string
+	Detalhe  string
+	Preco    string
+	Situacao string
+}
+
+// conteudoPainel são as seções do painel em um instante
+type conteudoPainel struct {
+	Atualizacao  int
+	Dias         int
+	GeradoEm     string
+	Chegados     []cartaoPainel
+	Chegando     []cartaoPainel
+	Vendidos     []cartaoPainel
+	TotalEstoque int
+}
+
+// paginaPainel é a página do painel, pensada para uma TV: letras grandes, fundo escuro e
+// recarga automática
+var paginaPainel = template.Must(template.New("painel").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Atualizacao}}">
+<title>Showroom</title>
+<style>
+body { background: #111; color: #eee; font-family: sans-serif; margin: 2vw; }
+h1 { font-size: 4vw; margin: 0 0 1vw; }
+h2 { font-size: 3vw; margin: 2vw 0 1vw; color: #ffb400; }
+.cartoes { display: flex; flex-wrap: wrap; gap: 1.5vw; }
+.cartao { background: #222; border-radius: 1vw; padding: 1.5vw; min-width: 22vw; }
+.titulo { font-size: 2.4vw; font-weight: bold; }
+.detalhe { font-size: 1.6vw; color: #aaa; }
+.preco { font-size: 2.8vw; color: #6f6; margin-top: .5vw; }
+.vendido { font-size: 2.2vw; color: #f66; margin-top: .5vw; }
+.rodape { font-size: 1.2vw; color: #777; margin-top: 3vw; }
+</style>
+</head>
+<body>
+<h1>{{.TotalEstoque}} carro(s) à pronta entrega</h1>
+{{if .Chegados}}<h2>Recém-chegados</h2>
+<div class="cartoes">{{range .Chegados}}<div class="cartao"><div class="titulo">{{.Titulo}}</div><div class="detalhe">{{.Detalhe}}</div><div class="preco">{{.Preco}}</div></div>{{end}}</div>{{end}}
+{{if .Chegando}}<h2>Chegando em breve</h2>
+<div class="cartoes">{{range .Chegando}}<div class="cartao"><div class="titulo">{{.Titulo}}</div><div class="detalhe">{{.Detalhe}}</div></div>{{end}}</div>{{end}}
+{{if .Vendidos}}<h2>Vendidos recentemente</h2>
+<div class="cartoes">{{range .Vendidos}}<div class="cartao"><div class="titulo">{{.Titulo}}</div><div class="detalhe">{{.Detalhe}}</div><div class="vendido">{{.Situacao}}</div></div>{{end}}</div>{{end}}
+<div class="rodape">Atualizado em {{.GeradoEm}} · recém-chegados e vendidos dos últimos {{.Dias}} dia(s)</div>
+</body>
+</html>
+`))
+
+// conteudoPainel monta as seções do painel: carros disponíveis que chegaram na janela, carros
+// em trânsito e vendas da janela, do mais recente para o mais antigo
+func (v *VisaoCarros) conteudoPainel(opcoes OpcoesPainel, agora time.Time) conteudoPainel {
+	limite := agora.AddDate(0, 0, -opcoes.Dias).Format("2006-01-02")
+	conteudo := conteudoPainel{Atualizacao: opcoes.Atualizacao, Dias: opcoes.Dias, GeradoEm: agora.Format("02/01/2006 15:04")}
+
+	var chegados, chegando []Carro
+	for _, carro := range v.carros {
+		switch StatusCarro(carro) {
+		case StatusEmEstoque:
+			conteudo.TotalEstoque++
+			if carro.DataCadastro >= limite {
+				chegados = append(chegados, carro)
+			}
+		case StatusEmTransito:
+			chegando = append(chegando, carro)
+		}
+	}
+	sort.SliceStable(chegados, func(i, j int) bool { return chegados[i].DataCadastro > chegados[j].DataCadastro })
+	cartao := func(carro Carro) cartaoPainel {
+		detalhe := strconv.Itoa(carro.Ano)
+		if carro.Cor != "" {
+			detalhe += " · " + carro.Cor
+		}
+		if carro.PaisOrigem != "" {
+			detalhe += " · " + carro.PaisOrigem
+		}
+		return cartaoPainel{Titulo: carro.Marca + " " + carro.Modelo, Detalhe: detalhe, Preco: v.exibicao.FormatarPreco(carro.Preco)}
+	}
+	for _, carro := range chegados {
+		conteudo.Chegados = append(conteudo.Chegados, cartao(carro))
+	}
+	for _, carro := range chegando {
+		conteudo.Chegando = append(conteudo.Chegando, cartao(carro))
+	}
+
+	vendas := make([]Venda, 0)
+	for _, venda := range v.vendidos {
+		if venda.DataVenda >= limite {
+			vendas = append(vendas, venda)
+		}
+	}
+	sort.SliceStable(vendas, func(i, j int) bool { return vendas[i].DataVenda > vendas[j].DataVenda })
+	for _, venda := range vendas {
+		c := cartao(venda.Carro)
+		if data, err := time.Parse("2006-01-02", venda.DataVenda); err == nil {
+			c.Situacao = "Vendido em " + data.Format("02/01")
+		}
+		conteudo.Vendidos = append(conteudo.Vendidos, c)
+	}
+	return conteudo
+}
+
+// IniciarPainel serve o painel do showroom em segundo plano e devolve o endereço em que ficou no
+// ar; a sessão continua disponível
+func (c *CadastroCarros) IniciarPainel(opcoes OpcoesPainel) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.painel != nil {
+		return "", fmt.Errorf("o painel já está no ar em %s. Use 'board stop' antes de iniciar outro", c.painel.Addr)
+	}
+
+	ouvinte, err := net.Listen("tcp", opcoes.Endereco)
+	if err != nil {
+		return "", fmt.Errorf("erro ao abrir %s: %v", opcoes.Endereco, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := paginaPainel.Execute(w, c.Snapshot().conteudoPainel(opcoes, time.Now())); err != nil {
+			c.notificarEstruturado(false, fmt.Sprintf("⚠️  Aviso: erro ao montar o painel: %v", err), "erro ao montar o painel", "erro", err)
+		}
+	})
+	servidor := &http.Server{Addr: ouvinte.Addr().String(), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	c.painel = servidor
+	go func() {
+		if err := servidor.Serve(ouvinte); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: o painel parou: %v", err), "o painel parou", "endereco", servidor.Addr, "erro", err)
+		}
+	}()
+	c.log.Info("painel do showroom no ar", "endereco", servidor.Addr)
+	return servidor.Addr, nil
+}
+
+// PararPainel encerra o painel do showroom, esperando as páginas em andamento; parou é false se
+// não havia painel no ar
+func (c *CadastroCarros) PararPainel() (parou bool, err error) {
+	c.mu.Lock()
+	servidor := c.painel
+	c.painel = nil
+	c.mu.Unlock()
+	if servidor == nil {
+		return false, nil
+	}
+	ctx, cancelar := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelar()
+	if err := servidor.Shutdown(ctx); err != nil {
+		return true, fmt.Errorf("o painel não encerrou de forma limpa: %v", err)
+	}
+	c.log.Info("painel do showroom encerrado", "endereco", servidor.Addr)
+	return true, nil
+}
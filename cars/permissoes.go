@@ -0,0 +1,124 @@
+package cars
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// comandosSempreLiberados nunca são bloqueados, para que a sessão possa ser consultada e encerrada
+var comandosSempreLiberados = []string{"help", "exit", "lock"}
+
+// ComandosConhecidos são os comandos que a seção de permissões pode citar; o programa que embute o
+// cadastro os informa antes de carregar a configuração (vazio = os nomes não são conferidos)
+var ComandosConhecidos []string
+
+// ListaComandos libera ou bloqueia comandos do prompt; vazia, libera todos
+type ListaComandos struct {
+	Permitidos []string `json:"permitidos,omitempty"` // Se informado, só estes comandos (além de help, exit e lock)
+	Negados    []string `json:"negados,omitempty"`    // Comandos bloqueados, mesmo que estejam em permitidos
+}
+
+// validar confere se os comandos citados existem e se nenhum dos sempre liberados foi negado; secao
+// identifica a lista na mensagem de erro
+func (l ListaComandos) validar(secao string) error {
+	for _, nome := range append(slices.Clone(l.Permitidos), l.Negados...) {
+		if len(ComandosConhecidos) > 0 && !slices.ContainsFunc(ComandosConhecidos, func(c string) bool { return strings.EqualFold(c, nome) }) {
+			return fmt.Errorf("%s: comando desconhecido '%s' (use %s)", secao, nome, strings.Join(ComandosConhecidos, ", "))
+		}
+	}
+	for _, nome := range l.Negados {
+		if slices.Contains(comandosSempreLiberados, strings.ToLower(nome)) {
+			return fmt.Errorf("%s: '%s' não pode ser negado", secao, nome)
+		}
+	}
+	return nil
+}
+
+// Permite informa se o comando pode ser executado
+func (l ListaComandos) Permite(nome string) bool {
+	igual := func(outro string) bool { return strings.EqualFold(outro, nome) }
+	switch {
+	case slices.Contains(comandosSempreLiberados, nome):
+		return true
+	case slices.ContainsFunc(l.Negados, igual):
+		return false
+	case len(l.Permitidos) > 0:
+		return slices.ContainsFunc(l.Permitidos, igual)
+	}
+	return true
+}
+
+// ChaveAPI é uma chave aceita pela API REST (cabeçalho X-API-Key) e pelo gRPC (metadado
+// x-api-key), com os comandos liberados a quem a usa
+type ChaveAPI struct {
+	Nome      string `json:"nome"`       // Quem usa a chave, para as mensagens e o log
+	ChaveHash string `json:"chave_hash"` // SHA-256 da chave, em hexadecimal (ex: printf %s "$CHAVE" | sha256sum)
+	ListaComandos
+}
+
+// OpcoesPermissoes é a seção "permissoes" da configuração: quais comandos do prompt esta instalação
+// (ou este perfil, com --profile) pode usar. Serve para montar perfis restritos, como um terminal de
+// estagiário que cadastra e lista mas não remove nem exporta. As listas gerais valem para todos; as
+// de usuarios (pelo usuário do sistema que abre o prompt) e as de cada chave da API só restringem
+// mais. No prompt é uma trava contra engano, não um controle de acesso: quem edita o config.json ou
+// escolhe outro perfil tem todos os comandos. Na API, com alguma chave configurada, toda chamada
+// precisa de uma chave conhecida.
+type OpcoesPermissoes struct {
+	ListaComandos
+	Usuarios  map[string]ListaComandos `json:"usuarios,omitempty"`   // Listas de cada usuário do sistema
+	ChavesAPI []ChaveAPI               `json:"chaves_api,omitempty"` // Chaves da API; vazio = API aberta, só com as listas gerais
+}
+
+// Validar confere os comandos citados em todas as listas e as chaves da API
+func (o OpcoesPermissoes) Validar() error {
+	if err := o.ListaComandos.validar("permissoes"); err != nil {
+		return err
+	}
+	for usuario, lista := range o.Usuarios {
+		if err := lista.validar("permissoes.usuarios." + usuario); err != nil {
+			return err
+		}
+	}
+	nomes := make(map[string]bool)
+	for i, chave := range o.ChavesAPI {
+		if chave.Nome == "" || nomes[chave.Nome] {
+			return fmt.Errorf("permissoes.chaves_api[%d]: nome vazio ou repetido: '%s'", i, chave.Nome)
+		}
+		nomes[chave.Nome] = true
+		if hash, err := hex.DecodeString(chave.ChaveHash); err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("permissoes.chaves_api.%s: chave_hash deve ser o SHA-256 da chave em hexadecimal", chave.Nome)
+		}
+		if err := chave.validar("permissoes.chaves_api." + chave.Nome); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PermiteAoUsuario informa se o comando pode ser executado pelo usuário: precisa passar nas listas
+// gerais e nas do usuário, se houver
+func (o OpcoesPermissoes) PermiteAoUsuario(usuario, nome string) bool {
+	return o.Permite(nome) && o.Usuarios[usuario].Permite(nome)
+}
+
+// BuscarChave devolve a chave da API configurada com o hash da chave informada
+func (o OpcoesPermissoes) BuscarChave(chave string) (ChaveAPI, bool) {
+	hash := sha256.Sum256([]byte(chave))
+	for _, configurada := range o.ChavesAPI {
+		esperado, _ := hex.DecodeString(configurada.ChaveHash)
+		if subtle.ConstantTimeCompare(hash[:], esperado) == 1 {
+			return configurada, true
+		}
+	}
+	return ChaveAPI{}, false
+}
+
+// ComandoPermitido confere a permissão do usuário do prompt na configuração em vigor, que
+// `config reload` pode trocar
+func (c *CadastroCarros) ComandoPermitido(nome string) bool {
+	return c.Config().Permissoes.PermiteAoUsuario(usuarioSistema(), nome)
+}
@@ -0,0 +1,141 @@
+package cars
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"strings"
+)
+
+// RegraPreco define ou ajusta o preço pedido de um carro ao cadastrar ou atualizar.
+// Precificar devolve o novo preço e se ele deve ser aplicado.
+type RegraPreco interface {
+	Nome() string
+	Precificar(carro Carro) (Dinheiro, bool)
+}
+
+// ParametrosPreco são os parâmetros de uma regra configurável de preço
+type ParametrosPreco struct {
+	Markup         float64            `json:"markup,omitempty"`          // Preço = custo × markup, quando o custo é conhecido (0 = não aplica)
+	ArredondarPara float64            `json:"arredondar_para,omitempty"` // Arredonda o preço para o múltiplo mais próximo (0 = não arredonda)
+	Opcionais      map[string]float64 `json:"opcionais,omitempty"`       // Acréscimo em R$ por opcional sobre custo × markup (ex: "teto solar": 8000)
+}
+
+// OpcoesPrecificacao é a seção "precificacao" da configuração: parâmetros gerais e
+// sobreposições por marca, que substituem apenas os campos informados
+type OpcoesPrecificacao struct {
+	ParametrosPreco
+	Marcas map[string]ParametrosPreco `json:"marcas,omitempty"`
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesPrecificacao) Validar() error {
+	todos := map[string]ParametrosPreco{"": o.ParametrosPreco}
+	for marca, p := range o.Marcas {
+		todos[marca] = p
+	}
+	for marca, p := range todos {
+		if p.Markup < 0 || p.ArredondarPara < 0 {
+			if marca == "" {
+				return fmt.Errorf("precificacao: markup e arredondar_para não podem ser negativos")
+			}
+			return fmt.Errorf("precificacao: markup e arredondar_para da marca '%s' não podem ser negativos", marca)
+		}
+		for opcional, acrescimo := range p.Opcionais {
+			if err := validarOpcionais([]string{opcional}); err != nil {
+				return fmt.Errorf("precificacao: %v", err)
+			}
+			if acrescimo < 0 {
+				return fmt.Errorf("precificacao: acréscimo do opcional '%s' não pode ser negativo", opcional)
+			}
+		}
+	}
+	return nil
+}
+
+// regraConfigurada é a regra de preço montada a partir da configuração
+type regraConfigurada struct {
+	opcoes OpcoesPrecificacao
+}
+
+// Nome identifica a regra nas mensagens
+func (r regraConfigurada) Nome() string { return "configuração" }
+
+// parametros devolve os parâmetros gerais com as sobreposições da marca do carro
+func (r regraConfigurada) parametros(marca string) ParametrosPreco {
+	p := r.opcoes.ParametrosPreco
+	for nome, sobreposicao := range r.opcoes.Marcas {
+		if !strings.EqualFold(nome, marca) {
+			continue
+		}
+		if sobreposicao.Markup > 0 {
+			p.Markup = sobreposicao.Markup
+		}
+		if sobreposicao.ArredondarPara > 0 {
+			p.ArredondarPara = sobreposicao.ArredondarPara
+		}
+		if len(sobreposicao.Opcionais) > 0 {
+			p.Opcionais = maps.Clone(p.Opcionais)
+			if p.Opcionais == nil {
+				p.Opcionais = make(map[string]float64)
+			}
+			maps.Copy(p.Opcionais, sobreposicao.Opcionais)
+		}
+	}
+	return p
+}
+
+// Precificar aplica o markup sobre o custo (se houver), somando o acréscimo de cada opcional do
+// carro, e o arredondamento configurado. Sem custo o preço digitado é mantido e os opcionais não
+// entram, para o acréscimo não se acumular a cada atualização.
+func (r regraConfigurada) Precificar(carro Carro) (Dinheiro, bool) {
+	p := r.parametros(carro.Marca)
+	preco := carro.Preco
+	if p.Markup > 0 && carro.Custo > 0 {
+		preco = carro.Custo.Multiplicar(p.Markup)
+		for _, opcional := range carro.Opcionais {
+			preco += Reais(p.Opcionais[opcional])
+		}
+	}
+	if passo := Reais(p.ArredondarPara); passo > 0 {
+		preco = Dinheiro(math.Round(float64(preco)/float64(passo))) * passo
+	}
+	return preco, preco > 0 && preco != carro.Preco
+}
+
+// RegistrarRegraPreco acrescenta uma regra de preço, aplicada depois das já registradas
+func (c *CadastroCarros) RegistrarRegraPreco(regra RegraPreco) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.regrasPreco = append(c.regrasPreco, regra)
+}
+
+// AjustePreco é a troca do preço pedido feita por uma regra ao cadastrar ou alterar um carro
+type AjustePreco struct {
+	Regra string   // Nome da regra que ajustou o preço
+	De    Dinheiro // Preço antes da regra
+	Para  Dinheiro // Preço definido pela regra
+}
+
+// aplicarRegrasPreco passa o carro por todas as regras em ordem e devolve cada ajuste feito
+func (c *CadastroCarros) aplicarRegrasPreco(carro *Carro) []AjustePreco {
+	var ajustes []AjustePreco
+	for _, regra := range c.regrasPreco {
+		preco, aplicar := regra.Precificar(*carro)
+		if !aplicar {
+			continue
+		}
+		ajustes = append(ajustes, AjustePreco{Regra: regra.Nome(), De: carro.Preco, Para: preco})
+		carro.Preco = preco
+	}
+	return ajustes
+}
+
+// AplicarRegrasPreco devolve o carro com o preço que as regras registradas lhe dariam e os
+// ajustes feitos, sem gravar nada
+func (c *CadastroCarros) AplicarRegrasPreco(carro Carro) (Carro, []AjustePreco) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ajustes := c.aplicarRegrasPreco(&carro)
+	return carro, ajustes
+}
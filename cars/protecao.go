@@ -0,0 +1,343 @@
+package cars
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// OpcoesProtecao é a seção "protecao" da configuração: campos sensíveis gravados cifrados no
+// arquivo de dados. Quem grava só precisa da chave pública (que fica na configuração); ler exige a
+// chave privada, entregue apenas a quem pode ver esses campos.
+type OpcoesProtecao struct {
+	Campos              []string `json:"campos,omitempty"`                // Campos cifrados (ver camposProtegiveis)
+	ChavePublica        string   `json:"chave_publica,omitempty"`         // Chave pública em base64 (gerada com `protect keygen`)
+	ArquivoChavePrivada string   `json:"arquivo_chave_privada,omitempty"` // Arquivo com a chave privada; CARROS_CHAVE_PRIVADA tem precedência
+}
+
+// campoProtegivel sabe ler, restaurar e apagar um campo do carro, sempre pela forma JSON do valor
+type campoProtegivel struct {
+	valor  func(c *Carro) interface{}
+	vazio  func(c *Carro) bool
+	limpar func(c *Carro)
+}
+
+// camposProtegiveis são os campos que podem ser cifrados
+var camposProtegiveis = map[string]campoProtegivel{
+	"custo": {
+		valor:  func(c *Carro) interface{} { return &c.Custo },
+		vazio:  func(c *Carro) bool { return c.Custo == 0 },
+		limpar: func(c *Carro) { c.Custo = 0 },
+	},
+	"cambio_compra": {
+		valor:  func(c *Carro) interface{} { return &c.CambioCompra },
+		vazio:  func(c *Carro) bool { return c.CambioCompra == 0 },
+		limpar: func(c *Carro) { c.CambioCompra = 0 },
+	},
+	"moeda": {
+		valor:  func(c *Carro) interface{} { return &c.Moeda },
+		vazio:  func(c *Carro) bool { return c.Moeda == "" },
+		limpar: func(c *Carro) { c.Moeda = "" },
+	},
+	"chassi": {
+		valor:  func(c *Carro) interface{} { return &c.Chassi },
+		vazio:  func(c *Carro) bool { return c.Chassi == "" },
+		limpar: func(c *Carro) { c.Chassi = "" },
+	},
+}
+
+// nomesCamposProtegiveis devolve os campos que podem ser cifrados em ordem alfabética
+func nomesCamposProtegiveis() []string {
+	nomes := make([]string, 0, len(camposProtegiveis))
+	for nome := range camposProtegiveis {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesProtecao) Validar() error {
+	for _, campo := range o.Campos {
+		if _, existe := camposProtegiveis[campo]; !existe {
+			return fmt.Errorf("protecao: campo '%s' não pode ser cifrado (use %s)", campo, strings.Join(nomesCamposProtegiveis(), ", "))
+		}
+	}
+	if len(o.Campos) > 0 && o.ChavePublica == "" {
+		return fmt.Errorf("protecao: campos cifrados exigem chave_publica (gere com `protect keygen`)")
+	}
+	if o.ChavePublica != "" {
+		if _, err := decodificarChave(o.ChavePublica); err != nil {
+			return fmt.Errorf("protecao: chave_publica inválida: %v", err)
+		}
+	}
+	return nil
+}
+
+// decodificarChave lê uma chave Curve25519 em base64
+func decodificarChave(texto string) (*[32]byte, error) {
+	bruto, err := base64.StdEncoding.DecodeString(strings.TrimSpace(texto))
+	if err != nil {
+		return nil, fmt.Errorf("base64 inválido: %v", err)
+	}
+	if len(bruto) != 32 {
+		return nil, fmt.Errorf("esperados 32 bytes, obtidos %d", len(bruto))
+	}
+	var chave [32]byte
+	copy(chave[:], bruto)
+	return &chave, nil
+}
+
+// chavePrivada devolve a chave privada de quem pode ler os campos cifrados (nil se não houver),
+// conferindo se ela corresponde à chave pública configurada
+func (o OpcoesProtecao) chavePrivada() (*[32]byte, error) {
+	texto := os.Getenv("CARROS_CHAVE_PRIVADA")
+	if texto == "" && o.ArquivoChavePrivada != "" {
+		data, err := os.ReadFile(o.ArquivoChavePrivada)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler chave privada: %v", err)
+		}
+		texto = string(data)
+	}
+	if texto == "" {
+		return nil, nil
+	}
+	privada, err := decodificarChave(texto)
+	if err != nil {
+		return nil, fmt.Errorf("chave privada inválida: %v", err)
+	}
+	publica, err := decodificarChave(o.ChavePublica)
+	if err != nil {
+		return nil, fmt.Errorf("chave_publica inválida: %v", err)
+	}
+	derivada, err := curve25519.X25519(privada[:], curve25519.Basepoint)
+	if err != nil || !bytes.Equal(derivada, publica[:]) {
+		return nil, fmt.Errorf("a chave privada não corresponde à chave_publica configurada")
+	}
+	return privada, nil
+}
+
+// cifrador cifra os campos sensíveis de cada versão de carro gravada. O mesmo valor do mesmo carro
+// recebe o mesmo texto cifrado durante uma gravação, para que o carro e o seu último evento
+// continuem iguais no arquivo e a conferência do log na abertura não acuse divergência.
+type cifrador struct {
+	campos  []string
+	publica *[32]byte
+	cache   map[string]string
+}
+
+// proteger devolve uma cópia do carro com os campos sensíveis movidos para Protegido. Campos vazios
+// mantêm o texto cifrado que já existia (carregado por quem não tinha a chave privada).
+func (cf *cifrador) proteger(carro Carro) (Carro, error) {
+	protegido := make(map[string]string, len(cf.campos))
+	for campo, cifrado := range carro.Protegido {
+		protegido[campo] = cifrado
+	}
+	for _, campo := range cf.campos {
+		p := camposProtegiveis[campo]
+		if p.vazio(&carro) {
+			continue
+		}
+		claro, err := json.Marshal(p.valor(&carro))
+		if err != nil {
+			return carro, fmt.Errorf("erro ao cifrar %s de %s: %v", campo, carro.ID, err)
+		}
+		chave := carro.ID + "\x00" + campo + "\x00" + string(claro)
+		cifrado, existe := cf.cache[chave]
+		if !existe {
+			selado, err := box.SealAnonymous(nil, claro, cf.publica, rand.Reader)
+			if err != nil {
+				return carro, fmt.Errorf("erro ao cifrar %s de %s: %v", campo, carro.ID, err)
+			}
+			cifrado = base64.StdEncoding.EncodeToString(selado)
+			cf.cache[chave] = cifrado
+		}
+		protegido[campo] = cifrado
+		p.limpar(&carro)
+	}
+	carro.Protegido = nil
+	if len(protegido) > 0 {
+		carro.Protegido = protegido
+	}
+	return carro, nil
+}
+
+// protegerEventos devolve cópias dos eventos com os campos sensíveis do carro e da venda cifrados
+func (cf *cifrador) protegerEventos(originais []Evento) ([]Evento, error) {
+	eventos := slices.Clone(originais)
+	for i, e := range eventos {
+		if e.Carro != nil {
+			carro, err := cf.proteger(*e.Carro)
+			if err != nil {
+				return nil, err
+			}
+			eventos[i].Carro = &carro
+		}
+		if e.Venda != nil {
+			venda := *e.Venda
+			var err error
+			if venda.Carro, err = cf.proteger(venda.Carro); err != nil {
+				return nil, err
+			}
+			eventos[i].Venda = &venda
+		}
+	}
+	return eventos, nil
+}
+
+// novoCifrador monta o cifrador da configuração ativa (nil se nenhum campo for protegido)
+func (c *CadastroCarros) novoCifrador() (*cifrador, error) {
+	opcoes := c.configAtiva.Protecao
+	if len(opcoes.Campos) == 0 {
+		return nil, nil
+	}
+	publica, err := decodificarChave(opcoes.ChavePublica)
+	if err != nil {
+		return nil, fmt.Errorf("protecao: chave_publica inválida: %v", err)
+	}
+	return &cifrador{campos: opcoes.Campos, publica: publica, cache: make(map[string]string)}, nil
+}
+
+// cifrarParaGravar troca carros, eventos e vendas por cópias com os campos sensíveis cifrados e
+// devolve a função que desfaz a troca depois da gravação (chamador deve segurar o lock)
+func (c *CadastroCarros) cifrarParaGravar() (restaurar func(), err error) {
+	cf, err := c.novoCifrador()
+	if cf == nil || err != nil {
+		return func() {}, err
+	}
+
+	carros := make([]Carro, len(c.carros))
+	for i, carro := range c.carros {
+		if carros[i], err = cf.proteger(carro); err != nil {
+			return nil, err
+		}
+	}
+	vendidos := slices.Clone(c.vendidos)
+	for i := range vendidos {
+		if vendidos[i].Carro, err = cf.proteger(vendidos[i].Carro); err != nil {
+			return nil, err
+		}
+	}
+	eventos, err := cf.protegerEventos(c.eventos)
+	if err != nil {
+		return nil, err
+	}
+
+	carrosOriginais, vendidosOriginais, eventosOriginais := c.carros, c.vendidos, c.eventos
+	c.carros, c.vendidos, c.eventos = carros, vendidos, eventos
+	return func() { c.carros, c.vendidos, c.eventos = carrosOriginais, vendidosOriginais, eventosOriginais }, nil
+}
+
+// revelar decifra os campos de Protegido que a chave privada abre; os demais continuam cifrados
+func revelar(carro *Carro, publica, privada *[32]byte) error {
+	for campo, cifrado := range carro.Protegido {
+		p, conhecido := camposProtegiveis[campo]
+		if !conhecido {
+			continue
+		}
+		selado, err := base64.StdEncoding.DecodeString(cifrado)
+		if err != nil {
+			return fmt.Errorf("%s de %s: base64 inválido", campo, carro.ID)
+		}
+		claro, ok := box.OpenAnonymous(nil, selado, publica, privada)
+		if !ok {
+			return fmt.Errorf("%s de %s não abre com esta chave", campo, carro.ID)
+		}
+		if err := json.Unmarshal(claro, p.valor(carro)); err != nil {
+			return fmt.Errorf("%s de %s: %v", campo, carro.ID, err)
+		}
+		delete(carro.Protegido, campo)
+	}
+	if len(carro.Protegido) == 0 {
+		carro.Protegido = nil
+	}
+	return nil
+}
+
+// revelarCampos decifra, logo depois do carregamento, os campos sensíveis de carros, vendas e
+// eventos, se a chave privada estiver disponível. Sem ela os campos ficam vazios na memória e o
+// texto cifrado é regravado como veio. Devolve os avisos do que não abriu (chamador deve segurar
+// o lock).
+func (c *CadastroCarros) revelarCampos() (avisos []string) {
+	privada, err := c.configAtiva.Protecao.chavePrivada()
+	if err != nil {
+		return []string{fmt.Sprintf("%v. Campos protegidos continuam cifrados.", err)}
+	}
+	if privada == nil {
+		return nil
+	}
+	publica, _ := decodificarChave(c.configAtiva.Protecao.ChavePublica) // Já conferida com a privada
+	falhas := 0
+	abrir := func(carro *Carro) {
+		if len(carro.Protegido) == 0 {
+			return
+		}
+		if err := revelar(carro, publica, privada); err != nil {
+			falhas++
+			if falhas == 1 {
+				avisos = append(avisos, fmt.Sprintf("campo protegido não decifrado: %v", err))
+			}
+		}
+	}
+	for i := range c.carros {
+		abrir(&c.carros[i])
+		c.carrosMap[c.carros[i].ID] = c.carros[i]
+	}
+	for i := range c.vendidos {
+		abrir(&c.vendidos[i].Carro)
+	}
+	for _, e := range c.eventos {
+		if e.Carro != nil {
+			abrir(e.Carro)
+		}
+		if e.Venda != nil {
+			abrir(&e.Venda.Carro)
+		}
+	}
+	if falhas > 1 {
+		avisos = append(avisos, fmt.Sprintf("%d campo(s) protegido(s) não decifrado(s) ao todo.", falhas))
+	}
+	return avisos
+}
+
+// SituacaoProtecao resume a proteção de campos da sessão
+type SituacaoProtecao struct {
+	Campos       []string // Campos cifrados no arquivo
+	ChavePrivada bool     // A sessão tem a chave privada e lê os campos protegidos
+	ErroChave    error    // A chave privada foi informada, mas não pôde ser usada
+	Cifrados     int      // Carros em estoque com campos que a sessão não abriu
+}
+
+// Protecao diz quais campos são cifrados, se esta sessão tem a chave privada e quantos carros
+// ainda têm campos que ela não abriu
+func (c *CadastroCarros) Protecao() SituacaoProtecao {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	s := SituacaoProtecao{Campos: slices.Clone(c.configAtiva.Protecao.Campos)}
+	for _, carro := range c.carros {
+		if len(carro.Protegido) > 0 {
+			s.Cifrados++
+		}
+	}
+	privada, err := c.configAtiva.Protecao.chavePrivada()
+	s.ChavePrivada, s.ErroChave = privada != nil, err
+	return s
+}
+
+// NovasChavesProtecao gera um par de chaves para a proteção de campos, em base64
+func NovasChavesProtecao() (publica, privada string, err error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao gerar chaves: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(pub[:]), base64.StdEncoding.EncodeToString(priv[:]), nil
+}
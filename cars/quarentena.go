@@ -0,0 +1,164 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RegistroQuarentena guarda um registro que não pôde ser carregado, exatamente como estava no arquivo
+type RegistroQuarentena struct {
+	Origem         string          `json:"origem"`          // Arquivo/banco de onde o registro veio
+	Posicao        int             `json:"posicao"`         // Posição do registro na origem (1 = primeiro)
+	Erro           string          `json:"erro"`            // Motivo da rejeição
+	QuarentenadoEm string          `json:"quarentenado_em"` // Instante em que foi separado (RFC 3339)
+	Bruto          json.RawMessage `json:"bruto"`           // Conteúdo original do registro
+}
+
+// acolherRegistro desserializa e valida um registro bruto, incluindo-o no banco em memória se estiver íntegro
+func (c *CadastroCarros) acolherRegistro(bruto json.RawMessage) error {
+	var carro Carro
+	if err := json.Unmarshal(bruto, &carro); err != nil {
+		return fmt.Errorf("JSON inválido: %v", err)
+	}
+	if carro.ID == "" {
+		return fmt.Errorf("ID ausente")
+	}
+	if _, existe := c.carrosMap[carro.ID]; existe {
+		return fmt.Errorf("ID duplicado '%s'", carro.ID)
+	}
+	// Um registro com o ID de um carro removido não pode ressuscitá-lo silenciosamente
+	if l, removido := c.lapide(carro.ID); removido {
+		return fmt.Errorf("ID '%s' pertence a um carro removido em %s (use 'purge' para liberá-lo)", carro.ID, l.RemovidoEm)
+	}
+	if err := ValidarCarro(carro); err != nil {
+		return err
+	}
+	c.carrosMap[carro.ID] = carro
+	c.carros = append(c.carros, carro)
+	return nil
+}
+
+// quarentenar separa um registro rejeitado para correção posterior com `repair`
+func (c *CadastroCarros) quarentenar(origem string, posicao int, bruto json.RawMessage, motivo error) {
+	c.quarentena = append(c.quarentena, RegistroQuarentena{
+		Origem:         origem,
+		Posicao:        posicao,
+		Erro:           motivo.Error(),
+		QuarentenadoEm: time.Now().UTC().Format(time.RFC3339Nano),
+		Bruto:          append(json.RawMessage(nil), bruto...),
+	})
+	c.log.Warn("registro inválido movido para a quarentena", "origem", origem, "posicao", posicao, "erro", motivo)
+}
+
+// Quarentena devolve os registros em quarentena, na ordem em que foram separados
+func (c *CadastroCarros) Quarentena() []RegistroQuarentena {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.quarentena)
+}
+
+// QuarentenadosAoAbrir devolve os registros que o carregamento dos dados separou para a quarentena
+func (c *CadastroCarros) QuarentenadosAoAbrir() []RegistroQuarentena {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.quarentena[len(c.quarentena)-min(c.quarentenados, len(c.quarentena)):])
+}
+
+// Recuperado devolve o que for possível ler do registro como carro, para ser corrigido
+func (r RegistroQuarentena) Recuperado() Carro {
+	return interpretarRegistroTolerante(r.Bruto)
+}
+
+// retirarDaQuarentena tira o registro da quarentena (chamador deve segurar o lock)
+func (c *CadastroCarros) retirarDaQuarentena(r RegistroQuarentena) error {
+	i := slices.IndexFunc(c.quarentena, func(q RegistroQuarentena) bool {
+		return q.Origem == r.Origem && q.Posicao == r.Posicao && q.QuarentenadoEm == r.QuarentenadoEm
+	})
+	if i < 0 {
+		return fmt.Errorf("o registro %d de %s não está mais na quarentena", r.Posicao, r.Origem)
+	}
+	c.quarentena = slices.Delete(c.quarentena, i, i+1)
+	return nil
+}
+
+// RecuperarDaQuarentena cadastra o carro corrigido a partir do registro em quarentena e tira o
+// registro de lá. Se o carro ainda for inválido, nada muda. Sem ID, ou com um ID já em uso, o
+// carro recebe um novo.
+func (c *CadastroCarros) RecuperarDaQuarentena(r RegistroQuarentena, carro Carro) (Carro, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := ValidarCarro(carro)
+	if err == nil {
+		err = c.validarRegistrados(carro)
+	}
+	if err != nil {
+		return Carro{}, err
+	}
+	if err := c.retirarDaQuarentena(r); err != nil {
+		return Carro{}, err
+	}
+	if carro.ID == "" || c.idEmUso(carro.ID) {
+		carro.ID = c.novoID()
+	}
+	if _, err := time.Parse("2006-01-02", carro.DataCadastro); err != nil {
+		carro.DataCadastro = time.Now().Format("2006-01-02")
+	}
+	carro.AtualizadoEm = time.Now().UTC().Format(time.RFC3339Nano)
+	c.emitir(Evento{Tipo: EventoCarroAdicionado, CarroID: carro.ID, Carro: &carro})
+	return carro, c.gravar()
+}
+
+// DescartarDaQuarentena apaga o registro da quarentena de vez
+func (c *CadastroCarros) DescartarDaQuarentena(r RegistroQuarentena) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.retirarDaQuarentena(r); err != nil {
+		return err
+	}
+	return c.gravar()
+}
+
+// interpretarRegistroTolerante recupera o que for possível de um registro malformado,
+// aceitando por exemplo números gravados como texto ("2021", "145000.50")
+func interpretarRegistroTolerante(bruto json.RawMessage) Carro {
+	var campos map[string]interface{}
+	if err := json.Unmarshal(bruto, &campos); err != nil {
+		return Carro{}
+	}
+
+	texto := func(chave string) string {
+		switch v := campos[chave].(type) {
+		case string:
+			return strings.TrimSpace(v)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return ""
+	}
+	numero := func(chave string) float64 {
+		switch v := campos[chave].(type) {
+		case float64:
+			return v
+		case string:
+			n, _ := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return n
+		}
+		return 0
+	}
+
+	return Carro{
+		ID:           texto("id"),
+		Marca:        texto("marca"),
+		Modelo:       texto("modelo"),
+		Ano:          int(numero("ano")),
+		Cor:          texto("cor"),
+		Preco:        Reais(numero("preco")),
+		Custo:        Reais(numero("custo")),
+		PaisOrigem:   texto("pais_origem"),
+		DataCadastro: texto("data_cadastro"),
+	}
+}
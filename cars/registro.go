@@ -0,0 +1,117 @@
+package cars
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// nivelDesligado fica acima de qualquer nível usado, para `--log-level=off`
+const nivelDesligado = slog.Level(100)
+
+// niveisLog são os nomes aceitos por --log-level e CARROS_LOG_LEVEL
+var niveisLog = map[string]slog.Level{
+	"debug": slog.LevelDebug, "info": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError, "off": nivelDesligado,
+}
+
+// registroDescartado é o logger de quem embute o cadastro sem configurar um (e dos testes)
+func registroDescartado() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// NovoRegistro monta o logger estruturado da execução, gravado em w (stderr no programa), a partir
+// do nível (debug, info, warn, error ou off; padrão warn) e do formato (text ou json; padrão text).
+// É o que a ferramenta rodando sem ninguém no terminal deixa para agregação de logs; a saída do
+// prompt continua a mesma.
+func NovoRegistro(w io.Writer, nivel, formato string) (*slog.Logger, error) {
+	if nivel == "" {
+		nivel = "warn"
+	}
+	n, existe := niveisLog[strings.ToLower(nivel)]
+	if !existe {
+		return nil, fmt.Errorf("nível de log desconhecido '%s' (use debug, info, warn, error ou off)", nivel)
+	}
+	opcoes := &slog.HandlerOptions{Level: n}
+	switch strings.ToLower(formato) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(w, opcoes)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opcoes)), nil
+	}
+	return nil, fmt.Errorf("formato de log desconhecido '%s' (use text ou json)", formato)
+}
+
+// RegistroDoAmbiente completa o nível e o formato vindos da linha de comando com CARROS_LOG_LEVEL
+// e CARROS_LOG_FORMAT e monta o logger em stderr
+func RegistroDoAmbiente(nivel, formato string) (*slog.Logger, error) {
+	if nivel == "" {
+		nivel = os.Getenv("CARROS_LOG_LEVEL")
+	}
+	if formato == "" {
+		formato = os.Getenv("CARROS_LOG_FORMAT")
+	}
+	return NovoRegistro(os.Stderr, nivel, formato)
+}
+
+// Registro devolve o logger estruturado do cadastro
+func (c *CadastroCarros) Registro() *slog.Logger {
+	return c.log
+}
+
+// UsarRegistro troca o logger estruturado do cadastro (nil descarta o log)
+func (c *CadastroCarros) UsarRegistro(log *slog.Logger) {
+	if log == nil {
+		log = registroDescartado()
+	}
+	c.log = log
+}
+
+// textoDoRegistro tira do aviso o emoji e o prefixo que só fazem sentido no terminal
+func textoDoRegistro(mensagem string) string {
+	for _, prefixo := range []string{"⚠️  Aviso: ", "⚠️  ", "✅ ", "❌ ", "🌐 "} {
+		mensagem = strings.TrimPrefix(mensagem, prefixo)
+	}
+	return mensagem
+}
+
+// respostaRegistrada guarda o status da resposta para o log da requisição; repassa o Flush,
+// de que o /audit/stream precisa
+type respostaRegistrada struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *respostaRegistrada) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *respostaRegistrada) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *respostaRegistrada) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// registrarRequisicoes registra cada requisição da API com método, caminho, status, duração e cliente
+func (c *CadastroCarros) registrarRequisicoes(proximo http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inicio := time.Now()
+		resposta := &respostaRegistrada{ResponseWriter: w, status: http.StatusOK}
+		proximo.ServeHTTP(resposta, r)
+		nivel := slog.LevelInfo
+		if resposta.status >= http.StatusInternalServerError {
+			nivel = slog.LevelError
+		}
+		c.log.Log(r.Context(), nivel, "requisição da API",
+			"metodo", r.Method, "caminho", r.URL.Path, "status", resposta.status,
+			"duracao", time.Since(inicio), "cliente", r.RemoteAddr)
+	})
+}
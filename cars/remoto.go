@@ -0,0 +1,282 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// motivoRecusa classifica as recusas das operações remotas; cada protocolo (REST, gRPC) traduz o
+// motivo para o seu código de resposta
+type motivoRecusa int
+
+const (
+	recusaInvalida       motivoRecusa = iota // Dados que não passam nas validações
+	recusaNaoEncontrado                      // ID fora do estoque
+	recusaConflito                           // Edição sobre uma versão desatualizada
+	recusaConfirmacao                        // Carro de alto valor sem o modelo repetido
+	recusaProibida                           // Comando não liberado ou réplica somente leitura
+	recusaNaoAutenticada                     // Chave da API ausente ou desconhecida
+)
+
+// recusaRemota é uma operação da API REST ou do gRPC recusada pelas regras do cadastro
+type recusaRemota struct {
+	motivo   motivoRecusa
+	mensagem string
+}
+
+func (r *recusaRemota) Error() string { return r.mensagem }
+
+// recusar monta uma recusaRemota com a mensagem formatada
+func recusar(motivo motivoRecusa, formato string, args ...interface{}) error {
+	return &recusaRemota{motivo: motivo, mensagem: fmt.Sprintf(formato, args...)}
+}
+
+// motivoDaRecusa devolve o motivo de uma recusaRemota; ok é false para outros erros
+func motivoDaRecusa(err error) (motivo motivoRecusa, ok bool) {
+	var recusa *recusaRemota
+	if !errors.As(err, &recusa) {
+		return 0, false
+	}
+	return recusa.motivo, true
+}
+
+// clienteRemoto identifica quem chama uma operação remota
+type clienteRemoto struct {
+	canal         string // Nome nas notificações e no log ("API", "API gRPC")
+	autor         string // Autor gravado nos eventos (ex: api:127.0.0.1:50292)
+	endereco      string // Endereço do cliente, para o log
+	confirmacao   string // Modelo repetido para confirmar operações de alto valor
+	comoConfirmar string // Onde o cliente repete o modelo, para a mensagem de recusa
+}
+
+// conferirAcessoRemoto confere a chave da API do cliente, obrigatória se a seção permissoes tiver
+// chaves, aplica as listas gerais e as da chave ao comando do prompt equivalente (vazio = nenhum)
+// e recusa alterações em uma réplica
+func (c *CadastroCarros) conferirAcessoRemoto(chave, comando string, altera bool) error {
+	permissoes := c.Config().Permissoes
+	if len(permissoes.ChavesAPI) > 0 {
+		if chave == "" {
+			return recusar(recusaNaoAutenticada, "informe a chave da API (seção permissoes.chaves_api)")
+		}
+		configurada, existe := permissoes.BuscarChave(chave)
+		if !existe {
+			return recusar(recusaNaoAutenticada, "chave da API não reconhecida")
+		}
+		if comando != "" && !configurada.Permite(comando) {
+			return recusar(recusaProibida, "o comando '%s' não está liberado para a chave '%s' (seção permissoes)", comando, configurada.Nome)
+		}
+	}
+	if comando != "" && !permissoes.Permite(comando) {
+		return recusar(recusaProibida, "o comando '%s' não está liberado nesta configuração (seção permissoes)", comando)
+	}
+	if altera && c.SomenteLeitura() {
+		return recusar(recusaProibida, "esta instância é uma réplica somente leitura; envie alterações ao primário")
+	}
+	return nil
+}
+
+// cadastrarRemoto cadastra um carro recebido por uma API. ID, datas e campos cifrados são definidos
+// pelo servidor; violações de conformidade recusam o cadastro, já que a exceção exige uma
+// justificativa no prompt.
+func (c *CadastroCarros) cadastrarRemoto(carro Carro, cliente clienteRemoto) (Carro, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.comoAutor(cliente.autor)()
+	carro, err := c.conferirCadastroRemoto(carro)
+	if err != nil {
+		return carro, err
+	}
+	carro, _, err = c.cadastrarCarro(carro, nil)
+	if err != nil {
+		c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: Falha ao salvar dados após cadastro pela %s: %v", cliente.canal, err),
+			"falha ao salvar cadastro feito pela "+cliente.canal, "id", carro.ID, "erro", err)
+	}
+	c.notificarEstruturado(false, fmt.Sprintf("🌐 %s: carro '%s %s' cadastrado com ID %s", cliente.canal, carro.Marca, carro.Modelo, carro.ID),
+		"carro cadastrado pela "+cliente.canal, "id", carro.ID, "cliente", cliente.endereco)
+	return carro, nil
+}
+
+// conferirCadastroRemoto limpa os campos definidos pelo servidor e confere o carro a cadastrar, sem
+// cadastrá-lo (chamador deve segurar o lock)
+func (c *CadastroCarros) conferirCadastroRemoto(carro Carro) (Carro, error) {
+	carro.ID, carro.DataCadastro, carro.AtualizadoEm, carro.StatusDesde, carro.Protegido = "", "", "", "", nil
+	carro.Chassi = NormalizarChassi(carro.Chassi)
+	if err := ValidarCarro(carro); err != nil {
+		return carro, recusar(recusaInvalida, "%v", err)
+	}
+	if err := c.validarRegistrados(carro); err != nil {
+		return carro, recusar(recusaInvalida, "%v", err)
+	}
+	if violacoes, _ := c.configAtiva.Conformidade.Avaliar(carro, time.Now().Year()); len(violacoes) > 0 {
+		return carro, recusar(recusaInvalida, "conformidade: %s (cadastre pelo prompt para registrar uma exceção justificada)",
+			strings.Join(violacoes, "; "))
+	}
+	return carro, nil
+}
+
+// atualizarRemoto substitui o carro pela versão recebida por uma API. Campos de sistema omitidos são
+// mantidos; um atualizado_em informado precisa ser o atual, para que uma edição não sobrescreva outra.
+func (c *CadastroCarros) atualizarRemoto(id string, editado Carro, cliente clienteRemoto) (Carro, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.comoAutor(cliente.autor)()
+	editado, err := c.conferirEdicaoRemota(id, editado, cliente)
+	if err != nil {
+		return editado, err
+	}
+	editado, _, err = c.regravarCarro(editado)
+	if err != nil {
+		c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: Falha ao salvar dados após edição pela %s: %v", cliente.canal, err),
+			"falha ao salvar edição feita pela "+cliente.canal, "id", id, "erro", err)
+	}
+	c.notificarEstruturado(false, fmt.Sprintf("🌐 %s: carro com ID %s atualizado", cliente.canal, id),
+		"carro atualizado pela "+cliente.canal, "id", id, "cliente", cliente.endereco)
+	return editado, nil
+}
+
+// conferirEdicaoRemota completa a versão recebida com os campos de sistema do carro e a confere, sem
+// gravá-la (chamador deve segurar o lock)
+func (c *CadastroCarros) conferirEdicaoRemota(id string, editado Carro, cliente clienteRemoto) (Carro, error) {
+	original, existe := c.carrosMap[id]
+	if !existe {
+		return editado, recusar(recusaNaoEncontrado, "carro com ID '%s' não encontrado", id)
+	}
+	if editado.AtualizadoEm != "" && editado.AtualizadoEm != original.AtualizadoEm {
+		return editado, recusar(recusaConflito, "o carro foi alterado em %s; busque a versão atual antes de gravar", original.AtualizadoEm)
+	}
+	if editado.ID == "" {
+		editado.ID = id
+	}
+	if editado.DataCadastro == "" {
+		editado.DataCadastro = original.DataCadastro
+	}
+	if editado.Status == "" && editado.StatusDesde == "" {
+		editado.Status, editado.StatusDesde = original.Status, original.StatusDesde
+	}
+	if editado.Protegido == nil {
+		editado.Protegido = original.Protegido
+	}
+	editado.AtualizadoEm = original.AtualizadoEm
+	editado.Chassi = NormalizarChassi(editado.Chassi)
+	if err := ConferirEdicao(original, editado); err != nil {
+		return editado, recusar(recusaInvalida, "%v", err)
+	}
+	if err := c.validarRegistrados(editado); err != nil {
+		return editado, recusar(recusaInvalida, "%v", err)
+	}
+	if editado.Preco != original.Preco {
+		if err := c.altoValorConfirmado(cliente, original, editado.Preco); err != nil {
+			return editado, err
+		}
+	}
+	return editado, nil
+}
+
+// registroLote é o resultado de um carro de gravarLoteRemoto
+type registroLote struct {
+	carro Carro
+	cria  bool  // Cadastro de um carro novo (false = atualização de um existente)
+	err   error // Recusa do registro (nil se ele passou nas conferências)
+}
+
+// gravarLoteRemoto cadastra ou atualiza os carros recebidos por uma API, numa só gravação: cada
+// registro atualiza o carro do mesmo ID ou, sem ID, o do mesmo chassi; os demais são cadastrados.
+// Todos os registros são conferidos antes de qualquer alteração e, se algum for recusado, nenhum é
+// aplicado (aplicado = false).
+func (c *CadastroCarros) gravarLoteRemoto(carros []Carro, cliente clienteRemoto) (registros []registroLote, aplicado bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.comoAutor(cliente.autor)()
+
+	porChassi := make(map[string]string)
+	for _, carro := range c.carros {
+		if carro.Chassi != "" {
+			porChassi[carro.Chassi] = carro.ID
+		}
+	}
+	vistos := make(map[string]int) // ID ou chassi → posição do registro que já o usou
+	recusados := 0
+	for i, carro := range carros {
+		id, chassi := carro.ID, NormalizarChassi(carro.Chassi)
+		if id == "" && chassi != "" {
+			id = porChassi[chassi]
+		}
+		chave := id
+		if chave == "" && chassi != "" {
+			chave = "chassi:" + chassi
+		}
+		r := registroLote{cria: id == ""}
+		if anterior, repetido := vistos[chave]; chave != "" && repetido {
+			r.carro, r.err = carro, recusar(recusaInvalida, "o registro %d já grava este carro", anterior)
+		} else if r.cria {
+			r.carro, r.err = c.conferirCadastroRemoto(carro)
+		} else {
+			r.carro, r.err = c.conferirEdicaoRemota(id, carro, cliente)
+		}
+		if chave != "" {
+			vistos[chave] = i
+		}
+		if r.err != nil {
+			recusados++
+		}
+		registros = append(registros, r)
+	}
+	if recusados > 0 {
+		return registros, false
+	}
+
+	criados := 0
+	for i, r := range registros {
+		if r.cria {
+			registros[i].carro, _ = c.incluirCarro(r.carro, nil)
+			criados++
+		} else {
+			registros[i].carro, _ = c.aplicarEdicao(r.carro)
+		}
+	}
+
+	// Persistir uma vez, após o lote inteiro
+	if err := c.salvar(); err != nil {
+		c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: Falha ao salvar dados após lote pela %s: %v", cliente.canal, err),
+			"falha ao salvar lote feito pela "+cliente.canal, "erro", err)
+	}
+	c.notificarEstruturado(false, fmt.Sprintf("🌐 %s: lote gravado (%d carro(s) cadastrado(s), %d atualizado(s))", cliente.canal, criados, len(registros)-criados),
+		"lote gravado pela "+cliente.canal, "cadastrados", criados, "atualizados", len(registros)-criados, "cliente", cliente.endereco)
+	return registros, true
+}
+
+// removerRemoto retira o carro do estoque a pedido de uma API, como o comando remove
+func (c *CadastroCarros) removerRemoto(id string, cliente clienteRemoto) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.comoAutor(cliente.autor)()
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return recusar(recusaNaoEncontrado, "carro com ID '%s' não encontrado", id)
+	}
+	if err := c.altoValorConfirmado(cliente, carro); err != nil {
+		return err
+	}
+	c.retirarCarro(id)
+
+	// Persistir após remover
+	if err := c.salvar(); err != nil {
+		c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: Falha ao salvar dados após remoção pela %s: %v", cliente.canal, err),
+			"falha ao salvar remoção feita pela "+cliente.canal, "id", id, "erro", err)
+	}
+	c.notificarEstruturado(false, fmt.Sprintf("🌐 %s: carro com ID %s removido", cliente.canal, id),
+		"carro removido pela "+cliente.canal, "id", id, "cliente", cliente.endereco)
+	return nil
+}
+
+// altoValorConfirmado exige, para carros de alto valor, o modelo repetido pelo cliente, o
+// equivalente remoto a digitar o modelo no prompt (chamador deve segurar o lock)
+func (c *CadastroCarros) altoValorConfirmado(cliente clienteRemoto, carro Carro, precos ...Dinheiro) error {
+	if !c.configAtiva.AltoValor.Exige(append(precos, carro.Preco)...) || ConfirmaModelo(carro, cliente.confirmacao) {
+		return nil
+	}
+	return recusar(recusaConfirmacao, "'%s %s' é de alto valor; repita o modelo %s para confirmar",
+		carro.Marca, carro.Modelo, cliente.comoConfirmar)
+}
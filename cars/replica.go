@@ -0,0 +1,359 @@
+package cars
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// replicaPrimario é o acompanhamento de uma instância primária: os eventos dela chegam pelo
+// /audit/stream e são aplicados aqui na mesma ordem, com o mesmo Seq
+type replicaPrimario struct {
+	URL           string
+	cancelar      context.CancelFunc
+	fim           chan struct{}
+	conectado     bool
+	erro          string    // Última falha de conexão, mostrada por `replica status`
+	recebidos     int       // Eventos aplicados desde o início do acompanhamento
+	ultimoContato time.Time // Último evento ou pulso recebido do primário
+}
+
+// errLacunaReplica indica que o primário está à frente do que chegou pelo stream (o assinante
+// perdeu eventos); a reconexão com Last-Event-ID recupera o que faltou
+var errLacunaReplica = errors.New("eventos perdidos no stream")
+
+// errLogDivergente indica que o log local não é um trecho do log do primário: aplicar os eventos
+// dele por cima misturaria dois históricos
+var errLogDivergente = errors.New("o log local divergiu do primário")
+
+// SomenteLeitura informa se a instância segue um primário e por isso recusa alterações
+func (c *CadastroCarros) SomenteLeitura() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.replica != nil
+}
+
+// SeguirPrimario passa a acompanhar o primário em segundo plano. Até `replica promote`, o
+// cadastro só recebe os eventos dele e recusa alterações do prompt e da API. Recusa seguir se o
+// log local não estiver vazio nem terminar num evento que o primário também tem.
+func (c *CadastroCarros) SeguirPrimario(endereco string) error {
+	c.mu.RLock()
+	atual := c.replica
+	c.mu.RUnlock()
+	if atual != nil {
+		return errJaSegue(atual.URL)
+	}
+	endereco = strings.TrimSuffix(endereco, "/")
+	conferencia, cancelarConferencia := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelarConferencia()
+	if err := c.conferirHistorico(conferencia, endereco); err != nil {
+		if errors.Is(err, errLogDivergente) {
+			return fmt.Errorf("%w. Comece de um arquivo vazio ou de uma cópia do primário", err)
+		}
+		return fmt.Errorf("não foi possível conferir o log com o primário %s: %v", endereco, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.replica != nil {
+		return errJaSegue(c.replica.URL)
+	}
+	ctx, cancelar := context.WithCancel(context.Background())
+	r := &replicaPrimario{URL: endereco, cancelar: cancelar, fim: make(chan struct{})}
+	c.replica = r
+	go c.acompanharPrimario(ctx, r)
+	c.log.Info("seguindo primário", "primario", r.URL, "seq", c.ultimoSeq())
+	return nil
+}
+
+// errJaSegue é a recusa de seguir um segundo primário
+func errJaSegue(url string) error {
+	return fmt.Errorf("esta instância já segue %s. Use 'replica promote' antes de seguir outro primário", url)
+}
+
+// conferirHistorico confere se o log local é um trecho do log do primário: vazio, ou com o último
+// evento igual ao evento de mesmo Seq lá (tipo, carro e instante). Só então os eventos seguintes
+// do primário podem ser acrescentados aqui.
+func (c *CadastroCarros) conferirHistorico(ctx context.Context, endereco string) error {
+	c.mu.RLock()
+	var ultimo Evento
+	if n := len(c.eventos); n > 0 {
+		ultimo = c.eventos[n-1]
+	}
+	c.mu.RUnlock()
+	if ultimo.Seq == 0 {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/audit/events/%d", endereco, ultimo.Seq), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: o evento #%d, o último daqui, não existe lá", errLogDivergente, ultimo.Seq)
+	default:
+		return fmt.Errorf("o primário respondeu %s", resp.Status)
+	}
+	var noPrimario Evento
+	if err := json.NewDecoder(resp.Body).Decode(&noPrimario); err != nil {
+		return fmt.Errorf("evento inválido do primário: %v", err)
+	}
+	if noPrimario.Seq != ultimo.Seq || noPrimario.Tipo != ultimo.Tipo || noPrimario.CarroID != ultimo.CarroID || noPrimario.Em != ultimo.Em {
+		return fmt.Errorf("%w: o evento #%d daqui (%s %s) não é o de lá (%s %s)",
+			errLogDivergente, ultimo.Seq, ultimo.Tipo, ultimo.CarroID, noPrimario.Tipo, noPrimario.CarroID)
+	}
+	return nil
+}
+
+// SituacaoReplica é o acompanhamento de um primário visto de fora: a conexão e até onde o log chegou
+type SituacaoReplica struct {
+	Primario      string
+	Conectado     bool
+	Erro          string // Última falha de conexão
+	UltimoSeq     int64
+	Carros        int       // Carros em estoque
+	Recebidos     int       // Eventos aplicados desde o início do acompanhamento
+	UltimoContato time.Time // Zero se o primário ainda não respondeu
+}
+
+// situacaoReplica descreve o acompanhamento (chamador deve segurar o lock)
+func (c *CadastroCarros) situacaoReplica(r *replicaPrimario) SituacaoReplica {
+	return SituacaoReplica{
+		Primario: r.URL, Conectado: r.conectado, Erro: r.erro, UltimoSeq: c.ultimoSeq(), Carros: len(c.carros),
+		Recebidos: r.recebidos, UltimoContato: r.ultimoContato,
+	}
+}
+
+// Replica devolve o acompanhamento do primário; ok é false se a instância não segue nenhum
+func (c *CadastroCarros) Replica() (situacao SituacaoReplica, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.replica == nil {
+		return SituacaoReplica{}, false
+	}
+	return c.situacaoReplica(c.replica), true
+}
+
+// PromoverReplica deixa de seguir o primário e volta a aceitar alterações (ex: o primário caiu e
+// esta instância assume). As alterações feitas aqui depois disso não chegam ao primário. Devolve
+// como o acompanhamento terminou; ok é false se a instância não seguia nenhum primário.
+func (c *CadastroCarros) PromoverReplica() (situacao SituacaoReplica, ok bool) {
+	c.mu.Lock()
+	r := c.replica
+	c.mu.Unlock()
+	if r == nil {
+		return SituacaoReplica{}, false
+	}
+	r.cancelar()
+	<-r.fim
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replica = nil
+	c.log.Info("réplica promovida", "primario", r.URL, "seq", c.ultimoSeq(), "recebidos", r.recebidos)
+	return c.situacaoReplica(r), true
+}
+
+// acompanharPrimario mantém a conexão com o primário até o cancelamento, reconectando com
+// espera crescente (até 30s) quando ela cai
+func (c *CadastroCarros) acompanharPrimario(ctx context.Context, r *replicaPrimario) {
+	defer close(r.fim)
+	espera := time.Second
+	for {
+		conectou, err := c.lerStreamPrimario(ctx, r)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, errLacunaReplica) {
+			c.log.Info("eventos perdidos no stream do primário; reconectando", "primario", r.URL)
+			continue
+		}
+		if errors.Is(err, errLogDivergente) {
+			// O primário mudou de histórico (ex: restaurado de um backup): seguir misturaria os logs
+			c.mu.Lock()
+			r.conectado, r.erro = false, err.Error()
+			c.mu.Unlock()
+			c.notificarEstruturado(true, fmt.Sprintf("❌ Acompanhamento de %s interrompido: %v. Use 'replica promote' e comece de uma cópia do primário.", r.URL, err),
+				"acompanhamento do primário interrompido", "primario", r.URL, "erro", err)
+			return
+		}
+		c.mu.Lock()
+		estava := r.conectado
+		r.conectado, r.erro = false, err.Error()
+		c.mu.Unlock()
+		if estava {
+			c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: conexão com o primário %s perdida: %v. Tentando de novo.", r.URL, err),
+				"conexão com o primário perdida", "primario", r.URL, "erro", err)
+		}
+		if conectou {
+			espera = time.Second
+		}
+		c.log.Debug("nova tentativa de conexão com o primário", "primario", r.URL, "espera", espera, "erro", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(espera):
+		}
+		espera = min(espera*2, 30*time.Second)
+	}
+}
+
+// mensagemPrimario é um evento ou um pulso (último Seq do primário) lido do stream
+type mensagemPrimario struct {
+	evento *Evento
+	pulso  int64
+}
+
+// lerStreamPrimario confere o log local com o do primário, assina o /audit/stream dele a partir
+// do último evento local e aplica o que chegar, em lotes, até a conexão cair. Devolve se chegou a conectar.
+func (c *CadastroCarros) lerStreamPrimario(ctx context.Context, r *replicaPrimario) (bool, error) {
+	// A cada conexão, porque o primário pode ter mudado enquanto ela estava caída
+	if err := c.conferirHistorico(ctx, r.URL); err != nil {
+		return false, err
+	}
+	c.mu.RLock()
+	ultimo := c.ultimoSeq()
+	c.mu.RUnlock()
+
+	ctx, cancelar := context.WithCancel(ctx)
+	defer cancelar()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL+"/audit/stream", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", strconv.FormatInt(ultimo, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("o primário respondeu %s", resp.Status)
+	}
+
+	c.mu.Lock()
+	reconectou := r.erro != ""
+	r.conectado, r.erro, r.ultimoContato = true, "", time.Now()
+	c.mu.Unlock()
+	if reconectou {
+		c.notificarEstruturado(false, fmt.Sprintf("✅ Conexão com o primário %s restabelecida.", r.URL), "conexão com o primário restabelecida", "primario", r.URL)
+	}
+
+	mensagens := make(chan mensagemPrimario, 1024)
+	falha := make(chan error, 1)
+	go func() {
+		defer close(mensagens)
+		falha <- lerEventosSSE(resp, mensagens)
+	}()
+	for m := range mensagens {
+		// O que já chegou entra no mesmo lote, para a sincronização inicial não gravar a cada evento
+		lote := []mensagemPrimario{m}
+	drenar:
+		for len(lote) < cap(mensagens) {
+			select {
+			case outra, ok := <-mensagens:
+				if !ok {
+					break drenar
+				}
+				lote = append(lote, outra)
+			default:
+				break drenar
+			}
+		}
+		if err := c.aplicarDoPrimario(r, lote); err != nil {
+			cancelar()
+			for range mensagens {
+			}
+			return true, err
+		}
+	}
+	return true, <-falha
+}
+
+// lerEventosSSE separa as mensagens do stream: eventos (data: com o JSON) e o pulso periódico do
+// primário (": pulso <seq>")
+func lerEventosSSE(resp *http.Response, mensagens chan<- mensagemPrimario) error {
+	leitor := bufio.NewScanner(resp.Body)
+	leitor.Buffer(make([]byte, 64*1024), 4<<20)
+	var dados strings.Builder
+	for leitor.Scan() {
+		linha := leitor.Text()
+		switch {
+		case linha == "" && dados.Len() > 0:
+			var e Evento
+			if err := json.Unmarshal([]byte(dados.String()), &e); err != nil {
+				return fmt.Errorf("evento inválido no stream: %v", err)
+			}
+			mensagens <- mensagemPrimario{evento: &e}
+			dados.Reset()
+		case strings.HasPrefix(linha, "data:"):
+			dados.WriteString(strings.TrimPrefix(strings.TrimPrefix(linha, "data:"), " "))
+		case strings.HasPrefix(linha, ": pulso "):
+			if seq, err := strconv.ParseInt(strings.TrimPrefix(linha, ": pulso "), 10, 64); err == nil {
+				mensagens <- mensagemPrimario{pulso: seq}
+			}
+		}
+	}
+	if err := leitor.Err(); err != nil {
+		return err
+	}
+	return errors.New("stream encerrado pelo primário")
+}
+
+// aplicarDoPrimario acrescenta ao log os eventos do lote que ainda não estão nele, com o Seq do
+// primário, e grava. Um pulso à frente do log local indica eventos perdidos.
+func (c *CadastroCarros) aplicarDoPrimario(r *replicaPrimario, lote []mensagemPrimario) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r.ultimoContato = time.Now()
+
+	var novos []Evento
+	for _, m := range lote {
+		ultimo := c.ultimoSeq()
+		if n := len(novos); n > 0 {
+			ultimo = novos[n-1].Seq
+		}
+		switch {
+		case m.evento != nil && m.evento.Seq > ultimo:
+			novos = append(novos, *m.evento)
+		case m.evento == nil && m.pulso > ultimo:
+			c.acrescentarDoPrimario(r, novos)
+			return errLacunaReplica
+		}
+	}
+	c.acrescentarDoPrimario(r, novos)
+	return nil
+}
+
+// acrescentarDoPrimario aplica e persiste eventos já numerados pelo primário (chamador deve
+// segurar o lock)
+func (c *CadastroCarros) acrescentarDoPrimario(r *replicaPrimario, eventos []Evento) {
+	if len(eventos) == 0 {
+		return
+	}
+	c.eventos = append(c.eventos, eventos...)
+	c.aplicarEventos(eventos)
+	c.assinaturas.publicar(eventos)
+	r.recebidos += len(eventos)
+	c.log.Debug("eventos recebidos do primário", "primario", r.URL, "eventos", len(eventos), "seq", eventos[len(eventos)-1].Seq)
+
+	// Persistir após receber eventos do primário
+	if err := c.salvar(); err != nil {
+		c.notificarEstruturado(true, fmt.Sprintf("⚠️  Aviso: Falha ao salvar dados recebidos do primário: %v", err),
+			"falha ao salvar eventos recebidos do primário", "primario", r.URL, "eventos", len(eventos), "erro", err)
+	}
+}
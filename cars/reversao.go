@@ -0,0 +1,193 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SituacaoCarro é como um carro aparece em uma projeção do log
+type SituacaoCarro struct {
+	carro    *Carro // Em estoque
+	venda    *Venda // Vendido
+	removido bool   // Retirado sem venda
+}
+
+// Descricao resume a situação para a prévia do rollback
+func (s SituacaoCarro) Descricao() string {
+	switch {
+	case s.carro != nil:
+		return "em estoque"
+	case s.venda != nil:
+		return "vendido"
+	case s.removido:
+		return "removido"
+	}
+	return "não existia"
+}
+
+// situacao devolve a situação do carro na projeção
+func (d *dadosCarros) situacao(id string) SituacaoCarro {
+	if carro, existe := d.carrosMap[id]; existe {
+		return SituacaoCarro{carro: &carro}
+	}
+	for i := len(d.vendidos) - 1; i >= 0; i-- {
+		if d.vendidos[i].Carro.ID == id {
+			venda := d.vendidos[i]
+			return SituacaoCarro{venda: &venda}
+		}
+	}
+	_, removido := d.lapide(id)
+	return SituacaoCarro{removido: removido}
+}
+
+// MudancaReversao é o que o rollback faz com um carro: a situação atual, a do ponto escolhido e
+// os eventos que levam de uma à outra
+type MudancaReversao struct {
+	ID      string
+	Carro   string
+	Agora   SituacaoCarro
+	NoPonto SituacaoCarro
+	Detalhe string
+	Eventos []Evento
+}
+
+// PlanejarReversao compara o estoque atual com a projeção do log até o evento seq e devolve, por
+// carro, os eventos que desfazem o que veio depois. O log não é reescrito: o rollback entra nele
+// como eventos novos, marcados com o ponto de destino. Carros cadastrados depois do ponto saem do
+// estoque como removidos, já que IDs nunca são reaproveitados.
+func (v *VisaoCarros) PlanejarReversao(seq int64) ([]MudancaReversao, error) {
+	if len(v.eventos) == 0 {
+		return nil, fmt.Errorf("o log de eventos está vazio")
+	}
+	fim, achou := slices.BinarySearchFunc(v.eventos, seq, func(e Evento, alvo int64) int {
+		return int(e.Seq - alvo)
+	})
+	if !achou {
+		return nil, fmt.Errorf("evento #%d não está no log (veja 'events')", seq)
+	}
+	if ultimo := v.eventos[len(v.eventos)-1].Seq; seq == ultimo {
+		return nil, fmt.Errorf("evento #%d é o último do log; não há o que reverter", seq)
+	}
+	// Antes do limite da retenção o log só tem o estado líquido: os pontos intermediários se perderam
+	if limite := v.limiteAuditoria(); !limite.IsZero() {
+		for _, e := range v.eventos[fim+1:] {
+			if em, err := time.Parse(time.RFC3339Nano, e.Em); err == nil && em.Before(limite) {
+				return nil, fmt.Errorf("evento #%d está no trecho compactado pela retenção (antes de %s); escolha um evento posterior a #%d",
+					seq, limite.Format("01/2006"), e.Seq)
+			}
+		}
+	}
+
+	mudancas := v.planejarVolta(projetar(v.eventos[:fim+1], time.Time{}), carrosDosEventos(v.eventos[fim+1:]))
+	for _, m := range mudancas {
+		for i := range m.Eventos {
+			m.Eventos[i].Rollback = seq
+		}
+	}
+	return mudancas, nil
+}
+
+// carrosDosEventos devolve os IDs dos carros citados nos eventos, na ordem da primeira citação
+func carrosDosEventos(eventos []Evento) []string {
+	var ids []string
+	vistos := make(map[string]bool)
+	for _, e := range eventos {
+		if !vistos[e.CarroID] {
+			vistos[e.CarroID] = true
+			ids = append(ids, e.CarroID)
+		}
+	}
+	return ids
+}
+
+// planejarVolta devolve, para cada carro que difere entre o estoque atual e a projeção alvo, os
+// eventos que o levam de volta à situação que tinha no alvo (usado pelo rollback e pelo undo/redo)
+func (v *VisaoCarros) planejarVolta(alvo *dadosCarros, ids []string) []MudancaReversao {
+	var mudancas []MudancaReversao
+	for _, id := range ids {
+		if v.purgado(id) {
+			continue // A purga é definitiva: nem rollback nem undo trazem o carro de volta
+		}
+		m := MudancaReversao{ID: id, Agora: v.situacao(id), NoPonto: alvo.situacao(id)}
+		// Último estado conhecido, para trazer de volta um carro vendido ou removido
+		var carro Carro
+		switch {
+		case m.Agora.carro != nil:
+			carro = *m.Agora.carro
+		case m.Agora.venda != nil:
+			carro = m.Agora.venda.Carro
+		}
+		restaurar := func(c Carro) {
+			m.Eventos = append(m.Eventos, Evento{Tipo: EventoCarroRestaurado, CarroID: id, Carro: &c})
+		}
+		volta := m.Agora.carro == nil
+
+		switch ponto := m.NoPonto; {
+		case ponto.carro != nil:
+			carro = *ponto.carro
+			if volta {
+				restaurar(carro)
+				m.Detalhe = "volta ao estoque"
+			} else if !m.Agora.carro.Igual(carro) {
+				atualizado := carro
+				m.Eventos = append(m.Eventos, Evento{Tipo: EventoCarroAtualizado, CarroID: id, Carro: &atualizado})
+				m.Detalhe = strings.Join(DiferencasCarro(*m.Agora.carro, carro), "; ")
+			}
+		case ponto.venda != nil:
+			carro = ponto.venda.Carro
+			if m.Agora.venda != nil && m.Agora.venda.PrecoFinal == ponto.venda.PrecoFinal && m.Agora.venda.DataVenda == ponto.venda.DataVenda {
+				break
+			}
+			if volta {
+				restaurar(carro)
+			}
+			venda := *ponto.venda
+			m.Eventos = append(m.Eventos, Evento{Tipo: EventoCarroVendido, CarroID: id, Venda: &venda})
+			m.Detalhe = fmt.Sprintf("venda de %s refeita por %s", venda.DataVenda, v.exibicao.FormatarPreco(venda.PrecoFinal))
+		default:
+			if m.Agora.carro == nil && m.Agora.venda == nil {
+				break
+			}
+			if m.Agora.venda != nil {
+				restaurar(carro)
+				m.Detalhe = "venda desfeita; "
+			}
+			m.Eventos = append(m.Eventos, Evento{Tipo: EventoCarroRemovido, CarroID: id})
+			if ponto.removido {
+				m.Detalhe += "removido de novo"
+			} else {
+				m.Detalhe += "cadastrado depois do ponto; sai do estoque como removido"
+			}
+		}
+		if len(m.Eventos) == 0 {
+			continue
+		}
+		m.Carro = fmt.Sprintf("%s %s %d", carro.Marca, carro.Modelo, carro.Ano)
+		mudancas = append(mudancas, m)
+	}
+	return mudancas
+}
+
+// AplicarReversao grava no log os eventos das mudanças planejadas sobre a visão, desde que o log
+// não tenha mudado depois dela, e devolve o primeiro e o último evento gravados
+func (c *CadastroCarros) AplicarReversao(visao *VisaoCarros, mudancas []MudancaReversao) (primeiro, ultimo int64, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ultimoSeq() != visao.ultimoSeq() {
+		return 0, 0, errors.New("o log mudou enquanto a prévia era mostrada. Rode o rollback de novo para ver as diferenças atuais")
+	}
+	var novos []Evento
+	for _, m := range mudancas {
+		novos = append(novos, m.Eventos...)
+	}
+	if len(novos) == 0 {
+		return 0, 0, nil
+	}
+	c.emitir(novos...)
+
+	// Persistir após reverter
+	return novos[0].Seq, novos[len(novos)-1].Seq, c.gravar()
+}
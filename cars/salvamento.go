@@ -0,0 +1,136 @@
+package cars
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// limiarResumoSalvamento é a quantidade de carros a partir da qual cada gravação registra seu resumo
+// em nível info e avisa quem se registrou em AoGravarBaseGrande (abaixo dele, só em debug)
+const limiarResumoSalvamento = 10000
+
+// ErroGravacao indica que uma alteração foi feita em memória, mas não chegou ao armazenamento;
+// ela fica pendente até a próxima gravação bem-sucedida (ou `flush`)
+type ErroGravacao struct {
+	Err error
+}
+
+func (e *ErroGravacao) Error() string { return fmt.Sprintf("falha ao salvar dados: %v", e.Err) }
+
+func (e *ErroGravacao) Unwrap() error { return e.Err }
+
+// gravar salva uma alteração já aplicada em memória, marcando a falha como ErroGravacao
+// (chamador deve segurar o lock)
+func (c *CadastroCarros) gravar() error {
+	if err := c.salvar(); err != nil {
+		return &ErroGravacao{Err: err}
+	}
+	return nil
+}
+
+// salvar persiste o cadastro. Em bases grandes, o resumo da gravação vai para o log e para quem se
+// registrou em AoGravarBaseGrande (chamador deve segurar o lock).
+func (c *CadastroCarros) salvar() error {
+	resumo, err := c.salvarMedindo()
+	if err == nil && resumo.Carros >= limiarResumoSalvamento && c.aoGravarBaseGrande != nil {
+		c.aoGravarBaseGrande(resumo)
+	}
+	return err
+}
+
+// salvarMedindo persiste o cadastro, registrando o instante da gravação e se ficaram alterações
+// pendentes, e devolve o resumo. Bytes e vazão só são medidos em bases grandes, onde o resumo vai
+// para o log em nível info (chamador deve segurar o lock).
+func (c *CadastroCarros) salvarMedindo() (ResumoGravacao, error) {
+	defer c.Medir("salvar")()
+	// Toda alteração termina aqui, então é o ponto único para descartar a visão em cache
+	c.invalidarVisao()
+	inicio := time.Now()
+	if err := c.persistir(); err != nil {
+		c.pendente = true
+		c.log.Error("falha ao gravar dados", "armazenamento", c.armazenamento.Descrever(), "erro", err)
+		return ResumoGravacao{}, err
+	}
+	c.pendente = false
+	c.ultimoSalvamento = time.Now()
+	resumo := ResumoGravacao{Carros: len(c.carros), Duracao: c.ultimoSalvamento.Sub(inicio)}
+	if resumo.Carros < limiarResumoSalvamento {
+		c.log.Debug("dados gravados", "carros", resumo.Carros, "eventos", len(c.eventos), "duracao", resumo.Duracao)
+		return resumo, nil
+	}
+	resumo.Bytes, resumo.Vazao = c.vazaoSalvamento(resumo.Duracao)
+	c.log.Info("dados gravados", "carros", resumo.Carros, "eventos", len(c.eventos), "duracao", resumo.Duracao,
+		"bytes", resumo.Bytes, "bytes_por_segundo", int64(resumo.Vazao))
+	return resumo, nil
+}
+
+// AoGravarBaseGrande registra quem é avisado, com o resumo, de cada gravação feita com pelo menos
+// 10000 carros em estoque (Gravar devolve o resumo e não avisa). O aviso roda com o cadastro
+// travado e não deve chamá-lo.
+func (c *CadastroCarros) AoGravarBaseGrande(aviso func(ResumoGravacao)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aoGravarBaseGrande = aviso
+}
+
+// vazaoSalvamento devolve os bytes em disco e a vazão de uma gravação que levou duracao
+// (chamador deve segurar o lock)
+func (c *CadastroCarros) vazaoSalvamento(duracao time.Duration) (bytes int64, vazao float64) {
+	bytes = c.bytesPersistidos()
+	if duracao > 0 {
+		vazao = float64(bytes) / duracao.Seconds()
+	}
+	return bytes, vazao
+}
+
+// ResumoGravacao diz quanto uma gravação completa do cadastro gravou e em quanto tempo
+type ResumoGravacao struct {
+	Carros  int
+	Duracao time.Duration
+	Bytes   int64   // Tamanho em disco dos arquivos do backend depois da gravação
+	Vazao   float64 // Bytes por segundo
+}
+
+// bytesPersistidos soma o tamanho dos arquivos de dados do backend em uso (chamador deve segurar o lock)
+func (c *CadastroCarros) bytesPersistidos() int64 {
+	var total int64
+	for _, arquivo := range c.armazenamento.Arquivos() {
+		if info, err := os.Stat(arquivo); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
+// Gravar grava imediatamente todo o cadastro e devolve o resumo da gravação
+func (c *CadastroCarros) Gravar() (ResumoGravacao, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resumo, err := c.salvarMedindo()
+	if err != nil {
+		return ResumoGravacao{}, fmt.Errorf("erro ao salvar dados: %v", err)
+	}
+	if resumo.Carros < limiarResumoSalvamento {
+		resumo.Bytes, resumo.Vazao = c.vazaoSalvamento(resumo.Duracao)
+	}
+	return resumo, nil
+}
+
+// AlteracoesPendentes informa se há alterações em memória que a última gravação não conseguiu persistir
+func (c *CadastroCarros) AlteracoesPendentes() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pendente
+}
+
+// GravarPendentes tenta de novo a gravação que falhou, se houver alterações pendentes
+func (c *CadastroCarros) GravarPendentes() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.pendente {
+		return nil
+	}
+	return c.salvar()
+}
@@ -0,0 +1,185 @@
+package cars
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/michellhornung/golang/cars/carrospb"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+// Serializador codifica as coleções persistidas em arquivo (carros e anexos). As tags json
+// continuam sendo o esquema único: os formatos além do JSON convertem a partir da árvore
+// genérica do JSON, então nomes de campos e valores monetários são os mesmos em todos.
+type Serializador interface {
+	Nome() string
+	Extensao() string // Sem o ponto, ex: "yaml"
+	Codificar(v interface{}) ([]byte, error)
+	Decodificar(data []byte, v interface{}) error
+}
+
+// Serializadores são os formatos aceitos em armazenamento.formato
+var Serializadores = map[string]Serializador{
+	"json":     serializadorJSON{},
+	"yaml":     serializadorYAML{},
+	"msgpack":  serializadorMsgpack{},
+	"protobuf": serializadorProtobuf{},
+}
+
+// nomesSerializadores devolve os formatos aceitos em ordem alfabética
+func nomesSerializadores() []string {
+	nomes := make([]string, 0, len(Serializadores))
+	for nome := range Serializadores {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
+
+// serializadorJSON é o formato padrão, indentado para continuar legível e versionável
+type serializadorJSON struct{}
+
+func (serializadorJSON) Nome() string     { return "json" }
+func (serializadorJSON) Extensao() string { return "json" }
+
+func (serializadorJSON) Codificar(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+func (serializadorJSON) Decodificar(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// paraGenerico converte v na árvore genérica (mapas, listas, números) definida pelas tags json
+func paraGenerico(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generico interface{}
+	err = json.Unmarshal(data, &generico)
+	return generico, err
+}
+
+// deGenerico preenche v a partir de uma árvore genérica, passando pelas tags json
+func deGenerico(generico interface{}, v interface{}) error {
+	data, err := json.Marshal(generico)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// serializadorYAML grava YAML, para quem prefere editar os dados à mão
+type serializadorYAML struct{}
+
+func (serializadorYAML) Nome() string     { return "yaml" }
+func (serializadorYAML) Extensao() string { return "yaml" }
+
+func (serializadorYAML) Codificar(v interface{}) ([]byte, error) {
+	generico, err := paraGenerico(v)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(generico)
+}
+
+func (serializadorYAML) Decodificar(data []byte, v interface{}) error {
+	var generico interface{}
+	if err := yaml.Unmarshal(data, &generico); err != nil {
+		return err
+	}
+	return deGenerico(generico, v)
+}
+
+// serializadorMsgpack grava MessagePack, binário e mais compacto para estoques grandes
+type serializadorMsgpack struct{}
+
+func (serializadorMsgpack) Nome() string     { return "msgpack" }
+func (serializadorMsgpack) Extensao() string { return "msgpack" }
+
+func (serializadorMsgpack) Codificar(v interface{}) ([]byte, error) {
+	generico, err := paraGenerico(v)
+	if err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(generico)
+}
+
+func (serializadorMsgpack) Decodificar(data []byte, v interface{}) error {
+	var generico interface{}
+	if err := msgpack.Unmarshal(data, &generico); err != nil {
+		return err
+	}
+	return deGenerico(generico, v)
+}
+
+// serializadorProtobuf grava protobuf (carrospb.Dados), binário e com esquema para outras
+// linguagens: os carros vão na mesma mensagem do gRPC, com os campos cifrados, e as listas
+// auxiliares como registros genéricos
+type serializadorProtobuf struct{}
+
+func (serializadorProtobuf) Nome() string     { return "protobuf" }
+func (serializadorProtobuf) Extensao() string { return "pb" }
+
+func (serializadorProtobuf) Codificar(v interface{}) ([]byte, error) {
+	dados := &carrospb.Dados{}
+	if carros, ehCarros := v.([]Carro); ehCarros {
+		for _, carro := range carros {
+			mensagem := CarroParaProto(carro)
+			mensagem.Protegido = carro.Protegido
+			dados.Carros = append(dados.Carros, mensagem)
+		}
+		return proto.Marshal(dados)
+	}
+	generico, err := paraGenerico(v)
+	if err != nil {
+		return nil, err
+	}
+	lista, _ := generico.([]interface{}) // nil = lista vazia
+	for i, item := range lista {
+		campos, ehObjeto := item.(map[string]interface{})
+		if !ehObjeto {
+			return nil, fmt.Errorf("registro %d não é um objeto", i+1)
+		}
+		registro, err := structpb.NewStruct(campos)
+		if err != nil {
+			return nil, fmt.Errorf("registro %d: %v", i+1, err)
+		}
+		dados.Registros = append(dados.Registros, registro)
+	}
+	return proto.Marshal(dados)
+}
+
+func (serializadorProtobuf) Decodificar(data []byte, v interface{}) error {
+	var dados carrospb.Dados
+	if err := proto.Unmarshal(data, &dados); err != nil {
+		return err
+	}
+	lista := make([]interface{}, 0, len(dados.GetCarros())+len(dados.GetRegistros()))
+	for _, mensagem := range dados.GetCarros() {
+		carro := CarroDoProto(mensagem)
+		carro.Protegido = mensagem.GetProtegido()
+		lista = append(lista, carro)
+	}
+	for _, registro := range dados.GetRegistros() {
+		lista = append(lista, registro.AsMap())
+	}
+	return deGenerico(lista, v)
+}
+
+// interpretarFormato devolve o serializador de um formato configurado ("" = json)
+func interpretarFormato(formato string) (Serializador, error) {
+	if formato == "" {
+		formato = "json"
+	}
+	s, existe := Serializadores[formato]
+	if !existe {
+		return nil, fmt.Errorf("formato inválido '%s' (use %v)", formato, nomesSerializadores())
+	}
+	return s, nil
+}
@@ -0,0 +1,50 @@
+package cars
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSerializadorProtobufPreservaCarrosEAnexos(t *testing.T) {
+	t.Parallel()
+	s := Serializadores["protobuf"]
+	carro := corollaTeste
+	carro.ID, carro.DataCadastro, carro.Custo = "car_1", "2025-01-10", Reais(120000)
+	carro.Opcionais = []string{"teto_solar"}
+	carro.PrecosCanal = map[string]Dinheiro{"site": Reais(143000)}
+	carro.Protegido = map[string]string{"custo": "cifrado"}
+
+	data, err := s.Codificar([]Carro{carro})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var brutos []json.RawMessage
+	if err := s.Decodificar(data, &brutos); err != nil || len(brutos) != 1 {
+		t.Fatalf("%v %s", err, brutos)
+	}
+	var lido Carro
+	if err := json.Unmarshal(brutos[0], &lido); err != nil || !lido.Igual(carro) {
+		t.Fatalf("carro mudou no protobuf: %v\n%+v\n%+v", err, lido, carro)
+	}
+
+	pagamentos := []Pagamento{{CarroID: "car_1", Valor: Reais(5000), Data: "2025-01-10", Metodo: "pix", Tipo: "sinal"}}
+	if data, err = s.Codificar(pagamentos); err != nil {
+		t.Fatal(err)
+	}
+	var lidos []Pagamento
+	if err := s.Decodificar(data, &lidos); err != nil || !slices.Equal(lidos, pagamentos) {
+		t.Fatalf("anexo mudou no protobuf: %v %+v", err, lidos)
+	}
+
+	// O arquivo .pb é reconhecido pela extensão, como os demais formatos
+	arquivo := filepath.Join(t.TempDir(), "carros.pb")
+	c := NewCadastroCarrosEm(&armazenamentoArquivo{arquivo: arquivo, serializador: s})
+	if _, _, err := c.Adicionar(unoTeste, nil); err != nil {
+		t.Fatal(err)
+	}
+	if carros, err := lerArquivoCarros(arquivo); err != nil || len(carros) != 1 || carros[0].Modelo != "Uno" {
+		t.Fatalf("arquivo protobuf: %v %+v", err, carros)
+	}
+}
@@ -0,0 +1,30 @@
+package cars
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// OpcoesSessao é a seção "sessao" da configuração: bloqueio da sessão interativa em terminais
+// compartilhados
+type OpcoesSessao struct {
+	BloqueioMinutos int    `json:"bloqueio_minutos,omitempty"` // Bloqueia após N minutos sem comandos (0 = nunca)
+	SenhaHash       string `json:"senha_hash,omitempty"`       // Hash bcrypt da senha de desbloqueio (gerado com `lock hash`)
+}
+
+// Validar confere se os parâmetros configurados fazem sentido
+func (o OpcoesSessao) Validar() error {
+	if o.BloqueioMinutos < 0 {
+		return fmt.Errorf("sessao: bloqueio_minutos não pode ser negativo")
+	}
+	if o.SenhaHash != "" {
+		if _, err := bcrypt.Cost([]byte(o.SenhaHash)); err != nil {
+			return fmt.Errorf("sessao: senha_hash inválido (gere com `lock hash`): %v", err)
+		}
+	}
+	if o.BloqueioMinutos > 0 && o.SenhaHash == "" {
+		return fmt.Errorf("sessao: bloqueio_minutos exige senha_hash (gere com `lock hash`)")
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package cars
+
+// InstalarSinais não faz nada em sistemas sem SIGUSR1/SIGHUP (Windows); lá a configuração
+// é recarregada com `config reload`
+func (c *CadastroCarros) InstalarSinais(arquivoDiagnostico string) {}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package cars
+
+import (
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+)
+
+// InstalarSinais trata os sinais de operação: SIGUSR1 (`kill -USR1 <pid>`) despeja o diagnóstico
+// em stderr ou no arquivo informado (variável CARROS_DIAGNOSTICO) e SIGHUP recarrega a configuração.
+// O resultado aparece como notificação antes do próximo prompt.
+func (c *CadastroCarros) InstalarSinais(arquivoDiagnostico string) {
+	// Amostra parte das disputas de lock para o relatório de contenção
+	runtime.SetMutexProfileFraction(5)
+
+	sinais := make(chan os.Signal, 1)
+	signal.Notify(sinais, syscall.SIGUSR1, syscall.SIGHUP)
+	go func() {
+		for sinal := range sinais {
+			switch sinal {
+			case syscall.SIGUSR1:
+				c.despejarDiagnostico(arquivoDiagnostico)
+				if arquivoDiagnostico != "" {
+					c.notificar(false, "Diagnóstico gravado em %s (SIGUSR1).", arquivoDiagnostico)
+				}
+			case syscall.SIGHUP:
+				mensagens, ok := c.recarregarConfig()
+				for _, m := range mensagens {
+					c.notificar(!ok, "%s (SIGHUP)", m)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,123 @@
+package cars
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Situações do ciclo de vida de um carro
+const (
+	StatusEmTransito = "em_transito" // Comprado no exterior, ainda não chegou ao pátio
+	StatusEmEstoque  = "em_estoque"  // No pátio, disponível para venda
+	StatusReservado  = "reservado"   // Segurado para um cliente, fora da vitrine
+	StatusVendido    = "vendido"     // Vendido (o carro sai do estoque e vai para os comparáveis)
+	StatusArquivado  = "arquivado"   // Fora de circulação sem venda (ex: sinistro, uso próprio)
+)
+
+// TransicoesStatus lista, para cada situação, para quais outras o carro pode passar
+var TransicoesStatus = map[string][]string{
+	StatusEmTransito: {StatusEmEstoque, StatusArquivado},
+	StatusEmEstoque:  {StatusReservado, StatusVendido, StatusArquivado},
+	StatusReservado:  {StatusEmEstoque, StatusVendido},
+	StatusArquivado:  {StatusEmEstoque},
+	StatusVendido:    nil,
+}
+
+// ordemStatus fixa a ordem de exibição das situações
+var ordemStatus = []string{StatusEmTransito, StatusEmEstoque, StatusReservado, StatusVendido, StatusArquivado}
+
+// MudancaStatus registra uma transição no histórico de situações de um carro
+type MudancaStatus struct {
+	CarroID string `json:"carro_id"`     // Carro que mudou de situação
+	De      string `json:"de,omitempty"` // Situação anterior ("" no cadastro)
+	Para    string `json:"para"`         // Nova situação
+	Em      string `json:"em"`           // Instante da mudança (RFC 3339)
+}
+
+// StatusCarro devolve a situação atual; carros gravados antes do campo existir estão em estoque
+func StatusCarro(carro Carro) string {
+	if carro.Status == "" {
+		return StatusEmEstoque
+	}
+	return carro.Status
+}
+
+// statusValido informa se o nome é uma das situações conhecidas
+func statusValido(status string) bool {
+	_, ok := TransicoesStatus[status]
+	return ok
+}
+
+// ValidarTransicao confere se a máquina de estados permite ir de uma situação para outra
+func ValidarTransicao(de, para string) error {
+	if !statusValido(para) {
+		return fmt.Errorf("situação '%s' desconhecida (use %s)", para, strings.Join(ordemStatus, ", "))
+	}
+	if de == para {
+		return fmt.Errorf("o carro já está em '%s'", para)
+	}
+	for _, permitido := range TransicoesStatus[de] {
+		if permitido == para {
+			return nil
+		}
+	}
+	if len(TransicoesStatus[de]) == 0 {
+		return fmt.Errorf("'%s' é uma situação final", de)
+	}
+	return fmt.Errorf("não é permitido passar de '%s' para '%s' (permitido: %s)", de, para, strings.Join(TransicoesStatus[de], ", "))
+}
+
+// registrarStatus muda a situação do carro e acrescenta a transição ao histórico, sem validá-la
+// (chamador deve segurar o lock)
+func (c *CadastroCarros) registrarStatus(carro *Carro, para string, em time.Time) {
+	mudanca := MudancaStatus{
+		CarroID: carro.ID,
+		De:      carro.Status,
+		Para:    para,
+		Em:      em.UTC().Format(time.RFC3339Nano),
+	}
+	c.historicoStatus = append(c.historicoStatus, mudanca)
+	carro.Status, carro.StatusDesde = para, mudanca.Em
+}
+
+// AlterarStatus aplica uma transição manual e devolve o carro com a nova situação e a situação
+// anterior. A venda tem fluxo próprio (Vender), que registra preço final e troca, por isso
+// "vendido" não é aceito aqui.
+func (c *CadastroCarros) AlterarStatus(id, para string) (Carro, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return Carro{}, "", ErroCarroNaoEncontrado(id)
+	}
+	if para == StatusVendido {
+		return Carro{}, "", errors.New("use 'sell <ID> <preço final>' para registrar a venda")
+	}
+	de := StatusCarro(carro)
+	if err := ValidarTransicao(de, para); err != nil {
+		return Carro{}, "", err
+	}
+
+	agora := time.Now()
+	carro.Status = de
+	c.registrarStatus(&carro, para, agora)
+	carro.AtualizadoEm = agora.UTC().Format(time.RFC3339Nano)
+	c.substituirCarro(carro)
+
+	// Persistir após mudar a situação
+	return carro, de, c.gravar()
+}
+
+// HistoricoStatus devolve as mudanças de situação de um carro, da mais antiga à mais recente
+func (v *VisaoCarros) HistoricoStatus(id string) []MudancaStatus {
+	var historico []MudancaStatus
+	for _, m := range v.historicoStatus {
+		if m.CarroID == id {
+			historico = append(historico, m)
+		}
+	}
+	return historico
+}
@@ -0,0 +1,140 @@
+package cars
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ModoTabela controla quanto da largura do terminal a tabela pode ocupar
+type ModoTabela int
+
+const (
+	TabelaAuto     ModoTabela = iota // Ajusta as colunas à largura do terminal, truncando com "…"
+	TabelaLarga                      // Nunca trunca (--wide); o terminal quebra as linhas se precisar
+	TabelaEstreita                   // Mostra só as colunas essenciais (--narrow), ajustadas à largura
+)
+
+// ColunaTabela descreve uma coluna da tabela
+type ColunaTabela struct {
+	Titulo    string
+	Direita   bool // Alinha à direita (números e preços)
+	Essencial bool // Mantida no modo estreito
+}
+
+// Tabela é um renderizador simples de tabelas de texto com colunas de largura automática
+type Tabela struct {
+	Colunas []ColunaTabela
+	Linhas  [][]string
+}
+
+// larguraMinimaColuna é o menor tamanho ao qual uma coluna é reduzida antes de truncar as demais
+const larguraMinimaColuna = 6
+
+// separadorColunas separa as colunas de uma linha
+const separadorColunas = " │ "
+
+// Renderizar monta a tabela para a largura informada (0 = sem limite)
+func (t Tabela) Renderizar(modo ModoTabela, largura int) string {
+	// Seleciona as colunas visíveis
+	var indices []int
+	for i, col := range t.Colunas {
+		if modo != TabelaEstreita || col.Essencial {
+			indices = append(indices, i)
+		}
+	}
+
+	// Largura natural de cada coluna (maior entre título e células)
+	larguras := make([]int, len(indices))
+	for j, i := range indices {
+		larguras[j] = utf8.RuneCountInString(t.Colunas[i].Titulo)
+		for _, linha := range t.Linhas {
+			if i < len(linha) {
+				if n := utf8.RuneCountInString(linha[i]); n > larguras[j] {
+					larguras[j] = n
+				}
+			}
+		}
+	}
+
+	if modo != TabelaLarga && largura > 0 {
+		// Colunas não essenciais encolhem primeiro; números e preços essenciais por último
+		prioridades := make([]int, len(indices))
+		for j, i := range indices {
+			switch col := t.Colunas[i]; {
+			case col.Essencial && col.Direita:
+				prioridades[j] = 2
+			case col.Essencial:
+				prioridades[j] = 1
+			}
+		}
+		ajustarLarguras(larguras, prioridades, largura-utf8.RuneCountInString(separadorColunas)*(len(larguras)-1))
+	}
+
+	var sb strings.Builder
+	escreverLinha := func(celulas func(i int) string) {
+		partes := make([]string, len(indices))
+		for j, i := range indices {
+			partes[j] = ajustarCelula(celulas(i), larguras[j], t.Colunas[i].Direita)
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(partes, separadorColunas), " "))
+		sb.WriteString("\n")
+	}
+
+	escreverLinha(func(i int) string { return t.Colunas[i].Titulo })
+	regua := make([]string, len(larguras))
+	for j, l := range larguras {
+		regua[j] = strings.Repeat("─", l)
+	}
+	sb.WriteString(strings.Join(regua, "─┼─"))
+	sb.WriteString("\n")
+	for _, linha := range t.Linhas {
+		escreverLinha(func(i int) string {
+			if i < len(linha) {
+				return linha[i]
+			}
+			return ""
+		})
+	}
+	return sb.String()
+}
+
+// ajustarLarguras reduz as colunas mais largas, uma unidade por vez, até caberem no espaço disponível.
+// As colunas de menor prioridade são reduzidas até o mínimo antes de tocar nas de prioridade maior.
+func ajustarLarguras(larguras, prioridades []int, disponivel int) {
+	total := 0
+	for _, l := range larguras {
+		total += l
+	}
+	for prioridade := 0; prioridade <= 2 && total > disponivel; prioridade++ {
+		for total > disponivel {
+			maior := -1
+			for j, l := range larguras {
+				if prioridades[j] <= prioridade && l > larguraMinimaColuna && (maior < 0 || l > larguras[maior]) {
+					maior = j
+				}
+			}
+			if maior < 0 {
+				break // Colunas desta prioridade já estão no mínimo
+			}
+			larguras[maior]--
+			total--
+		}
+	}
+}
+
+// ajustarCelula trunca (com reticências) ou completa com espaços o texto até a largura da coluna
+func ajustarCelula(texto string, largura int, direita bool) string {
+	n := utf8.RuneCountInString(texto)
+	if n > largura {
+		runas := []rune(texto)
+		if largura <= 1 {
+			return string(runas[:largura])
+		}
+		return string(runas[:largura-1]) + "…"
+	}
+	espacos := strings.Repeat(" ", largura-n)
+	if direita {
+		return espacos + texto
+	}
+	return texto + espacos
+}
@@ -0,0 +1,39 @@
+package cars
+
+// ValidadorCarro é uma regra de negócio própria de quem embute o cadastro (ex: o formato do
+// código do ERP da loja), conferida em todo cadastro, edição e importação junto com as regras
+// fixas de validarCarro. O erro devolvido é mostrado ao usuário como motivo da recusa.
+type ValidadorCarro func(Carro) error
+
+// RegistrarValidador acrescenta um validador, conferido depois dos já registrados
+func (c *CadastroCarros) RegistrarValidador(validar ValidadorCarro) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validadores = append(c.validadores, validar)
+}
+
+// conferirValidadores passa o carro pelos validadores em ordem e devolve o primeiro erro
+func conferirValidadores(validadores []ValidadorCarro, carro Carro) error {
+	for _, validar := range validadores {
+		if err := validar(carro); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validarRegistrados confere o carro com os validadores registrados (chamador deve segurar o lock)
+func (c *CadastroCarros) validarRegistrados(carro Carro) error {
+	return conferirValidadores(c.validadores, carro)
+}
+
+// Validar confere o carro com as regras fixas do cadastro e com os validadores registrados,
+// sem gravá-lo
+func (c *CadastroCarros) Validar(carro Carro) error {
+	if err := ValidarCarro(carro); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.validarRegistrados(carro)
+}
@@ -0,0 +1,133 @@
+package cars
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Venda guarda um carro vendido como comparável para análises de preço
+type Venda struct {
+	Carro         Carro    `json:"carro"`           // Carro como estava no estoque (Preco = preço pedido)
+	PrecoFinal    Dinheiro `json:"preco_final"`     // Valor efetivamente recebido em R$
+	DataVenda     string   `json:"data_venda"`      // Data da venda (formato YYYY-MM-DD)
+	DiasEmEstoque int      `json:"dias_em_estoque"` // Dias entre o cadastro e a venda
+	Troca         *Troca   `json:"troca,omitempty"` // Veículo recebido como parte do pagamento, se houver
+}
+
+// Troca liga uma venda ao veículo do cliente recebido na troca
+type Troca struct {
+	CarroID   string   `json:"carro_id"`  // ID do veículo da troca, cadastrado no estoque com origem "troca"
+	Avaliacao Dinheiro `json:"avaliacao"` // Valor abatido do preço final em R$
+}
+
+// ValorLiquido devolve quanto o cliente paga além do veículo da troca
+func (v Venda) ValorLiquido() Dinheiro {
+	if v.Troca == nil {
+		return v.PrecoFinal
+	}
+	return v.PrecoFinal - v.Troca.Avaliacao
+}
+
+// DescontoPercentual devolve quanto o preço final ficou abaixo do preço pedido, em %
+func (v Venda) DescontoPercentual() float64 {
+	if v.Carro.Preco <= 0 {
+		return 0
+	}
+	return (v.Carro.Preco - v.PrecoFinal).EmReais() / v.Carro.Preco.EmReais() * 100
+}
+
+// Vender retira o carro do estoque e o registra como comparável vendido. Se houver veículo na
+// troca, ele entra no estoque com origem "troca" e custo igual à avaliação; os ajustes devolvidos
+// são os das regras de preço sobre ele. Se só a gravação falhar (ErroGravacao), a venda fica no cadastro.
+func (c *CadastroCarros) Vender(id string, precoFinal Dinheiro, troca *Carro) (Venda, []AjustePreco, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	carro, existe := c.carrosMap[id]
+	if !existe {
+		return Venda{}, nil, ErroCarroNaoEncontrado(id)
+	}
+	if err := ValidarTransicao(StatusCarro(carro), StatusVendido); err != nil {
+		return Venda{}, nil, err
+	}
+
+	hoje := time.Now()
+	venda := Venda{
+		Carro:         carro,
+		PrecoFinal:    precoFinal,
+		DataVenda:     hoje.Format("2006-01-02"),
+		DiasEmEstoque: DiasEmEstoque(carro, hoje),
+	}
+	var ajustes []AjustePreco
+	if troca != nil {
+		if troca.Preco > precoFinal {
+			return Venda{}, nil, fmt.Errorf("a avaliação da troca (%s) supera o preço final da venda", c.exibicao.FormatarPreco(troca.Preco))
+		}
+		if err := c.validarRegistrados(*troca); err != nil {
+			return Venda{}, nil, fmt.Errorf("veículo da troca recusado: %v", err)
+		}
+		entrada := *troca
+		entrada.ID = c.novoID()
+		entrada.DataCadastro = hoje.Format("2006-01-02")
+		entrada.AtualizadoEm = hoje.UTC().Format(time.RFC3339Nano)
+		entrada.Origem = "troca"
+		entrada.Custo = troca.Preco
+		entrada.Status = ""
+		c.registrarStatus(&entrada, StatusEmEstoque, hoje)
+		venda.Troca = &Troca{CarroID: entrada.ID, Avaliacao: troca.Preco}
+		ajustes = c.aplicarRegrasPreco(&entrada)
+		c.emitir(Evento{Tipo: EventoCarroAdicionado, CarroID: entrada.ID, Carro: &entrada})
+	}
+
+	venda.Carro.Status = StatusCarro(carro)
+	c.registrarStatus(&venda.Carro, StatusVendido, hoje)
+	c.emitir(Evento{Tipo: EventoCarroVendido, CarroID: id, Venda: &venda})
+
+	// Persistir após vender
+	return venda, ajustes, c.gravar()
+}
+
+// Vendidos devolve os comparáveis vendidos, na ordem das vendas
+func (v *VisaoCarros) Vendidos() []Venda {
+	return slices.Clone(v.vendidos)
+}
+
+// EstatisticaVendas agrega comparáveis de um grupo (marca, segmento ou mês)
+type EstatisticaVendas struct {
+	Grupo           string
+	Quantidade      int
+	MediaDias       float64 // Média de dias até vender
+	MediaDesconto   float64 // Desconto médio sobre o preço pedido, em %
+	MediaPrecoFinal Dinheiro
+	somaDias        int
+	somaDesconto    float64
+	somaPrecoFinal  Dinheiro
+}
+
+// AgruparVendas calcula as estatísticas por grupo, ordenadas pelo nome do grupo (colação pt-BR)
+func AgruparVendas(vendas []Venda, chave func(Venda) string) []EstatisticaVendas {
+	grupos := make(map[string]*EstatisticaVendas)
+	for _, v := range vendas {
+		k := chave(v)
+		g, ok := grupos[k]
+		if !ok {
+			g = &EstatisticaVendas{Grupo: k}
+			grupos[k] = g
+		}
+		g.Quantidade++
+		g.somaDias += v.DiasEmEstoque
+		g.somaDesconto += v.DescontoPercentual()
+		g.somaPrecoFinal += v.PrecoFinal
+	}
+
+	resultado := make([]EstatisticaVendas, 0, len(grupos))
+	for _, g := range grupos {
+		g.MediaDias = float64(g.somaDias) / float64(g.Quantidade)
+		g.MediaDesconto = g.somaDesconto / float64(g.Quantidade)
+		g.MediaPrecoFinal = g.somaPrecoFinal.Dividir(g.Quantidade)
+		resultado = append(resultado, *g)
+	}
+	OrdenarPorTexto(resultado, func(e EstatisticaVendas) string { return e.Grupo })
+	return resultado
+}
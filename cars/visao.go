@@ -0,0 +1,81 @@
+package cars
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// VisaoCarros é uma cópia imutável do banco em memória, usada por relatórios, exportações e
+// estatísticas demoradas para não segurar o lock enquanto formatam e gravam a saída.
+// Nenhum método de VisaoCarros altera os dados; alterações no cadastro não a afetam.
+type VisaoCarros struct {
+	dadosCarros
+	exibicao OpcoesExibicao
+	geradaEm time.Time
+}
+
+// Snapshot devolve uma visão imutável do estado atual. A cópia é feita uma vez por versão
+// dos dados: chamadas seguidas sem alterações no meio reaproveitam a mesma visão.
+func (c *CadastroCarros) Snapshot() *VisaoCarros {
+	if v := c.visao.Load(); v != nil {
+		return v
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v := &VisaoCarros{
+		dadosCarros: dadosCarros{
+			carrosMap:       maps.Clone(c.carrosMap),
+			carros:          slices.Clone(c.carros),
+			removidos:       slices.Clone(c.removidos),
+			vendidos:        slices.Clone(c.vendidos),
+			quarentena:      slices.Clone(c.quarentena),
+			pagamentos:      slices.Clone(c.pagamentos),
+			lotes:           slices.Clone(c.lotes),
+			excecoes:        slices.Clone(c.excecoes),
+			historicoStatus: slices.Clone(c.historicoStatus),
+			cotacoes:        slices.Clone(c.cotacoes),
+			documentos:      slices.Clone(c.documentos),
+			eventos:         slices.Clip(c.eventos), // Nunca alterado no lugar; o Clip faz appends copiarem
+
+			resumosAuditoria: slices.Clone(c.resumosAuditoria),
+		},
+		exibicao: c.exibicao,
+		geradaEm: time.Now(),
+	}
+	// Lotes são alterados no lugar (inclusão e purga de carros, lançamento de custos)
+	for i := range v.lotes {
+		v.lotes[i].CarroIDs = slices.Clone(v.lotes[i].CarroIDs)
+		v.lotes[i].Custos = slices.Clone(v.lotes[i].Custos)
+	}
+	// Guardada ainda com o lock de leitura, para que nenhuma alteração fique entre a cópia e o cache
+	c.visao.Store(v)
+	return v
+}
+
+// invalidarVisao descarta a visão em cache (chamador deve segurar o lock de escrita)
+func (c *CadastroCarros) invalidarVisao() {
+	c.visao.Store(nil)
+}
+
+// Carros devolve os carros em estoque, na ordem de cadastro
+func (v *VisaoCarros) Carros() []Carro {
+	return slices.Clone(v.carros)
+}
+
+// Carro devolve o carro em estoque com o ID informado
+func (v *VisaoCarros) Carro(id string) (Carro, bool) {
+	carro, existe := v.carrosMap[id]
+	return carro, existe
+}
+
+// EstoqueEm devolve os carros em estoque ao fim do dia informado (AAAA-MM-DD), projetando o log
+func (v *VisaoCarros) EstoqueEm(data string) ([]Carro, error) {
+	return v.estoqueEm(data)
+}
+
+// Exibicao devolve as opções de formatação de preços em vigor quando a visão foi gerada
+func (v *VisaoCarros) Exibicao() OpcoesExibicao {
+	return v.exibicao
+}
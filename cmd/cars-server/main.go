@@ -0,0 +1,198 @@
+// O comando cars-server serve a API REST do cadastro de carros importados, e opcionalmente o gRPC e
+// o painel do showroom, sem o prompt interativo: é o artefato para rodar como serviço. Usa só o pacote cars;
+// o prompt fica em cmd/cars. Sob o systemd, aceita os sockets da ativação por socket (no lugar de --listen
+// e --grpc) e avisa quando está pronto com Type=notify.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// opcoesServidor são os argumentos do programa
+type opcoesServidor struct {
+	Perfil       string
+	ArquivoDados string
+	NivelLog     string
+	FormatoLog   string
+	API          string // Endereço da API REST (--listen)
+	GRPC         string // Endereço do serviço gRPC (--grpc; vazio = sem gRPC)
+	Painel       string // Endereço do painel do showroom (--board; vazio = sem painel)
+}
+
+const usoServidor = "Uso: cars-server [--listen=<endereço>] [--grpc=<endereço>] [--board=<endereço>] [--profile=<nome>] [--data-file=<caminho>] [--log-level=<nível>] [--log-format=text|json]"
+
+// interpretarArgsServidor lê os argumentos no mesmo formato `--opção=valor` do prompt
+func interpretarArgsServidor(args []string) (opcoesServidor, error) {
+	opcoes := opcoesServidor{API: "127.0.0.1:8081"}
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch nome {
+		case "--listen":
+			opcoes.API = valor
+		case "--grpc":
+			opcoes.GRPC = valor
+		case "--board":
+			opcoes.Painel = valor
+		case "--profile":
+			opcoes.Perfil = valor
+		case "--data-file":
+			opcoes.ArquivoDados = valor
+		case "--log-level":
+			opcoes.NivelLog = valor
+		case "--log-format":
+			opcoes.FormatoLog = valor
+		default:
+			return opcoes, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	if opcoes.API == "" {
+		return opcoes, fmt.Errorf("--listen não pode ser vazio")
+	}
+	return opcoes, nil
+}
+
+func main() {
+	opcoes, err := interpretarArgsServidor(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erro: %v\n%s\n", err, usoServidor)
+		os.Exit(2)
+	}
+	// Sem ninguém no terminal, o log é a saída do programa: o padrão sobe para info
+	if opcoes.NivelLog == "" && os.Getenv("CARROS_LOG_LEVEL") == "" {
+		opcoes.NivelLog = "info"
+	}
+	registro, err := cars.RegistroDoAmbiente(opcoes.NivelLog, opcoes.FormatoLog)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erro: %v\n", err)
+		os.Exit(2)
+	}
+	if opcoes.Perfil == "" {
+		opcoes.Perfil = os.Getenv("CARROS_PERFIL")
+	}
+
+	caminhoCfg := cars.CaminhoConfig()
+	cfg, err := cars.CarregarConfig(caminhoCfg, opcoes.Perfil)
+	if err != nil {
+		if opcoes.Perfil != "" {
+			registro.Error("configuração inválida", "arquivo", caminhoCfg, "perfil", opcoes.Perfil, "erro", err)
+			os.Exit(1)
+		}
+		registro.Warn("configuração inválida; usando padrões", "arquivo", caminhoCfg, "erro", err)
+	}
+	if opcoes.ArquivoDados != "" {
+		cfg.Armazenamento.Arquivo = opcoes.ArquivoDados
+		cfg.Armazenamento.ArquivoPadrao = false
+	}
+	if cfg.Armazenamento.ArquivoPadrao {
+		dir := filepath.Dir(cfg.Armazenamento.Arquivo)
+		if migrados, err := cars.PrepararDiretorioDados(dir); err != nil {
+			registro.Warn("falha ao preparar o diretório de dados", "diretorio", dir, "erro", err)
+		} else if migrados > 0 {
+			registro.Info("arquivos de dados migrados do diretório atual", "diretorio", dir, "arquivos", migrados)
+		}
+	}
+
+	cadastro := cars.NewCadastroCarros(filepath.Join(filepath.Dir(cfg.Armazenamento.Arquivo), "carros.json"))
+	cadastro.UsarRegistro(registro)
+	cadastro.Configurar(cfg, caminhoCfg, opcoes.ArquivoDados != "")
+	cadastro.InstalarSinais(os.Getenv("CARROS_DIAGNOSTICO"))
+	// As notificações já vão para o log; sem prompt para mostrá-las, a fila é esvaziada ao chegar
+	cadastro.AoNotificar(func(cars.Notificacao) { cadastro.RetirarNotificacoes() })
+
+	if _, err := cadastro.AbrirArmazenamento(); err != nil {
+		registro.Error("falha ao carregar dados", "arquivo", cfg.Armazenamento.Arquivo, "erro", err)
+		os.Exit(1)
+	}
+	for _, aviso := range cadastro.AvisosAoAbrir() {
+		registro.Warn(aviso)
+	}
+	if quarentenados := cadastro.QuarentenadosAoAbrir(); len(quarentenados) > 0 {
+		registro.Warn("registros em quarentena ao abrir; revise com 'quarantine' no prompt", "registros", len(quarentenados))
+	}
+	cadastro.LembrarVencimentos(cfg.Documentos)
+	if cfg.Auditoria.RetencaoMeses > 0 {
+		if _, err := cadastro.CompactarAuditoria(cfg.Auditoria.RetencaoMeses); err != nil {
+			registro.Warn("compactação do log de eventos falhou", "erro", err)
+		}
+	}
+
+	ouvintes, err := ouvintesSystemd()
+	if err != nil {
+		registro.Error("falha ao receber os sockets do systemd", "erro", err)
+		os.Exit(1)
+	}
+	codigo := servir(cadastro, opcoes, ouvintes)
+	if err := cadastro.FecharArmazenamento(); err != nil {
+		registro.Error("falha ao fechar o armazenamento", "erro", err)
+		codigo = 1
+	}
+	os.Exit(codigo)
+}
+
+// servir põe a API (e o gRPC e o painel, se pedidos) no ar até SIGINT ou SIGTERM e devolve o código
+// de saída. A API e o gRPC usam os sockets recebidos do systemd, se houver, em vez de abrir as portas.
+func servir(cadastro *cars.CadastroCarros, opcoes opcoesServidor, ouvintes map[string]net.Listener) int {
+	registro := cadastro.Registro()
+	ctx, parar := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer parar()
+
+	var err error
+	if ouvinte, herdado := ouvintes["api"]; herdado {
+		_, err = cadastro.IniciarAPIEm(ouvinte)
+	} else {
+		_, err = cadastro.IniciarAPI(opcoes.API)
+	}
+	if err != nil {
+		registro.Error("falha ao iniciar a API", "endereco", opcoes.API, "erro", err)
+		return 1
+	}
+	if ouvinte, herdado := ouvintes["grpc"]; herdado {
+		_, err = cadastro.IniciarGRPCEm(ouvinte)
+	} else if opcoes.GRPC != "" {
+		_, err = cadastro.IniciarGRPC(opcoes.GRPC)
+	}
+	if err != nil {
+		registro.Error("falha ao iniciar o gRPC", "endereco", opcoes.GRPC, "erro", err)
+		cadastro.PararAPI()
+		return 1
+	}
+	if opcoes.Painel != "" {
+		if _, err := cadastro.IniciarPainel(cars.OpcoesPainel{Endereco: opcoes.Painel, Dias: 7, Atualizacao: 30}); err != nil {
+			registro.Error("falha ao iniciar o painel", "endereco", opcoes.Painel, "erro", err)
+			cadastro.PararGRPC()
+			cadastro.PararAPI()
+			return 1
+		}
+	}
+
+	if err := notificarSystemd("READY=1\nSTATUS=Servindo a API de carros"); err != nil {
+		registro.Warn("falha ao avisar o systemd", "erro", err)
+	}
+
+	<-ctx.Done()
+	notificarSystemd("STOPPING=1")
+	codigo := 0
+	if _, err := cadastro.PararPainel(); err != nil {
+		registro.Warn("painel encerrado com erro", "erro", err)
+	}
+	if _, err := cadastro.PararGRPC(); err != nil {
+		registro.Warn("gRPC encerrado com erro", "erro", err)
+	}
+	if _, err := cadastro.PararAPI(); err != nil {
+		registro.Warn("API encerrada com erro", "erro", err)
+	}
+	if err := cadastro.GravarPendentes(); err != nil {
+		registro.Error("alterações não gravadas ao encerrar", "erro", err)
+		codigo = 1
+	}
+	return codigo
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArgsDoServidor(t *testing.T) {
+	t.Parallel()
+	opcoes, err := interpretarArgsServidor([]string{"--listen=:9000", "--grpc=:9002", "--board=:9001", "--data-file=/tmp/carros.json", "--log-format=json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcoes.API != ":9000" || opcoes.Painel != ":9001" || opcoes.GRPC != ":9002" || opcoes.ArquivoDados != "/tmp/carros.json" || opcoes.FormatoLog != "json" {
+		t.Fatalf("opções lidas erradas: %+v", opcoes)
+	}
+	if opcoes, _ := interpretarArgsServidor(nil); opcoes.API != "127.0.0.1:8081" || opcoes.Painel != "" || opcoes.GRPC != "" {
+		t.Fatalf("sem argumentos deveria servir só a API em 127.0.0.1:8081: %+v", opcoes)
+	}
+	for _, args := range [][]string{{"--bogus"}, {"list"}, {"--listen="}} {
+		if _, err := interpretarArgsServidor(args); err == nil {
+			t.Fatalf("%v deveria ser recusado", args)
+		}
+	}
+}
+
+func TestOuvintesDoSystemd(t *testing.T) {
+	t.Parallel()
+	aberto, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer aberto.Close()
+	arquivo, err := aberto.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer arquivo.Close()
+
+	ambiente := map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "1", "LISTEN_FDNAMES": "grpc"}
+	ouvintes, err := ouvintesHerdados(func(nome string) string { return ambiente[nome] }, 42, int(arquivo.Fd()))
+	if err != nil || len(ouvintes) != 1 || ouvintes["grpc"] == nil {
+		t.Fatalf("esperado o socket do gRPC: %v %v", err, ouvintes)
+	}
+	defer ouvintes["grpc"].Close()
+	if ouvintes["grpc"].Addr().String() != aberto.Addr().String() {
+		t.Fatalf("socket herdado em %s, aberto em %s", ouvintes["grpc"].Addr(), aberto.Addr())
+	}
+
+	// As variáveis de outro processo (LISTEN_PID diferente) são ignoradas
+	if ouvintes, err := ouvintesHerdados(func(nome string) string { return ambiente[nome] }, 7, int(arquivo.Fd())); err != nil || len(ouvintes) != 0 {
+		t.Fatalf("sem ativação para este processo: %v %v", err, ouvintes)
+	}
+	ambiente["LISTEN_FDNAMES"] = "board"
+	if _, err := ouvintesHerdados(func(nome string) string { return ambiente[nome] }, 42, int(arquivo.Fd())); err == nil {
+		t.Fatal("socket de serviço desconhecido deveria ser recusado")
+	}
+}
+
+func TestNotificaSystemd(t *testing.T) {
+	t.Parallel()
+	caminho := filepath.Join(t.TempDir(), "notify.sock")
+	socket, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: caminho, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer socket.Close()
+
+	if err := notificarSocket(caminho, "READY=1"); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 64)
+	socket.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := socket.Read(buf)
+	if err != nil || string(buf[:n]) != "READY=1" {
+		t.Fatalf("esperado READY=1: %q %v", buf[:n], err)
+	}
+	if err := notificarSocket("", "READY=1"); err != nil {
+		t.Fatalf("fora do systemd a notificação não faz nada: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// primeiroFDSystemd é o primeiro descritor passado pela ativação por socket (SD_LISTEN_FDS_START)
+const primeiroFDSystemd = 3
+
+// ouvintesSystemd devolve os sockets recebidos pela ativação por socket do systemd, por serviço
+// ("api" ou "grpc"): pelo FileDescriptorName= de cada um no .socket ou, sem nomes, na ordem do
+// .socket (o primeiro é a API e o segundo o gRPC). Sem ativação, devolve um mapa vazio. As
+// variáveis LISTEN_* são apagadas, para não passarem a processos filhos.
+func ouvintesSystemd() (map[string]net.Listener, error) {
+	defer func() {
+		for _, nome := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+			os.Unsetenv(nome)
+		}
+	}()
+	return ouvintesHerdados(os.Getenv, os.Getpid(), primeiroFDSystemd)
+}
+
+// ouvintesHerdados lê LISTEN_PID, LISTEN_FDS e LISTEN_FDNAMES pelo ambiente informado e abre os
+// descritores a partir de primeiro
+func ouvintesHerdados(ambiente func(string) string, pid, primeiro int) (map[string]net.Listener, error) {
+	ouvintes := make(map[string]net.Listener)
+	if ambiente("LISTEN_PID") != strconv.Itoa(pid) {
+		return ouvintes, nil // Sem ativação, ou as variáveis eram de outro processo
+	}
+	n, err := strconv.Atoi(ambiente("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return ouvintes, fmt.Errorf("LISTEN_FDS inválido: '%s'", ambiente("LISTEN_FDS"))
+	}
+	var nomes []string
+	if lista := ambiente("LISTEN_FDNAMES"); lista != "" {
+		nomes = strings.Split(lista, ":")
+	}
+	servicos := []string{"api", "grpc"}
+	for i := range n {
+		servico := ""
+		switch {
+		case i < len(nomes) && nomes[i] != "unknown":
+			servico = nomes[i]
+		case i < len(servicos):
+			servico = servicos[i]
+		}
+		if servico != "api" && servico != "grpc" {
+			return ouvintes, fmt.Errorf("socket %d do systemd sem serviço conhecido (use FileDescriptorName=api ou grpc): '%s'", i+1, servico)
+		}
+		if _, repetido := ouvintes[servico]; repetido {
+			return ouvintes, fmt.Errorf("o systemd passou dois sockets para '%s'", servico)
+		}
+		arquivo := os.NewFile(uintptr(primeiro+i), "systemd:"+servico)
+		ouvinte, err := net.FileListener(arquivo)
+		arquivo.Close() // FileListener duplica o descritor
+		if err != nil {
+			return ouvintes, fmt.Errorf("socket '%s' do systemd: %v", servico, err)
+		}
+		ouvintes[servico] = ouvinte
+	}
+	return ouvintes, nil
+}
+
+// notificarSystemd envia o estado ao systemd pelo NOTIFY_SOCKET (sd_notify), ex: "READY=1" com
+// Type=notify; sem NOTIFY_SOCKET (fora do systemd), não faz nada
+func notificarSystemd(estado string) error {
+	return notificarSocket(os.Getenv("NOTIFY_SOCKET"), estado)
+}
+
+// notificarSocket envia o estado ao socket informado; "@" no início é um endereço abstrato do Linux
+func notificarSocket(caminho, estado string) error {
+	if caminho == "" {
+		return nil
+	}
+	if strings.HasPrefix(caminho, "@") {
+		caminho = "\x00" + caminho[1:]
+	}
+	conexao, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: caminho, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("erro ao abrir NOTIFY_SOCKET: %v", err)
+	}
+	defer conexao.Close()
+	if _, err := conexao.Write([]byte(estado)); err != nil {
+		return fmt.Errorf("erro ao notificar o systemd: %v", err)
+	}
+	return nil
+}
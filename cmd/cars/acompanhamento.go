@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// linhaAuditoria formata um evento para o tail, atualizando o último estado conhecido de cada carro
+func linhaAuditoria(e cars.Evento, anterior map[string]cars.Carro, exibicao cars.OpcoesExibicao) string {
+	detalhe := detalheEvento(e, anterior, exibicao)
+	instante := e.Em
+	if em, err := time.Parse(time.RFC3339Nano, e.Em); err == nil {
+		instante = em.Local().Format("02/01 15:04:05")
+	}
+	autor := e.Autor
+	if autor == "" {
+		autor = "-"
+	}
+	return strings.TrimSuffix(fmt.Sprintf("[%s] #%d %s %s por %s: %s", instante, e.Seq, e.Tipo, e.CarroID, autor, detalhe), ": ")
+}
+
+// AcompanharAuditoria mostra as últimas entradas do log que passam pelo filtro e, com seguir,
+// continua mostrando as novas conforme acontecem até o usuário teclar Enter
+func (c *sessao) AcompanharAuditoria(filtro cars.FiltroAuditoria, ultimas int, seguir bool) {
+	canal, anteriores, cancelar := c.AssinarEventos()
+	defer cancelar()
+	exibicao := c.Exibicao()
+
+	estado := make(map[string]cars.Carro)
+	var linhas []string
+	for _, e := range anteriores {
+		linha := linhaAuditoria(e, estado, exibicao)
+		if filtro.Aceita(e) {
+			linhas = append(linhas, linha)
+		}
+	}
+	if len(linhas) > ultimas {
+		linhas = linhas[len(linhas)-ultimas:]
+	}
+	for _, linha := range linhas {
+		fmt.Println(linha)
+	}
+	if !seguir {
+		if len(linhas) == 0 {
+			fmt.Println("Nenhum evento registrado com esse filtro.")
+		}
+		return
+	}
+
+	fim := make(chan struct{})
+	go func() {
+		defer close(fim)
+		for e := range canal {
+			if linha := linhaAuditoria(e, estado, exibicao); filtro.Aceita(e) {
+				fmt.Println(linha)
+			}
+		}
+	}()
+	c.cli.Perguntar("🔧 Acompanhando o log ao vivo (Enter para parar)...\n")
+	cancelar()
+	<-fim
+	fmt.Println("Acompanhamento encerrado.")
+}
+
+// interpretarArgsAuditoria lê `tail [-f] [-n=<n>] [--user=<autor>] [--car=<ID>] [--action=<tipo>]`
+func interpretarArgsAuditoria(args []string) (filtro cars.FiltroAuditoria, ultimas int, seguir bool, err error) {
+	if len(args) == 0 || args[0] != "tail" {
+		return filtro, 0, false, fmt.Errorf("uso: audit tail [-f] [-n=<n>] [--user=<autor>] [--car=<ID>] [--action=<tipo>]")
+	}
+	ultimas = 10
+	for _, arg := range args[1:] {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch nome {
+		case "-f", "--follow":
+			seguir = true
+		case "-n":
+			if ultimas, err = strconv.Atoi(valor); err != nil || ultimas < 0 {
+				return filtro, 0, false, fmt.Errorf("-n deve ser um número inteiro não negativo")
+			}
+		case "--user":
+			filtro.Autor = valor
+		case "--car":
+			filtro.CarroID = valor
+		case "--action":
+			filtro.Acao = valor
+		default:
+			return filtro, 0, false, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	return filtro, ultimas, seguir, nil
+}
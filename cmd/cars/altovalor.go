@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// confirmarAltoValor pede o modelo do carro antes de uma operação sobre carro de alto valor;
+// devolve nil se a operação pode seguir. precos são os valores envolvidos além do preço atual
+// (ex: o novo preço de uma edição).
+func (c *sessao) confirmarAltoValor(carro cars.Carro, operacao string, precos ...cars.Dinheiro) error {
+	limite := c.Config().AltoValor
+	if !limite.Exige(append(precos, carro.Preco)...) {
+		return nil
+	}
+	exibicao := c.Exibicao()
+	fmt.Printf("⚠️  '%s %s' (ID %s) é de alto valor: %s, limite de %s (alto_valor.limite).\n",
+		carro.Marca, carro.Modelo, carro.ID, exibicao.FormatarPreco(carro.Preco), exibicao.FormatarPreco(limite.Limite))
+	resposta, _ := c.cli.Perguntar(fmt.Sprintf("Para confirmar %s, digite o modelo do carro (%s): ", operacao, carro.Modelo))
+	if !cars.ConfirmaModelo(carro, resposta) {
+		return operacaoCancelada("Modelo não confere. Operação cancelada.")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// nomesFormatosFeed devolve os formatos suportados em ordem alfabética
+func nomesFormatosFeed() []string {
+	nomes := make([]string, 0, len(cars.FormatosFeed))
+	for nome := range cars.FormatosFeed {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
+
+// ExportarFeed grava os carros em estoque no formato de um portal de anúncios (no arquivo ou na
+// saída padrão, se arquivo for vazio). Carros que não passam na validação do portal ficam de fora
+// e são listados com o motivo.
+func (c *sessao) ExportarFeed(formato, arquivo, canal string) error {
+	feed, err := c.MontarFeed(formato, canal)
+	if err != nil {
+		return err
+	}
+	for _, r := range feed.Recusados {
+		fmt.Printf("⚠️  %s (%s %s) fora do feed: %s\n", r.Carro.ID, r.Carro.Marca, r.Carro.Modelo, strings.Join(r.Problemas, "; "))
+	}
+
+	if arquivo == "" {
+		return feed.Gravar(os.Stdout)
+	}
+	saida, err := os.Create(arquivo)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo do feed: %v", err)
+	}
+	err = feed.Gravar(saida)
+	if errFechar := saida.Close(); err == nil {
+		err = errFechar
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Feed %s gravado em %s: %d carro(s) anunciado(s), %d fora do feed.\n", feed.Descricao, arquivo, len(feed.Carros), len(feed.Recusados))
+	return nil
+}
+
+// interpretarArgsFeed lê `--format=<webmotors|olx> [arquivo]`
+func interpretarArgsFeed(args []string) (string, string, error) {
+	var formato, arquivo string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--format="):
+			formato = strings.ToLower(strings.TrimPrefix(arg, "--format="))
+		case strings.HasPrefix(arg, "--"):
+			return "", "", fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			arquivo = arg
+		}
+	}
+	if _, existe := cars.FormatosFeed[formato]; !existe {
+		return "", "", fmt.Errorf("informe --format=<%s>", strings.Join(nomesFormatosFeed(), "|"))
+	}
+	return formato, arquivo, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IniciarAPI põe a API REST no ar e mostra o endereço
+func (c *sessao) IniciarAPI(endereco string) error {
+	endereco, err := c.CadastroCarros.IniciarAPI(endereco)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ API REST em http://%s/carros (sem autenticação). Use 'serve stop' para encerrar.\n", endereco)
+	return nil
+}
+
+// PararAPI encerra a API REST e confirma
+func (c *sessao) PararAPI() error {
+	parou, err := c.CadastroCarros.PararAPI()
+	switch {
+	case err != nil:
+		return err
+	case !parou:
+		fmt.Println("Nenhuma API no ar.")
+	default:
+		fmt.Println("✅ API encerrada.")
+	}
+	return nil
+}
+
+// interpretarArgsAPI lê `[--listen=<endereço>]`, com o endereço padrão de cada serviço
+func interpretarArgsAPI(args []string, padrao string) (string, error) {
+	endereco := padrao
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch {
+		case nome == "--listen" && valor != "":
+			endereco = valor
+		default:
+			return "", fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	return endereco, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// assistenteConfiguracao conduz a configuração inicial na primeira execução e grava o config.json
+// no diretório de dados, devolvendo o caminho gravado. Se algo falhar, os padrões são usados.
+func assistenteConfiguracao(cli *CLI) string {
+	caminho := filepath.Join(cars.DiretorioDados(), "config.json")
+
+	readInput := func(prompt, padrao string) string {
+		if v, _ := cli.Perguntar(fmt.Sprintf("%s [%s]: ", prompt, padrao)); v != "" {
+			return v
+		}
+		return padrao
+	}
+
+	fmt.Println("\n👋 Primeira execução: vamos configurar o sistema (Enter aceita o valor sugerido).")
+	cfg := cars.Config{Exibicao: cars.ConfigPadrao().Exibicao}
+
+	cfg.Exibicao.Moeda = readInput("Símbolo da moeda", "R$")
+	for {
+		casas, err := strconv.Atoi(readInput("Casas decimais nos preços", "2"))
+		if err == nil && casas >= 0 && casas <= 4 {
+			cfg.Exibicao.CasasDecimais = casas
+			break
+		}
+		fmt.Println("Informe um número de 0 a 4.")
+	}
+
+	for {
+		cfg.Armazenamento.Tipo = strings.ToLower(readInput("Armazenamento (json, yaml, msgpack, protobuf ou bbolt)", "json"))
+		if _, existe := cars.Serializadores[cfg.Armazenamento.Tipo]; existe || cfg.Armazenamento.Tipo == "bbolt" {
+			break
+		}
+		fmt.Println("Escolha json, yaml, msgpack, protobuf ou bbolt.")
+	}
+	arquivo := "carros.db"
+	if s, existe := cars.Serializadores[cfg.Armazenamento.Tipo]; existe {
+		arquivo = "carros." + s.Extensao()
+	}
+	cfg.Armazenamento.Arquivo = readInput("Arquivo de dados", filepath.Join(cars.DiretorioDados(), arquivo))
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(caminho), 0755)
+	}
+	if err == nil {
+		err = cars.GravarAtomico(caminho, data, false)
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Aviso: Falha ao gravar configuração: %v. Usando padrões.\n", err)
+		return caminho
+	}
+
+	// Relê o arquivo pelo caminho normal, garantindo que o que foi gravado é uma configuração válida
+	if _, err := cars.CarregarConfig(caminho, ""); err != nil {
+		fmt.Printf("⚠️  Aviso: configuração gravada é inválida (%v); corrija %s.\n", err, caminho)
+		return caminho
+	}
+	fmt.Printf("✅ Configuração gravada em %s.\n", caminho)
+	return caminho
+}
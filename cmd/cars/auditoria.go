@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CompactarAuditoria compacta o log de eventos e informa o resultado
+func (c *sessao) CompactarAuditoria(meses int) error {
+	resultado, err := c.CadastroCarros.CompactarAuditoria(meses)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	if resultado.Exportados == 0 {
+		fmt.Printf("Nenhum evento anterior a %s para compactar.\n", resultado.Corte.Format("01/2006"))
+		return nil
+	}
+	fmt.Printf("🗜️  Log de eventos compactado: %d evento(s) de %s a %s exportado(s) para %s; %d evento(s) com o estado líquido ficam no lugar.\n",
+		resultado.Exportados, resultado.De, resultado.Ate, resultado.Arquivo, resultado.Liquidos)
+	return err
+}
+
+// interpretarArgsCompactacao lê `[--months=<n>]`; sem a opção, usa meses (a retenção configurada)
+func interpretarArgsCompactacao(args []string, meses int) (int, error) {
+	for _, arg := range args {
+		valor, ok := strings.CutPrefix(arg, "--months=")
+		if !ok {
+			return 0, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		n, err := strconv.Atoi(valor)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("--months deve ser um número inteiro positivo")
+		}
+		meses = n
+	}
+	if meses <= 0 {
+		return 0, fmt.Errorf("informe --months=<n> ou configure auditoria.retencao_meses")
+	}
+	return meses, nil
+}
+
+// MostrarResumosAuditoria lista os meses compactados, com as contagens e o arquivo dos eventos
+func (c *sessao) MostrarResumosAuditoria(modo cars.ModoTabela) {
+	resumos := c.Snapshot().ResumosAuditoria()
+	if len(resumos) == 0 {
+		fmt.Println("\nNenhum mês compactado; o log de eventos está completo.")
+		return
+	}
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Mês", Essencial: true},
+		{Titulo: "Eventos", Direita: true, Essencial: true},
+		{Titulo: "Adicionados", Direita: true},
+		{Titulo: "Atualizados", Direita: true},
+		{Titulo: "Removidos", Direita: true},
+		{Titulo: "Vendidos", Direita: true},
+		{Titulo: "Carros", Direita: true},
+		{Titulo: "Arquivo", Essencial: true},
+	}}
+	for _, r := range resumos {
+		t.Linhas = append(t.Linhas, []string{
+			r.Mes, strconv.Itoa(r.Eventos), strconv.Itoa(r.Adicionados), strconv.Itoa(r.Atualizados),
+			strconv.Itoa(r.Removidos), strconv.Itoa(r.Vendidos), strconv.Itoa(r.Carros), r.Arquivo,
+		})
+	}
+	fmt.Printf("\n--- Meses Compactados do Log de Eventos (%d) ---\n", len(resumos))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// ListarBackups mostra os backups disponíveis para o restore, do mais recente ao mais antigo
+func (c *sessao) ListarBackups(modo cars.ModoTabela) error {
+	lista, err := c.Backups()
+	if err != nil {
+		return err
+	}
+	if len(lista.Backups) == 0 {
+		if lista.Manter == 0 {
+			fmt.Println("Nenhum backup: estão desligados (backup.manter = 0).")
+		} else {
+			fmt.Printf("Nenhum backup em %s ainda; o primeiro é feito na próxima gravação.\n", lista.Diretorio)
+		}
+		return nil
+	}
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "#", Direita: true, Essencial: true},
+		{Titulo: "Backup", Essencial: true},
+		{Titulo: "Feito em"},
+		{Titulo: "Tamanho", Direita: true},
+	}}
+	for i, backup := range lista.Backups {
+		feito, tamanho := "", ""
+		if !backup.FeitoEm.IsZero() {
+			feito, tamanho = backup.FeitoEm.Format("02/01/2006 15:04:05"), formatarBytes(float64(backup.Tamanho))
+		}
+		t.Linhas = append(t.Linhas, []string{strconv.Itoa(i + 1), filepath.Base(backup.Arquivo), feito, tamanho})
+	}
+	fmt.Printf("\n--- Backups em %s (mantidos: %d) ---\n", lista.Diretorio, lista.Manter)
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	fmt.Println("Use 'restore <#|arquivo> [--dry-run]' para voltar a um deles.")
+	return nil
+}
+
+// RestaurarBackup volta o estoque ao do backup escolhido: mostra a diferença e, após
+// confirmação, aplica a restauração
+func (c *sessao) RestaurarBackup(escolha string, simular bool, modo cars.ModoTabela) error {
+	r, err := c.PlanejarRestauracao(escolha)
+	if err != nil {
+		return err
+	}
+	d, nome := r.Diferenca, filepath.Base(r.Arquivo)
+	if d.Vazia() {
+		fmt.Printf("✅ O estoque já é igual ao de %s; nada a restaurar.\n", nome)
+		return nil
+	}
+	fmt.Printf("\n--- Restauração de %s ---", nome)
+	mostrarDiferenca(d, "Voltam ao estoque", "Saem do estoque (removidos)", c.Exibicao(), modo)
+	if simular {
+		fmt.Println("Simulação: nada foi alterado.")
+		return nil
+	}
+	if !c.cli.Confirmar("Restaurar o estoque deste backup? (s/N): ") {
+		return operacaoCancelada("Restauração cancelada.")
+	}
+
+	err = c.AplicarRestauracao(r)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Estoque restaurado de %s: %d carro(s) de volta, %d alterado(s), %d removido(s).\n",
+		nome, len(d.Adicionados), len(d.Alterados), len(d.Removidos))
+	return err
+}
+
+// interpretarArgsRestauracao lê `[<#|arquivo>] [--dry-run] [--wide|--narrow]`; sem backup, lista
+func interpretarArgsRestauracao(args []string) (escolha string, simular bool, modo cars.ModoTabela, err error) {
+	modo, args = interpretarModoTabela(args)
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			simular = true
+		case strings.HasPrefix(arg, "--"):
+			return "", false, modo, fmt.Errorf("opção desconhecida: %s", arg)
+		case escolha != "":
+			return "", false, modo, fmt.Errorf("informe um único backup")
+		default:
+			escolha = arg
+		}
+	}
+	return escolha, simular, modo, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// PesquisarCarros mostra os carros encontrados pelos filtros e termos livres
+func (c *sessao) PesquisarCarros(termos []string, modo cars.ModoTabela) error {
+	defer c.Medir("search")()
+	resultados, err := c.Pesquisar(termos)
+	if err != nil {
+		return err
+	}
+	if len(resultados) == 0 {
+		c.ultimoResultado.guardar("search "+strings.Join(termos, " "), nil)
+		return fmt.Errorf("nenhum carro encontrado para '%s'", strings.Join(termos, " "))
+	}
+
+	fmt.Printf("\n--- %d Carro(s) Encontrado(s) para '%s' ---\n", len(resultados), strings.Join(termos, " "))
+	carros := make([]cars.Carro, len(resultados))
+	for i, r := range resultados {
+		carros[i] = r.Carro
+	}
+	c.ultimoResultado.guardar("search "+strings.Join(termos, " "), carros)
+	t := c.tabelaCarros(carros)
+	// Só os termos livres pontuam, e todo resultado deles casou com algum campo
+	if resultados[0].Pontuacao > 0 {
+		t.Colunas = append(t.Colunas, cars.ColunaTabela{Titulo: "Relevância", Direita: true})
+		for i, r := range resultados {
+			t.Linhas[i] = append(t.Linhas[i], strconv.Itoa(r.Pontuacao))
+		}
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// ImportarCotacoes importa as cotações do arquivo, preenche o câmbio de compra e informa o resultado
+func (c *sessao) ImportarCotacoes(arquivo string, forcar bool) error {
+	resultado, err := c.PreencherCambio(arquivo, forcar)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ %d cotação(ões) importada(s); câmbio de compra preenchido em %d carro(s).\n", resultado.Cotacoes, resultado.Preenchidos)
+	if resultado.JaTinham > 0 {
+		fmt.Printf("   %d carro(s) já tinham câmbio e foram mantidos (use --force para refazer).\n", resultado.JaTinham)
+	}
+	if len(resultado.SemMoeda) > 0 {
+		fmt.Printf("⚠️  Aviso: moeda desconhecida para o país de origem de %d carro(s); informe o campo moeda com edit: %s\n",
+			len(resultado.SemMoeda), strings.Join(resultado.SemMoeda, ", "))
+	}
+	if len(resultado.SemCotacao) > 0 {
+		fmt.Printf("⚠️  Aviso: sem cotação até a data de cadastro de %d carro(s): %s\n", len(resultado.SemCotacao), strings.Join(resultado.SemCotacao, ", "))
+	}
+	return err
+}
+
+// RelatorioCambio mostra, para os carros em estoque com câmbio de compra e custo conhecidos,
+// quanto o câmbio andou desde a compra, o custo de reposição na cotação atual e o preço
+// necessário para manter a margem original
+func (c *sessao) RelatorioCambio(modo cars.ModoTabela) {
+	visao := c.Snapshot()
+	exibicao := visao.Exibicao()
+	carros := visao.Carros()
+	if len(visao.Cotacoes()) == 0 {
+		fmt.Println("\nNenhuma cotação importada ainda. Use 'fx backfill --file=<cotacoes.csv>'.")
+		return
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Moeda", Essencial: true},
+		{Titulo: "Câmbio Compra", Direita: true},
+		{Titulo: "Câmbio Atual", Direita: true},
+		{Titulo: "Variação", Direita: true, Essencial: true},
+		{Titulo: "Custo", Direita: true},
+		{Titulo: "Reposição", Direita: true},
+		{Titulo: "Margem Compra", Direita: true},
+		{Titulo: "Margem Atual", Direita: true, Essencial: true},
+		{Titulo: "Preço p/ Margem", Direita: true, Essencial: true},
+	}}
+	var semDados int
+	var custoTotal, reposicaoTotal cars.Dinheiro
+	for _, carro := range carros {
+		atual, ok := visao.CotacaoAtual(carro.Moeda)
+		if carro.CambioCompra <= 0 || carro.Custo <= 0 || carro.Preco <= 0 || !ok {
+			semDados++
+			continue
+		}
+		fator := atual.Taxa / carro.CambioCompra
+		reposicao := carro.Custo.Multiplicar(fator)
+		margem := func(custo cars.Dinheiro) string {
+			return fmt.Sprintf("%.1f%%", (carro.Preco-custo).EmReais()/carro.Preco.EmReais()*100)
+		}
+		custoTotal += carro.Custo
+		reposicaoTotal += reposicao
+		t.Linhas = append(t.Linhas, []string{
+			carro.ID, carro.Marca + " " + carro.Modelo, carro.Moeda,
+			strconv.FormatFloat(carro.CambioCompra, 'f', 4, 64), strconv.FormatFloat(atual.Taxa, 'f', 4, 64),
+			fmt.Sprintf("%+.1f%%", (fator-1)*100),
+			exibicao.FormatarPreco(carro.Custo), exibicao.FormatarPreco(reposicao),
+			margem(carro.Custo), margem(reposicao),
+			exibicao.FormatarPreco(carro.Preco.Multiplicar(fator)),
+		})
+	}
+
+	fmt.Println("\n--- Repreço pelo Câmbio (cotação atual = última importada) ---")
+	if len(t.Linhas) == 0 {
+		fmt.Println("Nenhum carro em estoque com câmbio de compra e custo informados.")
+	} else {
+		fmt.Print(t.Renderizar(modo, larguraTerminal()))
+		fmt.Printf("\nCusto de compra: %s | Custo de reposição: %s | Diferença: %s\n",
+			exibicao.FormatarPreco(custoTotal), exibicao.FormatarPreco(reposicaoTotal),
+			exibicao.FormatarPreco(reposicaoTotal-custoTotal))
+	}
+	if semDados > 0 {
+		fmt.Printf("%d carro(s) fora do relatório por falta de câmbio de compra, custo ou cotação atual.\n", semDados)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// MostrarPrecosCanal mostra o preço do carro em cada canal de venda e de onde ele vem
+func (c *sessao) MostrarPrecosCanal(id string, modo cars.ModoTabela) error {
+	visao := c.Snapshot()
+	carro, existe := visao.Carro(id)
+	if !existe {
+		return cars.ErroCarroNaoEncontrado(id)
+	}
+	canais := c.Config().Canais
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Canal", Essencial: true},
+		{Titulo: "Preço", Direita: true, Essencial: true},
+		{Titulo: "Sobre o Base", Direita: true},
+		{Titulo: "Origem"},
+	}}
+	for _, canal := range cars.CanaisVenda {
+		preco, origem := canais.Preco(carro, canal)
+		variacao := "-"
+		if preco != carro.Preco {
+			variacao = fmt.Sprintf("%+.1f%%", (float64(preco)/float64(carro.Preco)-1)*100)
+		}
+		t.Linhas = append(t.Linhas, []string{canal, visao.Exibicao().FormatarPreco(preco), variacao, origem})
+	}
+	fmt.Printf("\n--- Preços por Canal: %s %s %d (base %s) ---\n", carro.Marca, carro.Modelo, carro.Ano, visao.Exibicao().FormatarPreco(carro.Preco))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	return nil
+}
+
+// DefinirPrecoCanal grava (ou, com preco zero, retira) o preço próprio do carro em um canal
+func (c *sessao) DefinirPrecoCanal(id, canal string, preco cars.Dinheiro) error {
+	carro, alterado, err := c.AlterarPrecoCanal(id, canal, preco)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	if !alterado {
+		fmt.Printf("O carro não tem preço próprio em %s; nada a retirar.\n", canal)
+		return nil
+	}
+	exibicao := c.Exibicao()
+	if preco == 0 {
+		atual, _ := c.Config().Canais.Preco(carro, canal)
+		fmt.Printf("✅ Preço próprio em %s retirado; o canal volta a %s.\n", canal, exibicao.FormatarPreco(atual))
+	} else {
+		fmt.Printf("✅ Preço em %s definido em %s.\n", canal, exibicao.FormatarPreco(preco))
+	}
+	return err
+}
+
+// separarOpcaoCanal retira `--channel=<canal>` dos argumentos de uma exportação e devolve o canal
+// ("" = preço base) e os demais argumentos
+func separarOpcaoCanal(args []string) (canal string, resto []string, err error) {
+	for _, arg := range args {
+		valor, ehCanal := strings.CutPrefix(arg, "--channel=")
+		if !ehCanal {
+			resto = append(resto, arg)
+			continue
+		}
+		if canal, err = cars.InterpretarCanal(valor); err != nil {
+			return "", nil, err
+		}
+	}
+	return canal, resto, nil
+}
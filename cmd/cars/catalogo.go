@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// MostrarQRCode desenha no terminal o QR code da página do carro no catálogo, para o cliente
+// escanear no pátio, e opcionalmente grava o mesmo código em PNG
+func (c *sessao) MostrarQRCode(id, arquivoPNG string, tamanho int) error {
+	catalogo := c.Config().Catalogo
+	carro, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+	if catalogo.URL == "" {
+		return errors.New("endereço do catálogo não configurado. Informe catalogo.url no config.json (ex: \"https://loja.com.br/carros/{id}\")")
+	}
+
+	endereco := catalogo.EnderecoCarro(id)
+	codigo, err := qrcode.New(endereco, qrcode.Medium)
+	if err != nil {
+		return fmt.Errorf("erro ao gerar QR code: %v", err)
+	}
+	fmt.Printf("\n%s %s %d — %s\n", carro.Marca, carro.Modelo, carro.Ano, c.Exibicao().FormatarPreco(carro.Preco))
+	fmt.Print(codigo.ToSmallString(false))
+	fmt.Println(endereco)
+
+	if arquivoPNG != "" {
+		if err := codigo.WriteFile(tamanho, arquivoPNG); err != nil {
+			return fmt.Errorf("erro ao gravar PNG: %v", err)
+		}
+		fmt.Printf("✅ QR code gravado em %s (%dx%d px).\n", arquivoPNG, tamanho, tamanho)
+	}
+	return nil
+}
+
+// interpretarArgsQRCode lê `<ID> [--png=<arquivo>] [--size=<px>]`
+func interpretarArgsQRCode(args []string) (id, arquivoPNG string, tamanho int, err error) {
+	tamanho = 512
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch {
+		case nome == "--png":
+			if valor == "" {
+				return "", "", 0, fmt.Errorf("informe o arquivo em --png=<arquivo>")
+			}
+			arquivoPNG = valor
+		case nome == "--size":
+			if tamanho, err = strconv.Atoi(valor); err != nil || tamanho < 64 || tamanho > 4096 {
+				return "", "", 0, fmt.Errorf("tamanho inválido: %s (de 64 a 4096 px)", valor)
+			}
+		case strings.HasPrefix(arg, "--") || id != "":
+			return "", "", 0, fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			id = arg
+		}
+	}
+	if id == "" {
+		return "", "", 0, fmt.Errorf("informe o ID do carro")
+	}
+	return id, arquivoPNG, tamanho, nil
+}
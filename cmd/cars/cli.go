@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CLI é a fonte de entrada da sessão interativa: o laço de comandos e todas as perguntas
+// (confirmações, cadastro, edição) leem do mesmo scanner, para não haver dois leitores
+// disputando o buffer. O programa usa os.Stdin; testes e outras interfaces passam qualquer io.Reader.
+type CLI struct {
+	entrada *bufio.Scanner
+	linhas  chan leituraCLI // Resultado da leitura em andamento
+	lendo   bool            // Há uma leitura em andamento cujo resultado ainda não foi consumido
+}
+
+// sessao é o prompt interativo aberto sobre um cadastro: os comandos recebem a sessão, que
+// pergunta, mostra e pagina; o cadastro só guarda os dados e devolve valores e erros
+type sessao struct {
+	*cars.CadastroCarros
+	cli             *CLI            // Entrada das perguntas interativas (stdin, salvo quando outra é injetada)
+	ultimoResultado resultadoSessao // Último conjunto exibido por list/search, exportável com `:export`
+}
+
+// novaSessao abre o prompt sobre o cadastro, lendo da entrada padrão
+func novaSessao(c *cars.CadastroCarros) *sessao {
+	c.AoNotificar(tocarSino)
+	c.AoOperacaoLenta(avisarOperacaoLenta)
+	c.AoGravarBaseGrande(mostrarResumoGravacao)
+	return &sessao{CadastroCarros: c, cli: NovoCLI(os.Stdin)}
+}
+
+// leituraCLI é o resultado de uma leitura de linha
+type leituraCLI struct {
+	linha string
+	ok    bool
+}
+
+// NovoCLI cria uma fonte de entrada que lê linhas de r
+func NovoCLI(r io.Reader) *CLI {
+	return &CLI{entrada: bufio.NewScanner(r), linhas: make(chan leituraCLI, 1)}
+}
+
+// lerLinha lê a próxima linha crua; ok é false no fim da entrada ou em erro de leitura
+func (cli *CLI) lerLinha() (linha string, ok bool) {
+	linha, ok, _ = cli.lerLinhaAte(0)
+	return linha, ok
+}
+
+// lerLinhaAte lê a próxima linha esperando no máximo `limite` (0 = sem limite). Se o tempo
+// acabar, expirou é true e a leitura continua pendente: a próxima chamada recebe a linha.
+// A entrada só é lida quando alguém pede uma linha, para não disputar o terminal com o editor.
+func (cli *CLI) lerLinhaAte(limite time.Duration) (linha string, ok, expirou bool) {
+	if !cli.lendo {
+		cli.lendo = true
+		go func() {
+			ok := cli.entrada.Scan()
+			cli.linhas <- leituraCLI{linha: cli.entrada.Text(), ok: ok}
+		}()
+	}
+	var r leituraCLI
+	if limite <= 0 {
+		r = <-cli.linhas
+	} else {
+		temporizador := time.NewTimer(limite)
+		defer temporizador.Stop()
+		select {
+		case r = <-cli.linhas:
+		case <-temporizador.C:
+			return "", false, true
+		}
+	}
+	cli.lendo = false
+	return r.linha, r.ok, false
+}
+
+// Perguntar mostra o prompt e devolve a resposta sem espaços nas pontas.
+// No fim da entrada a resposta é vazia; o erro só vem de falhas de leitura.
+func (cli *CLI) Perguntar(prompt string) (string, error) {
+	fmt.Print(prompt)
+	linha, ok := cli.lerLinha()
+	if !ok {
+		if err := cli.entrada.Err(); err != nil {
+			return "", fmt.Errorf("erro no input: %v", err)
+		}
+	}
+	return strings.TrimSpace(linha), nil
+}
+
+// Confirmar faz uma pergunta de sim/não; só "s" ou "sim" confirmam
+func (cli *CLI) Confirmar(prompt string) bool {
+	resposta, _ := cli.Perguntar(prompt)
+	resposta = strings.ToLower(resposta)
+	return resposta == "s" || resposta == "sim"
+}
+
+// Executar roda o laço de comandos sobre o cadastro até `exit` ou o fim da entrada.
+// As perguntas feitas pelos comandos também passam a ler desta entrada.
+func (cli *CLI) Executar(c *sessao) {
+	c.cli = cli
+	for {
+		c.mostrarNotificacoes()
+		fmt.Printf("\n%s> ", c.indicadorPrompt())
+		linha, ok, expirou := cli.lerLinhaAte(c.tempoBloqueio())
+		if expirou {
+			fmt.Println()
+			if !c.BloquearSessao("inatividade") {
+				return
+			}
+			continue
+		}
+		if !ok {
+			if err := cli.entrada.Err(); err != nil {
+				fmt.Printf("Erro de leitura: %v. Saindo...\n", err)
+			}
+			return
+		}
+		if errors.Is(executarLinha(c, linha), errSair) {
+			return
+		}
+	}
+}
+
+// indicadorPrompt sinaliza no prompt quando há alterações que não foram gravadas
+func (c *sessao) indicadorPrompt() string {
+	if c.AlteracoesPendentes() {
+		return "[não salvo, use 'flush'] "
+	}
+	return ""
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+	"github.com/michellhornung/golang/cars/carrostest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// sessaoTeste roda os comandos da entrada sobre um cadastro vazio, gravado só em memória
+func sessaoTeste(t *testing.T, entrada string) *sessao {
+	t.Helper()
+	c := novaSessao(cars.NewCadastroCarrosEm(&cars.ArmazenamentoMemoria{}))
+	NovoCLI(strings.NewReader(entrada)).Executar(c)
+	return c
+}
+
+// configurar troca uma parte da configuração em vigor da sessão
+func configurar(c *sessao, alterar func(*cars.Config)) {
+	cfg := c.Config()
+	alterar(&cfg)
+	c.Configurar(cfg, c.ArquivoConfig(), false)
+}
+
+func TestCLIConduzSessaoPorReader(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "quickadd Toyota Corolla 2021 Prata 145k Japão\ns\nquickadd Fiat Uno 2020 Azul 50000 Itália\nn\nexit\nquickadd BMW X5 2022 Preto 400k Alemanha\ns\n")
+	carros := c.Snapshot().Carros()
+	if len(carros) != 1 {
+		t.Fatalf("esperado 1 carro cadastrado, obtido %d", len(carros))
+	}
+	if carro := carros[0]; carro.Marca != "Toyota" || carro.Preco != cars.Reais(145000) {
+		t.Fatalf("carro cadastrado inesperado: %+v", carro)
+	}
+}
+
+func TestCLIFimDaEntradaEncerraSessao(t *testing.T) {
+	t.Parallel()
+	// Sem resposta à confirmação, o cadastro é cancelado e o laço termina sem `exit`
+	c := sessaoTeste(t, "quickadd Toyota Corolla 2021 Prata 145k Japão\n")
+	if n := len(c.Snapshot().Carros()); n != 0 {
+		t.Fatalf("esperado nenhum carro, obtido %d", n)
+	}
+}
+
+func TestCLILeituraExpiradaContinuaPendente(t *testing.T) {
+	t.Parallel()
+	r, w := io.Pipe()
+	cli := NovoCLI(r)
+	if _, _, expirou := cli.lerLinhaAte(10 * time.Millisecond); !expirou {
+		t.Fatal("esperado tempo esgotado sem entrada")
+	}
+	go io.WriteString(w, "list\n")
+	if linha, ok, expirou := cli.lerLinhaAte(time.Second); !ok || expirou || linha != "list" {
+		t.Fatalf("esperado receber a linha pendente, obtido %q (ok=%v, expirou=%v)", linha, ok, expirou)
+	}
+}
+
+func TestBloqueioExigeSenha(t *testing.T) {
+	t.Parallel()
+	hash, err := bcrypt.GenerateFromPassword([]byte("certa"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := novaSessao(cars.NewCadastroCarros(filepath.Join(t.TempDir(), "carros.json")))
+	configurar(c, func(cfg *cars.Config) {
+		cfg.Sessao = cars.OpcoesSessao{BloqueioMinutos: 1, SenhaHash: string(hash)}
+	})
+
+	c.cli = NovoCLI(strings.NewReader("certa\n"))
+	if !c.BloquearSessao("teste") {
+		t.Fatal("esperado desbloqueio com a senha correta")
+	}
+	// Sem a senha correta e com a entrada no fim, a sessão não é devolvida
+	c.cli = NovoCLI(strings.NewReader(""))
+	if c.BloquearSessao("teste") {
+		t.Fatal("esperado encerramento sem a senha")
+	}
+}
+
+func TestAltoValorExigeModeloParaRemover(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "quickadd Ferrari Roma 2023 Vermelho 2500k Itália\ns\nquickadd Fiat Uno 2020 Azul 50000 Itália\ns\nexit\n")
+	configurar(c, func(cfg *cars.Config) {
+		cfg.AltoValor = cars.OpcoesAltoValor{Limite: cars.Reais(1000000)}
+	})
+	carros := c.Snapshot().Carros()
+	ferrari, uno := carros[0].ID, carros[1].ID
+
+	c.cli = NovoCLI(strings.NewReader("remove " + ferrari + "\nPortofino\nremove " + uno + "\nexit\n"))
+	c.cli.Executar(c)
+	if _, existe := c.Snapshot().Carro(ferrari); !existe {
+		t.Fatal("carro de alto valor removido sem o modelo correto")
+	}
+	if _, existe := c.Snapshot().Carro(uno); existe {
+		t.Fatal("carro abaixo do limite deveria ser removido sem confirmação")
+	}
+
+	c.cli = NovoCLI(strings.NewReader("remove " + ferrari + "\nroma\nexit\n"))
+	c.cli.Executar(c)
+	if _, existe := c.Snapshot().Carro(ferrari); existe {
+		t.Fatal("carro de alto valor deveria ser removido com o modelo digitado")
+	}
+}
+
+func TestAddPorOpcoesCadastraSemPerguntas(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "add --marca BMW --modelo \"Série 3\" --ano 2023 --preco=310000 --origem Alemanha --em-transito\nadd --marca Fiat --cavalos 80\nexit\n")
+	carros := c.Snapshot().Carros()
+	if len(carros) != 1 {
+		t.Fatalf("esperado 1 carro cadastrado, obtido %d", len(carros))
+	}
+	if carro := carros[0]; carro.Modelo != "Série 3" || carro.Preco != cars.Reais(310000) || cars.StatusCarro(carro) != cars.StatusEmTransito {
+		t.Fatalf("carro cadastrado inesperado: %+v", carro)
+	}
+	if _, _, err := interpretarOpcoesCarro([]string{"--marca", "Fiat"}, cars.OpcoesMarcas{}); err == nil {
+		t.Fatal("esperado erro sem modelo, ano, preço e origem")
+	}
+}
+
+func TestSubcomandoQueFalhaSaiComErro(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "quickadd Fiat Uno 2020 Azul 50000 Itália\ns\nexit\n")
+	c.cli = NovoCLI(strings.NewReader(""))
+	if codigo := executarSubcomando(c, []string{"remove", "car_inexistente"}); codigo != 1 {
+		t.Fatalf("remove de ID inexistente deveria sair com 1, saiu com %d", codigo)
+	}
+	if codigo := executarSubcomando(c, []string{"trash", "empty"}); codigo != 2 {
+		t.Fatalf("subcomando fora da sintaxe deveria sair com 2, saiu com %d", codigo)
+	}
+	for _, args := range [][]string{{"restore", "--bogus"}, {"search", "ano>=abc"}} {
+		if codigo := executarSubcomando(c, args); codigo != 1 {
+			t.Fatalf("%s com argumento inválido deveria sair com 1, saiu com %d", args[0], codigo)
+		}
+	}
+	id := c.Snapshot().Carros()[0].ID
+	if codigo := executarSubcomando(c, []string{"remove", id}); codigo != 0 || len(c.Snapshot().Carros()) != 0 {
+		t.Fatalf("remove de ID existente deveria sair com 0, saiu com %d", codigo)
+	}
+}
+
+func TestQuickaddPrefereOUltimoAno(t *testing.T) {
+	t.Parallel()
+	carro, err := interpretarLinhaRapida("Peugeot 2008 2015 Branco 62k França")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if carro.Modelo != "2008" || carro.Ano != 2015 || carro.Cor != "Branco" || carro.Preco != cars.Reais(62000) {
+		t.Fatalf("campos extraídos errados: %+v", carro)
+	}
+	if carro, err = interpretarLinhaRapida("Fiat Uno 2020 Azul 2000 Itália"); err != nil || carro.Ano != 2020 || carro.Preco != cars.Reais(2000) {
+		t.Fatalf("preço com cara de ano não deveria virar o ano: %+v (%v)", carro, err)
+	}
+}
+
+func TestListOrdenaEPagina(t *testing.T) {
+	t.Parallel()
+	opcoes, err := interpretarOpcoesListagem([]string{"--sort", "preco", "--desc", "--page", "2", "--page-size=2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carros := make([]cars.Carro, 5)
+	for i := range carros {
+		carros[i] = cars.Carro{ID: fmt.Sprintf("car_%d", i), Marca: "Toyota", Modelo: "Corolla", Ano: 2015 + i, Preco: cars.Reais(145000.5 + float64(i))}
+	}
+	ordenados := cars.OrdenarCarros(carros, opcoes.Ordenar, opcoes.Decrescente)
+	pagina, paginas, err := paginaCarros(ordenados, opcoes.Pagina, opcoes.PorPagina)
+	if err != nil || paginas != 3 || len(pagina) != 2 || pagina[0].ID != carros[2].ID || pagina[1].ID != carros[1].ID {
+		t.Fatalf("página inesperada: %v %d %+v", err, paginas, pagina)
+	}
+	if _, _, err := paginaCarros(ordenados, 4, 2); err == nil {
+		t.Fatal("esperado erro para página além da última")
+	}
+}
+
+func TestValidadorRegistradoRecusaCadastro(t *testing.T) {
+	t.Parallel()
+	c := cars.NewCadastroCarrosEm(&cars.ArmazenamentoMemoria{})
+	c.RegistrarValidador(func(carro cars.Carro) error {
+		if carro.Cor == "" {
+			return errors.New("cor obrigatória nesta loja")
+		}
+		return nil
+	})
+	NovoCLI(strings.NewReader("add --marca Fiat --modelo Uno --ano 2020 --preco 50000 --origem Itália\nquickadd Toyota Corolla 2021 Prata 145k Japão\ns\nexit\n")).Executar(novaSessao(c))
+	if carros := c.Snapshot().Carros(); len(carros) != 1 || carros[0].Marca != "Toyota" {
+		t.Fatalf("só o carro com cor deveria entrar: %+v", carros)
+	}
+}
+
+func TestUndoERedoDoCadastro(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "quickadd Toyota Corolla 2021 Prata 145k Japão\ns\nquickadd Fiat Uno 2020 Azul 50000 Itália\ns\nundo\n")
+	if carros := c.Snapshot().Carros(); len(carros) != 1 || carros[0].Marca != "Toyota" {
+		t.Fatalf("o undo deveria retirar só o último cadastro: %+v", carros)
+	}
+	NovoCLI(strings.NewReader("redo\nredo\nexit\n")).Executar(c)
+	if carros := c.Snapshot().Carros(); len(carros) != 2 || carros[1].Marca != "Fiat" {
+		t.Fatalf("o redo deveria trazer o Uno de volta: %+v", carros)
+	}
+	if _, err := c.CadastroCarros.Refazer(); !errors.Is(err, cars.ErrNadaARefazer) {
+		t.Fatalf("pilha do redo deveria estar vazia, obtido %v", err)
+	}
+}
+
+func TestPaisDaMarcaPreencheEAvisa(t *testing.T) {
+	t.Parallel()
+	marcas := cars.OpcoesMarcas{Paises: map[string]string{"Jeep": "Brasil"}}
+	carro, _, err := interpretarOpcoesCarro([]string{"--marca", "toyota", "--modelo", "Yaris", "--ano", "2022", "--preco", "90000"}, marcas)
+	if err != nil || carro.PaisOrigem != "Japão" {
+		t.Fatalf("país deveria vir da marca: %q (%v)", carro.PaisOrigem, err)
+	}
+	if aviso := marcas.AvisoPais("Toyota", "Alemanha"); aviso == "" {
+		t.Error("Toyota da Alemanha deveria gerar aviso")
+	}
+	for _, caso := range [][2]string{{"Ford", "EUA"}, {"Jeep", "Brasil"}, {"Citroen", "frança"}, {"Lada", "Rússia"}} {
+		if aviso := marcas.AvisoPais(caso[0], caso[1]); aviso != "" {
+			t.Errorf("%s de %s não deveria gerar aviso: %s", caso[0], caso[1], aviso)
+		}
+	}
+}
+
+func TestLixeiraRestauraEApagaRemovidos(t *testing.T) {
+	t.Parallel()
+	c := sessaoTeste(t, "quickadd Toyota Corolla 2021 Prata 145k Japão\ns\nquickadd Fiat Uno 2020 Azul 50000 Itália\ns\nexit\n")
+	carros := c.Snapshot().Carros()
+	corolla, uno := carros[0].ID, carros[1].ID
+
+	c.cli = NovoCLI(strings.NewReader("remove " + corolla + "\nremove " + uno + "\ntrash restore " + corolla + "\ntrash purge " + uno + "\npurge\nexit\n"))
+	c.cli.Executar(c)
+	visao := c.Snapshot()
+	if carro, existe := visao.Carro(corolla); !existe || carro.Preco != cars.Reais(145000) {
+		t.Fatalf("o Corolla deveria voltar da lixeira como estava: %+v", carro)
+	}
+	if itens := visao.Lixeira(); len(itens) != 0 {
+		t.Fatalf("a lixeira deveria estar vazia depois do purge: %+v", itens)
+	}
+	eventos := visao.Eventos()
+	if ultimo := eventos[len(eventos)-1]; ultimo.Tipo != cars.EventoCarroPurgado || ultimo.CarroID != uno || eventos[0].CarroID != corolla {
+		t.Fatalf("o purge deveria acrescentar um evento ao log, sem reescrevê-lo: %+v", eventos)
+	}
+}
+
+func TestReplicaRecusaAlteracoesNoPrompt(t *testing.T) {
+	t.Parallel()
+	primario := sessaoTeste(t, "quickadd Toyota Corolla 2021 Prata 145k Japão\ns\nexit\n")
+	endereco, err := primario.CadastroCarros.IniciarAPI("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primario.CadastroCarros.PararAPI()
+
+	replica := sessaoTeste(t, "replica follow http://"+endereco+"\nexit\n")
+	defer replica.CadastroCarros.PromoverReplica()
+	if !replica.SomenteLeitura() {
+		t.Fatal("a réplica deveria seguir o primário")
+	}
+	NovoCLI(strings.NewReader("quickadd BMW X1 2022 Preto 200k Alemanha\ns\nexit\n")).Executar(replica)
+	for _, carro := range replica.Snapshot().Carros() {
+		if carro.Marca == "BMW" {
+			t.Fatal("a réplica deveria recusar o cadastro")
+		}
+	}
+}
+
+func TestRegistroDoPromptSoMostraOQuePassaDoNivel(t *testing.T) {
+	t.Parallel()
+	var saida strings.Builder
+	registro, err := cars.NovoRegistro(&saida, "info", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cars.NewCadastroCarrosEm(&cars.ArmazenamentoMemoria{})
+	c.UsarRegistro(registro)
+	NovoCLI(strings.NewReader("quickadd Toyota Corolla 2021 Prata 145k Japão\ns\nexit\n")).Executar(novaSessao(c))
+	if saida.Len() != 0 {
+		t.Fatalf("comandos do prompt só entram no log em debug: %s", saida.String())
+	}
+}
+
+func TestArgsDoClienteRPC(t *testing.T) {
+	t.Parallel()
+	chamada, err := interpretarArgsRPC([]string{"delete", "car_1", "--confirm=X5", "--addr=estoque:9000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chamada.Operacao != "delete" || chamada.Endereco != "estoque:9000" || chamada.Confirmar != "X5" || len(chamada.Argumentos) != 1 {
+		t.Fatalf("chamada lida errada: %+v", chamada)
+	}
+	if chamada, err := interpretarArgsRPC([]string{"update", "car_1", `{"cor": "Preto"}`}); err != nil || chamada.Endereco != "127.0.0.1:8082" {
+		t.Fatalf("update com patch: %v %+v", err, chamada)
+	}
+	for _, args := range [][]string{{"get"}, {"update", "car_1"}, {"list", "x"}, {"add"}, {"sell", "car_1"}, {"--bogus", "list"}} {
+		if _, err := interpretarArgsRPC(args); err == nil {
+			t.Fatalf("%v deveria ser recusado", args)
+		}
+	}
+}
+
+func TestGravacaoDeBaseGrandeMostraResumo(t *testing.T) {
+	carros := make([]cars.Carro, 10000)
+	for i := range carros {
+		carros[i] = cars.Carro{ID: fmt.Sprintf("car_%d", i+1), Marca: "Toyota", Modelo: "Corolla", Ano: 2021, Cor: "Prata",
+			Preco: cars.Reais(145000), PaisOrigem: "Japão", DataCadastro: "2025-01-10"}
+	}
+	data, err := json.Marshal(carros)
+	if err != nil {
+		t.Fatal(err)
+	}
+	arquivo := filepath.Join(t.TempDir(), "carros.json")
+	if err := os.WriteFile(arquivo, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := cars.NewCadastroCarros(arquivo)
+	carrostest.CapturarSaida(t, func() {
+		if _, err := c.AbrirArmazenamento(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// O resumo sai no terminal mesmo com o log no nível padrão (warn)
+	saida := carrostest.CapturarSaida(t, func() {
+		NovoCLI(strings.NewReader("quickadd Fiat Uno 2020 Azul 50000 Itália\ns\nexit\n")).Executar(novaSessao(c))
+	})
+	if !strings.Contains(saida, "💾 10001 carro(s) salvo(s) em ") {
+		t.Fatalf("a gravação de uma base grande deveria mostrar o resumo:\n%s", saida)
+	}
+}
@@ -0,0 +1,1354 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// Comando descreve um comando do prompt interativo. O mesmo registro alimenta o
+// despacho e o `help`, para que a ajuda nunca fique diferente do comportamento real.
+type Comando struct {
+	Nome      string   // Nome digitado no prompt
+	Sintaxe   string   // Forma de uso, ex: "find <ID>"
+	Descricao string   // Resumo de uma linha
+	Opcoes    []string // Flags aceitas, uma por linha ("--flag=valor  explicação")
+	Exemplos  []string // Dois ou três usos concretos
+	MinArgs   int      // Quantidade mínima de argumentos após o nome
+	Paginado  bool     // Saída longa passa pelo pager quando a sessão está em um terminal
+
+	// Executar roda o comando; args são os argumentos após o nome e resto é a linha original
+	// sem o nome (útil quando o texto livre precisa ser preservado). Devolve o motivo da falha,
+	// que o despacho mostra, ou errSair para encerrar a sessão.
+	Executar func(c *sessao, args []string, resto string) error
+}
+
+// errSair é devolvido pelo comando que encerra a sessão
+var errSair = errors.New("sair")
+
+// erroUso indica argumentos fora da sintaxe do comando; é mostrado como "Uso: <sintaxe>"
+type erroUso string
+
+func (e erroUso) Error() string { return "uso: " + string(e) }
+
+// operacaoCancelada é a resposta negativa a uma confirmação: nada foi feito. É mostrada como está,
+// sem o ❌, e fora do prompt também faz o programa sair com erro.
+type operacaoCancelada string
+
+func (e operacaoCancelada) Error() string { return string(e) }
+
+// relatarErro mostra a falha devolvida por um comando e a repassa
+func relatarErro(err error) error {
+	var uso erroUso
+	var cancelada operacaoCancelada
+	switch {
+	case err == nil || errors.Is(err, errSair):
+	case errors.As(err, &uso):
+		fmt.Printf("Uso: %s\n", string(uso))
+	case errors.As(err, &cancelada):
+		fmt.Println(string(cancelada))
+	default:
+		mensagem := err.Error()
+		r, n := utf8.DecodeRuneInString(mensagem)
+		fmt.Printf("❌ %c%s\n", unicode.ToUpper(r), mensagem[n:])
+	}
+	return err
+}
+
+// alteracaoFeita informa se a operação alterou o cadastro, mesmo que a gravação tenha falhado
+func alteracaoFeita(err error) bool {
+	var gravacao *cars.ErroGravacao
+	return err == nil || errors.As(err, &gravacao)
+}
+
+// opcoesTabela documenta as flags comuns aos comandos que exibem tabelas
+var opcoesTabela = []string{
+	"--wide    Mostra todas as colunas sem truncar (o terminal quebra as linhas)",
+	"--narrow  Mostra só as colunas essenciais",
+}
+
+// comandos é o registro central de comandos, na ordem em que aparecem na ajuda
+var comandos []*Comando
+
+func init() {
+	comandos = []*Comando{
+		{
+			Nome:      "add",
+			Sintaxe:   "add [--marca <m> --modelo <m> --ano <a> --preco <p> --origem <país> ...]",
+			Descricao: "Cadastra um carro respondendo a perguntas campo a campo ou, com opções, sem perguntas (para scripts)",
+			Opcoes: []string{
+				"--marca, --modelo, --ano, --preco  Obrigatórias no cadastro por opções",
+				"--origem <país>        País de origem (padrão: o da marca, ex: Japão para Toyota)",
+				"--cor, --custo, --chassi           Opcionais",
+				"--opcionais <lista>    Opcionais do carro, separados por vírgula",
+				"--em-transito          O carro ainda não chegou ao pátio",
+				"--justificativa <t>    Cadastra mesmo violando regras de conformidade",
+			},
+			Exemplos: []string{
+				"add",
+				"add --marca Toyota --modelo Corolla --ano 2022 --preco 120000 --origem Japão",
+				"carros --data-file=estoque.json add --marca BMW --modelo \"Série 3\" --ano 2023 --preco 310k --origem Alemanha --em-transito",
+			},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) == 0 {
+					return c.AdicionarCarro()
+				}
+				return c.AdicionarPorOpcoes(dividirArgumentos(resto))
+			},
+		},
+		{
+			Nome:      "quickadd",
+			Sintaxe:   "quickadd \"Marca Modelo Ano Cor Preço País\"",
+			Descricao: "Cadastra um carro a partir de uma única linha, com prévia e confirmação",
+			Exemplos: []string{
+				"quickadd \"Toyota Corolla 2021 Prata 145000 Japão\"",
+				"quickadd Toyota Land Cruiser 2022 Preto Metálico 420k Japão",
+				"quickadd Fiat Uno 2020 Azul 45.500,50 Itália",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.AdicionarRapido(resto)
+			},
+		},
+		{
+			Nome: "list",
+			Sintaxe: "list [--wide|--narrow] [--aging|--aging-buckets|--group-by=<campo>] [--as-of=<data>] [--opcional=<nome>] " +
+				"[--sort <campo> [--desc]] [--page <n>] [--page-size <n>]",
+			Descricao: "Lista todos os carros cadastrados em tabela ajustada ao terminal",
+			Opcoes: append([]string{
+				"--aging             Inclui a coluna calculada de dias em estoque",
+				"--aging-buckets     Agrupa em faixas de 0–30, 31–60, 61–90 e 90+ dias com subtotais",
+				"--group-by=<campo>  Agrupa por marca, modelo, ano, cor, pais ou status, com subtotais",
+				"--as-of=<data>      Estoque ao fim do dia (AAAA-MM-DD ou DD/MM/AAAA), reconstruído do log",
+				"--opcional=<nome>   Só carros com o opcional (pode repetir; nomes com espaço entre aspas)",
+				"--sort <campo>      Ordena por preco, ano, marca, modelo ou cadastro (--desc inverte)",
+				"--page <n>          Mostra só a página n (com --page-size, padrão 20 carros por página)",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"list", "list --narrow --aging", "list --aging-buckets", "list --group-by=marca", "list --as-of=2024-12-31",
+				"list --opcional=\"teto solar\" --opcional=ACC",
+				"list --sort preco --desc --page 2 --page-size 20",
+			},
+			Paginado: true,
+			Executar: func(c *sessao, args []string, resto string) error {
+				opcoes, err := interpretarOpcoesListagem(dividirArgumentos(resto))
+				if err != nil {
+					return err
+				}
+				return c.ListarCarros(opcoes)
+			},
+		},
+		{
+			Nome:      "find",
+			Sintaxe:   "find <ID>",
+			Descricao: "Mostra um carro pelo ID",
+			Exemplos:  []string{"find car_1764960757141107000"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.BuscarCarro(args[0])
+			},
+		},
+		{
+			Nome:      "qr",
+			Sintaxe:   "qr <ID> [--png=<arquivo>] [--size=<px>]",
+			Descricao: "Mostra no terminal o QR code da página do carro no catálogo, para o cliente escanear no pátio",
+			Opcoes: []string{
+				"--png=<arquivo>     Também grava o QR code em PNG (para imprimir e colar no vidro)",
+				"--size=<px>         Lado do PNG em pixels (padrão 512)",
+			},
+			Exemplos: []string{"qr car_1764960757141107000", "qr car_1764960757141107000 --png=uno.png"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				id, arquivo, tamanho, err := interpretarArgsQRCode(args)
+				if err != nil {
+					return err
+				}
+				return c.MostrarQRCode(id, arquivo, tamanho)
+			},
+		},
+		{
+			Nome:      "share",
+			Sintaxe:   "share <ID> [--channel=<canal>]",
+			Descricao: "Monta a mensagem do carro pronta para colar no WhatsApp (modelo na seção compartilhar)",
+			Opcoes: []string{
+				"--channel=<canal>   Usa o preço do canal de venda (padrão: compartilhar.canal, ou o preço base)",
+			},
+			Exemplos: []string{"share car_1764960757141107000", "share car_1764960757141107000 --channel=site"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				id, canal, err := interpretarArgsCompartilhar(args)
+				if err != nil {
+					return err
+				}
+				return c.CompartilharCarro(id, canal)
+			},
+		},
+		{
+			Nome:      "compare",
+			Sintaxe:   "compare <ID> <ID> [<ID>...] [--html=<arquivo>] [--wide|--narrow]",
+			Descricao: "Compara de 2 a 4 carros lado a lado, opcionalmente em uma página HTML para o cliente",
+			Opcoes: append([]string{
+				"--html=<arquivo>  Grava a comparação em HTML autocontido (com QR code do catálogo, se configurado)",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"compare car_1764960757141107000 car_1764960757141108000",
+				"compare car_1764960757141107000 car_1764960757141108000 --html=comparacao.html",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, args := interpretarModoTabela(args)
+				ids, arquivoHTML, err := interpretarArgsComparacao(args)
+				if err != nil {
+					return err
+				}
+				return c.CompararCarros(ids, arquivoHTML, modo)
+			},
+		},
+		{
+			Nome:      "search",
+			Sintaxe:   "search <termos|filtros> [--wide|--narrow]",
+			Descricao: "Pesquisa por marca, modelo, cor, país ou ano, ordenando por relevância, e filtra por campo",
+			Opcoes: append([]string{
+				"<campo><op><valor>  Filtro; vários filtros valem juntos (E). Campos: marca, modelo, cor, origem,",
+				"                    status (= e !=), ano, preco, custo (= != > >= < <=). No shell, use aspas",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"search toyota",
+				"search bmw preto",
+				"search corolla 2022 --narrow",
+				"search marca=BMW ano>=2020 preco<200000 origem=Alemanha",
+				"search sedan preco<=150000",
+			},
+			MinArgs:  1,
+			Paginado: true,
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, termos := interpretarModoTabela(args)
+				if len(termos) == 0 {
+					return erroUso("search <termos|filtros> [--wide|--narrow]")
+				}
+				return c.PesquisarCarros(termos, modo)
+			},
+		},
+		{
+			Nome:      "remove",
+			Sintaxe:   "remove <ID> | remove --ids-file=<arquivo>",
+			Descricao: "Remove um carro pelo ID, ou todos os IDs listados em um arquivo",
+			Opcoes:    []string{"--ids-file=<arquivo>  Um ID por linha; linhas vazias e iniciadas por # são ignoradas"},
+			Exemplos:  []string{"remove car_1764960757141107000", "remove --ids-file=baixas.txt"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				if arquivo, ok := strings.CutPrefix(args[0], "--ids-file="); ok {
+					ids, err := lerArquivoIDs(arquivo)
+					if err != nil {
+						return err
+					}
+					return c.RemoverEmLote(ids)
+				}
+				return c.RemoverCarro(args[0])
+			},
+		},
+		{
+			Nome:      "purge",
+			Sintaxe:   "purge <ID>",
+			Descricao: "Apaga definitivamente um ID (carro, lápide, venda e pagamentos), com confirmação extra",
+			Exemplos:  []string{"purge car_1764960757141107000"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.PurgarCarro(args[0])
+			},
+		},
+		{
+			Nome:      "trash",
+			Sintaxe:   "trash list [--wide|--narrow] | trash restore <ID> | trash purge [<ID>|--older-than=<dias>]",
+			Descricao: "Mostra os carros removidos, devolve um deles ao estoque ou apaga-os definitivamente",
+			Opcoes: append([]string{
+				"list                   Carros removidos, com a data da remoção, do mais recente ao mais antigo",
+				"restore <ID>           Devolve o carro ao estoque no último estado registrado",
+				"purge [<ID>]           Apaga de vez um carro da lixeira, ou todos (como o purge, com confirmação)",
+				"--older-than=<dias>    No purge, só os removidos há pelo menos tantos dias",
+			}, opcoesTabela...),
+			Exemplos: []string{"trash list", "trash restore car_1764960757141107000", "trash purge --older-than=90"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch args[0] {
+				case "list":
+					modo, extras := interpretarModoTabela(args[1:])
+					if len(extras) > 0 {
+						return fmt.Errorf("opção desconhecida: %s", extras[0])
+					}
+					c.ListarLixeira(modo)
+					return nil
+				case "restore":
+					if len(args) != 2 {
+						return erroUso("trash restore <ID>")
+					}
+					return c.RestaurarDaLixeira(args[1])
+				case "purge":
+					id, dias, err := interpretarArgsLixeiraPurga(args[1:])
+					if err != nil {
+						return err
+					}
+					return c.EsvaziarLixeira(id, dias)
+				default:
+					return erroUso(buscarComando("trash").Sintaxe)
+				}
+			},
+		},
+		{
+			Nome:      "update",
+			Sintaxe:   "update <ID>",
+			Descricao: "Atualiza um carro campo a campo (Enter mantém o valor atual)",
+			Exemplos:  []string{"update car_1764960757141107000"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.AtualizarCarro(args[0])
+			},
+		},
+		{
+			Nome:      "edit",
+			Sintaxe:   "edit <ID>",
+			Descricao: "Edita o registro completo em JSON no $EDITOR, com validação, diferenças e confirmação",
+			Exemplos:  []string{"edit car_1764960757141107000"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.EditarCarro(args[0])
+			},
+		},
+		{
+			Nome:      "patch",
+			Sintaxe:   "patch <ID> '<json merge patch>'",
+			Descricao: "Aplica um JSON merge patch (RFC 7386) ao registro do carro, com as validações da edição",
+			Opcoes: []string{
+				"<json merge patch>  Objeto com os campos a mudar; null apaga o campo (volta ao valor vazio)",
+			},
+			Exemplos: []string{
+				"patch car_1764960757141107000 '{\"cor\": \"Prata\", \"chassi\": \"9BWZZZ377VT004251\"}'",
+				"patch car_1764960757141107000 '{\"moeda\": null, \"cambio_compra\": null}'",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				id, patch, _ := strings.Cut(resto, " ")
+				patch = strings.TrimSpace(patch)
+				if len(patch) >= 2 && patch[0] == '\'' && patch[len(patch)-1] == '\'' {
+					patch = patch[1 : len(patch)-1]
+				}
+				return c.AplicarPatch(id, patch)
+			},
+		},
+		{
+			Nome:      "channel",
+			Sintaxe:   "channel <ID> [--wide|--narrow] | channel set <ID> <canal> <preço> | channel clear <ID> <canal>",
+			Descricao: "Mostra o preço do carro em cada canal de venda ou define um preço próprio para um canal",
+			Opcoes: append([]string{
+				"<ID>                       Preço em showroom, site, marketplace e atacado e de onde vem (base, regra ou manual)",
+				"set <ID> <canal> <preço>   Preço próprio no canal, que vale sobre a regra da seção canais",
+				"clear <ID> <canal>         Retira o preço próprio; o canal volta à regra (ou ao preço base)",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"channel car_1764960757141107000",
+				"channel set car_1764960757141107000 marketplace 152900",
+				"channel clear car_1764960757141107000 marketplace",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch {
+				case args[0] == "set" && len(args) == 4:
+					canal, err := cars.InterpretarCanal(args[2])
+					if err != nil {
+						return err
+					}
+					preco, err := cars.InterpretarValorDigitado(args[3])
+					if err != nil || preco <= 0 {
+						return errors.New("o preço do canal deve ser um valor positivo")
+					}
+					return c.DefinirPrecoCanal(args[1], canal, preco)
+				case args[0] == "clear" && len(args) == 3:
+					canal, err := cars.InterpretarCanal(args[2])
+					if err != nil {
+						return err
+					}
+					return c.DefinirPrecoCanal(args[1], canal, 0)
+				case args[0] == "set" || args[0] == "clear":
+					return erroUso("channel set <ID> <canal> <preço> | channel clear <ID> <canal>")
+				default:
+					modo, extras := interpretarModoTabela(args[1:])
+					if len(extras) > 0 {
+						return fmt.Errorf("opção desconhecida: %s", extras[0])
+					}
+					return c.MostrarPrecosCanal(args[0], modo)
+				}
+			},
+		},
+		{
+			Nome:      "sell",
+			Sintaxe:   "sell <ID> <preço final> [--troca]",
+			Descricao: "Registra a venda de um carro, guardando-o como comparável para análises",
+			Opcoes: []string{
+				"--troca  Pergunta pelo veículo do cliente, que entra no estoque pela avaliação",
+			},
+			Exemplos: []string{
+				"sell car_1764960757141107000 138000",
+				"sell car_1764960757141107000 138k",
+				"sell car_1764960757141107000 138k --troca",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				preco, ok := interpretarPrecoRapido(args[1])
+				if !ok || preco <= 0 {
+					return errors.New("preço final deve ser um número positivo válido")
+				}
+				var troca *cars.Carro
+				for _, arg := range args[2:] {
+					if arg != "--troca" {
+						return fmt.Errorf("opção desconhecida: %s", arg)
+					}
+					veiculo, err := lerVeiculoTroca(c.cli)
+					if err != nil {
+						return err
+					}
+					troca = &veiculo
+				}
+				return c.VenderCarro(args[0], preco, troca)
+			},
+		},
+		{
+			Nome:      "sold",
+			Sintaxe:   "sold [--wide|--narrow]",
+			Descricao: "Lista os carros vendidos com preço final, desconto e dias em estoque",
+			Opcoes:    opcoesTabela,
+			Exemplos:  []string{"sold", "sold --narrow"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, _ := interpretarModoTabela(args)
+				c.ListarVendidos(modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "analytics",
+			Sintaxe:   "analytics",
+			Descricao: "Mostra dias até vender e desconto médio por marca, segmento e mês",
+			Exemplos:  []string{"analytics"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				c.AnalisarVendas()
+				return nil
+			},
+		},
+		{
+			Nome:      "kpi",
+			Sintaxe:   "kpi [--months=<n>] [--wide|--narrow]",
+			Descricao: "Mostra giro de estoque, dias médios em estoque e taxa de escoamento mês a mês",
+			Opcoes: append([]string{
+				"--months=<n>        Quantidade de meses do relatório (padrão 12)",
+			}, opcoesTabela...),
+			Exemplos: []string{"kpi", "kpi --months=6"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				meses, modo, err := interpretarArgsKPI(args)
+				if err != nil {
+					return err
+				}
+				c.RelatorioKPI(meses, modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "heatmap",
+			Sintaxe:   "heatmap [--step=<valor>] [--wide|--narrow]",
+			Descricao: "Mostra a quantidade de carros em estoque por faixa de preço e ano, em uma grade com tons",
+			Opcoes: append([]string{
+				"--step=<valor>      Largura das faixas de preço (padrão: escolhida para caber em até 8 faixas)",
+			}, opcoesTabela...),
+			Exemplos: []string{"heatmap", "heatmap --step=50000"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				passo, modo, err := interpretarArgsMapaCalor(args)
+				if err != nil {
+					return err
+				}
+				c.MapaCalor(passo, modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "profit",
+			Sintaxe:   "profit <ID> [--daily-cost=<valor>] [--wide|--narrow]",
+			Descricao: "Mostra a linha do tempo da margem do carro: custo, mudanças de preço, dias e custo de pátio",
+			Opcoes: append([]string{
+				"--daily-cost=<valor> Custo de pátio por dia (padrão: lucratividade.custo_diario da configuração)",
+			}, opcoesTabela...),
+			Exemplos: []string{"profit car_1764960757141107000", "profit car_1764960757141107000 --daily-cost=45"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				id, custoDiario, modo, err := interpretarArgsLucratividade(args)
+				if err != nil {
+					return err
+				}
+				return c.MostrarLucratividade(id, custoDiario, modo)
+			},
+		},
+		{
+			Nome:    "lot",
+			Sintaxe: "lot <new|add|cost|list|report> ...",
+			Descricao: "Agrupa carros de um mesmo contêiner ou leilão, rateia custos compartilhados " +
+				"e mostra a rentabilidade do lote",
+			Opcoes: append([]string{
+				"new <descrição>                  Cria um lote",
+				"add <lote> <ID> [<ID>...]        Inclui carros em estoque no lote",
+				"cost <lote> <valor> <descrição>  Lança um custo compartilhado (frete, despachante)",
+				"list                             Lista os lotes",
+				"report <lote>                    Rentabilidade por carro, com o rateio dos custos",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"lot new Contêiner MSCU1234567 Santos",
+				"lot add lot_1764960757141107000 car_1764960757141107000 car_1764961086035551000",
+				"lot cost lot_1764960757141107000 18500 Frete marítimo",
+				"lot report lot_1764960757141107000",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, args := interpretarModoTabela(args)
+				switch {
+				case args[0] == "new" && len(args) >= 2:
+					return c.CriarLote(strings.Join(args[1:], " "))
+				case args[0] == "add" && len(args) >= 3:
+					return c.IncluirNoLote(args[1], args[2:])
+				case args[0] == "cost" && len(args) >= 4:
+					valor, ok := interpretarPrecoRapido(args[2])
+					if !ok || valor <= 0 {
+						return errors.New("valor deve ser um número positivo válido")
+					}
+					return c.LancarCustoLote(args[1], cars.CustoLote{Descricao: strings.Join(args[3:], " "), Valor: valor})
+				case args[0] == "list":
+					c.ListarLotes(modo)
+					return nil
+				case args[0] == "report" && len(args) >= 2:
+					return c.RelatorioLote(args[1], modo)
+				default:
+					return erroUso(buscarComando("lot").Sintaxe)
+				}
+			},
+		},
+		{
+			Nome:      "pay",
+			Sintaxe:   "pay <ID> <valor> [--sinal] [--metodo=<método>] [--data=<data>]",
+			Descricao: "Registra um sinal de reserva ou parcela recebida para um carro em estoque ou vendido",
+			Opcoes: []string{
+				"--sinal             Lança como sinal (depósito de reserva) em vez de parcela",
+				"--metodo=<método>   pix (padrão), ted, boleto, cartao, dinheiro ou financiamento",
+				"--data=<data>       Data do recebimento, AAAA-MM-DD ou DD/MM/AAAA (padrão: hoje)",
+			},
+			Exemplos: []string{
+				"pay car_1764960757141107000 20000 --sinal",
+				"pay car_1764960757141107000 15000 --metodo=boleto --data=2024-07-10",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				p, err := interpretarArgsPagamento(args)
+				if err != nil {
+					return err
+				}
+				return c.LancarPagamento(p)
+			},
+		},
+		{
+			Nome:      "payments",
+			Sintaxe:   "payments <ID>",
+			Descricao: "Mostra o extrato de pagamentos de um carro com o saldo devedor",
+			Exemplos:  []string{"payments car_1764960757141107000"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.ExtratoPagamentos(args[0])
+			},
+		},
+		{
+			Nome:      "balances",
+			Sintaxe:   "balances",
+			Descricao: "Lista os carros com pagamentos lançados e o saldo ainda a receber",
+			Exemplos:  []string{"balances"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				c.RelatorioSaldos()
+				return nil
+			},
+		},
+		{
+			Nome:      "finance",
+			Sintaxe:   "finance <ID> --rate=<taxa>% [--down=<entrada>] [--months=<n>] [--sac] [--csv=<arquivo>]",
+			Descricao: "Simula o financiamento de um carro nas tabelas Price e SAC",
+			Opcoes: []string{
+				"--rate=<taxa>%      Juros ao mês (obrigatório), ex: 1.49%",
+				"--down=<entrada>    Entrada em % do preço (30%) ou em R$ (50000); padrão 0",
+				"--months=<n>        Número de parcelas (padrão 48)",
+				"--sac               Mostra/exporta o cronograma SAC em vez do Price",
+				"--csv=<arquivo>     Exporta o cronograma para CSV",
+			},
+			Exemplos: []string{
+				"finance car_1764960757141107000 --down=30% --months=48 --rate=1.49%",
+				"finance car_1764960757141107000 --down=50000 --months=36 --rate=1.2% --sac",
+				"finance car_1764960757141107000 --rate=1.49% --csv=cronograma.csv",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				sim, err := interpretarArgsFinanciamento(args[1:])
+				if err != nil {
+					return err
+				}
+				return c.SimularFinanciamento(args[0], sim)
+			},
+		},
+		{
+			Nome:      "doc",
+			Sintaxe:   "doc <ID> [<crlv|seguro|garantia> <vencimento>]",
+			Descricao: "Mostra ou registra o vencimento do CRLV, do seguro ou da garantia de um carro",
+			Opcoes: []string{
+				"<vencimento>  Data em AAAA-MM-DD ou DD/MM/AAAA; registrar de novo substitui a anterior",
+			},
+			Exemplos: []string{"doc car_1764960757141107000", "doc car_1764960757141107000 crlv 31/03/2026"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch len(args) {
+				case 1:
+					return c.MostrarDocumentos(args[0])
+				case 3:
+					return c.RegistrarDocumento(args[0], args[1], args[2])
+				default:
+					return erroUso("doc <ID> [<crlv|seguro|garantia> <vencimento>]")
+				}
+			},
+		},
+		{
+			Nome:      "expiring",
+			Sintaxe:   "expiring [--days=<n>] [--wide|--narrow]",
+			Descricao: "Lista os documentos de carros em estoque vencidos ou que vencem nos próximos dias",
+			Opcoes: append([]string{
+				"--days=<n>  Janela em dias (padrão 30); documentos já vencidos sempre aparecem",
+			}, opcoesTabela...),
+			Exemplos: []string{"expiring", "expiring --days=7"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				dias, modo, err := interpretarArgsVencimentos(args)
+				if err != nil {
+					return err
+				}
+				c.ListarVencimentos(dias, modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "fx",
+			Sintaxe:   "fx <backfill|report> ...",
+			Descricao: "Importa cotações históricas e mostra como o câmbio desde a compra afeta custo e margem do estoque",
+			Opcoes: append([]string{
+				"backfill --file=<cotacoes.csv> [--force]  Importa cotações (colunas data, moeda, taxa em R$) e grava",
+				"                                         moeda e câmbio da data de cadastro em cada carro do estoque",
+				"report                                   Custo de reposição, margem e preço para manter a margem",
+			}, opcoesTabela...),
+			Exemplos: []string{"fx backfill --file=ptax.csv", "fx backfill --file=ptax.csv --force", "fx report --narrow"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch args[0] {
+				case "backfill":
+					arquivo, forcar := "", false
+					for _, arg := range args[1:] {
+						switch {
+						case strings.HasPrefix(arg, "--file="):
+							arquivo = strings.TrimPrefix(arg, "--file=")
+						case arg == "--force":
+							forcar = true
+						default:
+							return fmt.Errorf("opção desconhecida: %s", arg)
+						}
+					}
+					if arquivo == "" {
+						return erroUso("fx backfill --file=<cotacoes.csv> [--force]")
+					}
+					return c.ImportarCotacoes(arquivo, forcar)
+				case "report":
+					modo, extras := interpretarModoTabela(args[1:])
+					if len(extras) > 0 {
+						return fmt.Errorf("opção desconhecida: %s", extras[0])
+					}
+					c.RelatorioCambio(modo)
+					return nil
+				default:
+					return erroUso("fx <backfill|report> ...")
+				}
+			},
+		},
+		{
+			Nome:      "metrics",
+			Sintaxe:   "metrics [--wide|--narrow]",
+			Descricao: "Mostra chamadas, tempo médio, maior e total de cada operação nesta execução",
+			Opcoes:    opcoesTabela,
+			Exemplos:  []string{"metrics"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, _ := interpretarModoTabela(args)
+				c.MostrarMetricas(modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "flush",
+			Sintaxe:   "flush",
+			Descricao: "Grava imediatamente todos os dados e mostra duração, bytes gravados e vazão",
+			Exemplos:  []string{"flush"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.Flush()
+			},
+		},
+		{
+			Nome:      "board",
+			Sintaxe:   "board [--listen=<endereço>] [--days=<n>] [--refresh=<segundos>] | board stop",
+			Descricao: "Serve em segundo plano uma página para a TV do showroom com recém-chegados, carros a caminho e vendas recentes",
+			Opcoes: []string{
+				"--listen=<endereço> Endereço HTTP do painel (padrão :8080)",
+				"--days=<n>          Janela de recém-chegados e vendidos, em dias (padrão 7)",
+				"--refresh=<s>       Intervalo de atualização da página, em segundos (padrão 30)",
+				"stop                Encerra o painel",
+			},
+			Exemplos: []string{"board", "board --listen=:9000 --days=15", "board stop"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) == 1 && args[0] == "stop" {
+					return c.PararPainel()
+				}
+				opcoes, err := interpretarArgsPainel(args)
+				if err != nil {
+					return err
+				}
+				return c.IniciarPainel(opcoes)
+			},
+		},
+		{
+			Nome:      "serve",
+			Sintaxe:   "serve [--listen=<endereço>] | serve stop",
+			Descricao: "Serve em segundo plano uma API REST (JSON) com GET/POST/PUT/DELETE em /carros e /carros/{id} e o log ao vivo (SSE) em /audit/stream; GET /carros aceita ?limit=, ?cursor=, ?fields= e ?format=jsonapi; POST /carros/bulk grava um lote de uma vez (com Idempotency-Key)",
+			Opcoes: []string{
+				"--listen=<endereço> Endereço HTTP da API (padrão 127.0.0.1:8081; não há autenticação)",
+				"stop                Encerra a API",
+			},
+			Exemplos: []string{"serve", "serve --listen=127.0.0.1:9000", "serve stop"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) == 1 && args[0] == "stop" {
+					return c.PararAPI()
+				}
+				endereco, err := interpretarArgsAPI(args, "127.0.0.1:8081")
+				if err != nil {
+					return err
+				}
+				return c.IniciarAPI(endereco)
+			},
+		},
+		{
+			Nome:      "grpc",
+			Sintaxe:   "grpc [--listen=<endereço>] | grpc stop",
+			Descricao: "Serve em segundo plano o serviço gRPC carros.v1.Carros (Add/Get/List/Update/Delete, ver cars/carrospb/carros.proto), com as regras da API REST",
+			Opcoes: []string{
+				"--listen=<endereço> Endereço do gRPC (padrão 127.0.0.1:8082; não há autenticação)",
+				"stop                Encerra o gRPC",
+			},
+			Exemplos: []string{"grpc", "grpc --listen=127.0.0.1:9001", "grpc stop"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) == 1 && args[0] == "stop" {
+					return c.PararGRPC()
+				}
+				endereco, err := interpretarArgsAPI(args, "127.0.0.1:8082")
+				if err != nil {
+					return err
+				}
+				return c.IniciarGRPC(endereco)
+			},
+		},
+		{
+			Nome:      "rpc",
+			Sintaxe:   "rpc [--addr=<endereço>] [--confirm=<modelo>] list | get <ID> | add \"<Marca Modelo Ano Cor Preço País>\" | update <ID> '<json merge patch>' | delete <ID>",
+			Descricao: "Cliente de teste do gRPC: chama o serviço carros.v1.Carros de outra instância (ou desta, depois de 'grpc')",
+			Opcoes: []string{
+				"--addr=<endereço>   Endereço do gRPC (padrão 127.0.0.1:8082)",
+				"--confirm=<modelo>  Modelo repetido, exigido no update de preço e no delete de carros de alto valor",
+				"add                 Linha no formato do quickadd",
+				"update              Busca o carro, aplica o merge patch e grava a versão que foi lida",
+			},
+			Exemplos: []string{
+				"rpc list",
+				"rpc --addr=estoque.interno:8082 get car_1764960757141107000",
+				"rpc add \"Toyota Corolla 2021 Prata 145000 Japão\"",
+				"rpc update car_1764960757141107000 '{\"cor\": \"Preto\"}'",
+				"rpc delete car_1764960757141107000 --confirm=911",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				chamada, err := interpretarArgsRPC(args)
+				if err != nil {
+					return err
+				}
+				return c.ChamarGRPC(chamada)
+			},
+		},
+		{
+			Nome:      "replica",
+			Sintaxe:   "replica follow <url> | replica status | replica promote",
+			Descricao: "Segue outra instância pelo stream de eventos da API dela e atende só consultas, como réplica de leitura ou reserva",
+			Opcoes: []string{
+				"follow <url>        Aplica aqui os eventos de /audit/stream do primário (comece de um arquivo vazio ou de uma cópia dele)",
+				"status              Mostra o primário, a conexão e o último evento recebido",
+				"promote             Para de seguir e volta a aceitar alterações (ex: o primário caiu)",
+			},
+			Exemplos: []string{"replica follow http://10.0.0.5:8081", "replica status", "replica promote"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch {
+				case args[0] == "follow" && len(args) == 2:
+					endereco, err := interpretarURLPrimario(args[1])
+					if err != nil {
+						return err
+					}
+					return c.SeguirPrimario(endereco)
+				case args[0] == "status" && len(args) == 1:
+					c.MostrarReplica()
+					return nil
+				case args[0] == "promote" && len(args) == 1:
+					c.PromoverReplica()
+					return nil
+				default:
+					return erroUso(buscarComando("replica").Sintaxe)
+				}
+			},
+		},
+		{
+			Nome:      "lock",
+			Sintaxe:   "lock [hash]",
+			Descricao: "Bloqueia a sessão até a senha ser digitada; o bloqueio também ocorre após sessao.bloqueio_minutos sem uso",
+			Opcoes: []string{
+				"hash                Gera o hash da senha para informar em sessao.senha_hash no config.json",
+			},
+			Exemplos: []string{"lock", "lock hash"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) > 0 && args[0] == "hash" {
+					return c.GerarHashSenha()
+				}
+				if !c.BloquearSessao("pedido do usuário") {
+					return errSair
+				}
+				return nil
+			},
+		},
+		{
+			Nome:      "protect",
+			Sintaxe:   "protect [keygen]",
+			Descricao: "Mostra a proteção dos campos sensíveis ou gera o par de chaves para cifrá-los",
+			Opcoes: []string{
+				"keygen  Gera a chave pública (config.json) e a privada (só para quem pode ler os campos)",
+			},
+			Exemplos: []string{"protect", "protect keygen"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch {
+				case len(args) == 0:
+					return c.MostrarProtecao()
+				case len(args) == 1 && args[0] == "keygen":
+					return GerarChavesProtecao()
+				default:
+					return erroUso("protect [keygen]")
+				}
+			},
+		},
+		{
+			Nome:      "config",
+			Sintaxe:   "config <show|reload>",
+			Descricao: "Mostra a configuração em vigor ou relê o arquivo sem reiniciar (também via SIGHUP)",
+			Exemplos:  []string{"config show", "config reload"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch args[0] {
+				case "show":
+					return c.MostrarConfig()
+				case "reload":
+					return c.RecarregarConfig()
+				default:
+					return erroUso("config <show|reload>")
+				}
+			},
+		},
+		{
+			Nome:      "exceptions",
+			Sintaxe:   "exceptions [--wide|--narrow]",
+			Descricao: "Lista cadastros feitos com justificativa apesar das regras de conformidade",
+			Opcoes:    opcoesTabela,
+			Exemplos:  []string{"exceptions", "exceptions --wide"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, _ := interpretarModoTabela(args)
+				c.ListarExcecoes(modo)
+				return nil
+			},
+		},
+		{
+			Nome:      "events",
+			Sintaxe:   "events [<ID>|rebuild|summary|compact [--months=<n>]] [--wide|--narrow]",
+			Descricao: "Mostra o log de eventos (trilha de auditoria), reconstrói o estoque a partir dele ou aplica a retenção",
+			Opcoes: append([]string{
+				"<ID>            Só os eventos de um carro",
+				"rebuild         Refaz carros, remoções e vendas a partir do log e grava o resultado",
+				"summary         Mostra os meses compactados pela retenção e o arquivo de cada um",
+				"compact         Exporta os eventos antigos para um arquivo e os compacta no log",
+				"--months=<n>    Meses mantidos por inteiro no compact (padrão: auditoria.retencao_meses)",
+			}, opcoesTabela...),
+			Exemplos: []string{"events", "events car_1764960757141107000", "events rebuild", "events summary", "events compact --months=12"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, args := interpretarModoTabela(args)
+				switch {
+				case len(args) == 0:
+					c.ListarEventos("", modo)
+					return nil
+				case len(args) == 1 && args[0] == "rebuild":
+					return c.ReconstruirProjecao()
+				case len(args) == 1 && args[0] == "summary":
+					c.MostrarResumosAuditoria(modo)
+					return nil
+				case len(args) <= 2 && args[0] == "compact":
+					meses, err := interpretarArgsCompactacao(args[1:], c.Config().Auditoria.RetencaoMeses)
+					if err != nil {
+						return err
+					}
+					return c.CompactarAuditoria(meses)
+				case len(args) == 1:
+					c.ListarEventos(args[0], modo)
+					return nil
+				default:
+					return erroUso("events [<ID>|rebuild|summary|compact [--months=<n>]] [--wide|--narrow]")
+				}
+			},
+		},
+		{
+			Nome:      "undo",
+			Sintaxe:   "undo",
+			Descricao: "Desfaz a última operação desta sessão que alterou o estoque (add, update, remove...)",
+			Exemplos:  []string{"remove car_1764960757141107000", "undo"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.Desfazer()
+			},
+		},
+		{
+			Nome:      "redo",
+			Sintaxe:   "redo",
+			Descricao: "Aplica de novo a última operação desfeita com undo",
+			Exemplos:  []string{"undo", "redo"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.Refazer()
+			},
+		},
+		{
+			Nome:      "rollback",
+			Sintaxe:   "rollback --to=<seq> [--dry-run] [--wide|--narrow]",
+			Descricao: "Volta o estoque ao estado logo após um evento do log, mostrando antes as diferenças",
+			Opcoes: append([]string{
+				"--to=<seq>      Evento de destino (coluna Seq de 'events')",
+				"--dry-run       Só mostra as diferenças, sem pedir confirmação nem alterar nada",
+			}, opcoesTabela...),
+			Exemplos: []string{"rollback --to=42 --dry-run", "rollback --to=42"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, args := interpretarModoTabela(args)
+				seq, simular, err := interpretarArgsReversao(args)
+				if err != nil {
+					return err
+				}
+				return c.ReverterPara(seq, simular, modo)
+			},
+		},
+		{
+			Nome:      "restore",
+			Sintaxe:   "restore [<#|arquivo>] [--dry-run] [--wide|--narrow]",
+			Descricao: "Lista os backups automáticos do arquivo de dados ou volta o estoque a um deles, mostrando antes as diferenças",
+			Opcoes: append([]string{
+				"<#|arquivo>     Número na lista (1 = mais recente), nome do backup ou caminho",
+				"--dry-run       Só mostra as diferenças, sem pedir confirmação nem alterar nada",
+			}, opcoesTabela...),
+			Exemplos: []string{"restore", "restore 1 --dry-run", "restore carros-20250301-120000-000000.json"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				escolha, simular, modo, err := interpretarArgsRestauracao(args)
+				switch {
+				case err != nil:
+					return err
+				case escolha == "":
+					return c.ListarBackups(modo)
+				}
+				return c.RestaurarBackup(escolha, simular, modo)
+			},
+		},
+		{
+			Nome:      "audit",
+			Sintaxe:   "audit tail [-f] [-n=<n>] [--user=<autor>] [--car=<ID>] [--action=<tipo>]",
+			Descricao: "Mostra as últimas entradas do log de eventos e, com -f, acompanha as novas conforme acontecem",
+			Opcoes: []string{
+				"-f              Continua mostrando as entradas novas até teclar Enter",
+				"-n=<n>          Quantas entradas anteriores mostrar (padrão 10)",
+				"--user=<autor>  Só entradas cujo autor contém o texto (ex: api para as integrações)",
+				"--car=<ID>      Só entradas de um carro",
+				"--action=<tipo> Só um tipo de evento (Adicionado, Atualizado, Removido, Vendido, Restaurado)",
+			},
+			Exemplos: []string{"audit tail", "audit tail -f --user=api", "audit tail -f --action=Vendido -n=0"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				filtro, ultimas, seguir, err := interpretarArgsAuditoria(args)
+				if err != nil {
+					return err
+				}
+				c.AcompanharAuditoria(filtro, ultimas, seguir)
+				return nil
+			},
+		},
+		{
+			Nome:      "status",
+			Sintaxe:   "status <ID> [em_transito|em_estoque|reservado|arquivado]",
+			Descricao: "Mostra a situação e o histórico de um carro, ou muda a situação seguindo as transições permitidas",
+			Opcoes: []string{
+				"em_transito → em_estoque, arquivado",
+				"em_estoque  → reservado, vendido (pelo sell), arquivado",
+				"reservado   → em_estoque, vendido (pelo sell)",
+				"arquivado   → em_estoque",
+			},
+			Exemplos: []string{"status car_1764960757141107000", "status car_1764960757141107000 reservado"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				switch len(args) {
+				case 1:
+					return c.MostrarStatus(args[0])
+				case 2:
+					return c.MudarStatus(args[0], strings.ToLower(args[1]))
+				default:
+					return erroUso("status <ID> [nova situação]")
+				}
+			},
+		},
+		{
+			Nome:      "reconcile",
+			Sintaxe:   "reconcile --file=<contagem.csv> [--wide|--narrow]",
+			Descricao: "Confere a contagem física do pátio contra o cadastro (faltantes, sem cadastro, divergências)",
+			Opcoes: append([]string{
+				"--file=<arquivo>  CSV com cabeçalho; colunas chassi ou id, e opcionalmente marca, modelo, ano e cor",
+			}, opcoesTabela...),
+			Exemplos: []string{"reconcile --file=contagem.csv", "reconcile --file=contagem.csv --narrow"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				modo, args := interpretarModoTabela(args)
+				if len(args) != 1 || !strings.HasPrefix(args[0], "--file=") || args[0] == "--file=" {
+					return erroUso("reconcile --file=<contagem.csv> [--wide|--narrow]")
+				}
+				return c.ConciliarEstoque(strings.TrimPrefix(args[0], "--file="), modo)
+			},
+		},
+		{
+			Nome:      "diff",
+			Sintaxe:   "diff <arquivoA> <arquivoB> [--json] [--wide|--narrow]",
+			Descricao: "Compara dois arquivos de dados e relata carros adicionados, removidos e alterados campo a campo",
+			Opcoes: append([]string{
+				"--json  Imprime o relatório em JSON, para conferir backups e sincronizações em scripts",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"diff backup/carros.json carros.json",
+				"diff carros.json replica/carros.yaml --json",
+			},
+			MinArgs: 2,
+			Executar: func(c *sessao, args []string, resto string) error {
+				// Redividido para aceitar caminhos com espaços entre aspas
+				modo, args := interpretarModoTabela(dividirArgumentos(resto))
+				arquivoA, arquivoB, emJSON, err := interpretarArgsDiferenca(args)
+				if err != nil {
+					return err
+				}
+				return c.CompararArquivos(arquivoA, arquivoB, emJSON, modo)
+			},
+		},
+		{
+			Nome:      "import",
+			Sintaxe:   "import csv <arquivo> | import --file=<arquivo> [--delimiter=<c>] [--map=<coluna>=<campo>]... [--dry-run|--yes] [--wide|--narrow]",
+			Descricao: "Cadastra carros de uma planilha CSV, com prévia do mapeamento das colunas e confirmação",
+			Opcoes: append([]string{
+				"csv <arquivo>          CSV com cabeçalho (o mesmo que --file=<arquivo>)",
+				"--delimiter=<c>        Delimitador, ex: ; | ou tab (padrão: vírgula ou ponto e vírgula, detectado)",
+				"--map=<coluna>=<campo> Liga uma coluna (cabeçalho ou posição) a um campo; - ignora a coluna",
+				"--dry-run              Relata os problemas de cada linha sem importar nada",
+				"--yes                  Importa sem perguntar (as linhas com problema ficam de fora)",
+			}, opcoesTabela...),
+			Exemplos: []string{
+				"import csv lote.csv --dry-run",
+				"import csv lote.csv --delimiter=tab --map=valor=preco --map=3=-",
+				"import --file=lote.csv --yes",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				// A linha é redividida para que arquivos e cabeçalhos com espaços venham entre aspas
+				modo, args := interpretarModoTabela(dividirArgumentos(resto))
+				opcoes, err := interpretarArgsImportacao(args)
+				if err != nil {
+					return err
+				}
+				return c.ImportarCarros(opcoes, modo)
+			},
+		},
+		{
+			Nome:      "reindex",
+			Sintaxe:   "reindex [--repair]",
+			Descricao: "Confere estoque, lápides, vendas, log de eventos e referências a carros, e aponta inconsistências",
+			Opcoes: []string{
+				"--repair  Refaz o estoque a partir do log e descarta referências a IDs inexistentes",
+			},
+			Exemplos: []string{"reindex", "reindex --repair"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) > 1 || len(args) == 1 && args[0] != "--repair" {
+					return erroUso("reindex [--repair]")
+				}
+				return c.Reindexar(len(args) == 1)
+			},
+		},
+		{
+			Nome:      "repair",
+			Sintaxe:   "repair",
+			Descricao: "Corrige ou descarta registros malformados separados na quarentena ao carregar",
+			Exemplos:  []string{"repair"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.RepararQuarentena()
+			},
+		},
+		{
+			Nome: "export",
+			Sintaxe: "export --since=<instante> [arquivo] | export feed --format=<portal> [arquivo] [--channel=<canal>] | " +
+				"export parquet <diretório> | export csv <arquivo> [--delimiter=<c>] [--channel=<canal>]",
+			Descricao: "Exporta carros alterados após o instante, o estoque no formato de um portal de anúncios, " +
+				"o histórico em Parquet para análise ou o estoque em CSV reimportável",
+			Opcoes: []string{
+				"--since=<instante>  Instante de corte em RFC 3339 (exportação incremental)",
+				"--format=<portal>   Portal do feed: webmotors (XML) ou olx (CSV)",
+				"--delimiter=<c>     Delimitador do CSV, ex: ; | ou tab (padrão: vírgula)",
+				"--channel=<canal>   Preços do canal de venda (showroom, site, marketplace, atacado) no feed ou no CSV",
+			},
+			Exemplos: []string{
+				"export csv estoque.csv --delimiter=;",
+				"export csv atacado.csv --channel=atacado",
+				"export --since=2024-06-01T00:00:00Z alteracoes.json",
+				"export feed --format=webmotors estoque.xml",
+				"export feed --format=olx anuncios.csv",
+				"export feed --format=olx anuncios.csv --channel=marketplace",
+				"export parquet historico/",
+			},
+			MinArgs: 1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				canal, args, err := separarOpcaoCanal(args)
+				if err != nil {
+					return err
+				}
+				if len(args) == 0 {
+					return erroUso(buscarComando("export").Sintaxe)
+				}
+				if canal != "" && args[0] != "csv" && args[0] != "feed" {
+					return errors.New("--channel vale só para export csv e export feed")
+				}
+				if args[0] == "csv" {
+					arquivo, workers, delimitador, err := interpretarArgsCSV(args[1:])
+					if err != nil {
+						return err
+					}
+					return c.ExportarCSV(arquivo, workers, delimitador, canal)
+				}
+				if args[0] == "parquet" {
+					if len(args) != 2 {
+						return erroUso("export parquet <diretório>")
+					}
+					return c.ExportarParquet(args[1])
+				}
+				if args[0] == "feed" {
+					formato, arquivo, err := interpretarArgsFeed(args[1:])
+					if err != nil {
+						return err
+					}
+					return c.ExportarFeed(formato, arquivo, canal)
+				}
+				desde, arquivo, err := interpretarArgsExport(args)
+				if err != nil {
+					return err
+				}
+				return c.ExportarDesde(desde, arquivo)
+			},
+		},
+		{
+			Nome:      "csv",
+			Sintaxe:   "csv <arquivo> [--workers=<n>] [--delimiter=<c>]",
+			Descricao: "Exporta todos os carros em estoque para CSV, codificando em paralelo (o mesmo que export csv)",
+			Opcoes: []string{
+				"--workers=<n>    Goroutines de codificação (padrão: uma por CPU; 1 = sequencial)",
+				"--delimiter=<c>  Delimitador, ex: ; | ou tab (padrão: vírgula)",
+			},
+			Exemplos: []string{"csv estoque.csv", "csv estoque.csv --workers=1 --delimiter=;"},
+			MinArgs:  1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				arquivo, workers, delimitador, err := interpretarArgsCSV(args)
+				if err != nil {
+					return err
+				}
+				return c.ExportarCSV(arquivo, workers, delimitador, "")
+			},
+		},
+		{
+			Nome:      ":export",
+			Sintaxe:   ":export <arquivo>",
+			Descricao: "Exporta para CSV o último resultado exibido por list ou search",
+			Exemplos:  []string{"search bmw preto", ":export resultado.csv"},
+			MinArgs:   1,
+			Executar: func(c *sessao, args []string, resto string) error {
+				return c.ExportarResultado(resto)
+			},
+		},
+		{
+			Nome:      "help",
+			Sintaxe:   "help [comando]",
+			Descricao: "Mostra os comandos disponíveis ou detalhes e exemplos de um comando",
+			Exemplos:  []string{"help", "help search"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				if len(args) == 0 {
+					mostrarAjudaGeral()
+					return nil
+				}
+				return mostrarAjudaComando(args[0])
+			},
+		},
+		{
+			Nome:      "exit",
+			Sintaxe:   "exit",
+			Descricao: "Sai do sistema",
+			Exemplos:  []string{"exit"},
+			Executar: func(c *sessao, args []string, resto string) error {
+				fmt.Println("Saindo do sistema. Dados do banco em memória perdidos (temporário). Até logo!")
+				return errSair
+			},
+		},
+	}
+	// A seção de permissões da configuração só pode citar comandos deste prompt
+	cars.ComandosConhecidos = nomesComandos()
+}
+
+// buscarComando localiza um comando do registro pelo nome (sem diferenciar maiúsculas)
+func buscarComando(nome string) *Comando {
+	nome = strings.ToLower(nome)
+	for _, cmd := range comandos {
+		if cmd.Nome == nome {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// executarLinha interpreta uma linha digitada no prompt e despacha para o comando registrado.
+// Devolve a falha do comando (já mostrada), ou errSair quando o usuário pediu para sair.
+func executarLinha(c *sessao, linha string) error {
+	parts := strings.Fields(linha)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	cmd := buscarComando(parts[0])
+	if cmd == nil {
+		fmt.Printf("Comando inválido. Comandos disponíveis: %s. Digite 'help' para detalhes.\n", strings.Join(nomesComandos(), ", "))
+		return fmt.Errorf("comando desconhecido '%s'", parts[0])
+	}
+
+	if !c.ComandoPermitido(cmd.Nome) {
+		return relatarErro(fmt.Errorf("o comando '%s' não está liberado nesta configuração (seção permissoes)", cmd.Nome))
+	}
+
+	args := parts[1:]
+	if c.alteracaoBloqueada(cmd.Nome, args) {
+		return relatarErro(fmt.Errorf("esta instância é uma réplica somente leitura; '%s' alteraria os dados. Use 'replica promote' para voltar a aceitar alterações", cmd.Nome))
+	}
+	if len(args) < cmd.MinArgs {
+		return relatarErro(erroUso(cmd.Sintaxe))
+	}
+
+	resto := strings.TrimSpace(strings.TrimSpace(linha)[len(parts[0]):])
+	c.Registro().Debug("comando do prompt", "comando", cmd.Nome)
+	if !slices.Contains(comandosForaDoDiario, cmd.Nome) {
+		defer c.RegistrarOperacao(strings.TrimSpace(linha), c.MarcarDiario())
+	}
+	executar := func() error { return relatarErro(cmd.Executar(c, args, resto)) }
+	if cmd.Paginado && c.usarPager() {
+		return c.paginarComando(executar)
+	}
+	return executar()
+}
+
+// dividirArgumentos separa os argumentos por espaços, mantendo juntos os trechos entre aspas
+// duplas ou simples (--opcional="teto solar" vira --opcional=teto solar)
+func dividirArgumentos(linha string) []string {
+	var args []string
+	var atual strings.Builder
+	aspas, temArg := rune(0), false
+	for _, r := range linha {
+		switch {
+		case aspas != 0 && r == aspas:
+			aspas = 0
+		case aspas == 0 && (r == '"' || r == '\''):
+			aspas, temArg = r, true
+		case aspas == 0 && unicode.IsSpace(r):
+			if temArg {
+				args = append(args, atual.String())
+				atual.Reset()
+				temArg = false
+			}
+		default:
+			atual.WriteRune(r)
+			temArg = true
+		}
+	}
+	if temArg {
+		args = append(args, atual.String())
+	}
+	return args
+}
+
+// nomesComandos devolve os nomes de todos os comandos registrados
+func nomesComandos() []string {
+	nomes := make([]string, 0, len(comandos))
+	for _, cmd := range comandos {
+		nomes = append(nomes, cmd.Nome)
+	}
+	return nomes
+}
+
+// mostrarAjudaGeral lista todos os comandos com sintaxe e descrição
+func mostrarAjudaGeral() {
+	largura := 0
+	for _, cmd := range comandos {
+		if n := utf8.RuneCountInString(cmd.Sintaxe); n > largura {
+			largura = n
+		}
+	}
+
+	fmt.Println("\n--- Comandos Disponíveis ---")
+	for _, cmd := range comandos {
+		espacos := strings.Repeat(" ", largura-utf8.RuneCountInString(cmd.Sintaxe))
+		fmt.Printf("  %s%s  %s\n", cmd.Sintaxe, espacos, cmd.Descricao)
+	}
+	fmt.Println("\nDigite 'help <comando>' para ver opções e exemplos.")
+}
+
+// mostrarAjudaComando mostra sintaxe, opções e exemplos de um comando
+func mostrarAjudaComando(nome string) error {
+	cmd := buscarComando(nome)
+	if cmd == nil {
+		return fmt.Errorf("comando '%s' não existe. Comandos disponíveis: %s", nome, strings.Join(nomesComandos(), ", "))
+	}
+
+	fmt.Printf("\n--- Ajuda: %s ---\n", cmd.Nome)
+	fmt.Println(cmd.Descricao)
+	fmt.Printf("\nUso:\n  %s\n", cmd.Sintaxe)
+	if len(cmd.Opcoes) > 0 {
+		fmt.Println("\nOpções:")
+		for _, opcao := range cmd.Opcoes {
+			fmt.Printf("  %s\n", opcao)
+		}
+	}
+	if len(cmd.Exemplos) > 0 {
+		fmt.Println("\nExemplos:")
+		for _, exemplo := range cmd.Exemplos {
+			fmt.Printf("  > %s\n", exemplo)
+		}
+	}
+	return nil
+}
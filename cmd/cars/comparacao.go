@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CompararCarros mostra os carros lado a lado e, com arquivoHTML, grava a mesma comparação em uma
+// página HTML autocontida
+func (c *sessao) CompararCarros(ids []string, arquivoHTML string, modo cars.ModoTabela) error {
+	cmp, err := c.Snapshot().Comparar(ids, c.Config().Catalogo, time.Now())
+	if err != nil {
+		return err
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{{Titulo: "", Essencial: true}}}
+	for i, titulo := range cmp.Titulos {
+		t.Colunas = append(t.Colunas, cars.ColunaTabela{Titulo: fmt.Sprintf("%s (%s)", titulo, ids[i]), Essencial: true})
+	}
+	for _, l := range cmp.Linhas {
+		linha := []string{l.Atributo}
+		for i, valor := range l.Valores {
+			if l.Destaques[i] {
+				valor += " ★"
+			}
+			linha = append(linha, valor)
+		}
+		t.Linhas = append(t.Linhas, linha)
+	}
+	fmt.Printf("\n--- Comparação de %d Carros ---\n", len(ids))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+
+	if arquivoHTML == "" {
+		return nil
+	}
+	f, err := os.Create(arquivoHTML)
+	if err != nil {
+		return fmt.Errorf("erro ao criar %s: %v", arquivoHTML, err)
+	}
+	err = cmp.GravarHTML(f)
+	if errFechar := f.Close(); err == nil {
+		err = errFechar
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao gravar a comparação em HTML: %v", err)
+	}
+	fmt.Printf("✅ Comparação gravada em %s (arquivo único, pronto para enviar ao cliente).\n", arquivoHTML)
+	return nil
+}
+
+// interpretarArgsComparacao lê `<ID> <ID> [<ID>...] [--html=<arquivo>]`
+func interpretarArgsComparacao(args []string) (ids []string, arquivoHTML string, err error) {
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--html="):
+			if arquivoHTML = strings.TrimPrefix(arg, "--html="); arquivoHTML == "" {
+				return nil, "", fmt.Errorf("informe o arquivo em --html=<arquivo>")
+			}
+		case strings.HasPrefix(arg, "--"):
+			return nil, "", fmt.Errorf("opção desconhecida: %s", arg)
+		case slices.Contains(ids, arg):
+			return nil, "", fmt.Errorf("ID repetido: %s", arg)
+		default:
+			ids = append(ids, arg)
+		}
+	}
+	if len(ids) < 2 || len(ids) > 4 {
+		return nil, "", fmt.Errorf("informe de 2 a 4 IDs para comparar")
+	}
+	return ids, arquivoHTML, nil
+}
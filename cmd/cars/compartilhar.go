@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CompartilharCarro mostra a mensagem do carro pronta para colar no WhatsApp
+func (c *sessao) CompartilharCarro(id, canal string) error {
+	mensagem, carro, err := c.Mensagem(id, canal)
+	if err != nil {
+		return err
+	}
+	if status := cars.StatusCarro(carro); status != cars.StatusEmEstoque {
+		fmt.Printf("⚠️  Aviso: o carro está %s.\n", status)
+	}
+	fmt.Println("\n--- Copie e cole no WhatsApp ---")
+	fmt.Println(mensagem)
+	fmt.Println("--------------------------------")
+	return nil
+}
+
+// interpretarArgsCompartilhar lê `<ID> [--channel=<canal>]`
+func interpretarArgsCompartilhar(args []string) (id, canal string, err error) {
+	canal, resto, err := separarOpcaoCanal(args)
+	if err != nil {
+		return "", "", err
+	}
+	for _, arg := range resto {
+		if strings.HasPrefix(arg, "--") || id != "" {
+			return "", "", fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		id = arg
+	}
+	if id == "" {
+		return "", "", fmt.Errorf("informe o ID do carro")
+	}
+	return id, canal, nil
+}
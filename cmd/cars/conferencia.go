@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// lerContagem lê o CSV da contagem física. O cabeçalho é obrigatório e precisa de "chassi" ou "id";
+// marca, modelo, ano e cor são opcionais e, se presentes, são conferidos. Aceita vírgula ou ponto e vírgula.
+func lerContagem(arquivo string) ([]cars.ItemContagem, error) {
+	linhas, colunas, err := cars.LerPlanilha(arquivo, "contagem")
+	if err != nil {
+		return nil, err
+	}
+	_, temChassi := colunas["chassi"]
+	_, temID := colunas["id"]
+	if !temChassi && !temID {
+		return nil, fmt.Errorf("o cabeçalho precisa da coluna \"chassi\" ou \"id\"")
+	}
+	campo := func(linha []string, nome string) string {
+		if i, ok := colunas[nome]; ok && i < len(linha) {
+			return strings.TrimSpace(linha[i])
+		}
+		return ""
+	}
+
+	var itens []cars.ItemContagem
+	for n, linha := range linhas {
+		item := cars.ItemContagem{
+			Linha:  n + 2,
+			Chassi: cars.NormalizarChassi(campo(linha, "chassi")),
+			ID:     campo(linha, "id"),
+			Marca:  campo(linha, "marca"),
+			Modelo: campo(linha, "modelo"),
+			Cor:    campo(linha, "cor"),
+		}
+		if item.Chassi == "" && item.ID == "" {
+			continue // Linha em branco
+		}
+		if ano := campo(linha, "ano"); ano != "" {
+			if item.Ano, err = strconv.Atoi(ano); err != nil {
+				return nil, fmt.Errorf("linha %d: ano inválido '%s'", item.Linha, ano)
+			}
+		}
+		itens = append(itens, item)
+	}
+	return itens, nil
+}
+
+// ConciliarEstoque lê a contagem física do arquivo e mostra a conferência com o cadastro
+func (c *sessao) ConciliarEstoque(arquivo string, modo cars.ModoTabela) error {
+	itens, err := lerContagem(arquivo)
+	if err != nil {
+		return err
+	}
+	r := c.CadastroCarros.ConciliarEstoque(itens)
+
+	semCadastro := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Linha", Direita: true},
+		{Titulo: "Chassi/ID", Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Situação", Essencial: true},
+	}}
+	for _, s := range r.SemCadastro {
+		semCadastro.Linhas = append(semCadastro.Linhas, []string{strconv.Itoa(s.Item.Linha), s.Item.Chave(), s.Carro, s.Situacao})
+	}
+	divergentes := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Divergência", Essencial: true},
+	}}
+	for _, d := range r.Divergencias {
+		divergentes.Linhas = append(divergentes.Linhas, []string{d.Carro.ID, d.Carro.Marca + " " + d.Carro.Modelo, d.Descricao})
+	}
+
+	fmt.Printf("\n--- Conferência de Estoque: %d item(ns) contado(s), %d carro(s) no cadastro ---\n", r.Contados, r.EmEstoque)
+	secoes := []struct {
+		titulo string
+		tabela cars.Tabela
+	}{
+		{"Cadastrados e não encontrados no pátio", c.tabelaCarros(r.Ausentes)},
+		{"No pátio sem cadastro em estoque", semCadastro},
+		{"Atributos divergentes", divergentes},
+	}
+	for _, s := range secoes {
+		fmt.Printf("\n%s: %d\n", s.titulo, len(s.tabela.Linhas))
+		if len(s.tabela.Linhas) > 0 {
+			fmt.Print(s.tabela.Renderizar(modo, larguraTerminal()))
+		}
+	}
+	if r.Confere() {
+		fmt.Println("\n✅ Contagem física confere com o cadastro.")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RecarregarConfig relê o arquivo de configuração e mostra o resultado
+func (c *sessao) RecarregarConfig() error {
+	mensagens, err := c.CadastroCarros.RecarregarConfig()
+	if err != nil {
+		return err
+	}
+	for _, m := range mensagens {
+		fmt.Println(m)
+	}
+	return nil
+}
+
+// MostrarConfig exibe a configuração em vigor, já com padrões e perfil aplicados
+func (c *sessao) MostrarConfig() error {
+	cfg := c.Config()
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar configuração: %v", err)
+	}
+	fmt.Printf("\n--- Configuração em vigor (%s", c.ArquivoConfig())
+	if cfg.Perfil != "" {
+		fmt.Printf(", perfil %s", cfg.Perfil)
+	}
+	fmt.Printf(") ---\n%s\n", data)
+	return nil
+}
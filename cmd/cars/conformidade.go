@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// verificarConformidade mostra alertas e violações do carro. Havendo violações, pede uma
+// justificativa para cadastrar mesmo assim; devolve erro se o cadastro deve ser cancelado.
+func (c *sessao) verificarConformidade(carro cars.Carro) (*cars.ExcecaoConformidade, error) {
+	violacoes, alertas := c.AvaliarConformidade(carro)
+
+	for _, alerta := range alertas {
+		fmt.Printf("⚠️  Aviso: %s\n", alerta)
+	}
+	if len(violacoes) == 0 {
+		return nil, nil
+	}
+	for _, violacao := range violacoes {
+		fmt.Printf("❌ Conformidade: %s\n", violacao)
+	}
+	justificativa, _ := c.cli.Perguntar("Justificativa para cadastrar mesmo assim (Enter cancela): ")
+	if justificativa == "" {
+		return nil, operacaoCancelada("Cadastro cancelado por regra de conformidade.")
+	}
+	return &cars.ExcecaoConformidade{Violacoes: violacoes, Justificativa: justificativa}, nil
+}
+
+// ListarExcecoes mostra os cadastros feitos com justificativa apesar das regras de conformidade
+func (c *sessao) ListarExcecoes(modo cars.ModoTabela) {
+	excecoes := c.Snapshot().Excecoes()
+	if len(excecoes) == 0 {
+		fmt.Println("\nNenhuma exceção de conformidade registrada.")
+		return
+	}
+
+	fmt.Println("\n--- Exceções de Conformidade ---")
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Carro", Essencial: true},
+		{Titulo: "Registrada em"},
+		{Titulo: "Violações"},
+		{Titulo: "Justificativa", Essencial: true},
+	}}
+	for _, e := range excecoes {
+		t.Linhas = append(t.Linhas, []string{e.CarroID, e.RegistradaEm, strings.Join(e.Violacoes, "; "), e.Justificativa})
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// primeiraExecucao informa se não há configuração nem dados, nem no diretório de dados nem no atual
+func primeiraExecucao(caminhoCfg string) bool {
+	candidatos := []string{caminhoCfg, "carros.json", "carros.db",
+		filepath.Join(cars.DiretorioDados(), "carros.json"), filepath.Join(cars.DiretorioDados(), "carros.db")}
+	for _, caminho := range candidatos {
+		if _, err := os.Stat(caminho); err == nil {
+			return false
+		}
+	}
+	return true
+}
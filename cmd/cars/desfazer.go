@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// comandosForaDoDiario não entram no diário: o undo e o redo não desfazem a si mesmos
+var comandosForaDoDiario = []string{"undo", "redo"}
+
+// Desfazer desfaz a última operação do prompt e mostra o que mudou
+func (c *sessao) Desfazer() error {
+	resultado, err := c.CadastroCarros.Desfazer()
+	if errors.Is(err, cars.ErrNadaADesfazer) {
+		fmt.Println("Nada a desfazer nesta sessão.")
+		return nil
+	}
+	return c.mostrarVolta("Desfazer", resultado, err)
+}
+
+// Refazer refaz a última operação desfeita e mostra o que mudou
+func (c *sessao) Refazer() error {
+	resultado, err := c.CadastroCarros.Refazer()
+	if errors.Is(err, cars.ErrNadaARefazer) {
+		fmt.Println("Nada a refazer.")
+		return nil
+	}
+	return c.mostrarVolta("Refazer", resultado, err)
+}
+
+// mostrarVolta lista as mudanças de um undo ou redo
+func (c *sessao) mostrarVolta(acao string, resultado cars.ResultadoVolta, err error) error {
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("↩️  %s: %s\n", acao, resultado.Comando)
+	if len(resultado.Mudancas) == 0 {
+		fmt.Println("Os carros já estavam nessa situação; nada mudou.")
+		return nil
+	}
+	for _, m := range resultado.Mudancas {
+		linha := fmt.Sprintf("   %s (%s): %s → %s", m.ID, m.Carro, m.Agora.Descricao(), m.NoPonto.Descricao())
+		if m.Detalhe != "" {
+			linha += " (" + strings.TrimSuffix(m.Detalhe, "; ") + ")"
+		}
+		fmt.Println(linha)
+	}
+	if acao == "Desfazer" {
+		fmt.Println("✅ Operação desfeita. Use 'redo' para aplicá-la de novo.")
+	} else {
+		fmt.Println("✅ Operação refeita.")
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CompararArquivos mostra o que mudou do primeiro para o segundo arquivo de dados (ex: um backup e
+// o arquivo atual, ou as duas pontas de uma sincronização). Com emJSON, imprime o relatório em JSON.
+func (c *sessao) CompararArquivos(arquivoA, arquivoB string, emJSON bool, modo cars.ModoTabela) error {
+	d, err := cars.DiferencaEntreArquivos(arquivoA, arquivoB)
+	if err != nil {
+		return err
+	}
+
+	if emJSON {
+		data, _ := json.MarshalIndent(d, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	if d.Vazia() {
+		fmt.Printf("✅ Os arquivos têm os mesmos %d carro(s).\n", d.Iguais)
+		return nil
+	}
+
+	mostrarDiferenca(d, "Adicionados em "+arquivoB, "Removidos de "+arquivoA, c.Exibicao(), modo)
+	return nil
+}
+
+// mostrarDiferenca imprime as tabelas de carros adicionados, removidos e alterados com os
+// títulos informados, e a contagem final
+func mostrarDiferenca(d cars.DiferencaArquivos, adicionados, removidos string, exibicao cars.OpcoesExibicao, modo cars.ModoTabela) {
+	resumo := func(titulo string, carros []cars.Carro) {
+		if len(carros) == 0 {
+			return
+		}
+		t := cars.Tabela{Colunas: []cars.ColunaTabela{
+			{Titulo: "ID", Essencial: true},
+			{Titulo: "Carro", Essencial: true},
+			{Titulo: "Ano", Direita: true},
+			{Titulo: "Preço", Direita: true},
+		}}
+		for _, carro := range carros {
+			t.Linhas = append(t.Linhas, []string{carro.ID, carro.Marca + " " + carro.Modelo,
+				fmt.Sprint(carro.Ano), exibicao.FormatarPreco(carro.Preco)})
+		}
+		fmt.Printf("\n--- %s (%d) ---\n", titulo, len(carros))
+		fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	}
+	resumo(adicionados, d.Adicionados)
+	resumo(removidos, d.Removidos)
+	if len(d.Alterados) > 0 {
+		t := cars.Tabela{Colunas: []cars.ColunaTabela{
+			{Titulo: "ID", Essencial: true},
+			{Titulo: "Carro"},
+			{Titulo: "Campo", Essencial: true},
+			{Titulo: "Antes", Essencial: true},
+			{Titulo: "Depois", Essencial: true},
+		}}
+		for _, alterado := range d.Alterados {
+			for i, campo := range alterado.Campos {
+				id, carro := alterado.ID, alterado.Carro
+				if i > 0 {
+					id, carro = "", ""
+				}
+				t.Linhas = append(t.Linhas, []string{id, carro, campo.Campo, fmt.Sprint(campo.Antes), fmt.Sprint(campo.Depois)})
+			}
+		}
+		fmt.Printf("\n--- Alterados (%d) ---\n", len(d.Alterados))
+		fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	}
+	fmt.Printf("%d adicionado(s), %d removido(s), %d alterado(s), %d igual(is).\n",
+		len(d.Adicionados), len(d.Removidos), len(d.Alterados), d.Iguais)
+}
+
+// interpretarArgsDiferenca lê `<arquivoA> <arquivoB> [--json]`
+func interpretarArgsDiferenca(args []string) (arquivoA, arquivoB string, emJSON bool, err error) {
+	var arquivos []string
+	for _, arg := range args {
+		switch {
+		case arg == "--json":
+			emJSON = true
+		case strings.HasPrefix(arg, "--"):
+			return "", "", false, fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			arquivos = append(arquivos, arg)
+		}
+	}
+	if len(arquivos) != 2 {
+		return "", "", false, fmt.Errorf("informe dois arquivos: diff <arquivoA> <arquivoB>")
+	}
+	return arquivos[0], arquivos[1], emJSON, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// descreverPrazo resume os dias restantes ("vence hoje", "em 12 dia(s)", "vencido há 3 dia(s)")
+func descreverPrazo(dias int) string {
+	switch {
+	case dias == 0:
+		return "vence hoje"
+	case dias < 0:
+		return fmt.Sprintf("vencido há %d dia(s)", -dias)
+	}
+	return fmt.Sprintf("em %d dia(s)", dias)
+}
+
+// RegistrarDocumento grava o vencimento de um documento do carro e confirma a data
+func (c *sessao) RegistrarDocumento(id, tipo, vencimento string) error {
+	carro, doc, err := c.GravarDocumento(id, tipo, vencimento)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ %s de '%s %s' vence em %s.\n", cars.TiposDocumento[doc.Tipo], carro.Marca, carro.Modelo, doc.Vencimento)
+	return err
+}
+
+// MostrarDocumentos lista os vencimentos registrados para um carro
+func (c *sessao) MostrarDocumentos(id string) error {
+	visao := c.Snapshot()
+	carro, existe := visao.Carro(id)
+	if !existe {
+		return cars.ErroCarroNaoEncontrado(id)
+	}
+	fmt.Printf("\n--- Documentos de '%s %s' (%s) ---\n", carro.Marca, carro.Modelo, id)
+	encontrados := 0
+	for _, v := range visao.Vencimentos(1<<30, time.Now()) {
+		if v.CarroID == id {
+			fmt.Printf("%-22s %s (%s)\n", cars.TiposDocumento[v.Tipo]+":", v.Vencimento, descreverPrazo(v.Dias))
+			encontrados++
+		}
+	}
+	if encontrados == 0 {
+		fmt.Println("Nenhum vencimento registrado. Use 'doc <ID> <crlv|seguro|garantia> <data>'.")
+	}
+	return nil
+}
+
+// ListarVencimentos mostra os documentos vencidos ou que vencem nos próximos `dias` dias
+func (c *sessao) ListarVencimentos(dias int, modo cars.ModoTabela) {
+	lista := c.Snapshot().Vencimentos(dias, time.Now())
+	if len(lista) == 0 {
+		fmt.Printf("\nNenhum documento de carro em estoque vence nos próximos %d dia(s).\n", dias)
+		return
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Documento", Essencial: true},
+		{Titulo: "Vencimento", Essencial: true},
+		{Titulo: "Prazo", Essencial: true},
+	}}
+	vencidos := 0
+	for _, v := range lista {
+		if v.Dias < 0 {
+			vencidos++
+		}
+		t.Linhas = append(t.Linhas, []string{v.CarroID, v.Carro, cars.TiposDocumento[v.Tipo], v.Vencimento, descreverPrazo(v.Dias)})
+	}
+	fmt.Printf("\n--- Documentos vencendo em até %d dia(s): %d (%d já vencido(s)) ---\n", dias, len(lista), vencidos)
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
+
+// interpretarArgsVencimentos lê --days=<n> (padrão 30) e o modo da tabela
+func interpretarArgsVencimentos(args []string) (int, cars.ModoTabela, error) {
+	modo, args := interpretarModoTabela(args)
+	dias := 30
+	for _, arg := range args {
+		valor, ok := strings.CutPrefix(arg, "--days=")
+		if !ok {
+			return 0, modo, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		n, err := strconv.Atoi(valor)
+		if err != nil || n < 0 {
+			return 0, modo, fmt.Errorf("--days deve ser um número inteiro não negativo")
+		}
+		dias = n
+	}
+	return dias, modo, nil
+}
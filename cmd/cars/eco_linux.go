@@ -0,0 +1,25 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// desligarEco esconde o que é digitado no terminal (para senhas), mantendo a leitura por linha,
+// e devolve a função que restaura o terminal. Sem terminal, não faz nada.
+func desligarEco() (religar func()) {
+	fd := int(os.Stdin.Fd())
+	estado, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return func() {}
+	}
+	semEco := *estado
+	semEco.Lflag &^= unix.ECHO
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &semEco); err != nil {
+		return func() {}
+	}
+	return func() { unix.IoctlSetTermios(fd, unix.TCSETS, estado) }
+}
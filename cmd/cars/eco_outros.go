@@ -0,0 +1,6 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package main
+
+// desligarEco não esconde a digitação em sistemas sem termios (Windows); a senha aparece na tela
+func desligarEco() (religar func()) { return func() {} }
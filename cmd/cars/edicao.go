@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// comandoEditor devolve o editor do usuário ($VISUAL, $EDITOR ou o padrão do sistema), já separado em argumentos
+func comandoEditor() []string {
+	for _, variavel := range []string{"VISUAL", "EDITOR"} {
+		if partes := strings.Fields(os.Getenv(variavel)); len(partes) > 0 {
+			return partes
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// EditarCarro abre o registro completo no editor do usuário em JSON. Ao salvar e fechar o editor,
+// o registro é validado, as diferenças são mostradas e as alterações só são gravadas após confirmação.
+func (c *sessao) EditarCarro(id string) error {
+	original, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+
+	arquivo, err := os.CreateTemp("", "carro-*.json")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+	defer os.Remove(arquivo.Name())
+	data, _ := json.MarshalIndent(original, "", "  ")
+	arquivo.Write(data)
+	arquivo.Close()
+
+	readInput := func(prompt string) string {
+		resposta, _ := c.cli.Perguntar(prompt)
+		return strings.ToLower(resposta)
+	}
+
+	// O editor roda sem o lock; a versão editada é conferida contra a atual antes de gravar
+	var editado cars.Carro
+	for {
+		editor := comandoEditor()
+		cmd := exec.Command(editor[0], append(editor[1:], arquivo.Name())...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("erro ao executar o editor '%s': %v", strings.Join(editor, " "), err)
+		}
+
+		err := func() error {
+			data, err := os.ReadFile(arquivo.Name())
+			if err != nil {
+				return err
+			}
+			editado = cars.Carro{}
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&editado); err != nil {
+				return fmt.Errorf("JSON inválido: %v", err)
+			}
+			return cars.ConferirEdicao(original, editado)
+		}()
+		if err == nil {
+			break
+		}
+		fmt.Printf("❌ Registro inválido: %v\n", err)
+		if readInput("(e)ditar de novo ou (d)escartar? ") != "e" {
+			return operacaoCancelada("Edição descartada.")
+		}
+	}
+
+	diferencas := cars.DiferencasCarro(original, editado)
+	if len(diferencas) == 0 {
+		fmt.Println("Nenhuma alteração informada.")
+		return nil
+	}
+	fmt.Println("\n--- Alterações ---")
+	for _, linha := range diferencas {
+		fmt.Println("  " + linha)
+	}
+	if r := readInput("Gravar alterações? (s/N): "); r != "s" && r != "sim" {
+		return operacaoCancelada("Edição descartada.")
+	}
+
+	return c.gravarEdicao(original, editado)
+}
+
+// gravarEdicao confirma o novo preço, se o carro for de alto valor, e grava a versão editada
+func (c *sessao) gravarEdicao(original, editado cars.Carro) error {
+	if editado.Preco != original.Preco {
+		if err := c.confirmarAltoValor(original, "o novo preço de "+c.Exibicao().FormatarPreco(editado.Preco), editado.Preco); err != nil {
+			return err
+		}
+	}
+	_, ajustes, err := c.Regravar(original, editado)
+	if !alteracaoFeita(err) {
+		return fmt.Errorf("%v; edição descartada", err)
+	}
+	c.mostrarAjustes(ajustes)
+	fmt.Printf("✅ Carro com ID '%s' atualizado no banco em memória.\n", original.ID)
+	return err
+}
+
+// AplicarPatch aplica um JSON merge patch ao registro do carro, sem abrir o editor (útil em
+// scripts de correção)
+func (c *sessao) AplicarPatch(id, patch string) error {
+	original, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+	editado, err := cars.EditarPorPatch(original, patch)
+	if err != nil {
+		return err
+	}
+
+	diferencas := cars.DiferencasCarro(original, editado)
+	if len(diferencas) == 0 {
+		fmt.Println("Nenhuma alteração: o patch não muda o registro.")
+		return nil
+	}
+	fmt.Println("\n--- Alterações ---")
+	for _, linha := range diferencas {
+		fmt.Println("  " + linha)
+	}
+	return c.gravarEdicao(original, editado)
+}
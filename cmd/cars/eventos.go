@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// ReconstruirProjecao refaz a projeção a partir do log e informa o resultado
+func (c *sessao) ReconstruirProjecao() error {
+	reconstruida, err := c.CadastroCarros.ReconstruirProjecao()
+	if !alteracaoFeita(err) {
+		return err
+	}
+	visao := c.Snapshot()
+	if !reconstruida {
+		fmt.Printf("✅ Projeção confere com o log (%d evento(s), %d carro(s) em estoque).\n", len(visao.Eventos()), len(visao.Carros()))
+		return nil
+	}
+	fmt.Printf("✅ Projeção reconstruída a partir de %d evento(s): %d carro(s) em estoque.\n", len(visao.Eventos()), len(visao.Carros()))
+	return err
+}
+
+// ListarEventos mostra o log de eventos, de todos os carros ou de um só, como trilha de auditoria
+func (c *sessao) ListarEventos(id string, modo cars.ModoTabela) {
+	visao := c.Snapshot()
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Seq", Direita: true, Essencial: true},
+		{Titulo: "Instante", Essencial: true},
+		{Titulo: "Evento", Essencial: true},
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Autor"},
+		{Titulo: "Detalhe"},
+	}}
+	anterior := make(map[string]cars.Carro)
+	for _, e := range visao.Eventos() {
+		detalhe := detalheEvento(e, anterior, visao.Exibicao())
+		if id != "" && e.CarroID != id {
+			continue
+		}
+		t.Linhas = append(t.Linhas, []string{strconv.FormatInt(e.Seq, 10), e.Em, e.Tipo, e.CarroID, e.Autor, detalhe})
+	}
+
+	if len(t.Linhas) == 0 {
+		fmt.Println("\nNenhum evento registrado.")
+		return
+	}
+	fmt.Printf("\n--- Log de Eventos (%d) ---\n", len(t.Linhas))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	if limite := visao.LimiteAuditoria(); !limite.IsZero() {
+		fmt.Printf("Eventos anteriores a %s compactados pela retenção (só o estado líquido aparece); veja 'events summary'.\n", limite.Format("01/2006"))
+	}
+}
+
+// detalheEvento resume o evento para a trilha de auditoria; atualizações aparecem como as
+// diferenças para o estado anterior do carro, que é mantido em anterior conforme o log avança
+func detalheEvento(e cars.Evento, anterior map[string]cars.Carro, exibicao cars.OpcoesExibicao) string {
+	detalhe := ""
+	switch {
+	case e.Carro != nil && e.Tipo == cars.EventoCarroAtualizado:
+		detalhe = strings.Join(cars.DiferencasCarro(anterior[e.CarroID], *e.Carro), "; ")
+	case e.Carro != nil:
+		detalhe = fmt.Sprintf("%s %s %d, %s", e.Carro.Marca, e.Carro.Modelo, e.Carro.Ano, exibicao.FormatarPreco(e.Carro.Preco))
+	case e.Venda != nil:
+		detalhe = "por " + exibicao.FormatarPreco(e.Venda.PrecoFinal)
+	}
+	if e.Rollback > 0 {
+		detalhe = strings.TrimSuffix(fmt.Sprintf("rollback até #%d; %s", e.Rollback, detalhe), "; ")
+	}
+	if e.Carro != nil {
+		anterior[e.CarroID] = *e.Carro
+	}
+	return detalhe
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ExportarDesde grava (no arquivo ou na saída padrão, se arquivo for vazio) os carros criados,
+// atualizados ou removidos depois do instante informado
+func (c *sessao) ExportarDesde(desde time.Time, arquivo string) error {
+	exportacao := c.ExportacaoDesde(desde)
+	data, err := json.MarshalIndent(exportacao, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar exportação: %v", err)
+	}
+
+	if arquivo == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(arquivo, data, 0644); err != nil {
+		return fmt.Errorf("erro ao escrever arquivo de exportação: %v", err)
+	}
+	fmt.Printf("✅ Exportação incremental gravada em %s: %d alterado(s), %d removido(s) desde %s.\n",
+		arquivo, len(exportacao.Alterados), len(exportacao.Removidos), exportacao.Desde)
+	return nil
+}
+
+// interpretarArgsExport lê `--since=<instante>` (ou `--since <instante>`) e o arquivo de destino opcional
+func interpretarArgsExport(args []string) (time.Time, string, error) {
+	var desdeStr, arquivo string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "--since="):
+			desdeStr = strings.TrimPrefix(arg, "--since=")
+		case arg == "--since" && i+1 < len(args):
+			i++
+			desdeStr = args[i]
+		case strings.HasPrefix(arg, "--"):
+			return time.Time{}, "", fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			arquivo = arg
+		}
+	}
+	if desdeStr == "" {
+		return time.Time{}, "", fmt.Errorf("informe --since=<instante RFC 3339>")
+	}
+	desde, err := time.Parse(time.RFC3339, desdeStr)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("instante inválido '%s' (ex: 2024-06-01T00:00:00Z)", desdeStr)
+	}
+	return desde, arquivo, nil
+}
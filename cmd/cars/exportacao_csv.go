@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ExportarCSV grava todos os carros em estoque no arquivo CSV informado
+func (c *sessao) ExportarCSV(arquivo string, workers int, delimitador rune, canal string) error {
+	inicio := time.Now()
+	f, err := os.Create(arquivo)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo CSV: %v", err)
+	}
+	total, err := c.EscreverCSV(f, workers, delimitador, canal)
+	if errFechar := f.Close(); err == nil {
+		err = errFechar
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao exportar CSV: %v", err)
+	}
+	precos := ""
+	if canal != "" {
+		precos = ", preços do canal " + canal
+	}
+	fmt.Printf("✅ %d carro(s) exportado(s) para %s em %s (%d worker(s)%s).\n",
+		total, arquivo, time.Since(inicio).Round(time.Millisecond), workers, precos)
+	return nil
+}
+
+// interpretarDelimitador lê o valor de --delimiter: um caractere, ou "tab" para tabulação
+func interpretarDelimitador(valor string) (rune, error) {
+	if strings.EqualFold(valor, "tab") || valor == `\t` {
+		return '\t', nil
+	}
+	r := []rune(valor)
+	if len(r) != 1 || r[0] == '"' || r[0] == '\r' || r[0] == '\n' || r[0] == utf8.RuneError {
+		return 0, fmt.Errorf("delimitador inválido '%s' (use um caractere, ex: ; ou |, ou tab)", valor)
+	}
+	return r[0], nil
+}
+
+// interpretarArgsCSV lê `<arquivo> [--workers=N] [--delimiter=<c>]`; o padrão é um worker por CPU
+// e vírgula como delimitador
+func interpretarArgsCSV(args []string) (arquivo string, workers int, delimitador rune, err error) {
+	workers, delimitador = runtime.NumCPU(), ','
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--workers="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--workers="))
+			if err != nil || n < 1 || n > 256 {
+				return "", 0, 0, fmt.Errorf("número de workers inválido: %s (de 1 a 256)", arg)
+			}
+			workers = n
+		case strings.HasPrefix(arg, "--delimiter="):
+			if delimitador, err = interpretarDelimitador(strings.TrimPrefix(arg, "--delimiter=")); err != nil {
+				return "", 0, 0, err
+			}
+		case strings.HasPrefix(arg, "--"):
+			return "", 0, 0, fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			arquivo = arg
+		}
+	}
+	if arquivo == "" {
+		return "", 0, 0, fmt.Errorf("informe o arquivo de destino")
+	}
+	return arquivo, workers, delimitador, nil
+}
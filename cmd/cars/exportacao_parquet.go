@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportarParquet grava o histórico do estoque em Parquet particionado por ano de cadastro
+func (c *sessao) ExportarParquet(destino string) error {
+	inicio := time.Now()
+	exportacao, err := c.GravarParquet(destino)
+	if err != nil {
+		return err
+	}
+	if exportacao.Carros == 0 {
+		fmt.Println("Nenhum carro no histórico para exportar.")
+		return nil
+	}
+	fmt.Printf("✅ %d carro(s) exportado(s) em Parquet para %s em %s, %d partição(ões) por ano de cadastro (%s).\n",
+		exportacao.Carros, destino, time.Since(inicio).Round(time.Millisecond), len(exportacao.Anos), strings.Join(exportacao.Anos, ", "))
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// totaisCronograma soma prestações e juros de um cronograma
+func totaisCronograma(parcelas []cars.ParcelaFinanciamento) (total, juros cars.Dinheiro) {
+	for _, p := range parcelas {
+		total += p.Prestacao
+		juros += p.Juros
+	}
+	return total, juros
+}
+
+// SimularFinanciamento mostra o resumo Price e SAC para um carro e o cronograma do sistema escolhido
+func (c *sessao) SimularFinanciamento(id string, sim cars.SimulacaoFinanciamento) error {
+	fin, err := c.CalcularFinanciamento(id, sim)
+	if err != nil {
+		return err
+	}
+	carro, price, sac := fin.Carro, fin.Price, fin.SAC
+	totalPrice, jurosPrice := totaisCronograma(price)
+	totalSAC, jurosSAC := totaisCronograma(sac)
+	f := c.Exibicao().FormatarPreco
+
+	fmt.Printf("\n--- Simulação de Financiamento: %s %s %d (ID: %s) ---\n", carro.Marca, carro.Modelo, carro.Ano, carro.ID)
+	fmt.Printf("Preço: %s | Entrada: %s | Financiado: %s | %d meses a %.2f%% a.m.\n\n",
+		f(carro.Preco), f(fin.Entrada), f(fin.Financiado), sim.Meses, sim.TaxaMensal*100)
+	fmt.Printf("Price: %d x %s | Total pago: %s | Juros: %s\n", sim.Meses, f(price[0].Prestacao), f(totalPrice+fin.Entrada), f(jurosPrice))
+	fmt.Printf("SAC:   1ª %s → última %s | Total pago: %s | Juros: %s\n",
+		f(sac[0].Prestacao), f(sac[len(sac)-1].Prestacao), f(totalSAC+fin.Entrada), f(jurosSAC))
+
+	parcelas := price
+	if sim.Sistema == "sac" {
+		parcelas = sac
+	}
+
+	fmt.Printf("\nCronograma (%s):\n", strings.ToUpper(sim.Sistema))
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Nº", Direita: true, Essencial: true},
+		{Titulo: "Prestação", Direita: true, Essencial: true},
+		{Titulo: "Juros", Direita: true},
+		{Titulo: "Amortização", Direita: true},
+		{Titulo: "Saldo", Direita: true, Essencial: true},
+	}}
+	for _, p := range parcelas {
+		t.Linhas = append(t.Linhas, []string{strconv.Itoa(p.Numero), f(p.Prestacao), f(p.Juros), f(p.Amortizacao), f(p.Saldo)})
+	}
+	fmt.Print(t.Renderizar(cars.TabelaAuto, larguraTerminal()))
+
+	if sim.ArquivoCSV != "" {
+		if err := exportarCronogramaCSV(sim.ArquivoCSV, parcelas); err != nil {
+			return fmt.Errorf("erro ao exportar cronograma: %v", err)
+		}
+		fmt.Printf("✅ Cronograma exportado para %s.\n", sim.ArquivoCSV)
+	}
+	return nil
+}
+
+// exportarCronogramaCSV grava o cronograma em CSV (valores com duas casas, ponto decimal)
+func exportarCronogramaCSV(caminho string, parcelas []cars.ParcelaFinanciamento) error {
+	arquivo, err := os.Create(caminho)
+	if err != nil {
+		return err
+	}
+	defer arquivo.Close()
+
+	w := csv.NewWriter(arquivo)
+	w.Write([]string{"parcela", "prestacao", "juros", "amortizacao", "saldo"})
+	for _, p := range parcelas {
+		w.Write([]string{
+			strconv.Itoa(p.Numero),
+			p.Prestacao.String(),
+			p.Juros.String(),
+			p.Amortizacao.String(),
+			p.Saldo.String(),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// interpretarArgsFinanciamento lê `--down=30%|50000 --months=48 --rate=1.49% [--sac] [--csv=arquivo]`
+func interpretarArgsFinanciamento(args []string) (cars.SimulacaoFinanciamento, error) {
+	sim := cars.SimulacaoFinanciamento{Meses: 48, Sistema: "price"}
+	taxaInformada := false
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch nome {
+		case "--down":
+			if strings.HasSuffix(valor, "%") {
+				p, err := cars.InterpretarNumero(strings.TrimSuffix(valor, "%"))
+				if err != nil || p < 0 || p >= 100 {
+					return sim, fmt.Errorf("entrada percentual inválida: %s", valor)
+				}
+				sim.EntradaPercentual = p / 100
+			} else {
+				v, ok := interpretarPrecoRapido(valor)
+				if !ok || v < 0 {
+					return sim, fmt.Errorf("entrada inválida: %s", valor)
+				}
+				sim.Entrada = v
+			}
+		case "--months":
+			m, err := strconv.Atoi(valor)
+			if err != nil || m <= 0 || m > 120 {
+				return sim, fmt.Errorf("prazo inválido: %s (de 1 a 120 meses)", valor)
+			}
+			sim.Meses = m
+		case "--rate":
+			r, err := cars.InterpretarNumero(strings.TrimSuffix(valor, "%"))
+			if err != nil || r < 0 {
+				return sim, fmt.Errorf("taxa inválida: %s", valor)
+			}
+			sim.TaxaMensal = r / 100
+			taxaInformada = true
+		case "--sac":
+			sim.Sistema = "sac"
+		case "--csv":
+			if valor == "" {
+				return sim, fmt.Errorf("informe o arquivo em --csv=<arquivo>")
+			}
+			sim.ArquivoCSV = valor
+		default:
+			return sim, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	if !taxaInformada {
+		return sim, fmt.Errorf("informe a taxa mensal em --rate=<taxa>%%")
+	}
+	return sim, nil
+}
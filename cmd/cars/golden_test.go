@@ -0,0 +1,27 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michellhornung/golang/cars/carrostest"
+)
+
+func TestGoldenRelatorios(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+	for _, linha := range []string{
+		"list",
+		"list --aging --sort preco --desc",
+		"list --aging-buckets",
+		"list --group-by=marca --narrow",
+		"search marca=toyota ano>=2021",
+		"heatmap",
+	} {
+		t.Run(linha, func(t *testing.T) {
+			c := carrostest.CarregarFixture(t, "estoque")
+			saida := carrostest.CapturarSaida(t, func() { executarLinha(novaSessao(c), linha) })
+			nome := strings.NewReplacer(" ", "_", "=", "-", ">", "", "-", "").Replace(linha)
+			carrostest.ConferirGolden(t, nome, saida)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+	"github.com/michellhornung/golang/cars/carrospb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// IniciarGRPC põe o serviço gRPC no ar e mostra o endereço
+func (c *sessao) IniciarGRPC(endereco string) error {
+	endereco, err := c.CadastroCarros.IniciarGRPC(endereco)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ gRPC (carros.v1.Carros) em %s (sem autenticação). Use 'grpc stop' para encerrar.\n", endereco)
+	return nil
+}
+
+// PararGRPC encerra o serviço gRPC e confirma
+func (c *sessao) PararGRPC() error {
+	parou, err := c.CadastroCarros.PararGRPC()
+	switch {
+	case err != nil:
+		return err
+	case !parou:
+		fmt.Println("Nenhum gRPC no ar.")
+	default:
+		fmt.Println("✅ gRPC encerrado.")
+	}
+	return nil
+}
+
+// chamadaRPC é uma chamada do cliente de teste do gRPC (comando rpc)
+type chamadaRPC struct {
+	Endereco   string
+	Confirmar  string   // Modelo repetido para operações de alto valor (--confirm)
+	Operacao   string   // list, get, add, update ou delete
+	Argumentos []string // Argumentos da operação
+}
+
+// interpretarArgsRPC lê `[--addr=<endereço>] [--confirm=<modelo>] <operação> [<argumentos>]`; as
+// opções podem vir em qualquer posição
+func interpretarArgsRPC(args []string) (chamadaRPC, error) {
+	chamada := chamadaRPC{Endereco: "127.0.0.1:8082"}
+	var posicionais []string
+	for _, arg := range args {
+		switch nome, valor, _ := strings.Cut(arg, "="); {
+		case nome == "--addr" && valor != "":
+			chamada.Endereco = valor
+		case nome == "--confirm" && valor != "":
+			chamada.Confirmar = valor
+		case strings.HasPrefix(arg, "--"):
+			return chamada, fmt.Errorf("opção desconhecida: %s", arg)
+		default:
+			posicionais = append(posicionais, arg)
+		}
+	}
+	if len(posicionais) == 0 {
+		return chamada, erroUso(buscarComando("rpc").Sintaxe)
+	}
+	chamada.Operacao, chamada.Argumentos = posicionais[0], posicionais[1:]
+	aridade := map[string]int{"list": 0, "get": 1, "delete": 1, "update": 2}
+	switch n, existe := aridade[chamada.Operacao]; {
+	case chamada.Operacao == "add":
+		if len(chamada.Argumentos) == 0 {
+			return chamada, erroUso(`rpc add "<Marca Modelo Ano Cor Preço País>"`)
+		}
+	case !existe:
+		return chamada, fmt.Errorf("operação desconhecida '%s' (use list, get, add, update ou delete)", chamada.Operacao)
+	case len(chamada.Argumentos) != n:
+		return chamada, erroUso(buscarComando("rpc").Sintaxe)
+	}
+	return chamada, nil
+}
+
+// erroRPC devolve a mensagem do servidor com o código gRPC, ex: "carro ... não encontrado (NotFound)"
+func erroRPC(err error) error {
+	if s, ok := status.FromError(err); ok {
+		return fmt.Errorf("%s (%s)", s.Message(), s.Code())
+	}
+	return err
+}
+
+// ChamarGRPC faz uma chamada ao serviço gRPC de uma instância e mostra o resultado como os comandos
+// locais equivalentes
+func (c *sessao) ChamarGRPC(chamada chamadaRPC) error {
+	conexao, err := grpc.NewClient(chamada.Endereco, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("erro ao conectar a %s: %v", chamada.Endereco, err)
+	}
+	defer conexao.Close()
+	cliente := carrospb.NewCarrosClient(conexao)
+	ctx, cancelar := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelar()
+
+	switch chamada.Operacao {
+	case "list":
+		resposta, err := cliente.List(ctx, &carrospb.ListRequest{})
+		if err != nil {
+			return erroRPC(err)
+		}
+		if len(resposta.GetCarros()) == 0 {
+			fmt.Printf("\nNenhum carro cadastrado em %s.\n", chamada.Endereco)
+			return nil
+		}
+		carros := make([]cars.Carro, 0, len(resposta.GetCarros()))
+		for _, carro := range resposta.GetCarros() {
+			carros = append(carros, cars.CarroDoProto(carro))
+		}
+		fmt.Printf("\n--- Carros em %s (gRPC) ---\n", chamada.Endereco)
+		fmt.Print(c.tabelaCarros(carros).Renderizar(cars.TabelaAuto, larguraTerminal()))
+	case "get":
+		carro, err := cliente.Get(ctx, &carrospb.GetRequest{Id: chamada.Argumentos[0]})
+		if err != nil {
+			return erroRPC(err)
+		}
+		fmt.Println(c.linhaCarro(cars.CarroDoProto(carro)))
+	case "add":
+		carro, err := interpretarLinhaRapida(strings.Join(chamada.Argumentos, " "))
+		if err != nil {
+			return err
+		}
+		criado, err := cliente.Add(ctx, &carrospb.AddRequest{Carro: cars.CarroParaProto(carro)})
+		if err != nil {
+			return erroRPC(err)
+		}
+		fmt.Printf("✅ Carro '%s %s' cadastrado em %s com ID: %s\n", criado.GetMarca(), criado.GetModelo(), chamada.Endereco, criado.GetId())
+	case "update":
+		id, patch := chamada.Argumentos[0], chamada.Argumentos[1]
+		atual, err := cliente.Get(ctx, &carrospb.GetRequest{Id: id})
+		if err != nil {
+			return erroRPC(err)
+		}
+		// A versão lida vai junto (atualizado_em): se outro cliente gravar antes, o servidor recusa
+		editado, err := cars.EditarPorPatch(cars.CarroDoProto(atual), patch)
+		if err != nil {
+			return err
+		}
+		if _, err := cliente.Update(ctx, &carrospb.UpdateRequest{Carro: cars.CarroParaProto(editado), ConfirmarModelo: chamada.Confirmar}); err != nil {
+			return erroRPC(err)
+		}
+		fmt.Printf("✅ Carro com ID '%s' atualizado em %s.\n", id, chamada.Endereco)
+	case "delete":
+		id := chamada.Argumentos[0]
+		if _, err := cliente.Delete(ctx, &carrospb.DeleteRequest{Id: id, ConfirmarModelo: chamada.Confirmar}); err != nil {
+			return erroRPC(err)
+		}
+		fmt.Printf("✅ Carro com ID '%s' removido em %s.\n", id, chamada.Endereco)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// mostrarPreviaImportacao mostra o mapeamento das colunas e como as primeiras linhas seriam gravadas
+func (c *sessao) mostrarPreviaImportacao(colunas []cars.ColunaImportacao, itens []cars.LinhaImportada, amostra int, modo cars.ModoTabela) (validos int) {
+	mapa := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Coluna", Essencial: true},
+		{Titulo: "Tipo Detectado", Essencial: true},
+		{Titulo: "Exemplo"},
+		{Titulo: "Campo", Essencial: true},
+	}}
+	for _, col := range colunas {
+		campo := col.Campo
+		switch {
+		case campo == "":
+			campo = "(ignorada)"
+		case col.Inferido:
+			campo += " (pelo conteúdo)"
+		}
+		if esperado := cars.BuscarCampoImportacao(col.Campo); esperado != nil && esperado.Tipo != col.Tipo &&
+			!(esperado.Tipo == "decimal" && col.Tipo == "inteiro") && col.Tipo != "vazia" && esperado.Tipo != "texto" {
+			campo += fmt.Sprintf(" ⚠️  espera %s", esperado.Tipo)
+		}
+		mapa.Linhas = append(mapa.Linhas, []string{col.Cabecalho, col.Tipo, col.Exemplo, campo})
+	}
+	fmt.Println("\n--- Mapeamento das Colunas ---")
+	fmt.Print(mapa.Renderizar(modo, larguraTerminal()))
+
+	previa := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Linha", Direita: true, Essencial: true},
+		{Titulo: "Marca", Essencial: true},
+		{Titulo: "Modelo", Essencial: true},
+		{Titulo: "Ano", Direita: true},
+		{Titulo: "Cor"},
+		{Titulo: "Preço", Direita: true, Essencial: true},
+		{Titulo: "Custo", Direita: true},
+		{Titulo: "País"},
+		{Titulo: "Problemas", Essencial: true},
+	}}
+	exibicao := c.Exibicao()
+	mostrados := 0
+	for _, item := range itens {
+		if len(item.Problemas) == 0 {
+			validos++
+		}
+		// A amostra traz as primeiras linhas e, depois delas, as que têm problema ou aviso
+		if mostrados >= amostra && len(item.Problemas) == 0 && len(item.Avisos) == 0 {
+			continue
+		}
+		if mostrados >= amostra*3 {
+			continue
+		}
+		mostrados++
+		carro := item.Carro
+		problemas := "ok"
+		switch {
+		case len(item.Problemas) > 0:
+			problemas = strings.Join(item.Problemas, "; ")
+		case len(item.Avisos) > 0:
+			problemas = "ok, ⚠️  " + strings.Join(item.Avisos, "; ")
+		}
+		previa.Linhas = append(previa.Linhas, []string{
+			strconv.Itoa(item.Numero), carro.Marca, carro.Modelo, strconv.Itoa(carro.Ano), carro.Cor,
+			exibicao.FormatarPreco(carro.Preco), exibicao.FormatarPreco(carro.Custo), carro.PaisOrigem, problemas,
+		})
+	}
+	fmt.Println("\n--- Prévia (como os carros seriam gravados) ---")
+	fmt.Print(previa.Renderizar(modo, larguraTerminal()))
+	fmt.Printf("%d linha(s): %d pronta(s) para importar, %d com problema (ficam de fora).\n", len(itens), validos, len(itens)-validos)
+	return validos
+}
+
+// interpretarArgsImportacao lê `csv <arquivo> | --file=<arquivo>` com `[--delimiter=<c>]
+// [--map=<coluna>=<campo>]... [--dry-run] [--yes]`
+func interpretarArgsImportacao(args []string) (cars.OpcoesImportacao, error) {
+	var opcoes cars.OpcoesImportacao
+	if len(args) > 0 && args[0] == "csv" {
+		if len(args) < 2 || strings.HasPrefix(args[1], "--") {
+			return opcoes, fmt.Errorf("informe o arquivo: import csv <arquivo>")
+		}
+		opcoes.Arquivo, args = args[1], args[2:]
+	}
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch nome {
+		case "--file":
+			opcoes.Arquivo = valor
+		case "--delimiter":
+			d, err := interpretarDelimitador(valor)
+			if err != nil {
+				return opcoes, err
+			}
+			opcoes.Delimitador = d
+		case "--map":
+			opcoes.Mapeamento = append(opcoes.Mapeamento, valor)
+		case "--dry-run":
+			opcoes.Simular = true
+		case "--yes":
+			opcoes.Confirmar = true
+		default:
+			return opcoes, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	if opcoes.Arquivo == "" {
+		return opcoes, fmt.Errorf("informe o arquivo com 'import csv <arquivo>' ou --file=<arquivo>")
+	}
+	if opcoes.Simular && opcoes.Confirmar {
+		return opcoes, fmt.Errorf("--dry-run e --yes não podem ser usados juntos")
+	}
+	return opcoes, nil
+}
+
+// relatarProblemasImportacao lista todas as linhas com problema, sem a amostragem da prévia
+func relatarProblemasImportacao(itens []cars.LinhaImportada, modo cars.ModoTabela) {
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Linha", Direita: true, Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Problemas", Essencial: true},
+	}}
+	for _, item := range itens {
+		if len(item.Problemas) > 0 {
+			carro := strings.TrimSpace(item.Carro.Marca + " " + item.Carro.Modelo)
+			t.Linhas = append(t.Linhas, []string{strconv.Itoa(item.Numero), carro, strings.Join(item.Problemas, "; ")})
+		}
+	}
+	if len(t.Linhas) == 0 {
+		fmt.Println("✅ Nenhuma linha com problema.")
+		return
+	}
+	fmt.Printf("\n--- Problemas por Linha (%d) ---\n", len(t.Linhas))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
+
+// ImportarCarros lê uma planilha CSV de carros, mostra o mapeamento inferido e a prévia das linhas
+// e, após confirmação (ou direto com --yes), cadastra as linhas sem problema de uma vez. Com
+// --dry-run, relata os problemas de todas as linhas e para sem gravar.
+func (c *sessao) ImportarCarros(opcoes cars.OpcoesImportacao, modo cars.ModoTabela) error {
+	planilha, err := cars.LerPlanilhaImportacao(opcoes)
+	if err != nil {
+		return err
+	}
+
+	var itens []cars.LinhaImportada
+	for {
+		itens = c.ConferirImportacao(planilha)
+		validos := c.mostrarPreviaImportacao(planilha.Colunas, itens, 5, modo)
+		if opcoes.Simular {
+			relatarProblemasImportacao(itens, modo)
+			fmt.Printf("Simulação: nada foi importado (%d carro(s) seriam importados).\n", validos)
+			return nil
+		}
+		if opcoes.Confirmar {
+			break
+		}
+		if validos == 0 {
+			fmt.Println("Nenhuma linha pronta. Corrija a planilha ou remapeie as colunas com 'map <coluna>=<campo>'.")
+		}
+		resposta, _ := c.cli.Perguntar(fmt.Sprintf("Importar %d carro(s)? (s/N, ou 'map <coluna>=<campo>' para remapear): ", validos))
+		if pedido, ok := strings.CutPrefix(resposta, "map "); ok {
+			if err := planilha.Remapear(pedido); err != nil {
+				fmt.Printf("❌ Erro: %v\n", err)
+			}
+			continue
+		}
+		if r := strings.ToLower(resposta); r != "s" && r != "sim" {
+			return operacaoCancelada("Importação cancelada.")
+		}
+		break
+	}
+
+	importados, ajustes, err := c.Importar(itens)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	if importados == 0 {
+		fmt.Println("Nenhuma linha sem problemas para importar.")
+		return nil
+	}
+	c.mostrarAjustes(ajustes)
+	fmt.Printf("✅ %d carro(s) importado(s) de %s.\n", importados, planilha.Arquivo)
+	return err
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// Reindexar confere as invariantes do banco em memória e, com reparar, corrige o que for possível
+func (c *sessao) Reindexar(reparar bool) error {
+	v, err := c.VerificarIndices(reparar)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("\n--- Verificação dos Índices (%d carro(s), %d evento(s)) ---\n", v.Carros, v.Eventos)
+	for _, verificacao := range cars.VerificacoesIndices {
+		var detalhes []string
+		for _, p := range v.Problemas {
+			if p.Verificacao == verificacao {
+				detalhes = append(detalhes, p.Detalhe)
+			}
+		}
+		if len(detalhes) == 0 {
+			fmt.Printf("✅ %s\n", verificacao)
+			continue
+		}
+		fmt.Printf("❌ %s: %d problema(s)\n", verificacao, len(detalhes))
+		for _, d := range detalhes {
+			fmt.Printf("   - %s\n", d)
+		}
+	}
+	if len(v.Problemas) == 0 {
+		fmt.Println("Nenhuma inconsistência encontrada.")
+		return nil
+	}
+	if !reparar {
+		return fmt.Errorf("%d inconsistência(s) encontrada(s). Use 'reindex --repair' para refazer os índices a partir do log e descartar as referências órfãs", len(v.Problemas))
+	}
+
+	for _, f := range v.Reparos {
+		fmt.Printf("🔧 %s\n", f)
+	}
+	if len(v.Restantes) == 0 {
+		fmt.Println("✅ Índices reparados.")
+	}
+	if err != nil {
+		return err
+	}
+	if len(v.Restantes) > 0 {
+		return fmt.Errorf("%d problema(s) continuam após o reparo; veja 'reindex'", len(v.Restantes))
+	}
+	return nil
+}
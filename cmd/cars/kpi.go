@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// RelatorioKPI mostra giro de estoque, dias médios em estoque e taxa de escoamento mês a mês,
+// no formato usado na reunião mensal de gestão
+func (c *sessao) RelatorioKPI(meses int, modo cars.ModoTabela) {
+	defer c.Medir("kpi")()
+	visao := c.Snapshot()
+
+	if len(visao.Carros()) == 0 && len(visao.Vendidos()) == 0 {
+		fmt.Println("\nNenhum carro cadastrado ou vendido ainda.")
+		return
+	}
+
+	indicadores := visao.Indicadores(meses, c.Agora())
+	f := visao.Exibicao().FormatarPreco
+
+	fmt.Printf("\n--- Indicadores de Estoque e Vendas (últimos %d meses) ---\n", meses)
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Mês", Essencial: true},
+		{Titulo: "Estoque Inicial", Direita: true},
+		{Titulo: "Entradas", Direita: true},
+		{Titulo: "Vendas", Direita: true, Essencial: true},
+		{Titulo: "Estoque Final", Direita: true},
+		{Titulo: "Giro", Direita: true, Essencial: true},
+		{Titulo: "Escoamento", Direita: true, Essencial: true},
+		{Titulo: "Dias Médios", Direita: true},
+		{Titulo: "Desconto", Direita: true},
+		{Titulo: "Margem", Direita: true},
+		{Titulo: "Faturamento", Direita: true},
+	}}
+	margem := func(m cars.IndicadoresMes) string {
+		if p, ok := m.MargemBruta(); ok {
+			return fmt.Sprintf("%.1f%%", p)
+		}
+		return "—"
+	}
+
+	var total cars.IndicadoresMes
+	somaDias, somaDesconto := 0.0, 0.0
+	for _, m := range indicadores {
+		t.Linhas = append(t.Linhas, []string{
+			fmt.Sprintf("%s/%d", nomesMeses[m.Mes.Month()-1][:3], m.Mes.Year()),
+			strconv.Itoa(m.EstoqueInicio), strconv.Itoa(m.Entradas), strconv.Itoa(m.Vendas), strconv.Itoa(m.EstoqueFim),
+			fmt.Sprintf("%.2f", m.Giro()), fmt.Sprintf("%.1f%%", m.TaxaEscoamento()),
+			fmt.Sprintf("%.1f", m.MediaDias), fmt.Sprintf("%.1f%%", m.MediaDesconto), margem(m), f(m.Faturamento),
+		})
+		total.Entradas += m.Entradas
+		total.Vendas += m.Vendas
+		total.Faturamento += m.Faturamento
+		total.ReceitaCusto += m.ReceitaCusto
+		total.Custo += m.Custo
+		somaDias += m.MediaDias * float64(m.Vendas)
+		somaDesconto += m.MediaDesconto * float64(m.Vendas)
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+
+	// Giro do período: vendas sobre o estoque médio dos meses
+	total.EstoqueInicio = indicadores[0].EstoqueInicio
+	total.EstoqueFim = indicadores[len(indicadores)-1].EstoqueFim
+	fmt.Printf("\nPeríodo: %d venda(s), %d entrada(s), faturamento de %s\n", total.Vendas, total.Entradas, f(total.Faturamento))
+	fmt.Printf("Giro no período: %.2f | Escoamento: %.1f%%", total.Giro(), total.TaxaEscoamento())
+	if total.Vendas > 0 {
+		fmt.Printf(" | Dias médios em estoque: %.1f | Desconto médio: %.1f%%",
+			somaDias/float64(total.Vendas), somaDesconto/float64(total.Vendas))
+	}
+	fmt.Printf(" | Margem bruta: %s\n", margem(total))
+	fmt.Println("A margem considera só as vendas com custo de importação informado.")
+}
+
+// interpretarArgsKPI lê `[--months=N] [--wide|--narrow]`
+func interpretarArgsKPI(args []string) (int, cars.ModoTabela, error) {
+	modo, args := interpretarModoTabela(args)
+	meses := 12
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--months=") {
+			return 0, modo, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		m, err := strconv.Atoi(strings.TrimPrefix(arg, "--months="))
+		if err != nil || m <= 0 || m > 60 {
+			return 0, modo, fmt.Errorf("número de meses inválido: %s (de 1 a 60)", arg)
+		}
+		meses = m
+	}
+	return meses, modo, nil
+}
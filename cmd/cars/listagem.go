@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// tamanhoPaginaPadrao é a quantidade de carros por página quando list --page vem sem --page-size
+const tamanhoPaginaPadrao = 20
+
+// paginaCarros recorta a página pedida (a partir de 1); devolve também o total de páginas
+func paginaCarros(carros []cars.Carro, pagina, porPagina int) ([]cars.Carro, int, error) {
+	paginas := max((len(carros)+porPagina-1)/porPagina, 1)
+	if pagina > paginas {
+		return nil, paginas, fmt.Errorf("a página %d não existe (são %d, com %d carro(s) por página)", pagina, paginas, porPagina)
+	}
+	inicio := (pagina - 1) * porPagina
+	return carros[inicio:min(inicio+porPagina, len(carros))], paginas, nil
+}
+
+// juntarValoresOpcoes aceita `--opcao valor` além de `--opcao=valor` para as opções informadas
+func juntarValoresOpcoes(args []string, nomes ...string) []string {
+	var juntos []string
+	for i := 0; i < len(args); i++ {
+		if slices.Contains(nomes, args[i]) && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			juntos = append(juntos, args[i]+"="+args[i+1])
+			i++
+			continue
+		}
+		juntos = append(juntos, args[i])
+	}
+	return juntos
+}
+
+// camposAgrupamento são os campos aceitos por list --group-by
+var camposAgrupamento = map[string]func(cars.Carro) string{
+	"marca":  func(c cars.Carro) string { return c.Marca },
+	"modelo": func(c cars.Carro) string { return c.Marca + " " + c.Modelo },
+	"ano":    func(c cars.Carro) string { return strconv.Itoa(c.Ano) },
+	"cor":    func(c cars.Carro) string { return c.Cor },
+	"pais":   func(c cars.Carro) string { return c.PaisOrigem },
+	"status": cars.StatusCarro,
+}
+
+// nomesCamposAgrupamento devolve os campos de agrupamento em ordem alfabética, para mensagens
+func nomesCamposAgrupamento() []string {
+	nomes := make([]string, 0, len(camposAgrupamento))
+	for nome := range camposAgrupamento {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
+
+// interpretarOpcoesListagem lê as flags do comando list
+func interpretarOpcoesListagem(args []string) (cars.OpcoesListagem, error) {
+	var opcoes cars.OpcoesListagem
+	opcoes.Modo, args = interpretarModoTabela(args)
+	for _, arg := range juntarValoresOpcoes(args, "--sort", "--page", "--page-size") {
+		switch arg {
+		case "--aging":
+			opcoes.Dias = true
+		case "--aging-buckets":
+			opcoes.FaixasIdade = true
+			opcoes.Dias = true
+		case "--desc":
+			opcoes.Decrescente = true
+		default:
+			if campo, ok := strings.CutPrefix(arg, "--sort="); ok {
+				if !slices.Contains(cars.NomesCamposOrdenacao(), strings.ToLower(campo)) {
+					return opcoes, fmt.Errorf("campo de ordenação '%s' inválido (use %s)", campo, strings.Join(cars.NomesCamposOrdenacao(), ", "))
+				}
+				opcoes.Ordenar = strings.ToLower(campo)
+				continue
+			}
+			if valor, ok := strings.CutPrefix(arg, "--page="); ok {
+				n, err := strconv.Atoi(valor)
+				if err != nil || n < 1 {
+					return opcoes, fmt.Errorf("página inválida: %s (a partir de 1)", valor)
+				}
+				opcoes.Pagina = n
+				continue
+			}
+			if valor, ok := strings.CutPrefix(arg, "--page-size="); ok {
+				n, err := strconv.Atoi(valor)
+				if err != nil || n < 1 {
+					return opcoes, fmt.Errorf("tamanho de página inválido: %s", valor)
+				}
+				opcoes.PorPagina = n
+				continue
+			}
+			if valor, ok := strings.CutPrefix(arg, "--as-of="); ok {
+				data, err := cars.InterpretarData(valor)
+				if err != nil {
+					return opcoes, err
+				}
+				opcoes.Em = data
+				continue
+			}
+			if nome, ok := strings.CutPrefix(arg, "--opcional="); ok {
+				opcional, conhecido := cars.OpcionalDoVocabulario(nome)
+				if !conhecido {
+					return opcoes, fmt.Errorf("opcional '%s' fora do vocabulário (use %s)", nome, strings.Join(cars.VocabularioOpcionais, ", "))
+				}
+				opcoes.Opcionais = append(opcoes.Opcionais, opcional)
+				continue
+			}
+			if campo, ok := strings.CutPrefix(arg, "--group-by="); ok {
+				if _, existe := camposAgrupamento[strings.ToLower(campo)]; !existe {
+					return opcoes, fmt.Errorf("campo de agrupamento '%s' inválido (use %s)", campo, strings.Join(nomesCamposAgrupamento(), ", "))
+				}
+				opcoes.AgruparPor = strings.ToLower(campo)
+				continue
+			}
+			return opcoes, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	if opcoes.AgruparPor != "" && opcoes.FaixasIdade {
+		return opcoes, fmt.Errorf("--group-by e --aging-buckets não podem ser usados juntos")
+	}
+	if opcoes.Decrescente && opcoes.Ordenar == "" {
+		return opcoes, fmt.Errorf("--desc precisa de --sort=<campo>")
+	}
+	if opcoes.Pagina > 0 || opcoes.PorPagina > 0 {
+		if opcoes.AgruparPor != "" || opcoes.FaixasIdade {
+			return opcoes, fmt.Errorf("--page não pode ser usado com --group-by nem --aging-buckets")
+		}
+		opcoes.Pagina = max(opcoes.Pagina, 1)
+		if opcoes.PorPagina == 0 {
+			opcoes.PorPagina = tamanhoPaginaPadrao
+		}
+	}
+	return opcoes, nil
+}
+
+// faixaIdade é um intervalo de dias em estoque usado no relatório de envelhecimento
+type faixaIdade struct {
+	Rotulo string
+	Max    int // Limite superior inclusivo (-1 = sem limite)
+}
+
+// faixasIdade são as faixas de envelhecimento do estoque
+var faixasIdade = []faixaIdade{
+	{"0–30 dias", 30},
+	{"31–60 dias", 60},
+	{"61–90 dias", 90},
+	{"Mais de 90 dias", -1},
+}
+
+// indiceFaixaIdade devolve a posição da faixa em que os dias se encaixam
+func indiceFaixaIdade(dias int) int {
+	for i, f := range faixasIdade {
+		if f.Max < 0 || dias <= f.Max {
+			return i
+		}
+	}
+	return len(faixasIdade) - 1
+}
+
+// tabelaListagem monta a tabela de carros com as colunas opcionais pedidas
+func (c *sessao) tabelaListagem(carros []cars.Carro, opcoes cars.OpcoesListagem) cars.Tabela {
+	t := c.tabelaCarros(carros)
+	if opcoes.Dias {
+		agora := c.Agora()
+		t.Colunas = append(t.Colunas, cars.ColunaTabela{Titulo: "Dias em Estoque", Direita: true, Essencial: true})
+		for i, carro := range carros {
+			t.Linhas[i] = append(t.Linhas[i], strconv.Itoa(cars.DiasEmEstoque(carro, agora)))
+		}
+	}
+	return t
+}
+
+// listarPorFaixaIdade mostra os carros agrupados por faixa de dias em estoque, com quantidade
+// e valor total por faixa e o total geral
+func (c *sessao) listarPorFaixaIdade(carros []cars.Carro, opcoes cars.OpcoesListagem) {
+	agora := c.Agora()
+	grupos := make([][]cars.Carro, len(faixasIdade))
+	for _, carro := range carros {
+		i := indiceFaixaIdade(cars.DiasEmEstoque(carro, agora))
+		grupos[i] = append(grupos[i], carro)
+	}
+
+	var totalValor cars.Dinheiro
+	for i, grupo := range grupos {
+		var valor cars.Dinheiro
+		for _, carro := range grupo {
+			valor += carro.Preco
+		}
+		totalValor += valor
+
+		fmt.Printf("\n== %s: %d carro(s) | Valor: %s ==\n", faixasIdade[i].Rotulo, len(grupo), c.Exibicao().FormatarPreco(valor))
+		if len(grupo) > 0 {
+			fmt.Print(c.tabelaListagem(grupo, opcoes).Renderizar(opcoes.Modo, larguraTerminal()))
+		}
+	}
+	fmt.Printf("\nTotal geral: %d carro(s) | Valor: %s\n", len(carros), c.Exibicao().FormatarPreco(totalValor))
+}
+
+// listarAgrupado mostra os carros agrupados pelo campo escolhido, com quantidade e valor total
+// por grupo e o total geral. Grupos seguem a ordem pt-BR; valores que diferem só na caixa ou em
+// espaços ("toyota" e "Toyota") caem no mesmo grupo.
+func (c *sessao) listarAgrupado(carros []cars.Carro, opcoes cars.OpcoesListagem) {
+	type grupo struct {
+		rotulo string
+		carros []cars.Carro
+		valor  cars.Dinheiro
+	}
+	chave := camposAgrupamento[opcoes.AgruparPor]
+	porChave := make(map[string]*grupo)
+	var grupos []*grupo
+	var totalValor cars.Dinheiro
+	for _, carro := range carros {
+		rotulo := strings.TrimSpace(chave(carro))
+		if rotulo == "" {
+			rotulo = "(não informado)"
+		}
+		g, existe := porChave[strings.ToLower(rotulo)]
+		if !existe {
+			g = &grupo{rotulo: rotulo}
+			porChave[strings.ToLower(rotulo)] = g
+			grupos = append(grupos, g)
+		}
+		g.carros = append(g.carros, carro)
+		g.valor += carro.Preco
+		totalValor += carro.Preco
+	}
+	cars.OrdenarPorTexto(grupos, func(g *grupo) string { return g.rotulo })
+
+	for _, g := range grupos {
+		fmt.Printf("\n== %s: %d carro(s) | Valor: %s ==\n", g.rotulo, len(g.carros), c.Exibicao().FormatarPreco(g.valor))
+		fmt.Print(c.tabelaListagem(g.carros, opcoes).Renderizar(opcoes.Modo, larguraTerminal()))
+	}
+	fmt.Printf("\nTotal geral: %d carro(s) em %d grupo(s) | Valor: %s\n", len(carros), len(grupos), c.Exibicao().FormatarPreco(totalValor))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// ListarLixeira mostra os carros removidos, que podem voltar ao estoque com `trash restore`
+func (c *sessao) ListarLixeira(modo cars.ModoTabela) {
+	visao := c.Snapshot()
+	itens := visao.Lixeira()
+	if len(itens) == 0 {
+		fmt.Println("A lixeira está vazia.")
+		return
+	}
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro", Essencial: true},
+		{Titulo: "Preço", Direita: true},
+		{Titulo: "Removido em", Essencial: true},
+		{Titulo: "Dias", Direita: true},
+	}}
+	agora := c.Agora()
+	for _, item := range itens {
+		carro, preco := "(sem registro no log)", "-"
+		if item.Conhecido {
+			carro = fmt.Sprintf("%s %s %d", item.Carro.Marca, item.Carro.Modelo, item.Carro.Ano)
+			preco = visao.Exibicao().FormatarPreco(item.Carro.Preco)
+		}
+		removidoEm, dias := item.RemovidoEm, "-"
+		if em, err := time.Parse(time.RFC3339Nano, item.RemovidoEm); err == nil {
+			removidoEm = em.Local().Format("02/01/2006 15:04")
+			dias = strconv.Itoa(int(agora.Sub(em).Hours() / 24))
+		}
+		t.Linhas = append(t.Linhas, []string{item.ID, carro, preco, removidoEm, dias})
+	}
+	fmt.Printf("\n--- Lixeira (%d carro(s) removido(s)) ---\n", len(itens))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	fmt.Println("Use 'trash restore <ID>' para devolver um carro ao estoque ou 'trash purge' para apagá-los de vez.")
+}
+
+// RestaurarDaLixeira devolve ao estoque um carro removido
+func (c *sessao) RestaurarDaLixeira(id string) error {
+	carro, err := c.Restaurar(id)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Carro '%s %s' (ID %s) de volta ao estoque.\n", carro.Marca, carro.Modelo, id)
+	return err
+}
+
+// EsvaziarLixeira apaga de vez os carros da lixeira: um ID, ou todos os removidos há pelo menos
+// `dias` dias (0 = todos). Pede que "purge" seja digitado para confirmar.
+func (c *sessao) EsvaziarLixeira(id string, dias int) error {
+	ids, err := c.Snapshot().SelecionarLixeira(id, dias, c.Agora())
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Println("Nenhum carro na lixeira para apagar.")
+		return nil
+	}
+
+	fmt.Printf("\n⚠️  %d carro(s) serão apagados da lixeira sem volta (o log de eventos registra a purga):\n", len(ids))
+	fmt.Println("   " + strings.Join(ids, ", "))
+	fmt.Println("   Exportações incrementais não informarão estas remoções aos destinos.")
+	if confirmacao, _ := c.cli.Perguntar("Digite 'purge' para confirmar: "); confirmacao != "purge" {
+		fmt.Println("Lixeira mantida.")
+		return nil
+	}
+
+	apagados, err := c.ApagarDaLixeira(ids)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ %d carro(s) apagado(s) definitivamente da lixeira.\n", apagados)
+	return err
+}
+
+// interpretarArgsLixeiraPurga lê `[<ID>] [--older-than=<dias>]`
+func interpretarArgsLixeiraPurga(args []string) (id string, dias int, err error) {
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--older-than="):
+			dias, err = strconv.Atoi(strings.TrimPrefix(arg, "--older-than="))
+			if err != nil || dias < 1 {
+				return "", 0, fmt.Errorf("--older-than deve ser um número de dias positivo")
+			}
+		case strings.HasPrefix(arg, "--"):
+			return "", 0, fmt.Errorf("opção desconhecida: %s", arg)
+		case id != "":
+			return "", 0, fmt.Errorf("informe um único ID")
+		default:
+			id = arg
+		}
+	}
+	if id != "" && dias > 0 {
+		return "", 0, fmt.Errorf("use um ID ou --older-than, não os dois")
+	}
+	return id, dias, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// CriarLote cadastra um lote vazio e informa o ID dele
+func (c *sessao) CriarLote(descricao string) error {
+	lote, err := c.NovoLote(descricao)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Lote '%s' criado com ID: %s\n", descricao, lote.ID)
+	return err
+}
+
+// IncluirNoLote acrescenta carros ao lote e informa os incluídos e os recusados
+func (c *sessao) IncluirNoLote(loteID string, carroIDs []string) error {
+	lote, recusas, err := c.AdicionarAoLote(loteID, carroIDs)
+	for _, r := range recusas {
+		if r.Lote == "" {
+			fmt.Printf("❌ Carro com ID '%s' não encontrado no banco em memória.\n", r.CarroID)
+		} else {
+			fmt.Printf("❌ Carro '%s' já pertence ao lote %s.\n", r.CarroID, r.Lote)
+		}
+	}
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ %d carro(s) incluído(s) no lote %s (%d no total).\n", len(carroIDs)-len(recusas), lote.ID, len(lote.CarroIDs))
+	if err != nil {
+		return err
+	}
+	if len(recusas) > 0 {
+		return fmt.Errorf("%d carro(s) não incluído(s) no lote", len(recusas))
+	}
+	return nil
+}
+
+// LancarCustoLote registra um custo compartilhado no lote e informa o novo total
+func (c *sessao) LancarCustoLote(loteID string, custo cars.CustoLote) error {
+	lote, err := c.RegistrarCustoLote(loteID, custo)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	exibicao := c.Exibicao()
+	fmt.Printf("✅ Custo '%s' de %s lançado no lote %s (total compartilhado: %s).\n",
+		custo.Descricao, exibicao.FormatarPreco(custo.Valor), lote.ID, exibicao.FormatarPreco(lote.TotalCustos()))
+	return err
+}
+
+// ListarLotes mostra os lotes com quantidade de carros, vendidos e custos compartilhados
+func (c *sessao) ListarLotes(modo cars.ModoTabela) {
+	visao := c.Snapshot()
+	lotes := visao.Lotes()
+
+	if len(lotes) == 0 {
+		fmt.Println("\nNenhum lote cadastrado ainda.")
+		return
+	}
+
+	fmt.Println("\n--- Lotes ---")
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Descrição", Essencial: true},
+		{Titulo: "Data"},
+		{Titulo: "Carros", Direita: true, Essencial: true},
+		{Titulo: "Vendidos", Direita: true},
+		{Titulo: "Custos Compartilhados", Direita: true, Essencial: true},
+	}}
+	for _, l := range lotes {
+		_, itens, _ := visao.Lote(l.ID)
+		vendidos := 0
+		for _, item := range itens {
+			if item.Venda != nil {
+				vendidos++
+			}
+		}
+		t.Linhas = append(t.Linhas, []string{l.ID, l.Descricao, l.Data, strconv.Itoa(len(l.CarroIDs)),
+			strconv.Itoa(vendidos), visao.Exibicao().FormatarPreco(l.TotalCustos())})
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
+
+// RelatorioLote mostra a rentabilidade de um lote: custo de cada carro mais o rateio dos custos
+// compartilhados, contra o preço final (vendidos) ou o preço pedido (ainda em estoque)
+func (c *sessao) RelatorioLote(loteID string, modo cars.ModoTabela) error {
+	visao := c.Snapshot()
+
+	lote, itens, err := visao.Lote(loteID)
+	if err != nil {
+		return err
+	}
+
+	f := visao.Exibicao().FormatarPreco
+	fmt.Printf("\n--- Lote %s: %s (%s) ---\n", lote.ID, lote.Descricao, lote.Data)
+	for _, custo := range lote.Custos {
+		fmt.Printf("  %-30s %s\n", custo.Descricao, f(custo.Valor))
+	}
+	fmt.Printf("  %-30s %s\n\n", "Total compartilhado", f(lote.TotalCustos()))
+	if len(lote.CarroIDs) == 0 {
+		fmt.Println("Nenhum carro no lote.")
+		return nil
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro", Essencial: true},
+		{Titulo: "Situação"},
+		{Titulo: "Custo", Direita: true},
+		{Titulo: "Rateio", Direita: true},
+		{Titulo: "Custo Total", Direita: true},
+		{Titulo: "Receita", Direita: true},
+		{Titulo: "Margem", Direita: true, Essencial: true},
+	}}
+	var custoVendidos, receitaVendidos, custoEstoque, receitaEstoque cars.Dinheiro
+	for _, item := range itens {
+		custoTotal := item.Carro.Custo + item.Rateio
+		situacao := "em estoque"
+		switch {
+		case !item.Achado:
+			situacao = "removido"
+		case item.Venda != nil:
+			situacao = "vendido em " + item.Venda.DataVenda
+			custoVendidos += custoTotal
+			receitaVendidos += item.Receita
+		default:
+			custoEstoque += custoTotal
+			receitaEstoque += item.Receita
+		}
+		t.Linhas = append(t.Linhas, []string{item.ID, strings.TrimSpace(item.Carro.Marca + " " + item.Carro.Modelo), situacao,
+			f(item.Carro.Custo), f(item.Rateio), f(custoTotal), f(item.Receita), f(item.Receita - custoTotal)})
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+
+	fmt.Printf("\nRealizado (vendidos): receita %s, custo %s, margem %s\n",
+		f(receitaVendidos), f(custoVendidos), f(receitaVendidos-custoVendidos))
+	fmt.Printf("Projetado (em estoque, pelo preço pedido): receita %s, custo %s, margem %s\n",
+		f(receitaEstoque), f(custoEstoque), f(receitaEstoque-custoEstoque))
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// MostrarLucratividade mostra como a margem do carro evoluiu desde a entrada: cada mudança de preço
+// ou custo e o custo de pátio acumulado dia a dia, até a venda ou até hoje. custoDiario < 0 usa o
+// valor da configuração.
+func (c *sessao) MostrarLucratividade(id string, custoDiario float64, modo cars.ModoTabela) error {
+	if custoDiario < 0 {
+		custoDiario = c.Config().Lucratividade.CustoDiario
+	}
+	visao := c.Snapshot()
+	exibicao := visao.Exibicao()
+
+	diario := cars.Reais(custoDiario)
+	marcos, carro, err := visao.Lucratividade(id, diario, c.Agora())
+	if err != nil {
+		return err
+	}
+
+	percentual := func(m cars.MarcoLucratividade) string {
+		if m.Receita <= 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", m.Margem().EmReais()/m.Receita.EmReais()*100)
+	}
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Data", Essencial: true},
+		{Titulo: "Dias", Direita: true, Essencial: true},
+		{Titulo: "Evento"},
+		{Titulo: "Preço", Direita: true},
+		{Titulo: "Custo Acumulado", Direita: true},
+		{Titulo: "Margem", Direita: true, Essencial: true},
+		{Titulo: "Margem %", Direita: true, Essencial: true},
+	}}
+	for _, m := range marcos {
+		t.Linhas = append(t.Linhas, []string{
+			m.Data, strconv.Itoa(m.Dias), m.Evento,
+			exibicao.FormatarPreco(m.Receita), exibicao.FormatarPreco(m.Custo),
+			exibicao.FormatarPreco(m.Margem()), percentual(m),
+		})
+	}
+
+	fmt.Printf("\n--- Lucratividade de '%s %s' (%s) ---\n", carro.Marca, carro.Modelo, id)
+	fmt.Printf("Pátio: %s por dia\n", exibicao.FormatarPreco(diario))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+
+	inicio, fim := marcos[0], marcos[len(marcos)-1]
+	vendido := fim.Evento == "Venda"
+	rotuloFim := "Hoje"
+	if vendido {
+		rotuloFim = "Na venda"
+	}
+	fmt.Printf("\nMargem na entrada: %s (%s) | %s: %s (%s) | Erosão: %s em %d dia(s)\n",
+		exibicao.FormatarPreco(inicio.Margem()), percentual(inicio), rotuloFim,
+		exibicao.FormatarPreco(fim.Margem()), percentual(fim),
+		exibicao.FormatarPreco(inicio.Margem()-fim.Margem()), fim.Dias)
+	if carro.Custo <= 0 {
+		fmt.Println("⚠️  Aviso: custo de importação não informado; a margem considera só o rateio do lote e o pátio.")
+	}
+	if !vendido && diario > 0 {
+		if fim.Margem() <= 0 {
+			fmt.Println("⚠️  Aviso: o custo acumulado já passou do preço pedido; cada dia a mais no pátio aumenta o prejuízo.")
+		} else {
+			fmt.Printf("No preço atual, o pátio consome o restante da margem em %d dia(s).\n", int(fim.Margem()/diario))
+		}
+	}
+	return nil
+}
+
+// interpretarArgsLucratividade lê `<ID> [--daily-cost=<valor>]` e o modo da tabela; sem a opção,
+// o custo diário vem da configuração (devolvido como -1)
+func interpretarArgsLucratividade(args []string) (string, float64, cars.ModoTabela, error) {
+	modo, args := interpretarModoTabela(args)
+	id, custoDiario := "", -1.0
+	for _, arg := range args {
+		if valor, ok := strings.CutPrefix(arg, "--daily-cost="); ok {
+			v, err := cars.InterpretarValorDigitado(valor)
+			if err != nil || v < 0 {
+				return "", 0, modo, fmt.Errorf("custo diário inválido: %s", valor)
+			}
+			custoDiario = v.EmReais()
+			continue
+		}
+		if strings.HasPrefix(arg, "--") || id != "" {
+			return "", 0, modo, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		id = arg
+	}
+	if id == "" {
+		return "", 0, modo, fmt.Errorf("informe o ID do carro")
+	}
+	return id, custoDiario, modo, nil
+}
@@ -0,0 +1,698 @@
+// O comando cars é o prompt interativo do cadastro de carros importados; também roda um comando
+// só, sem o prompt, para scripts (ex: cars list). Toda a lógica fica no pacote cars.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+	"golang.org/x/term"
+)
+
+// AdicionarCarro cadastra um carro perguntando campo a campo
+func (c *sessao) AdicionarCarro() error {
+	fmt.Println("\n--- Cadastro de Novo Carro Importado ---")
+	readInput := c.cli.Perguntar
+
+	marca, err := readInput("Marca: ")
+	if err != nil {
+		return err
+	}
+	if marca == "" {
+		return errors.New("marca não pode ser vazia")
+	}
+
+	modelo, err := readInput("Modelo: ")
+	if err != nil {
+		return err
+	}
+	if modelo == "" {
+		return errors.New("modelo não pode ser vazio")
+	}
+
+	anoStr, err := readInput("Ano: ")
+	if err != nil {
+		return err
+	}
+	ano, err := strconv.Atoi(anoStr)
+	if err != nil || ano <= 0 || ano > time.Now().Year()+1 {
+		return fmt.Errorf("ano deve ser um número positivo válido (até %d)", time.Now().Year()+1)
+	}
+	marcas := c.Config().Marcas
+	if aviso := marcas.AvisoAno(marca, modelo, ano); aviso != "" {
+		fmt.Printf("⚠️  Aviso: %s.\n", aviso)
+	}
+
+	cor, _ := readInput("Cor: ") // Cor pode ser vazia
+
+	precoStr, err := readInput("Preço (R$): ")
+	if err != nil {
+		return err
+	}
+	preco, err := cars.InterpretarValorDigitado(precoStr)
+	if err != nil || preco <= 0 {
+		return errors.New("preço deve ser um número positivo válido")
+	}
+
+	var custo cars.Dinheiro
+	if custoStr, _ := readInput("Custo de importação (R$, Enter se não souber): "); custoStr != "" {
+		custo, err = cars.InterpretarValorDigitado(custoStr)
+		if err != nil || custo < 0 {
+			return errors.New("custo deve ser um número válido, não negativo")
+		}
+	}
+
+	perguntaPais := "País de Origem: "
+	padrao := marcas.PaisDaMarca(marca)
+	if padrao != "" {
+		perguntaPais = fmt.Sprintf("País de Origem [%s]: ", padrao)
+	}
+	paisOrigem, err := readInput(perguntaPais)
+	if err != nil {
+		return err
+	}
+	if paisOrigem == "" {
+		paisOrigem = padrao
+	}
+	if paisOrigem == "" {
+		return errors.New("país de origem não pode ser vazio")
+	}
+	if aviso := marcas.AvisoPais(marca, paisOrigem); aviso != "" {
+		fmt.Printf("⚠️  Aviso: %s.\n", aviso)
+	}
+
+	chassi, _ := readInput("Chassi (Enter se não souber): ")
+
+	var opcionais []string
+	if texto, _ := readInput("Opcionais (separados por vírgula, Enter para nenhum): "); texto != "" {
+		if opcionais, err = cars.InterpretarOpcionais(texto); err != nil {
+			return err
+		}
+	}
+
+	status := cars.StatusEmEstoque
+	if noPatio, _ := readInput("O carro já chegou ao pátio? (S/n): "); strings.EqualFold(noPatio, "n") || strings.EqualFold(noPatio, "não") {
+		status = cars.StatusEmTransito
+	}
+
+	carro := cars.Carro{
+		Marca:      marca,
+		Modelo:     modelo,
+		Ano:        ano,
+		Cor:        cor,
+		Preco:      preco,
+		Custo:      custo,
+		PaisOrigem: paisOrigem,
+		Chassi:     cars.NormalizarChassi(chassi),
+		Status:     status,
+		Opcionais:  opcionais,
+	}
+	excecao, err := c.verificarConformidade(carro)
+	if err != nil {
+		return err
+	}
+	return c.inserirCarro(carro, excecao)
+}
+
+// inserirCarro cadastra o carro já conferido e mostra o ID recebido. Se o cadastro violou regras
+// de conformidade, a exceção justificada é registrada junto.
+func (c *sessao) inserirCarro(novoCarro cars.Carro, excecao *cars.ExcecaoConformidade) error {
+	novoCarro, ajustes, err := c.Adicionar(novoCarro, excecao)
+	if !alteracaoFeita(err) {
+		return fmt.Errorf("%v. Carro não cadastrado", err)
+	}
+	c.mostrarAjustes(ajustes)
+	fmt.Printf("✅ Carro '%s %s' cadastrado no banco em memória com ID: %s\n", novoCarro.Marca, novoCarro.Modelo, novoCarro.ID)
+	if excecao != nil {
+		fmt.Println("📝 Exceção de conformidade registrada com a justificativa informada.")
+	}
+	return err
+}
+
+// AdicionarRapido cadastra um carro a partir de uma única linha livre, no formato
+// "Marca Modelo Ano Cor Preço País" (ex: Toyota Corolla 2021 Prata 145000 Japão),
+// mostrando uma prévia e pedindo confirmação antes de gravar
+func (c *sessao) AdicionarRapido(linha string) error {
+	carro, err := interpretarLinhaRapida(linha)
+	if err != nil {
+		return fmt.Errorf("%v\nFormato: quickadd \"Marca Modelo Ano Cor Preço País\" (ex: quickadd \"Toyota Corolla 2021 Prata 145000 Japão\")", err)
+	}
+
+	fmt.Println("\n--- Prévia do Cadastro Rápido ---")
+	fmt.Printf("Marca: %s | Modelo: %s | Ano: %d | Cor: %s | Preço: %s | Origem: %s\n",
+		carro.Marca, carro.Modelo, carro.Ano, carro.Cor, c.Exibicao().FormatarPreco(carro.Preco), carro.PaisOrigem)
+	marcas := c.Config().Marcas
+	for _, aviso := range []string{marcas.AvisoAno(carro.Marca, carro.Modelo, carro.Ano), marcas.AvisoPais(carro.Marca, carro.PaisOrigem)} {
+		if aviso != "" {
+			fmt.Printf("⚠️  Aviso: %s.\n", aviso)
+		}
+	}
+	excecao, err := c.verificarConformidade(carro)
+	if err != nil {
+		return err
+	}
+	resposta, err := c.cli.Perguntar("Confirmar cadastro? (s/N): ")
+	if err != nil {
+		return fmt.Errorf("erro no input: %v", err)
+	}
+	if resposta = strings.ToLower(resposta); resposta != "s" && resposta != "sim" {
+		return operacaoCancelada("Cadastro rápido cancelado.")
+	}
+
+	return c.inserirCarro(carro, excecao)
+}
+
+// interpretarLinhaRapida extrai os campos de um carro de uma linha livre usando heurísticas:
+// o ano é o último número de 4 dígitos plausível seguido de um preço (modelos como "Peugeot 2008"
+// também têm um), o preço é o último número após o ano, antes do ano ficam marca e modelo, entre
+// ano e preço a cor e depois do preço o país
+func interpretarLinhaRapida(linha string) (cars.Carro, error) {
+	linha = strings.Trim(strings.TrimSpace(linha), "\"'")
+	tokens := strings.Fields(linha)
+	if len(tokens) < 5 {
+		return cars.Carro{}, fmt.Errorf("linha incompleta, informe ao menos marca, modelo, ano, preço e país")
+	}
+
+	// Ano: último número de 4 dígitos dentro do intervalo válido (após a marca) que ainda tenha
+	// um preço depois dele
+	idxAno := -1
+	var ano int
+	for i := len(tokens) - 2; i >= 1 && idxAno < 0; i-- {
+		if len(tokens[i]) != 4 {
+			continue
+		}
+		v, err := strconv.Atoi(tokens[i])
+		if err != nil || v < 1900 || v > time.Now().Year()+1 {
+			continue
+		}
+		for _, t := range tokens[i+1:] {
+			if _, ok := interpretarPrecoRapido(t); ok {
+				idxAno, ano = i, v
+				break
+			}
+		}
+	}
+	if idxAno < 0 {
+		return cars.Carro{}, fmt.Errorf("ano não encontrado (esperado entre 1900 e %d)", time.Now().Year()+1)
+	}
+	if idxAno < 2 {
+		return cars.Carro{}, fmt.Errorf("marca e modelo devem vir antes do ano")
+	}
+
+	// Preço: último token numérico depois do ano
+	idxPreco := -1
+	var preco cars.Dinheiro
+	for i := len(tokens) - 1; i > idxAno; i-- {
+		if v, ok := interpretarPrecoRapido(tokens[i]); ok {
+			idxPreco, preco = i, v
+			break
+		}
+	}
+	if idxPreco < 0 || preco <= 0 {
+		return cars.Carro{}, fmt.Errorf("preço não encontrado após o ano")
+	}
+	if idxPreco == len(tokens)-1 {
+		return cars.Carro{}, fmt.Errorf("país de origem não pode ser vazio")
+	}
+
+	return cars.Carro{
+		Marca:      tokens[0],
+		Modelo:     strings.Join(tokens[1:idxAno], " "),
+		Ano:        ano,
+		Cor:        strings.Join(tokens[idxAno+1:idxPreco], " "),
+		Preco:      preco,
+		PaisOrigem: strings.Join(tokens[idxPreco+1:], " "),
+	}, nil
+}
+
+// interpretarPrecoRapido aceita preços como 145000, 145.000,50, R$145000 ou 145k
+func interpretarPrecoRapido(token string) (cars.Dinheiro, bool) {
+	t := strings.ToLower(strings.TrimPrefix(strings.ToUpper(token), "R$"))
+	multiplicador := cars.Dinheiro(1)
+	if strings.HasSuffix(t, "k") {
+		multiplicador = 1000
+		t = strings.TrimSuffix(t, "k")
+	}
+	v, err := cars.InterpretarValorDigitado(t)
+	if err != nil {
+		return 0, false
+	}
+	return v * multiplicador, true
+}
+
+// ListarCarros exibe todos os carros do banco em memória
+func (c *sessao) ListarCarros(opcoes cars.OpcoesListagem) error {
+	defer c.Medir("list")()
+	visao := c.Snapshot()
+
+	carros, titulo := visao.Carros(), "Lista de Carros Importados (Banco em Memória)"
+	if opcoes.Em != "" {
+		var err error
+		if carros, err = visao.EstoqueEm(opcoes.Em); err != nil {
+			return err
+		}
+		titulo = fmt.Sprintf("Estoque ao fim de %s (reconstruído do log de eventos)", opcoes.Em)
+	}
+	if len(opcoes.Opcionais) > 0 {
+		carros = slices.DeleteFunc(carros, func(carro cars.Carro) bool { return !temOpcionais(carro, opcoes.Opcionais) })
+		titulo += " com " + strings.Join(opcoes.Opcionais, ", ")
+		if len(carros) == 0 {
+			fmt.Printf("\nNenhum carro com %s.\n", strings.Join(opcoes.Opcionais, ", "))
+			return nil
+		}
+	}
+	if len(carros) == 0 {
+		fmt.Println("\nNenhum carro cadastrado no banco em memória ainda.")
+		return nil
+	}
+
+	if opcoes.Ordenar != "" {
+		carros = cars.OrdenarCarros(carros, opcoes.Ordenar, opcoes.Decrescente)
+	}
+	total, paginas := len(carros), 1
+	if opcoes.Pagina > 0 {
+		var err error
+		if carros, paginas, err = paginaCarros(carros, opcoes.Pagina, opcoes.PorPagina); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n--- %s ---\n", titulo)
+	c.ultimoResultado.guardar("list", carros)
+	if opcoes.FaixasIdade {
+		c.listarPorFaixaIdade(carros, opcoes)
+		return nil
+	}
+	if opcoes.AgruparPor != "" {
+		c.listarAgrupado(carros, opcoes)
+		return nil
+	}
+	fmt.Print(c.tabelaListagem(carros, opcoes).Renderizar(opcoes.Modo, larguraTerminal()))
+	if opcoes.Pagina > 0 {
+		inicio := (opcoes.Pagina - 1) * opcoes.PorPagina
+		fmt.Printf("Página %d de %d (carros %d–%d de %d).", opcoes.Pagina, paginas, inicio+1, inicio+len(carros), total)
+		if opcoes.Pagina < paginas {
+			fmt.Printf(" Próxima: --page %d", opcoes.Pagina+1)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// tabelaCarros monta a tabela padrão de listagem de carros
+func (c *sessao) tabelaCarros(carros []cars.Carro) cars.Tabela {
+	exibicao := c.Exibicao()
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Marca", Essencial: true},
+		{Titulo: "Modelo", Essencial: true},
+		{Titulo: "Ano", Direita: true, Essencial: true},
+		{Titulo: "Cor"},
+		{Titulo: "Preço", Direita: true, Essencial: true},
+		{Titulo: "Origem"},
+		{Titulo: "Cadastrado"},
+		{Titulo: "Situação"},
+	}}
+	for _, carro := range carros {
+		t.Linhas = append(t.Linhas, []string{
+			carro.ID, carro.Marca, carro.Modelo, strconv.Itoa(carro.Ano), carro.Cor,
+			exibicao.FormatarPreco(carro.Preco), carro.PaisOrigem, carro.DataCadastro, cars.StatusCarro(carro),
+		})
+	}
+	return t
+}
+
+// linhaCarro formata um carro em uma linha para exibição individual
+func (c *sessao) linhaCarro(carro cars.Carro) string {
+	return fmt.Sprintf("ID: %s | Marca: %s | Modelo: %s | Ano: %d | Cor: %s | Preço: %s | Origem: %s | Cadastrado: %s",
+		carro.ID, carro.Marca, carro.Modelo, carro.Ano, carro.Cor, c.Exibicao().FormatarPreco(carro.Preco), carro.PaisOrigem, carro.DataCadastro)
+}
+
+// BuscarCarro mostra um carro pelo ID
+func (c *sessao) BuscarCarro(id string) error {
+	defer c.Medir("find")()
+	carro, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n--- Carro Encontrado no Banco em Memória ---\n")
+	fmt.Println(c.linhaCarro(carro))
+	if len(carro.Opcionais) > 0 {
+		fmt.Printf("Opcionais: %s\n", descreverOpcionais(carro.Opcionais))
+	}
+	return nil
+}
+
+// RemoverCarro remove um carro por ID, confirmando antes se for de alto valor
+func (c *sessao) RemoverCarro(id string) error {
+	carro, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+	if err := c.confirmarAltoValor(carro, "a remoção"); err != nil {
+		return err
+	}
+
+	err = c.Remover(id)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Carro com ID '%s' removido do estoque e movido para a lixeira (recupere com 'trash restore %s').\n", id, id)
+	return err
+}
+
+// RemoverEmLote remove vários carros de uma vez, depois de confirmação, e persiste uma só vez no
+// final. IDs repetidos contam uma vez; os não encontrados são listados.
+func (c *sessao) RemoverEmLote(ids []string) error {
+	visao := c.Snapshot()
+	remover := make(map[string]bool)
+	var naoEncontrados []string
+	for _, id := range ids {
+		if _, existe := visao.Carro(id); !existe {
+			naoEncontrados = append(naoEncontrados, id)
+			continue
+		}
+		remover[id] = true
+	}
+	for _, id := range naoEncontrados {
+		fmt.Printf("❌ Carro com ID '%s' não encontrado no banco em memória.\n", id)
+	}
+	if len(remover) == 0 {
+		return errors.New("nenhum carro a remover")
+	}
+
+	if !c.cli.Confirmar(fmt.Sprintf("Remover %d carro(s) do banco em memória? (s/N): ", len(remover))) {
+		return operacaoCancelada("Remoção em lote cancelada.")
+	}
+	for _, carro := range visao.Carros() {
+		if !remover[carro.ID] {
+			continue
+		}
+		if err := c.confirmarAltoValor(carro, "a remoção em lote"); err != nil {
+			return operacaoCancelada("Remoção em lote cancelada.")
+		}
+	}
+
+	removidos, err := c.RemoverVarios(ids)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ %d carro(s) removido(s), %d ID(s) não encontrado(s).\n", removidos, len(naoEncontrados))
+	if err != nil {
+		return err
+	}
+	if len(naoEncontrados) > 0 {
+		return fmt.Errorf("%d ID(s) do arquivo não encontrado(s)", len(naoEncontrados))
+	}
+	return nil
+}
+
+// lerArquivoIDs lê um ID por linha, ignorando linhas vazias e comentários iniciados por #
+func lerArquivoIDs(arquivo string) ([]string, error) {
+	data, err := os.ReadFile(arquivo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de IDs: %v", err)
+	}
+	var ids []string
+	for _, linha := range strings.Split(string(data), "\n") {
+		if linha = strings.TrimSpace(linha); linha != "" && !strings.HasPrefix(linha, "#") {
+			ids = append(ids, linha)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("nenhum ID em %s", arquivo)
+	}
+	return ids, nil
+}
+
+// PurgarCarro apaga definitivamente um ID depois de pedir que ele seja digitado de novo
+func (c *sessao) PurgarCarro(id string) error {
+	if !c.IDEmUso(id) {
+		return cars.ErroIDNaoEncontrado(id)
+	}
+
+	fmt.Printf("\n⚠️  Purga definitiva de '%s': o carro, sua lápide, venda e pagamentos serão apagados sem volta.\n", id)
+	fmt.Println("   Exportações incrementais não informarão esta remoção aos destinos.")
+	if confirmacao, _ := c.cli.Perguntar("Digite o ID novamente para confirmar: "); confirmacao != id {
+		return operacaoCancelada("Purga cancelada.")
+	}
+
+	err := c.Purgar(id)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ ID '%s' purgado definitivamente.\n", id)
+	return err
+}
+
+// AtualizarCarro atualiza um carro por ID no banco em memória
+func (c *sessao) AtualizarCarro(id string) error {
+	carro, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+	original := carro
+	exibicao := c.Exibicao()
+
+	fmt.Printf("\n--- Atualização de Carro (ID: %s) ---\n", id)
+	fmt.Printf("Dados atuais: Marca: %s, Modelo: %s, Ano: %d, Cor: %s, Preço: %s, Origem: %s\n",
+		carro.Marca, carro.Modelo, carro.Ano, carro.Cor, exibicao.FormatarPreco(carro.Preco), carro.PaisOrigem)
+
+	readInput := c.cli.Perguntar
+
+	// Atualiza campos opcionais (pergunta se quer mudar)
+	updateOptional := func(current string, field string, prompt string, validator func(string) (string, error)) {
+		opt, err := readInput(fmt.Sprintf("%s atual: %s. Novo %s (Enter para manter atual): ", field, current, field))
+		if err != nil {
+			fmt.Printf("Erro: %v. Mantendo atual.\n", err)
+			return
+		}
+		if opt == "" {
+			return // Mantém atual
+		}
+		newVal, err := validator(opt)
+		if err != nil {
+			fmt.Printf("Erro: %v. Mantendo atual.\n", err)
+			return
+		}
+		switch field {
+		case "Marca":
+			carro.Marca = newVal
+		case "Modelo":
+			carro.Modelo = newVal
+		case "Cor":
+			carro.Cor = newVal
+		case "País de Origem":
+			carro.PaisOrigem = newVal
+		case "Chassi":
+			carro.Chassi = newVal
+		}
+	}
+
+	updateOptional(carro.Marca, "Marca", "Marca", func(s string) (string, error) {
+		if s == "" {
+			return "", fmt.Errorf("marca não pode ser vazia")
+		}
+		return s, nil
+	})
+
+	updateOptional(carro.Modelo, "Modelo", "Modelo", func(s string) (string, error) {
+		if s == "" {
+			return "", fmt.Errorf("modelo não pode ser vazio")
+		}
+		return s, nil
+	})
+
+	// Ano
+	anoStr, err := readInput(fmt.Sprintf("Ano atual: %d. Novo ano (Enter para manter): ", carro.Ano))
+	if err == nil && anoStr != "" {
+		ano, err := strconv.Atoi(anoStr)
+		if err == nil && ano > 0 && ano <= time.Now().Year()+1 {
+			carro.Ano = ano
+		} else {
+			fmt.Println("Ano inválido. Mantendo atual.")
+		}
+	}
+
+	// Cor (opcional)
+	updateOptional(carro.Cor, "Cor", "Cor", func(s string) (string, error) { return s, nil }) // Cor pode ser vazia
+
+	// Preço
+	precoStr, err := readInput(fmt.Sprintf("Preço atual: %s. Novo preço (Enter para manter): ", exibicao.FormatarPreco(carro.Preco)))
+	if err == nil && precoStr != "" {
+		preco, err := cars.InterpretarValorDigitado(precoStr)
+		if err == nil && preco > 0 {
+			carro.Preco = preco
+		} else {
+			fmt.Println("Preço inválido. Mantendo atual.")
+		}
+	}
+
+	// Custo de importação
+	custoStr, err := readInput(fmt.Sprintf("Custo atual: %s. Novo custo (Enter para manter): ", exibicao.FormatarPreco(carro.Custo)))
+	if err == nil && custoStr != "" {
+		custo, err := cars.InterpretarValorDigitado(custoStr)
+		if err == nil && custo >= 0 {
+			carro.Custo = custo
+		} else {
+			fmt.Println("Custo inválido. Mantendo atual.")
+		}
+	}
+
+	// País de Origem
+	updateOptional(carro.PaisOrigem, "País de Origem", "País de Origem", func(s string) (string, error) {
+		if s == "" {
+			return "", fmt.Errorf("país de origem não pode ser vazio")
+		}
+		return s, nil
+	})
+
+	updateOptional(carro.Chassi, "Chassi", "Chassi", func(s string) (string, error) { return cars.NormalizarChassi(s), nil })
+
+	// Opcionais ("-" tira todos)
+	opcionaisStr, err := readInput(fmt.Sprintf("Opcionais atuais: %s. Novos opcionais (separados por vírgula, - para nenhum, Enter para manter): ",
+		descreverOpcionais(carro.Opcionais)))
+	if err == nil && opcionaisStr == "-" {
+		carro.Opcionais = nil
+	} else if err == nil && opcionaisStr != "" {
+		if opcionais, err := cars.InterpretarOpcionais(opcionaisStr); err == nil {
+			carro.Opcionais = opcionais
+		} else {
+			fmt.Printf("Erro: %v. Mantendo atuais.\n", err)
+		}
+	}
+
+	carro, ajustes := c.AplicarRegrasPreco(carro)
+	c.mostrarAjustes(ajustes)
+
+	if carro.Igual(original) {
+		fmt.Println("Nenhuma alteração informada.")
+		return nil
+	}
+	if err := c.Validar(carro); err != nil {
+		return fmt.Errorf("%v. Alterações descartadas", err)
+	}
+	if carro.Preco != original.Preco {
+		if err := c.confirmarAltoValor(original, "o novo preço de "+exibicao.FormatarPreco(carro.Preco), carro.Preco); err != nil {
+			return err
+		}
+	}
+
+	_, _, err = c.Regravar(original, carro)
+	if !alteracaoFeita(err) {
+		return fmt.Errorf("%v. Alterações descartadas", err)
+	}
+	fmt.Printf("✅ Carro com ID '%s' atualizado no banco em memória.\n", id)
+	return err
+}
+
+// Menu principal interativo
+func main() {
+	// Perfil de configuração: --profile=<nome> na linha de comando ou CARROS_PERFIL no ambiente;
+	// --data-file=<caminho> força o arquivo de dados, ignorando configuração e diretório padrão
+	// Depois delas, um comando e seus argumentos rodam uma única vez, sem o prompt (ex: em scripts e cron)
+	// --log-level e --log-format (ou CARROS_LOG_LEVEL e CARROS_LOG_FORMAT) ligam o log estruturado em stderr
+	programa := separarArgsPrograma(os.Args[1:])
+	perfil, arquivoDados, subcomando := programa.Perfil, programa.ArquivoDados, programa.Subcomando
+	registro, err := cars.RegistroDoAmbiente(programa.NivelLog, programa.FormatoLog)
+	if err != nil {
+		fmt.Printf("❌ Erro: %v\n", err)
+		os.Exit(2)
+	}
+	if perfil == "" {
+		perfil = os.Getenv("CARROS_PERFIL")
+	}
+
+	// Na primeira execução em um terminal, o assistente cria a configuração em vez de assumir padrões
+	// Uma única fonte de entrada atende o assistente, o laço de comandos e as perguntas dos comandos
+	cli := NovoCLI(os.Stdin)
+	caminhoCfg := cars.CaminhoConfig()
+	if perfil == "" && arquivoDados == "" && subcomando == nil && primeiraExecucao(caminhoCfg) && term.IsTerminal(int(os.Stdin.Fd())) {
+		caminhoCfg = assistenteConfiguracao(cli)
+	}
+
+	// Carregar configuração opcional (preferências de exibição e armazenamento)
+	cfg, err := cars.CarregarConfig(caminhoCfg, perfil)
+	if err != nil {
+		if perfil != "" {
+			// Nunca cair silenciosamente no cadastro real quando um perfil específico foi pedido
+			fmt.Printf("❌ Erro ao carregar configuração: %v\n", err)
+			registro.Error("configuração inválida", "arquivo", caminhoCfg, "perfil", perfil, "erro", err)
+			os.Exit(1)
+		}
+		fmt.Printf("⚠️  Aviso ao carregar configuração: %v. Usando padrões.\n", err)
+		registro.Warn("configuração inválida; usando padrões", "arquivo", caminhoCfg, "erro", err)
+	}
+	if arquivoDados != "" {
+		cfg.Armazenamento.Arquivo = arquivoDados
+		cfg.Armazenamento.ArquivoPadrao = false
+	}
+	if cfg.Armazenamento.ArquivoPadrao {
+		// Primeira execução com o diretório de dados do sistema: traz os arquivos antigos do diretório atual
+		dir := filepath.Dir(cfg.Armazenamento.Arquivo)
+		if migrados, err := cars.PrepararDiretorioDados(dir); err != nil {
+			registro.Warn("falha ao preparar o diretório de dados", "diretorio", dir, "erro", err)
+		} else if migrados > 0 {
+			fmt.Printf("✅ %d arquivo(s) de dados migrado(s) do diretório atual para %s (os originais foram mantidos e não são mais usados).\n", migrados, dir)
+		}
+	}
+	if cfg.Perfil != "" {
+		fmt.Printf("🔧 Perfil ativo: %s (dados em %s)\n", cfg.Perfil, cfg.Armazenamento.Arquivo)
+	}
+
+	// O arquivo JSON fica no mesmo diretório do banco (no bbolt, é a origem da migração inicial)
+	cadastro := cars.NewCadastroCarros(filepath.Join(filepath.Dir(cfg.Armazenamento.Arquivo), "carros.json"))
+	cadastro.UsarRegistro(registro)
+	cadastro.AoOperacaoLenta(avisarOperacaoLenta)
+	cadastro.AoGravarBaseGrande(mostrarResumoGravacao)
+	cadastro.Configurar(cfg, caminhoCfg, arquivoDados != "")
+	cadastro.InstalarSinais(os.Getenv("CARROS_DIAGNOSTICO"))
+
+	// Carregar dados persistidos
+	origem := "arquivo " + strings.ToUpper(cfg.Armazenamento.Tipo)
+	if cfg.Armazenamento.Tipo == "bbolt" {
+		origem = "banco bbolt"
+	}
+	carregados, err := cadastro.AbrirArmazenamento()
+	if err == nil {
+		defer cadastro.FecharArmazenamento()
+	}
+	for _, aviso := range cadastro.AvisosAoAbrir() {
+		fmt.Printf("⚠️  Aviso: %s\n", aviso)
+	}
+	relatarQuarentena(cadastro.QuarentenadosAoAbrir())
+	if err != nil {
+		registro.Error("falha ao carregar dados", "arquivo", cfg.Armazenamento.Arquivo, "erro", err)
+	} else if subcomando == nil && carregados > 0 {
+		fmt.Printf("✅ %d carro(s) carregado(s) do %s.\n", carregados, origem)
+	}
+	cadastro.LembrarVencimentos(cfg.Documentos)
+	if cfg.Auditoria.RetencaoMeses > 0 {
+		if _, err := cadastro.CompactarAuditoria(cfg.Auditoria.RetencaoMeses); err != nil {
+			registro.Warn("compactação do log de eventos falhou", "erro", err)
+		}
+	}
+
+	prompt := novaSessao(cadastro)
+	prompt.cli = cli
+	if subcomando != nil {
+		if codigo := executarSubcomando(prompt, subcomando); codigo != 0 {
+			cadastro.FecharArmazenamento()
+			os.Exit(codigo)
+		}
+		return
+	}
+
+	fmt.Println("🚗 Bem-vindo ao Sistema de Cadastro de Carros Importados!")
+	fmt.Printf("Comandos: %s. Digite 'help' para ver a sintaxe ou 'help <comando>' para exemplos.\n", strings.Join(nomesComandos(), ", "))
+
+	cli.Executar(prompt)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// passosMapaCalor são as larguras de faixa de preço tentadas pelo heatmap, da menor para a maior
+var passosMapaCalor = []float64{10000, 20000, 25000, 50000, 100000, 200000, 250000, 500000, 1000000, 2000000, 5000000}
+
+// maxFaixasMapaCalor é o número de linhas que o passo automático procura não ultrapassar
+const maxFaixasMapaCalor = 8
+
+// maxColunasMapaCalor é o número de colunas de ano; acima disso os anos são agrupados
+const maxColunasMapaCalor = 12
+
+// tonsMapaCalor são os tons das células, do menos para o mais concentrado
+var tonsMapaCalor = []string{"░", "▒", "▓", "█"}
+
+// passoAutomatico escolhe a menor largura de faixa que cobre os preços em até maxFaixasMapaCalor linhas
+func passoAutomatico(menor, maior cars.Dinheiro) cars.Dinheiro {
+	for _, reais := range passosMapaCalor {
+		passo := cars.Reais(reais)
+		if int(maior/passo-menor/passo)+1 <= maxFaixasMapaCalor {
+			return passo
+		}
+	}
+	return cars.Reais(passosMapaCalor[len(passosMapaCalor)-1])
+}
+
+// celulaMapaCalor mostra a contagem com um tom proporcional ao maior valor da grade
+func celulaMapaCalor(n, maximo int) string {
+	if n == 0 {
+		return "·"
+	}
+	tom := (n*len(tonsMapaCalor) - 1) / maximo
+	return tonsMapaCalor[tom] + " " + strconv.Itoa(n)
+}
+
+// MapaCalor mostra a quantidade de carros em estoque por faixa de preço (linhas) e ano do modelo
+// (colunas), para ver de relance onde o estoque se concentra. passo 0 escolhe a largura das faixas.
+func (c *sessao) MapaCalor(passo cars.Dinheiro, modo cars.ModoTabela) {
+	visao := c.Snapshot()
+	exibicao := visao.Exibicao()
+	carros := visao.Carros()
+	if len(carros) == 0 {
+		fmt.Println("Nenhum carro em estoque.")
+		return
+	}
+
+	menorPreco, maiorPreco := carros[0].Preco, carros[0].Preco
+	menorAno, maiorAno := carros[0].Ano, carros[0].Ano
+	for _, carro := range carros {
+		menorPreco, maiorPreco = min(menorPreco, carro.Preco), max(maiorPreco, carro.Preco)
+		menorAno, maiorAno = min(menorAno, carro.Ano), max(maiorAno, carro.Ano)
+	}
+	if passo == 0 {
+		passo = passoAutomatico(menorPreco, maiorPreco)
+	}
+	primeira := menorPreco / passo
+	faixas := int(maiorPreco/passo-primeira) + 1
+	// Anos agrupados de passoAno em passoAno quando o estoque cobre muitos anos (ex: clássicos)
+	passoAno := (maiorAno - menorAno + maxColunasMapaCalor) / maxColunasMapaCalor
+	anos := (maiorAno-menorAno)/passoAno + 1
+
+	contagem := make([][]int, faixas)
+	for i := range contagem {
+		contagem[i] = make([]int, anos)
+	}
+	totalAno := make([]int, anos)
+	maximo := 0
+	for _, carro := range carros {
+		f, a := int(carro.Preco/passo-primeira), (carro.Ano-menorAno)/passoAno
+		contagem[f][a]++
+		totalAno[a]++
+		maximo = max(maximo, contagem[f][a])
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{{Titulo: "Faixa de Preço", Essencial: true}}}
+	for a := range anos {
+		titulo := strconv.Itoa(menorAno + a*passoAno)
+		if passoAno > 1 {
+			titulo += "–" + strconv.Itoa(min(menorAno+(a+1)*passoAno-1, maiorAno))
+		}
+		t.Colunas = append(t.Colunas, cars.ColunaTabela{Titulo: titulo, Direita: true, Essencial: true})
+	}
+	t.Colunas = append(t.Colunas, cars.ColunaTabela{Titulo: "Total", Direita: true, Essencial: true})
+	// Preços mais altos em cima, como no eixo de um gráfico
+	for f := faixas - 1; f >= 0; f-- {
+		inicio := (primeira + cars.Dinheiro(f)) * passo
+		linha := []string{fmt.Sprintf("%s – %s", exibicao.FormatarPreco(inicio), exibicao.FormatarPreco(inicio+passo-1))}
+		total := 0
+		for a := range anos {
+			linha = append(linha, celulaMapaCalor(contagem[f][a], maximo))
+			total += contagem[f][a]
+		}
+		t.Linhas = append(t.Linhas, append(linha, strconv.Itoa(total)))
+	}
+	rodape := []string{"Total"}
+	for _, n := range totalAno {
+		rodape = append(rodape, strconv.Itoa(n))
+	}
+	t.Linhas = append(t.Linhas, append(rodape, strconv.Itoa(len(carros))))
+
+	fmt.Printf("\n--- Mapa de Calor: Preço × Ano (%d carros, faixas de %s) ---\n", len(carros), exibicao.FormatarPreco(passo))
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	fmt.Printf("Tons: %s (do menos ao mais concentrado; o máximo é %d carro(s) numa célula)\n", strings.Join(tonsMapaCalor, " "), maximo)
+}
+
+// interpretarArgsMapaCalor lê `[--step=<valor>] [--wide|--narrow]`
+func interpretarArgsMapaCalor(args []string) (cars.Dinheiro, cars.ModoTabela, error) {
+	modo, args := interpretarModoTabela(args)
+	var passo cars.Dinheiro
+	for _, arg := range args {
+		valor, ok := strings.CutPrefix(arg, "--step=")
+		if !ok {
+			return 0, modo, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+		p, err := cars.InterpretarValorDigitado(valor)
+		if err != nil || p <= 0 {
+			return 0, modo, fmt.Errorf("largura de faixa inválida: %s", valor)
+		}
+		passo = p
+	}
+	return passo, modo, nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// avisarOperacaoLenta mostra na hora a operação que passou do limiar
+func avisarOperacaoLenta(operacao string, duracao time.Duration) {
+	fmt.Printf("⚠️  Operação lenta: %s levou %s\n", operacao, duracao.Round(time.Millisecond))
+}
+
+// MostrarMetricas exibe as métricas de tempo das operações desde o início da execução
+func (c *sessao) MostrarMetricas(modo cars.ModoTabela) {
+	t := c.TabelaMetricas()
+	if len(t.Linhas) == 0 {
+		fmt.Println("\nNenhuma operação medida ainda.")
+		return
+	}
+	fmt.Printf("\n--- Métricas das Operações (lentas a partir de %s) ---\n", cars.LimiarOperacaoLenta)
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
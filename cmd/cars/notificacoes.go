@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/michellhornung/golang/cars"
+	"golang.org/x/term"
+)
+
+// tocarSino toca o sino do terminal para avisos importantes, o que não atrapalha a linha sendo digitada
+func tocarSino(n cars.Notificacao) {
+	if n.Importante && term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Print("\a")
+	}
+}
+
+// mostrarNotificacoes imprime e descarta os avisos pendentes; chamado antes de cada prompt
+func (c *sessao) mostrarNotificacoes() {
+	pendentes := c.RetirarNotificacoes()
+	if len(pendentes) == 0 {
+		return
+	}
+	fmt.Println()
+	for _, n := range pendentes {
+		fmt.Printf("🔔 [%s] %s\n", n.Instante.Format("15:04:05"), n.Mensagem)
+	}
+}
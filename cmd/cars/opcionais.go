@@ -0,0 +1,26 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// temOpcionais informa se o carro tem todos os opcionais pedidos
+func temOpcionais(carro cars.Carro, pedidos []string) bool {
+	for _, opcional := range pedidos {
+		if !slices.Contains(carro.Opcionais, opcional) {
+			return false
+		}
+	}
+	return true
+}
+
+// descreverOpcionais junta os opcionais para exibição ("-" se não houver nenhum)
+func descreverOpcionais(opcionais []string) string {
+	if len(opcionais) == 0 {
+		return "-"
+	}
+	return strings.Join(opcionais, ", ")
+}
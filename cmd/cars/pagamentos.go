@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// metodosPagamento são os meios de pagamento aceitos
+var metodosPagamento = []string{"pix", "ted", "boleto", "cartao", "dinheiro", "financiamento"}
+
+// LancarPagamento registra o pagamento e informa o saldo devedor
+func (c *sessao) LancarPagamento(p cars.Pagamento) error {
+	saldo, err := c.RegistrarPagamento(p)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	exibicao := c.Exibicao()
+	rotulo := "Parcela"
+	if p.Tipo == "sinal" {
+		rotulo = "Sinal"
+	}
+	fmt.Printf("✅ %s de %s (%s) registrado para %s. Saldo devedor: %s\n",
+		rotulo, exibicao.FormatarPreco(p.Valor), p.Metodo, saldo.Descricao, exibicao.FormatarPreco(saldo.EmAberto()))
+	if saldo.EmAberto() < 0 {
+		fmt.Printf("⚠️  Aviso: pagamentos excedem o valor devido em %s.\n", exibicao.FormatarPreco(-saldo.EmAberto()))
+	}
+	return err
+}
+
+// ExtratoPagamentos mostra o razão de pagamentos de um carro com saldo acumulado
+func (c *sessao) ExtratoPagamentos(id string) error {
+	visao := c.Snapshot()
+	saldo, pagamentos, err := visao.Pagamentos(id)
+	if err != nil {
+		return err
+	}
+	exibicao := visao.Exibicao()
+
+	fmt.Printf("\n--- Pagamentos: %s (ID: %s) ---\n", saldo.Descricao, id)
+	fmt.Printf("Valor devido: %s\n\n", exibicao.FormatarPreco(saldo.Devido))
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "Data", Essencial: true},
+		{Titulo: "Tipo"},
+		{Titulo: "Método"},
+		{Titulo: "Valor", Direita: true, Essencial: true},
+		{Titulo: "Saldo", Direita: true, Essencial: true},
+	}}
+	acumulado := saldo.Devido
+	for _, p := range pagamentos {
+		acumulado -= p.Valor
+		t.Linhas = append(t.Linhas, []string{p.Data, p.Tipo, p.Metodo,
+			exibicao.FormatarPreco(p.Valor), exibicao.FormatarPreco(acumulado)})
+	}
+	if len(t.Linhas) == 0 {
+		fmt.Println("Nenhum pagamento registrado.")
+		return nil
+	}
+	fmt.Print(t.Renderizar(cars.TabelaAuto, larguraTerminal()))
+	fmt.Printf("\nTotal pago: %s | Saldo devedor: %s\n", exibicao.FormatarPreco(saldo.Pago), exibicao.FormatarPreco(saldo.EmAberto()))
+	return nil
+}
+
+// RelatorioSaldos lista todos os carros com pagamentos lançados e o saldo ainda em aberto
+func (c *sessao) RelatorioSaldos() {
+	defer c.Medir("balances")()
+	visao := c.Snapshot()
+	exibicao := visao.Exibicao()
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro", Essencial: true},
+		{Titulo: "Devido", Direita: true},
+		{Titulo: "Pago", Direita: true},
+		{Titulo: "Saldo", Direita: true, Essencial: true},
+		{Titulo: "Último Pgto"},
+	}}
+	var totalSaldo cars.Dinheiro
+	for _, s := range visao.Saldos() {
+		if !s.Removido && s.EmAberto() > 0 {
+			totalSaldo += s.EmAberto()
+		}
+		t.Linhas = append(t.Linhas, []string{s.CarroID, s.Descricao, exibicao.FormatarPreco(s.Devido),
+			exibicao.FormatarPreco(s.Pago), exibicao.FormatarPreco(s.EmAberto()), s.Ultimo})
+	}
+
+	if len(t.Linhas) == 0 {
+		fmt.Println("\nNenhum pagamento registrado ainda.")
+		return
+	}
+	fmt.Println("\n--- Saldos em Aberto ---")
+	fmt.Print(t.Renderizar(cars.TabelaAuto, larguraTerminal()))
+	fmt.Printf("\nTotal a receber: %s\n", exibicao.FormatarPreco(totalSaldo))
+}
+
+// interpretarArgsPagamento lê `<ID> <valor> [--metodo=pix] [--data=AAAA-MM-DD] [--sinal]`
+func interpretarArgsPagamento(args []string) (cars.Pagamento, error) {
+	p := cars.Pagamento{
+		CarroID: args[0],
+		Data:    time.Now().Format("2006-01-02"),
+		Metodo:  "pix",
+		Tipo:    "parcela",
+	}
+	valor, ok := interpretarPrecoRapido(args[1])
+	if !ok || valor <= 0 {
+		return p, fmt.Errorf("valor deve ser um número positivo válido")
+	}
+	p.Valor = valor
+
+	for _, arg := range args[2:] {
+		switch {
+		case arg == "--sinal":
+			p.Tipo = "sinal"
+		case strings.HasPrefix(arg, "--metodo="):
+			p.Metodo = strings.ToLower(strings.TrimPrefix(arg, "--metodo="))
+			valido := false
+			for _, m := range metodosPagamento {
+				valido = valido || m == p.Metodo
+			}
+			if !valido {
+				return p, fmt.Errorf("método '%s' inválido (use %s)", p.Metodo, strings.Join(metodosPagamento, ", "))
+			}
+		case strings.HasPrefix(arg, "--data="):
+			data, err := cars.InterpretarData(strings.TrimPrefix(arg, "--data="))
+			if err != nil {
+				return p, err
+			}
+			p.Data = data
+		default:
+			return p, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// terminalPaginado é o terminal de verdade enquanto a saída de um comando passa pelo pager
+// (os.Stdout aponta para o pipe nesse meio-tempo); as tabelas continuam medindo a largura nele
+var terminalPaginado *os.File
+
+// saidaTerminal devolve onde a saída chega ao usuário
+func saidaTerminal() *os.File {
+	if terminalPaginado != nil {
+		return terminalPaginado
+	}
+	return os.Stdout
+}
+
+// alturaTerminal devolve quantas linhas cabem no terminal (0 se a saída não for um terminal)
+func alturaTerminal() int {
+	if _, altura, err := term.GetSize(int(saidaTerminal().Fd())); err == nil && altura > 0 {
+		return altura
+	}
+	return 0
+}
+
+// usarPager informa se a saída dos comandos longos deve passar pelo pager: só com entrada e
+// saída no terminal, e se exibicao.sem_paginacao não o desligou
+func (c *sessao) usarPager() bool {
+	return !c.Exibicao().SemPaginacao && terminalPaginado == nil && alturaTerminal() > 0 && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// paginarComando roda o comando com a saída passando pelo pager. As linhas são lidas do pipe à
+// medida que as páginas são pedidas, então um resultado de milhares de linhas não é montado antes
+// de aparecer; o que já foi lido fica guardado para voltar e buscar.
+func (c *sessao) paginarComando(executar func() error) error {
+	leitor, escritor, err := os.Pipe()
+	if err != nil {
+		return executar()
+	}
+	terminal := os.Stdout
+	terminalPaginado, os.Stdout = terminal, escritor
+	defer func() { os.Stdout, terminalPaginado = terminal, nil }()
+
+	fim := make(chan error, 1)
+	go func() {
+		err := executar()
+		escritor.Close()
+		fim <- err
+	}()
+	p := &pager{entrada: bufio.NewReader(leitor), saida: terminal, cli: c.cli, altura: alturaTerminal()}
+	p.mostrar()
+	// Se o usuário saiu antes do fim, descarta o resto para o comando terminar
+	io.Copy(io.Discard, leitor)
+	leitor.Close()
+	return <-fim
+}
+
+// pager mostra a saída de um comando uma tela por vez, lendo as respostas do prompt por linha:
+// Enter (ou espaço) avança, b volta, /texto busca, n repete a busca e q sai
+type pager struct {
+	entrada *bufio.Reader
+	saida   io.Writer
+	cli     *CLI
+	altura  int
+	linhas  []string // Linhas já lidas do comando
+	fim     bool     // O comando terminou e todas as linhas foram lidas
+}
+
+// garantir lê do comando até ter n linhas ou a saída acabar
+func (p *pager) garantir(n int) {
+	for len(p.linhas) < n && !p.fim {
+		linha, err := p.entrada.ReadString('\n')
+		if linha != "" {
+			p.linhas = append(p.linhas, strings.TrimSuffix(linha, "\n"))
+		}
+		if err != nil {
+			p.fim = true
+		}
+	}
+}
+
+// buscar devolve a primeira linha a partir de desde que contém o texto, sem diferenciar
+// maiúsculas (-1 se não houver)
+func (p *pager) buscar(texto string, desde int) int {
+	texto = strings.ToLower(texto)
+	for i := desde; ; i++ {
+		if p.garantir(i + 1); i >= len(p.linhas) {
+			return -1
+		}
+		if strings.Contains(strings.ToLower(p.linhas[i]), texto) {
+			return i
+		}
+	}
+}
+
+// mostrar exibe as páginas até o fim da saída ou até o usuário sair; saídas que cabem em uma
+// tela são impressas direto, sem pausa
+func (p *pager) mostrar() {
+	pagina := max(p.altura-1, 5)
+	topo, busca := 0, ""
+	for {
+		p.garantir(topo + pagina + 1)
+		ate := min(topo+pagina, len(p.linhas))
+		for _, linha := range p.linhas[topo:ate] {
+			fmt.Fprintln(p.saida, linha)
+		}
+		if p.fim && ate >= len(p.linhas) {
+			return
+		}
+
+		total := ""
+		if p.fim {
+			total = " de " + strconv.Itoa(len(p.linhas))
+		}
+		fmt.Fprintf(p.saida, "-- linhas %d–%d%s -- Enter: avançar, b: voltar, /texto: buscar, n: próxima, q: sair ", topo+1, ate, total)
+		resposta, ok := p.cli.lerLinha()
+		if !ok {
+			fmt.Fprintln(p.saida)
+			return
+		}
+		switch resposta = strings.TrimSpace(resposta); {
+		case resposta == "q":
+			return
+		case resposta == "b":
+			topo = max(topo-pagina, 0)
+		case strings.HasPrefix(resposta, "/") || resposta == "n":
+			if strings.HasPrefix(resposta, "/") {
+				busca = resposta[1:]
+			}
+			if busca == "" {
+				fmt.Fprintln(p.saida, "Informe o texto: /texto")
+				continue
+			}
+			if i := p.buscar(busca, topo+1); i >= 0 {
+				topo = i
+			} else {
+				fmt.Fprintf(p.saida, "'%s' não encontrado daqui em diante.\n", busca)
+			}
+		default:
+			topo = ate
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// IniciarPainel põe o painel do showroom no ar e mostra o endereço
+func (c *sessao) IniciarPainel(opcoes cars.OpcoesPainel) error {
+	endereco, err := c.CadastroCarros.IniciarPainel(opcoes)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Painel do showroom em http://%s/ (atualiza a cada %d s). Use 'board stop' para encerrar.\n",
+		endereco, opcoes.Atualizacao)
+	return nil
+}
+
+// PararPainel encerra o painel do showroom e confirma
+func (c *sessao) PararPainel() error {
+	parou, err := c.CadastroCarros.PararPainel()
+	switch {
+	case err != nil:
+		return err
+	case !parou:
+		fmt.Println("Nenhum painel no ar.")
+	default:
+		fmt.Println("✅ Painel encerrado.")
+	}
+	return nil
+}
+
+// interpretarArgsPainel lê `[--listen=<endereço>] [--days=<n>] [--refresh=<segundos>]`
+func interpretarArgsPainel(args []string) (cars.OpcoesPainel, error) {
+	opcoes := cars.OpcoesPainel{Endereco: ":8080", Dias: 7, Atualizacao: 30}
+	for _, arg := range args {
+		nome, valor, _ := strings.Cut(arg, "=")
+		switch nome {
+		case "--listen":
+			opcoes.Endereco = valor
+		case "--days", "--refresh":
+			n, err := strconv.Atoi(valor)
+			if err != nil || n <= 0 {
+				return opcoes, fmt.Errorf("%s deve ser um número inteiro positivo", nome)
+			}
+			if nome == "--days" {
+				opcoes.Dias = n
+			} else {
+				opcoes.Atualizacao = n
+			}
+		default:
+			return opcoes, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	return opcoes, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// mostrarAjustes informa cada ajuste de preço feito pelas regras
+func (c *sessao) mostrarAjustes(ajustes []cars.AjustePreco) {
+	exibicao := c.Exibicao()
+	for _, a := range ajustes {
+		fmt.Printf("💲 Preço ajustado pela regra '%s': %s → %s\n", a.Regra, exibicao.FormatarPreco(a.De), exibicao.FormatarPreco(a.Para))
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// MostrarProtecao resume a proteção de campos da sessão
+func (c *sessao) MostrarProtecao() error {
+	s := c.Protecao()
+	if len(s.Campos) == 0 {
+		fmt.Println("Nenhum campo protegido. Gere as chaves com 'protect keygen' e informe protecao.campos no config.json.")
+	} else {
+		fmt.Printf("🔐 Campos cifrados no arquivo: %s\n", strings.Join(s.Campos, ", "))
+	}
+	switch {
+	case s.ErroChave != nil:
+		fmt.Printf("❌ %v\n", s.ErroChave)
+	case !s.ChavePrivada:
+		fmt.Println("Sem chave privada nesta sessão: os campos protegidos aparecem vazios e são regravados como estão.")
+	default:
+		fmt.Println("✅ Chave privada carregada: os campos protegidos aparecem decifrados.")
+	}
+	if s.Cifrados > 0 {
+		fmt.Printf("%d carro(s) em estoque com campos que esta sessão não consegue ler.\n", s.Cifrados)
+	}
+	return nil
+}
+
+// GerarChavesProtecao gera um par de chaves e mostra como configurá-lo
+func GerarChavesProtecao() error {
+	publica, privada, err := cars.NovasChavesProtecao()
+	if err != nil {
+		return err
+	}
+	fmt.Println("✅ Chave pública, para o config.json de todos:")
+	fmt.Printf("  \"protecao\": {\"campos\": [\"custo\"], \"chave_publica\": \"%s\"}\n", publica)
+	fmt.Println("🔑 Chave privada, só para quem pode ver os campos protegidos (em CARROS_CHAVE_PRIVADA ou em um")
+	fmt.Println("   arquivo indicado em protecao.arquivo_chave_privada; nunca no config.json):")
+	fmt.Printf("  %s\n", privada)
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// relatarQuarentena informa os registros enviados à quarentena ao abrir os dados
+func relatarQuarentena(novos []cars.RegistroQuarentena) {
+	if len(novos) == 0 {
+		return
+	}
+	fmt.Printf("⚠️  %d registro(s) inválido(s) ignorado(s) e movido(s) para a quarentena:\n", len(novos))
+	for _, r := range novos {
+		fmt.Printf("   - %s, registro %d: %s\n", r.Origem, r.Posicao, r.Erro)
+	}
+	fmt.Println("   Use 'repair' para corrigi-los ou descartá-los.")
+}
+
+// RepararQuarentena percorre os registros em quarentena, permitindo corrigir campo a campo,
+// descartar ou pular cada um. Registros corrigidos e válidos voltam para o banco.
+func (c *sessao) RepararQuarentena() error {
+	registros := c.Quarentena()
+	if len(registros) == 0 {
+		fmt.Println("\nNenhum registro em quarentena.")
+		return nil
+	}
+
+	readInput := func(prompt string) string {
+		resposta, _ := c.cli.Perguntar(prompt)
+		return resposta
+	}
+
+percorrer:
+	for i, r := range registros {
+		fmt.Printf("\n--- Quarentena %d/%d: %s, registro %d ---\n", i+1, len(registros), r.Origem, r.Posicao)
+		fmt.Printf("Erro: %s\nConteúdo: %s\n", r.Erro, string(r.Bruto))
+
+		switch strings.ToLower(readInput("(c)orrigir, (d)escartar, (p)ular ou (s)air? ")) {
+		case "c":
+			carro := r.Recuperado()
+			corrigirCampos(&carro, readInput)
+			carro, err := c.RecuperarDaQuarentena(r, carro)
+			if !alteracaoFeita(err) {
+				fmt.Printf("❌ Registro ainda inválido: %v. Mantido na quarentena.\n", err)
+				continue
+			}
+			fmt.Printf("✅ Carro '%s %s' recuperado com ID: %s\n", carro.Marca, carro.Modelo, carro.ID)
+			if err != nil {
+				return err
+			}
+		case "d":
+			err := c.DescartarDaQuarentena(r)
+			if !alteracaoFeita(err) {
+				return err
+			}
+			fmt.Println("🗑️  Registro descartado.")
+			if err != nil {
+				return err
+			}
+		case "s":
+			break percorrer
+		}
+	}
+	fmt.Printf("\n%d registro(s) continuam em quarentena.\n", len(c.Quarentena()))
+	return nil
+}
+
+// corrigirCampos pergunta cada campo mostrando o valor recuperado (Enter mantém)
+func corrigirCampos(carro *cars.Carro, readInput func(string) string) {
+	texto := func(nome string, atual *string) {
+		if v := readInput(fmt.Sprintf("%s [%s]: ", nome, *atual)); v != "" {
+			*atual = v
+		}
+	}
+	texto("Marca", &carro.Marca)
+	texto("Modelo", &carro.Modelo)
+	if v := readInput(fmt.Sprintf("Ano [%d]: ", carro.Ano)); v != "" {
+		if ano, err := strconv.Atoi(v); err == nil {
+			carro.Ano = ano
+		} else {
+			fmt.Println("Ano inválido, mantendo o anterior.")
+		}
+	}
+	texto("Cor", &carro.Cor)
+	if v := readInput(fmt.Sprintf("Preço [%s]: ", carro.Preco)); v != "" {
+		if preco, err := cars.InterpretarValorDigitado(v); err == nil {
+			carro.Preco = preco
+		} else {
+			fmt.Println("Preço inválido, mantendo o anterior.")
+		}
+	}
+	texto("País de Origem", &carro.PaisOrigem)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// comandosEscrita informa, para cada comando que pode alterar os dados, se os argumentos pedem
+// uma alteração. Numa réplica eles ficam bloqueados; as consultas dos mesmos comandos continuam.
+var comandosEscrita = map[string]func(args []string) bool{
+	"add": sempreEscreve, "quickadd": sempreEscreve, "remove": sempreEscreve, "purge": sempreEscreve,
+	"update": sempreEscreve, "edit": sempreEscreve, "patch": sempreEscreve, "sell": sempreEscreve,
+	"pay": sempreEscreve, "undo": sempreEscreve, "redo": sempreEscreve, "rollback": sempreEscreve,
+	"restore": sempreEscreve, "import": sempreEscreve, "repair": sempreEscreve,
+	"trash":   subcomandoEscreve("restore", "purge"),
+	"channel": subcomandoEscreve("set", "clear"),
+	"lot":     subcomandoEscreve("new", "add", "cost"),
+	"events":  subcomandoEscreve("rebuild", "compact"),
+	"fx":      subcomandoEscreve("backfill"),
+	"reindex": func(args []string) bool { return slices.Contains(args, "--repair") },
+	"status":  func(args []string) bool { return len(args) > 1 },
+	"doc":     func(args []string) bool { return len(args) > 1 },
+}
+
+func sempreEscreve([]string) bool { return true }
+
+func subcomandoEscreve(nomes ...string) func(args []string) bool {
+	return func(args []string) bool { return len(args) > 0 && slices.Contains(nomes, args[0]) }
+}
+
+// alteracaoBloqueada informa se o comando, com esses argumentos, alteraria uma réplica
+func (c *sessao) alteracaoBloqueada(nome string, args []string) bool {
+	escreve, existe := comandosEscrita[nome]
+	return existe && escreve(args) && c.SomenteLeitura()
+}
+
+// SeguirPrimario passa a acompanhar o primário e mostra a partir de qual evento
+func (c *sessao) SeguirPrimario(endereco string) error {
+	if err := c.CadastroCarros.SeguirPrimario(endereco); err != nil {
+		return err
+	}
+	r, _ := c.Replica()
+	fmt.Printf("✅ Seguindo %s a partir do evento #%d. O cadastro fica somente leitura; use 'replica promote' para voltar a aceitar alterações.\n",
+		r.Primario, r.UltimoSeq)
+	return nil
+}
+
+// PromoverReplica promove a réplica e mostra em que evento
+func (c *sessao) PromoverReplica() {
+	r, ok := c.CadastroCarros.PromoverReplica()
+	if !ok {
+		fmt.Println("Esta instância não segue nenhum primário.")
+		return
+	}
+	fmt.Printf("✅ Réplica promovida no evento #%d: o cadastro volta a aceitar alterações, que não são enviadas a %s.\n", r.UltimoSeq, r.Primario)
+}
+
+// MostrarReplica mostra o primário seguido, a conexão e até onde o log chegou
+func (c *sessao) MostrarReplica() {
+	r, ok := c.Replica()
+	if !ok {
+		fmt.Println("Esta instância não segue nenhum primário (use 'replica follow <url>').")
+		return
+	}
+	situacao := "conectado"
+	if !r.Conectado {
+		situacao = "reconectando"
+		if r.Erro != "" {
+			situacao += " (" + r.Erro + ")"
+		}
+	}
+	contato := "-"
+	if !r.UltimoContato.IsZero() {
+		contato = fmt.Sprintf("%s (há %s)", r.UltimoContato.Local().Format("02/01 15:04:05"), time.Since(r.UltimoContato).Round(time.Second))
+	}
+	fmt.Printf("\n--- Réplica somente leitura ---\n")
+	fmt.Printf("Primário:         %s\n", r.Primario)
+	fmt.Printf("Situação:         %s\n", situacao)
+	fmt.Printf("Último evento:    #%d (%d carro(s) em estoque)\n", r.UltimoSeq, r.Carros)
+	fmt.Printf("Recebidos:        %d evento(s) nesta sessão\n", r.Recebidos)
+	fmt.Printf("Último contato:   %s\n", contato)
+}
+
+// interpretarURLPrimario confere o endereço da API do primário (ex: http://10.0.0.5:8081)
+func interpretarURLPrimario(endereco string) (string, error) {
+	u, err := url.Parse(endereco)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("endereço '%s' inválido (use a URL da API do primário, ex: http://10.0.0.5:8081)", endereco)
+	}
+	return endereco, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// resultadoSessao guarda o último conjunto de carros exibido por `list` ou `search`, para que
+// possa ser exportado sem repetir os filtros. Tem lock próprio porque é gravado por comandos
+// que só seguram o lock de leitura do cadastro.
+type resultadoSessao struct {
+	mu     sync.Mutex
+	origem string       // Comando que produziu o resultado, ex: "search bmw preto"
+	carros []cars.Carro // Cópia dos carros na ordem exibida
+}
+
+// guardar substitui o resultado da sessão por uma cópia dos carros exibidos
+func (r *resultadoSessao) guardar(origem string, carros []cars.Carro) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.origem = origem
+	r.carros = append([]cars.Carro(nil), carros...)
+}
+
+// obter devolve o último resultado e o comando que o produziu
+func (r *resultadoSessao) obter() (string, []cars.Carro) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.origem, r.carros
+}
+
+// ExportarResultado grava em CSV o último resultado exibido, como estava no momento da exibição
+func (c *sessao) ExportarResultado(arquivo string) error {
+	origem, carros := c.ultimoResultado.obter()
+	if origem == "" {
+		return errors.New("nenhum resultado para exportar. Use 'list' ou 'search' antes de ':export'")
+	}
+
+	f, err := os.Create(arquivo)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo CSV: %v", err)
+	}
+	saida := bufio.NewWriter(f)
+	err = cars.EscreverCarrosCSV(saida, carros, runtime.NumCPU(), ',')
+	if err == nil {
+		err = saida.Flush()
+	}
+	if errFechar := f.Close(); err == nil {
+		err = errFechar
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao exportar CSV: %v", err)
+	}
+	fmt.Printf("✅ %d carro(s) do resultado de '%s' exportado(s) para %s.\n", len(carros), origem, arquivo)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// ReverterPara volta carros, remoções e vendas ao estado em que estavam logo após o evento seq.
+// A prévia com as diferenças é sempre mostrada; com simular, para nela. Os eventos do rollback
+// ficam no log como qualquer outra alteração, e o próprio rollback pode ser revertido.
+func (c *sessao) ReverterPara(seq int64, simular bool, modo cars.ModoTabela) error {
+	visao := c.Snapshot()
+	mudancas, err := visao.PlanejarReversao(seq)
+	if err != nil {
+		return err
+	}
+	if len(mudancas) == 0 {
+		fmt.Printf("✅ O estoque já está como estava no evento #%d; nada a reverter.\n", seq)
+		return nil
+	}
+
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Carro"},
+		{Titulo: "Agora", Essencial: true},
+		{Titulo: "#" + strconv.FormatInt(seq, 10), Essencial: true},
+		{Titulo: "Detalhe"},
+	}}
+	eventos := 0
+	for _, m := range mudancas {
+		t.Linhas = append(t.Linhas, []string{m.ID, m.Carro, m.Agora.Descricao(), m.NoPonto.Descricao(), m.Detalhe})
+		eventos += len(m.Eventos)
+	}
+	fmt.Printf("\n--- Rollback até o Evento #%d (%d carro(s), %d evento(s) novos) ---\n", seq, len(mudancas), eventos)
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+	if simular {
+		fmt.Println("Simulação: nada foi alterado.")
+		return nil
+	}
+	resposta, _ := c.cli.Perguntar(fmt.Sprintf("Reverter %d carro(s) ao estado do evento #%d? (s/N): ", len(mudancas), seq))
+	if r := strings.ToLower(resposta); r != "s" && r != "sim" {
+		return operacaoCancelada("Rollback cancelado.")
+	}
+
+	primeiro, ultimo, err := c.AplicarReversao(visao, mudancas)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Rollback até o evento #%d aplicado: %d carro(s) revertido(s), registrado(s) nos eventos #%d a #%d.\n",
+		seq, len(mudancas), primeiro, ultimo)
+	return err
+}
+
+// interpretarArgsReversao lê `--to=<seq> [--dry-run]`
+func interpretarArgsReversao(args []string) (seq int64, simular bool, err error) {
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--to="):
+			seq, err = strconv.ParseInt(strings.TrimPrefix(arg, "--to="), 10, 64)
+			if err != nil || seq <= 0 {
+				return 0, false, fmt.Errorf("--to deve ser o número (Seq) de um evento do log")
+			}
+		case arg == "--dry-run":
+			simular = true
+		default:
+			return 0, false, fmt.Errorf("opção desconhecida: %s", arg)
+		}
+	}
+	if seq == 0 {
+		return 0, false, fmt.Errorf("informe o evento de destino com --to=<seq> (veja 'events')")
+	}
+	return seq, simular, nil
+}
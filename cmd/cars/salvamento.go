@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// formatarBytes mostra um tamanho em B, KB, MB ou GB
+func formatarBytes(n float64) string {
+	unidades := []string{"B", "KB", "MB", "GB"}
+	i := 0
+	for n >= 1024 && i < len(unidades)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, unidades[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, unidades[i])
+}
+
+// mostrarResumoGravacao mostra quanto uma gravação gravou e em quanto tempo; o prompt o mostra em
+// cada gravação de uma base grande e no flush
+func mostrarResumoGravacao(resumo cars.ResumoGravacao) {
+	fmt.Printf("💾 %d carro(s) salvo(s) em %s (%s, %s/s)\n",
+		resumo.Carros, resumo.Duracao.Round(time.Millisecond), formatarBytes(float64(resumo.Bytes)), formatarBytes(resumo.Vazao))
+}
+
+// Flush grava imediatamente todo o cadastro e mostra o resumo da gravação
+func (c *sessao) Flush() error {
+	resumo, err := c.Gravar()
+	if err != nil {
+		return err
+	}
+	mostrarResumoGravacao(resumo)
+	fmt.Println("✅ Dados gravados.")
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tempoBloqueio devolve quanto tempo o prompt espera antes de bloquear a sessão (0 = não bloqueia)
+func (c *sessao) tempoBloqueio() time.Duration {
+	return time.Duration(c.Config().Sessao.BloqueioMinutos) * time.Minute
+}
+
+// BloquearSessao grava o que estiver pendente, limpa a tela e só devolve o controle depois da
+// senha correta. Devolve false se a entrada acabar antes do desbloqueio (a sessão deve encerrar).
+func (c *sessao) BloquearSessao(motivo string) bool {
+	hash := c.Config().Sessao.SenhaHash
+	if hash == "" {
+		fmt.Println("❌ Nenhuma senha configurada. Gere uma com 'lock hash' e informe-a em sessao.senha_hash no config.json.")
+		return true
+	}
+	// Persistir antes de bloquear, caso a última gravação tenha falhado
+	if err := c.GravarPendentes(); err != nil {
+		c.Registro().Warn("falha ao salvar dados antes do bloqueio", "erro", err)
+	}
+
+	fmt.Print("\033[H\033[2J") // Limpa a tela para não deixar dados à vista
+	fmt.Printf("🔒 Sessão bloqueada por %s.\n", motivo)
+	for {
+		senha, ok := c.lerSenha("Senha para desbloquear: ")
+		if !ok {
+			return false
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(senha)) == nil {
+			fmt.Println("🔓 Sessão desbloqueada.")
+			return true
+		}
+		time.Sleep(time.Second) // Atrasa tentativas em sequência
+		fmt.Println("❌ Senha incorreta.")
+	}
+}
+
+// lerSenha pergunta uma senha sem eco no terminal; ok é false no fim da entrada
+func (c *sessao) lerSenha(prompt string) (string, bool) {
+	fmt.Print(prompt)
+	religar := desligarEco()
+	senha, ok := c.cli.lerLinha()
+	religar()
+	fmt.Println()
+	return senha, ok
+}
+
+// GerarHashSenha pede a senha duas vezes e mostra o hash bcrypt para a configuração
+func (c *sessao) GerarHashSenha() error {
+	senha, _ := c.lerSenha("Nova senha: ")
+	if len(senha) < 4 {
+		return errors.New("a senha precisa ter pelo menos 4 caracteres")
+	}
+	if confirmacao, _ := c.lerSenha("Repita a senha: "); confirmacao != senha {
+		return errors.New("as senhas não conferem")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("erro ao gerar hash: %v", err)
+	}
+	fmt.Println("✅ Informe no config.json e use 'config reload':")
+	fmt.Printf("  \"sessao\": {\"bloqueio_minutos\": 10, \"senha_hash\": \"%s\"}\n", hash)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// MudarStatus aplica uma transição manual e informa a mudança
+func (c *sessao) MudarStatus(id, para string) error {
+	carro, de, err := c.AlterarStatus(id, para)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	fmt.Printf("✅ Carro '%s %s' passou de '%s' para '%s'.\n", carro.Marca, carro.Modelo, de, para)
+	return err
+}
+
+// MostrarStatus exibe a situação atual, as transições permitidas e o histórico de um carro
+func (c *sessao) MostrarStatus(id string) error {
+	visao := c.Snapshot()
+	carro, existe := visao.Carro(id)
+	if !existe {
+		return cars.ErroCarroNaoEncontrado(id)
+	}
+
+	atual := cars.StatusCarro(carro)
+	fmt.Printf("\n--- Situação de '%s %s' (%s) ---\n", carro.Marca, carro.Modelo, carro.ID)
+	fmt.Printf("Atual: %s", atual)
+	if carro.StatusDesde != "" {
+		fmt.Printf(" (desde %s)", carro.StatusDesde)
+	}
+	fmt.Println()
+	if proximos := cars.TransicoesStatus[atual]; len(proximos) > 0 {
+		fmt.Printf("Pode passar para: %s\n", strings.Join(proximos, ", "))
+	}
+	historico := visao.HistoricoStatus(id)
+	if len(historico) == 0 {
+		fmt.Println("Sem mudanças registradas (carro cadastrado antes do controle de situação).")
+		return nil
+	}
+	fmt.Println("Histórico:")
+	for _, m := range historico {
+		de := m.De
+		if de == "" {
+			de = "cadastro"
+		}
+		fmt.Printf("   %s  %s → %s\n", m.Em, de, m.Para)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// interpretarOpcoesCarro monta um carro a partir de `--marca Toyota --modelo Corolla --ano 2022
+// --preco 120000 --origem Japão` (também aceita --marca=Toyota). Devolve também a justificativa
+// de conformidade, se informada. Sem --origem, o país vem do padrão da marca.
+func interpretarOpcoesCarro(args []string, marcas cars.OpcoesMarcas) (carro cars.Carro, justificativa string, err error) {
+	opcoes := flag.NewFlagSet("add", flag.ContinueOnError)
+	opcoes.SetOutput(io.Discard)
+	opcoes.StringVar(&carro.Marca, "marca", "", "")
+	opcoes.StringVar(&carro.Modelo, "modelo", "", "")
+	opcoes.IntVar(&carro.Ano, "ano", 0, "")
+	opcoes.StringVar(&carro.Cor, "cor", "", "")
+	opcoes.StringVar(&carro.PaisOrigem, "origem", "", "")
+	opcoes.StringVar(&carro.Chassi, "chassi", "", "")
+	preco := opcoes.String("preco", "", "")
+	custo := opcoes.String("custo", "", "")
+	opcionais := opcoes.String("opcionais", "", "")
+	emTransito := opcoes.Bool("em-transito", false, "")
+	opcoes.StringVar(&justificativa, "justificativa", "", "")
+	if err := opcoes.Parse(args); err != nil {
+		msg := err.Error()
+		switch {
+		case strings.HasPrefix(msg, "flag provided but not defined: "):
+			return carro, "", fmt.Errorf("opção desconhecida: %s", strings.TrimPrefix(msg, "flag provided but not defined: "))
+		case strings.HasPrefix(msg, "flag needs an argument: "):
+			return carro, "", fmt.Errorf("a opção %s precisa de um valor", strings.TrimPrefix(msg, "flag needs an argument: "))
+		}
+		return carro, "", fmt.Errorf("opção inválida: %v", err)
+	}
+	if opcoes.NArg() > 0 {
+		return carro, "", fmt.Errorf("argumento inesperado: %s (as opções são --nome valor)", opcoes.Arg(0))
+	}
+
+	if *preco != "" {
+		if carro.Preco, err = cars.InterpretarValorDigitado(*preco); err != nil {
+			return carro, "", fmt.Errorf("--preco: %v", err)
+		}
+	}
+	if *custo != "" {
+		if carro.Custo, err = cars.InterpretarValorDigitado(*custo); err != nil {
+			return carro, "", fmt.Errorf("--custo: %v", err)
+		}
+	}
+	if *opcionais != "" {
+		if carro.Opcionais, err = cars.InterpretarOpcionais(*opcionais); err != nil {
+			return carro, "", fmt.Errorf("--opcionais: %v", err)
+		}
+	}
+	if carro.PaisOrigem == "" {
+		carro.PaisOrigem = marcas.PaisDaMarca(carro.Marca)
+	}
+	carro.Chassi = cars.NormalizarChassi(carro.Chassi)
+	carro.Status = cars.StatusEmEstoque
+	if *emTransito {
+		carro.Status = cars.StatusEmTransito
+	}
+	return carro, justificativa, cars.ValidarCarro(carro)
+}
+
+// AdicionarPorOpcoes cadastra sem perguntas o carro descrito pelas opções, para scripts e cron.
+// Violações de conformidade só são aceitas com --justificativa, que faz o papel da resposta no prompt.
+func (c *sessao) AdicionarPorOpcoes(args []string) error {
+	marcas := c.Config().Marcas
+	carro, justificativa, err := interpretarOpcoesCarro(args, marcas)
+	if err != nil {
+		return err
+	}
+	if err := c.Validar(carro); err != nil {
+		return err
+	}
+	violacoes, alertas := c.AvaliarConformidade(carro)
+	for _, aviso := range []string{marcas.AvisoAno(carro.Marca, carro.Modelo, carro.Ano), marcas.AvisoPais(carro.Marca, carro.PaisOrigem)} {
+		if aviso != "" {
+			fmt.Printf("⚠️  Aviso: %s.\n", aviso)
+		}
+	}
+	for _, alerta := range alertas {
+		fmt.Printf("⚠️  Aviso: %s\n", alerta)
+	}
+	var excecao *cars.ExcecaoConformidade
+	if len(violacoes) > 0 {
+		if justificativa == "" {
+			return fmt.Errorf("conformidade: %s (informe --justificativa para cadastrar mesmo assim)", strings.Join(violacoes, "; "))
+		}
+		excecao = &cars.ExcecaoConformidade{Violacoes: violacoes, Justificativa: justificativa}
+	}
+	return c.inserirCarro(carro, excecao)
+}
+
+// argsPrograma são as opções globais da linha de comando do programa e o subcomando que vem depois
+type argsPrograma struct {
+	Perfil       string   // --profile=<nome>
+	ArquivoDados string   // --data-file=<caminho>
+	NivelLog     string   // --log-level=<nível>
+	FormatoLog   string   // --log-format=<text|json>
+	Subcomando   []string // Comando e argumentos (vazio para o modo interativo)
+}
+
+// separarArgsPrograma divide a linha de comando do programa nas opções globais, que vêm antes, e
+// no subcomando com seus argumentos
+func separarArgsPrograma(args []string) argsPrograma {
+	var p argsPrograma
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--profile="):
+			p.Perfil = strings.TrimPrefix(arg, "--profile=")
+		case strings.HasPrefix(arg, "--data-file="):
+			p.ArquivoDados = strings.TrimPrefix(arg, "--data-file=")
+		case strings.HasPrefix(arg, "--log-level="):
+			p.NivelLog = strings.TrimPrefix(arg, "--log-level=")
+		case strings.HasPrefix(arg, "--log-format="):
+			p.FormatoLog = strings.TrimPrefix(arg, "--log-format=")
+		default:
+			p.Subcomando = args[i:]
+			return p
+		}
+	}
+	return p
+}
+
+// juntarArgumentos refaz a linha do prompt a partir de argumentos já separados pelo shell,
+// pondo entre aspas os que têm espaços (dividirArgumentos e o patch as retiram de novo)
+func juntarArgumentos(args []string) string {
+	partes := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case !strings.ContainsAny(arg, " \t"):
+			partes[i] = arg
+		case strings.Contains(arg, `"`):
+			partes[i] = "'" + arg + "'"
+		default:
+			partes[i] = `"` + arg + `"`
+		}
+	}
+	return strings.Join(partes, " ")
+}
+
+// executarSubcomando roda um único comando do prompt, vindo da linha de comando do programa
+// (ex: `carros add --marca Toyota ...` ou `carros list --wide`), e devolve o código de saída:
+// 2 para comando desconhecido, não liberado ou com argumentos fora da sintaxe e 1 se o comando
+// falhar. Confirmações que o comando pedir são lidas da entrada padrão, como no prompt.
+func executarSubcomando(c *sessao, args []string) int {
+	cmd := buscarComando(args[0])
+	if cmd == nil {
+		fmt.Printf("❌ Comando desconhecido '%s'. Comandos disponíveis: %s.\n", args[0], strings.Join(nomesComandos(), ", "))
+		c.Registro().Error("comando desconhecido", "comando", args[0])
+		return 2
+	}
+	if !c.ComandoPermitido(cmd.Nome) {
+		fmt.Printf("❌ O comando '%s' não está liberado nesta configuração (seção permissoes).\n", cmd.Nome)
+		c.Registro().Error("comando não liberado", "comando", cmd.Nome)
+		return 2
+	}
+	if len(args)-1 < cmd.MinArgs {
+		fmt.Printf("Uso: %s\n", cmd.Sintaxe)
+		return 2
+	}
+	// Os argumentos já vêm separados pelo shell; a linha refeita atende os comandos que a reinterpretam
+	inicio := time.Now()
+	err := relatarErro(cmd.Executar(c, args[1:], juntarArgumentos(args[1:])))
+	var uso erroUso
+	switch {
+	case errors.As(err, &uso):
+		return 2
+	case err != nil && !errors.Is(err, errSair):
+		c.Registro().Error("comando falhou", "comando", cmd.Nome, "erro", err)
+		return 1
+	}
+	c.Registro().Info("comando executado", "comando", cmd.Nome, "duracao", time.Since(inicio))
+	return 0
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/michellhornung/golang/cars"
+	"golang.org/x/term"
+)
+
+// larguraTerminal devolve a largura da saída padrão em colunas, ou 0 se desconhecida
+// (saída redirecionada para arquivo/pipe sem $COLUMNS), caso em que nada é truncado
+func larguraTerminal() int {
+	if largura, _, err := term.GetSize(int(saidaTerminal().Fd())); err == nil && largura > 0 {
+		return largura
+	}
+	if largura, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && largura > 0 {
+		return largura
+	}
+	return 0
+}
+
+// interpretarModoTabela extrai --wide/--narrow dos argumentos, devolvendo os demais
+func interpretarModoTabela(args []string) (cars.ModoTabela, []string) {
+	modo := cars.TabelaAuto
+	var resto []string
+	for _, arg := range args {
+		switch arg {
+		case "--wide":
+			modo = cars.TabelaLarga
+		case "--narrow":
+			modo = cars.TabelaEstreita
+		default:
+			resto = append(resto, arg)
+		}
+	}
+	return modo, resto
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/michellhornung/golang/cars"
+)
+
+// nomesMeses são os nomes dos meses usados nos relatórios
+var nomesMeses = [12]string{"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro"}
+
+// segmentoPreco classifica um carro pela faixa de preço pedido
+func segmentoPreco(preco cars.Dinheiro) string {
+	switch {
+	case preco < cars.Reais(150000):
+		return "Entrada (até R$ 150k)"
+	case preco < cars.Reais(400000):
+		return "Intermediário (R$ 150k–400k)"
+	case preco < cars.Reais(1000000):
+		return "Premium (R$ 400k–1M)"
+	}
+	return "Luxo (acima de R$ 1M)"
+}
+
+// lerVeiculoTroca pergunta pelo veículo do cliente no formato do quickadd, com a avaliação no lugar do preço
+func lerVeiculoTroca(cli *CLI) (cars.Carro, error) {
+	linha, err := cli.Perguntar("Veículo na troca (Marca Modelo Ano Cor Avaliação País): ")
+	if err != nil {
+		return cars.Carro{}, err
+	}
+	troca, err := interpretarLinhaRapida(linha)
+	if err != nil {
+		return cars.Carro{}, err
+	}
+	if err := cars.ValidarCarro(troca); err != nil {
+		return cars.Carro{}, err
+	}
+	return troca, nil
+}
+
+// VenderCarro vende o carro, confirmando antes se for de alto valor, e mostra o resultado da venda
+func (c *sessao) VenderCarro(id string, precoFinal cars.Dinheiro, troca *cars.Carro) error {
+	carro, err := c.Carro(id)
+	if err != nil {
+		return err
+	}
+	if err := cars.ValidarTransicao(cars.StatusCarro(carro), cars.StatusVendido); err != nil {
+		return err
+	}
+	exibicao := c.Exibicao()
+	if err := c.confirmarAltoValor(carro, "a venda por "+exibicao.FormatarPreco(precoFinal), precoFinal); err != nil {
+		return err
+	}
+
+	venda, ajustes, err := c.Vender(id, precoFinal, troca)
+	if !alteracaoFeita(err) {
+		return err
+	}
+	if venda.Troca != nil {
+		c.mostrarAjustes(ajustes)
+		fmt.Printf("🔁 Troca: '%s %s %d' avaliado em %s e cadastrado no estoque com ID: %s\n",
+			troca.Marca, troca.Modelo, troca.Ano, exibicao.FormatarPreco(venda.Troca.Avaliacao), venda.Troca.CarroID)
+	}
+	fmt.Printf("✅ Carro '%s %s' vendido por %s (pedido: %s, desconto: %.1f%%, %d dia(s) em estoque).\n",
+		carro.Marca, carro.Modelo, exibicao.FormatarPreco(precoFinal), exibicao.FormatarPreco(venda.Carro.Preco),
+		venda.DescontoPercentual(), venda.DiasEmEstoque)
+	if venda.Troca != nil {
+		fmt.Printf("   Valor líquido a receber além da troca: %s\n", exibicao.FormatarPreco(venda.ValorLiquido()))
+	}
+	return err
+}
+
+// ListarVendidos exibe os comparáveis vendidos
+func (c *sessao) ListarVendidos(modo cars.ModoTabela) {
+	defer c.Medir("sold")()
+	visao := c.Snapshot()
+	vendidos := visao.Vendidos()
+
+	if len(vendidos) == 0 {
+		fmt.Println("\nNenhum carro vendido registrado ainda.")
+		return
+	}
+
+	fmt.Println("\n--- Carros Vendidos (Comparáveis) ---")
+	t := cars.Tabela{Colunas: []cars.ColunaTabela{
+		{Titulo: "ID", Essencial: true},
+		{Titulo: "Marca", Essencial: true},
+		{Titulo: "Modelo", Essencial: true},
+		{Titulo: "Ano", Direita: true},
+		{Titulo: "Pedido", Direita: true},
+		{Titulo: "Final", Direita: true, Essencial: true},
+		{Titulo: "Desconto", Direita: true},
+		{Titulo: "Troca", Direita: true},
+		{Titulo: "Dias", Direita: true, Essencial: true},
+		{Titulo: "Vendido"},
+	}}
+	exibicao := visao.Exibicao()
+	for _, v := range vendidos {
+		troca := ""
+		if v.Troca != nil {
+			troca = exibicao.FormatarPreco(v.Troca.Avaliacao)
+		}
+		t.Linhas = append(t.Linhas, []string{
+			v.Carro.ID, v.Carro.Marca, v.Carro.Modelo, strconv.Itoa(v.Carro.Ano),
+			exibicao.FormatarPreco(v.Carro.Preco), exibicao.FormatarPreco(v.PrecoFinal),
+			fmt.Sprintf("%.1f%%", v.DescontoPercentual()), troca, strconv.Itoa(v.DiasEmEstoque), v.DataVenda,
+		})
+	}
+	fmt.Print(t.Renderizar(modo, larguraTerminal()))
+}
+
+// AnalisarVendas mostra dias médios até vender e desconto médio por marca e por segmento,
+// além da sazonalidade (vendas por mês do ano)
+func (c *sessao) AnalisarVendas() {
+	defer c.Medir("analytics")()
+	visao := c.Snapshot()
+	vendidos := visao.Vendidos()
+
+	if len(vendidos) == 0 {
+		fmt.Println("\nNenhum carro vendido registrado ainda. Use 'sell <ID> <preço final>' para registrar vendas.")
+		return
+	}
+
+	imprimir := func(titulo string, stats []cars.EstatisticaVendas) {
+		fmt.Printf("\n--- %s ---\n", titulo)
+		for _, s := range stats {
+			fmt.Printf("%-30s | Vendas: %3d | Dias até vender: %6.1f | Desconto médio: %5.1f%% | Preço final médio: %s\n",
+				s.Grupo, s.Quantidade, s.MediaDias, s.MediaDesconto, visao.Exibicao().FormatarPreco(s.MediaPrecoFinal))
+		}
+	}
+
+	imprimir("Por Marca", cars.AgruparVendas(vendidos, func(v cars.Venda) string { return v.Carro.Marca }))
+	imprimir("Por Segmento", cars.AgruparVendas(vendidos, func(v cars.Venda) string { return segmentoPreco(v.Carro.Preco) }))
+	imprimir("Sazonalidade (Mês da Venda)", cars.AgruparVendas(vendidos, func(v cars.Venda) string {
+		data, err := time.Parse("2006-01-02", v.DataVenda)
+		if err != nil {
+			return "??"
+		}
+		return fmt.Sprintf("%02d %s", int(data.Month()), nomesMeses[data.Month()-1])
+	}))
+}